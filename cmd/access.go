@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var accessCmd = &cobra.Command{
+	Use:   "access",
+	Short: "Access verification commands",
+	Long:  "Commands for checking what the active gcloud account can do.",
+}
+
+var accessVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check a new team member has the access their role needs",
+	Long: `Check the active gcloud account against an access profile and print a pass/fail
+checklist to hand to IT when something's missing. For each configured environment (or
+those given with --env) it checks the account can see the project, get cluster
+credentials, read pods and their logs, read Cloud Logging, and exec into pods where the
+profile allows it. Access the profile shouldn't have, such as exec into a protected
+environment for developers, is reported as a failure too.
+
+Profiles:
+  viewer     read-only access to every environment
+  developer  read access everywhere, exec into unprotected environments only
+  sre        read access and exec into every environment
+
+Cluster permissions are checked across all namespaces, or in the namespace given with -n.
+Credentials are fetched into a temporary kubeconfig, so your kubectl setup is untouched.
+Exits with code 1 when any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, _ := cmd.Flags().GetString("profile")
+		envs, _ := cmd.Flags().GetStringSlice("env")
+		output, _ := cmd.Flags().GetString("output")
+		passed, err := verifyAccess(profile, envs, output)
+		if err != nil {
+			fmt.Printf("Error verifying access: %v\n", err)
+			exitWithCode(1)
+		}
+		if !passed {
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	accessVerifyCmd.Flags().String("profile", "developer", "Access profile to check against: "+strings.Join(internal.AccessProfileNames(), ", "))
+	accessVerifyCmd.Flags().StringSlice("env", nil, "Environments to check (default: all configured environments, or the current project)")
+	accessVerifyCmd.Flags().StringP("output", "o", "text", "Output format: text or markdown")
+	addNamespaceFlag(accessVerifyCmd)
+	accessCmd.AddCommand(accessVerifyCmd)
+	rootCmd.AddCommand(accessCmd)
+}
+
+// accessEnvironment is an environment to verify access to
+type accessEnvironment struct {
+	ProjectID string
+	Protected bool
+}
+
+// verifyAccess prints the access checklist and reports whether every check passed
+func verifyAccess(profileName string, envNames []string, output string) (bool, error) {
+	profile, ok := internal.AccessProfiles[profileName]
+	if !ok {
+		return false, fmt.Errorf("unknown profile %q (use %s)", profileName, strings.Join(internal.AccessProfileNames(), ", "))
+	}
+	output = strings.ToLower(output)
+	if output != "text" && output != "markdown" {
+		return false, fmt.Errorf("unsupported output format %q (use text or markdown)", output)
+	}
+
+	account := getActiveAccount()
+	if account == "" {
+		return false, fmt.Errorf("not signed in to gcloud; run 'gcpeasy login' first")
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to load config: %w", err)
+	}
+	var envs []accessEnvironment
+	for _, name := range envNames {
+		projectID, err := cfg.ResolveEnvironment(name)
+		if err != nil {
+			return false, err
+		}
+		envs = append(envs, accessEnvironment{ProjectID: projectID, Protected: cfg.Environment(projectID).Protected})
+	}
+	if len(envNames) == 0 {
+		for projectID, env := range cfg.Environments {
+			envs = append(envs, accessEnvironment{ProjectID: projectID, Protected: env.Protected})
+		}
+		sort.Slice(envs, func(i, k int) bool { return envs[i].ProjectID < envs[k].ProjectID })
+	}
+	if len(envs) == 0 {
+		projectID := getCurrentProject()
+		if projectID == "" {
+			return false, fmt.Errorf("no environments are configured and no project is set; pass --env")
+		}
+		envs = append(envs, accessEnvironment{ProjectID: projectID})
+	}
+
+	fmt.Printf("🔍 Verifying access of %s against the %s profile (%s)...\n", account, profile.Name, profile.Description)
+	checks := []internal.AccessCheck{internal.CheckListProjects()}
+	for _, env := range envs {
+		fmt.Printf("🔍 Checking %s...\n", env.ProjectID)
+		checks = append(checks, internal.VerifyEnvironmentAccess(env.ProjectID, env.Protected, profile)...)
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if !c.Passed() {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	if output == "markdown" {
+		printAccessMarkdown(account, profile, envs, checks, failed)
+	} else {
+		printAccessChecklist(profile, envs, checks)
+		fmt.Println()
+		if failed == 0 {
+			fmt.Printf("✅ All %d checks passed\n", len(checks))
+		} else {
+			fmt.Printf("❌ %d of %d checks failed\n", failed, len(checks))
+			fmt.Println("💡 Use -o markdown for a checklist to paste into an IT ticket")
+		}
+	}
+	return failed == 0, nil
+}
+
+func printAccessChecklist(profile internal.AccessProfile, envs []accessEnvironment, checks []internal.AccessCheck) {
+	fmt.Println("📋 Access checklist:")
+	environment := ""
+	for _, c := range checks {
+		if c.Environment != environment {
+			environment = c.Environment
+			fmt.Println()
+			fmt.Println(internal.Colorize(internal.ColorCyan, accessEnvironmentTitle(environment, envs)))
+		}
+		line := "   "
+		if c.Passed() {
+			line += "✅ " + c.Name
+		} else {
+			line += internal.Colorize(internal.ColorRed, "❌ "+c.Name)
+		}
+		if detail := accessCheckDetail(c, profile); detail != "" {
+			line += ": " + detail
+		}
+		fmt.Println(line)
+	}
+}
+
+func printAccessMarkdown(account string, profile internal.AccessProfile, envs []accessEnvironment, checks []internal.AccessCheck, failed int) {
+	fmt.Printf("## Access check for %s\n\n", account)
+	fmt.Printf("- Profile: %s (%s)\n", profile.Name, profile.Description)
+	fmt.Printf("- Checked: %s\n", time.Now().UTC().Format(time.RFC3339))
+	if namespace := internal.NamespaceScope(); namespace != "" {
+		fmt.Printf("- Namespace: %s\n", namespace)
+	}
+	fmt.Printf("- Result: %d of %d checks failed\n", failed, len(checks))
+
+	environment := "-"
+	for _, c := range checks {
+		if c.Environment != environment {
+			environment = c.Environment
+			title := "General"
+			if environment != "" {
+				title = accessEnvironmentTitle(environment, envs)
+			}
+			fmt.Printf("\n### %s\n\n", title)
+		}
+		box := "[ ]"
+		if c.Passed() {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("- %s %s", box, c.Name)
+		if detail := accessCheckDetail(c, profile); detail != "" {
+			line += ": " + detail
+		}
+		fmt.Println(line)
+	}
+}
+
+func accessEnvironmentTitle(projectID string, envs []accessEnvironment) string {
+	for _, env := range envs {
+		if env.ProjectID == projectID && env.Protected {
+			return projectID + " (protected)"
+		}
+	}
+	return projectID
+}
+
+// accessCheckDetail explains a check's outcome when it isn't simply the expected access
+func accessCheckDetail(c internal.AccessCheck, profile internal.AccessProfile) string {
+	switch {
+	case c.Allowed && !c.Expected:
+		return fmt.Sprintf("allowed, but the %s profile shouldn't have it", profile.Name)
+	case !c.Allowed && !c.Expected && c.Detail == "denied by RBAC":
+		return "denied, as expected"
+	case !c.Allowed:
+		return truncate(strings.ReplaceAll(c.Detail, "\n", " "), 150)
+	}
+	return ""
+}
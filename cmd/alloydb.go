@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var alloydbCmd = &cobra.Command{
+	Use:   "alloydb",
+	Short: "AlloyDB commands",
+	Long:  "Commands for connecting to AlloyDB for PostgreSQL instances in the current project.",
+}
+
+var alloydbConnectCmd = &cobra.Command{
+	Use:   "connect [instance]",
+	Short: "Connect to an AlloyDB instance with psql",
+	Long: `Start the AlloyDB Auth Proxy for an instance and open psql through it, stopping the proxy
+when psql exits. The instance can be given as <instance>, <cluster>/<instance> or its full
+resource name; without one it is chosen from the project's instances.
+
+The database password can be read from the vault with --vault (see 'gcpeasy vault'), or
+--iam logs in as your gcloud account with IAM database authentication. With --proxy-only,
+or when psql isn't installed, only the proxy is started so another client can connect to
+it; press Ctrl+C to stop it.
+
+Requires the alloydb-auth-proxy binary:
+https://cloud.google.com/alloydb/docs/auth-proxy/connect`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts alloydbConnectOptions
+		if len(args) == 1 {
+			opts.Instance = args[0]
+		}
+		opts.Port, _ = cmd.Flags().GetInt("port")
+		opts.User, _ = cmd.Flags().GetString("user")
+		opts.Database, _ = cmd.Flags().GetString("database")
+		opts.Vault, _ = cmd.Flags().GetString("vault")
+		opts.PublicIP, _ = cmd.Flags().GetBool("public-ip")
+		opts.IAM, _ = cmd.Flags().GetBool("iam")
+		opts.ProxyOnly, _ = cmd.Flags().GetBool("proxy-only")
+		if err := connectAlloyDB(opts); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error connecting to AlloyDB: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	alloydbConnectCmd.Flags().Int("port", 0, "Local port for the proxy (default: 5432, or a free port when taken)")
+	alloydbConnectCmd.Flags().StringP("user", "U", "postgres", "Database user (default with --iam: your gcloud account)")
+	alloydbConnectCmd.Flags().StringP("database", "d", "postgres", "Database to connect to")
+	alloydbConnectCmd.Flags().String("vault", "", "Name of the vault entry holding the database password")
+	alloydbConnectCmd.Flags().Bool("public-ip", false, "Connect to the instance's public IP instead of its private IP")
+	alloydbConnectCmd.Flags().Bool("iam", false, "Log in as your gcloud account with IAM database authentication")
+	alloydbConnectCmd.Flags().Bool("proxy-only", false, "Only start the proxy, without psql")
+	alloydbCmd.AddCommand(alloydbConnectCmd)
+	rootCmd.AddCommand(alloydbCmd)
+}
+
+type alloydbConnectOptions struct {
+	Instance  string
+	Port      int
+	User      string
+	Database  string
+	Vault     string
+	PublicIP  bool
+	IAM       bool
+	ProxyOnly bool
+}
+
+func connectAlloyDB(opts alloydbConnectOptions) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Looking for AlloyDB instances in project: %s\n", currentProject)
+	instances, err := internal.GetAlloyDBInstances(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list AlloyDB instances: %w", err)
+	}
+	var instance *internal.AlloyDBInstance
+	if opts.Instance != "" {
+		instance, err = internal.FindAlloyDBInstance(instances, opts.Instance)
+	} else {
+		instance, err = internal.SelectAlloyDBInstance(instances)
+	}
+	if err != nil {
+		return err
+	}
+	if instance.State != "READY" {
+		fmt.Printf("⚠️  Instance %s is %s\n", instance.ID(), instance.State)
+	}
+
+	password := ""
+	if opts.Vault != "" {
+		if password, err = internal.VaultGet(opts.Vault); err != nil {
+			return fmt.Errorf("failed to read the password: %w", err)
+		}
+	}
+	if opts.IAM && opts.User == "postgres" {
+		opts.User = getActiveAccount()
+		// IAM users of service accounts drop the .gserviceaccount.com suffix
+		opts.User = strings.TrimSuffix(opts.User, ".gserviceaccount.com")
+	}
+
+	if opts.Port == 0 {
+		if opts.Port, err = internal.LocalPortFor(5432, nil); err != nil {
+			return err
+		}
+	} else if !internal.LocalPortFree(opts.Port) {
+		return fmt.Errorf("local port %d is already in use", opts.Port)
+	}
+
+	fmt.Printf("🔌 Starting the AlloyDB Auth Proxy for %s/%s on localhost:%d...\n", instance.Cluster(), instance.ID(), opts.Port)
+	proxy, err := internal.StartAlloyDBProxy(*instance, internal.AlloyDBProxyOptions{
+		Port:         opts.Port,
+		PublicIP:     opts.PublicIP,
+		AutoIAMAuthn: opts.IAM,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, psqlErr := exec.LookPath("psql")
+	if opts.ProxyOnly || psqlErr != nil {
+		if psqlErr != nil && !opts.ProxyOnly {
+			fmt.Println("⚠️  psql not found, only starting the proxy")
+		}
+		fmt.Printf("✅ Proxy ready: host=127.0.0.1 port=%d user=%s dbname=%s\n", opts.Port, opts.User, opts.Database)
+		fmt.Println("(Press Ctrl+C to stop)")
+		// The proxy doesn't get the terminal's Ctrl+C, so stop it here
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			<-interrupt
+			proxy.Close()
+		}()
+		proxy.Wait()
+		return nil
+	}
+	defer proxy.Close()
+
+	fmt.Printf("🚀 Connecting to %s as %s...\n", opts.Database, opts.User)
+	fmt.Println("(Type \\q or press Ctrl+D to disconnect)")
+	fmt.Println()
+
+	// psql handles Ctrl+C itself (cancelling the running query); ignoring it here keeps it
+	// from stopping gcpeasy, and the proxy runs in its own process group so never gets it
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	psql := exec.Command("psql", fmt.Sprintf("host=127.0.0.1 port=%d user=%s dbname=%s", opts.Port, opts.User, opts.Database))
+	psql.Env = os.Environ()
+	if password != "" {
+		psql.Env = append(psql.Env, "PGPASSWORD="+password)
+	}
+	psql.Stdin = os.Stdin
+	psql.Stdout = os.Stdout
+	psql.Stderr = os.Stderr
+	if err := psql.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
 	"os"
 	"os/exec"
 	"strings"
@@ -36,6 +37,7 @@ var logoutCmd = &cobra.Command{
 
 func runLogin() error {
 	fmt.Println("🔐 Authenticating with Google Cloud...")
+	internal.InvalidatePreflightCache()
 	
 	// Check if gcloud is installed
 	if _, err := exec.LookPath("gcloud"); err != nil {
@@ -72,6 +74,7 @@ func runLogin() error {
 
 func runLogout() error {
 	fmt.Println("🔐 Logging out from Google Cloud...")
+	internal.InvalidatePreflightCache()
 	
 	// Check if gcloud is installed
 	if _, err := exec.LookPath("gcloud"); err != nil {
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const certExpiryWarning = 30 * 24 * time.Hour
+
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "TLS certificate commands",
+	Long:  "Commands for inspecting cert-manager Certificates and GKE ManagedCertificates.",
+}
+
+var certsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List certificates with readiness and expiry",
+	Long:  "List cert-manager Certificates and GKE ManagedCertificates with readiness, expiry dates and failing challenge reasons. Certificates expiring within 30 days are highlighted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listCertificates(); err != nil {
+			fmt.Printf("Error listing certificates: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	certsCmd.AddCommand(certsListCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+func listCertificates() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Detecting certificate controllers...")
+
+	var certs []internal.CertificateInfo
+	found := false
+
+	if internal.HasAPIResource(internal.CertManagerCertificateResource) {
+		found = true
+		cmCerts, err := internal.GetCertManagerCertificates()
+		if err != nil {
+			return fmt.Errorf("failed to get cert-manager certificates: %w", err)
+		}
+		fmt.Printf("✅ cert-manager detected (%d certificate(s))\n", len(cmCerts))
+		certs = append(certs, cmCerts...)
+	}
+
+	if internal.HasAPIResource(internal.ManagedCertificateResource) {
+		found = true
+		managed, err := internal.GetManagedCertificates()
+		if err != nil {
+			return fmt.Errorf("failed to get managed certificates: %w", err)
+		}
+		fmt.Printf("✅ GKE ManagedCertificates detected (%d certificate(s))\n", len(managed))
+		certs = append(certs, managed...)
+	}
+
+	if !found {
+		fmt.Println("❌ Neither cert-manager nor GKE ManagedCertificates are installed in this cluster")
+		return nil
+	}
+	if len(certs) == 0 {
+		fmt.Println("No certificates found.")
+		return nil
+	}
+	fmt.Println()
+
+	fmt.Printf("%-13s %-15s %-30s %-20s %-12s %-8s %s\n", "TYPE", "NAMESPACE", "NAME", "STATUS", "EXPIRES", "IN", "DOMAINS")
+	fmt.Println(strings.Repeat("-", 130))
+
+	notReady, expiring := 0, 0
+	for _, c := range certs {
+		expires, remaining := "-", "-"
+		if !c.Expiry.IsZero() {
+			expires = c.Expiry.Format("2006-01-02")
+			if until := time.Until(c.Expiry); until > 0 {
+				remaining = internal.FormatDuration(until)
+			} else {
+				remaining = "expired"
+			}
+		}
+
+		line := fmt.Sprintf("%-13s %-15s %-30s %-20s %-12s %-8s %s",
+			c.Type,
+			truncate(c.Namespace, 15),
+			truncate(c.Name, 30),
+			truncate(c.Status, 20),
+			expires,
+			remaining,
+			strings.Join(c.Domains, ","))
+
+		switch {
+		case !c.Ready:
+			notReady++
+			fmt.Println(internal.Colorize(internal.ColorRed, line))
+		case c.ExpiresWithin(certExpiryWarning):
+			expiring++
+			fmt.Println(internal.Colorize(internal.ColorYellow, line))
+		default:
+			fmt.Println(line)
+		}
+		for _, p := range c.Problems {
+			fmt.Printf("              ↳ %s\n", truncate(strings.ReplaceAll(p, "\n", " "), 110))
+		}
+	}
+
+	fmt.Println()
+	if notReady == 0 && expiring == 0 {
+		fmt.Println("✅ All certificates are ready and valid for at least 30 days")
+		return nil
+	}
+	if notReady > 0 {
+		fmt.Printf("❌ %d certificate(s) not ready\n", notReady)
+	}
+	if expiring > 0 {
+		fmt.Printf("⚠️  %d certificate(s) expiring within 30 days\n", expiring)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var chaosCmd = &cobra.Command{
+	Use:   "chaos",
+	Short: "Resilience testing commands",
+	Long:  "Commands for lightweight resilience testing, such as game days, in non-production environments.",
+}
+
+var chaosKillCmd = &cobra.Command{
+	Use:   "kill",
+	Short: "Delete random pods and watch the workload recover",
+	Long: `Delete random running pods matching --selector, then watch until as many matching pods
+are ready as before and report how long recovery took, e.g.:
+
+  gcpeasy chaos kill --selector app=web --count 2
+
+Pods are deleted without waiting for them to terminate. Protected environments are refused.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		count, _ := cmd.Flags().GetInt("count")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		noWatch, _ := cmd.Flags().GetBool("no-watch")
+		if err := killRandomPods(count, timeout, !noWatch); err != nil {
+			fmt.Printf("Error killing pods: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	chaosKillCmd.Flags().Int("count", 1, "Number of pods to delete")
+	chaosKillCmd.Flags().Duration("timeout", 5*time.Minute, "How long to watch for recovery")
+	chaosKillCmd.Flags().Bool("no-watch", false, "Don't watch the recovery")
+	addSelectorFlag(chaosKillCmd)
+	addNamespaceFlag(chaosCmd)
+	chaosCmd.AddCommand(chaosKillCmd)
+	rootCmd.AddCommand(chaosCmd)
+}
+
+func killRandomPods(count int, timeout time.Duration, watch bool) error {
+	if selectorFlag == "" {
+		return fmt.Errorf("--selector is required (e.g. --selector app=web)")
+	}
+	if count < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Environment(currentProject).Protected {
+		return fmt.Errorf("%s is a protected environment; chaos testing is for non-production environments", currentProject)
+	}
+
+	pods, err := internal.GetApplicationPods()
+	if err != nil {
+		return fmt.Errorf("failed to get pods: %w", err)
+	}
+	var running []internal.Pod
+	for _, p := range pods {
+		if p.Status.Phase == "Running" && p.Metadata.DeletionTimestamp == "" {
+			running = append(running, p)
+		}
+	}
+	fmt.Println()
+	if len(running) == 0 {
+		fmt.Printf("❌ No running pods match %s\n", selectorFlag)
+		return nil
+	}
+	if count > len(running) {
+		return fmt.Errorf("only %d running pod(s) match %s, can't delete %d", len(running), selectorFlag, count)
+	}
+	readyBefore := internal.CountReady(pods)
+
+	victims := internal.PickRandomPods(running, count)
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📋 %d of %d matching pod(s) ready; deleting at random:\n", readyBefore, len(pods))
+	for _, p := range victims {
+		fmt.Printf("   %s/%s\n", p.Metadata.Namespace, p.Metadata.Name)
+	}
+	fmt.Println()
+	for _, p := range victims {
+		warnManaged("pod "+p.Metadata.Namespace+"/"+p.Metadata.Name, p.Metadata.Labels, p.Metadata.Annotations)
+	}
+	warnPodDisruptionBudgets(victims)
+
+	if !confirm(fmt.Sprintf("Delete %d pod(s) in %s?", count, currentProject)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	var names []string
+	for _, p := range victims {
+		names = append(names, p.Metadata.Name)
+	}
+	killedAt := time.Now()
+	err = runNotified("chaos kill", fmt.Sprintf("%s (%s)", selectorFlag, strings.Join(names, ", ")), func() error {
+		for _, p := range victims {
+			if err := internal.DeletePod(p); err != nil {
+				return err
+			}
+			fmt.Printf("💥 Deleted %s/%s\n", p.Metadata.Namespace, p.Metadata.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !watch {
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("🔄 Watching for %d ready pod(s) (up to %s, press Ctrl+C to stop)...\n", readyBefore, timeout)
+	return watchRecovery(readyBefore, killedAt, timeout)
+}
+
+// watchRecovery polls the matching pods until as many are ready as before the kill,
+// reporting changes in the ready count. Deleted pods stop counting as ready as soon as
+// they are marked for deletion.
+func watchRecovery(readyBefore int, killedAt time.Time, timeout time.Duration) error {
+	lastReady := -1
+	for {
+		pods, err := internal.GetApplicationPods()
+		if err != nil {
+			return fmt.Errorf("failed to get pods: %w", err)
+		}
+		ready := internal.CountReady(pods)
+
+		elapsed := time.Since(killedAt).Round(100 * time.Millisecond)
+		if ready != lastReady {
+			fmt.Printf("   %6s  %d/%d ready\n", elapsed, ready, readyBefore)
+			lastReady = ready
+		}
+		if ready >= readyBefore {
+			fmt.Println()
+			fmt.Printf("✅ Recovered in %s\n", elapsed)
+			return nil
+		}
+		if elapsed > timeout {
+			fmt.Println()
+			fmt.Printf("❌ Not recovered after %s: %d/%d pod(s) ready\n", timeout, ready, readyBefore)
+			fmt.Println("💡 Check the pods with: gcpeasy pod list")
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete finished jobs, dead pods and orphaned ReplicaSets",
+	Long: `Find leftovers in application namespaces and delete them after showing the list:
+
+  - Jobs that succeeded or failed more than --older-than ago (default: 7d)
+  - Evicted, Failed and Completed pods that aren't managed by a Job
+  - ReplicaSets with no replicas that no Deployment owns anymore
+
+Use --dry-run to only show what would be deleted. Respects -n/--namespace and the
+namespace chosen with 'ns select'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if err := cleanupCluster(olderThan, dryRun); err != nil {
+			fmt.Printf("Error cleaning up: %v\n", err)
+		}
+	},
+}
+
+var cleanupGCPCmd = &cobra.Command{
+	Use:   "gcp",
+	Short: "Find and delete unused GCP resources",
+	Long: `Find resources in the current project that cost money without being used, with an
+estimate of the monthly waste:
+
+  - Persistent disks not attached to any VM
+  - Reserved external IP addresses that aren't in use
+  - Cloud SQL on-demand backups and exports to Cloud Storage older than --older-than
+    (default: 30d); automated backups are left to the instance's retention settings
+
+Choose which items to delete, then confirm. Disks provisioned for a PersistentVolumeClaim
+are marked, as the claim may still exist (e.g. a StatefulSet scaled to zero or a paused
+environment) and deleting the disk loses its data; each one must be confirmed on its own,
+even when selected with 'all'. Use --dry-run to only list them. Estimates use approximate
+us-central1 list prices.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if err := cleanupGCP(olderThan, dryRun); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error cleaning up: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	cleanupCmd.Flags().String("older-than", "7d", "Only delete jobs that finished longer ago than this (e.g. 1d, 30d)")
+	cleanupCmd.Flags().Bool("dry-run", false, "Show what would be deleted without deleting anything")
+	// Not persistent, as with addNamespaceFlag: cleanup gcp works on the project, not namespaces
+	cleanupCmd.Flags().StringVarP(&namespaceFlag, "namespace", "n", "", "Only clean up this namespace (default: the namespace chosen with 'ns select', or all)")
+	cleanupGCPCmd.Flags().String("older-than", "30d", "Only consider Cloud SQL backups and exports older than this")
+	cleanupGCPCmd.Flags().Bool("dry-run", false, "Show unused resources without deleting anything")
+	cleanupCmd.AddCommand(cleanupGCPCmd)
+	rootCmd.AddCommand(cleanupCmd)
+}
+
+func cleanupCluster(olderThanFlag string, dryRun bool) error {
+	olderThan, err := internal.ParseDuration(olderThanFlag)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	fmt.Printf("🔍 Looking for jobs finished more than %s ago, dead pods and orphaned ReplicaSets...\n", olderThanFlag)
+	garbage, err := internal.FindClusterGarbage(olderThan)
+	if err != nil {
+		return err
+	}
+	if len(garbage) == 0 {
+		fmt.Println("✅ Nothing to clean up")
+		return nil
+	}
+	fmt.Println()
+
+	fmt.Printf("%-11s %-15s %-45s %-22s %s\n", "KIND", "NAMESPACE", "NAME", "REASON", "AGE")
+	fmt.Println(strings.Repeat("-", 105))
+	counts := make(map[string]int)
+	for _, g := range garbage {
+		counts[g.Kind]++
+		age := "-"
+		if g.Age > 0 {
+			age = internal.FormatDuration(g.Age)
+		}
+		fmt.Printf("%-11s %-15s %-45s %-22s %s\n", g.Kind, truncate(g.Namespace, 15), truncate(g.Name, 45), truncate(g.Reason, 22), age)
+	}
+	fmt.Println()
+
+	summary := fmt.Sprintf("%d job(s), %d pod(s) and %d ReplicaSet(s)", counts["job"], counts["pod"], counts["replicaset"])
+	if dryRun {
+		fmt.Printf("💡 Dry run: would delete %s\n", summary)
+		return nil
+	}
+
+	if !confirmProtected(currentProject, "delete "+summary) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Delete %s?", summary)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("cleanup", summary, func() error {
+		return internal.DeleteGarbage(garbage)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted %s\n", summary)
+	return nil
+}
+
+func cleanupGCP(olderThanFlag string, dryRun bool) error {
+	olderThan, err := internal.ParseDuration(olderThanFlag)
+	if err != nil {
+		return err
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Println("🔍 Looking for unattached disks, unused static IPs and old Cloud SQL backups and exports...")
+	unused, warnings, err := internal.FindUnusedResources(currentProject, olderThan)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Printf("⚠️  Warning: %s\n", w)
+	}
+	if len(unused) == 0 {
+		fmt.Println("✅ No unused resources found")
+		return nil
+	}
+	fmt.Println()
+
+	total := 0.0
+	claimed := 0
+	fmt.Printf("%-4s %-11s %-40s %-18s %-22s %-8s %s\n", "#", "KIND", "NAME", "LOCATION", "DETAIL", "AGE", "$/MONTH")
+	fmt.Println(strings.Repeat("-", 120))
+	for i, r := range unused {
+		total += r.MonthlyCost
+		age, cost := "-", "-"
+		if r.Age > 0 {
+			age = internal.FormatDuration(r.Age)
+		}
+		if r.MonthlyCost > 0 {
+			cost = fmt.Sprintf("%.2f", r.MonthlyCost)
+		}
+		fmt.Printf("%-4d %-11s %-40s %-18s %-22s %-8s %s\n", i+1, r.Kind, truncate(r.Name, 40), truncate(r.Location, 18), truncate(r.Detail, 22), age, cost)
+		if r.PVC != "" {
+			fmt.Printf("     🔒 provisioned for PVC %s\n", r.PVC)
+			claimed++
+		}
+	}
+	fmt.Println()
+	if claimed > 0 {
+		fmt.Printf("⚠️  %d disk(s) were provisioned for a PersistentVolumeClaim that may still exist, e.g. for a StatefulSet scaled to zero; deleting them loses their data\n", claimed)
+	}
+	fmt.Printf("💰 Estimated waste: ~$%.2f per month (approximate list prices; backup storage not included)\n", total)
+
+	if dryRun {
+		return nil
+	}
+	fmt.Println()
+
+	indexes, err := selectMany("Select items to delete", len(unused))
+	if err != nil {
+		return err
+	}
+	selected := make([]internal.UnusedResource, 0, len(indexes))
+	saving := 0.0
+	for _, i := range indexes {
+		r := unused[i]
+		if r.PVC != "" && !confirm(fmt.Sprintf("Disk %s was provisioned for PVC %s. Delete it and its data anyway?", r.Name, r.PVC)) {
+			fmt.Printf("   Keeping %s\n", r.Name)
+			continue
+		}
+		selected = append(selected, r)
+		saving += r.MonthlyCost
+	}
+	if len(selected) == 0 {
+		fmt.Println("Nothing to delete.")
+		return nil
+	}
+
+	summary := fmt.Sprintf("%d unused resource(s)", len(selected))
+	if !confirmProtected(currentProject, "delete "+summary) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Permanently delete %s (~$%.2f per month)?", summary, saving)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	failed := 0
+	err = runNotified("cleanup gcp", summary, func() error {
+		for _, r := range selected {
+			if err := internal.DeleteUnusedResource(currentProject, r); err != nil {
+				fmt.Printf("❌ %s %s: %v\n", r.Kind, r.Name, err)
+				failed++
+				continue
+			}
+			fmt.Printf("✅ Deleted %s %s\n", r.Kind, r.Name)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d resources could not be deleted", failed, len(selected))
+		}
+		return nil
+	})
+	return err
+}
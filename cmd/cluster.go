@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"gcpeasy/internal"
+	"github.com/scttymn/gcpeasy/internal"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -200,6 +200,7 @@ func selectClusterByIdentifier(identifier string) error {
 
 func switchToCluster(projectID string, cluster internal.ClusterInfo) error {
 	fmt.Printf("Switching to cluster: %s in %s\n", cluster.Name, cluster.Location)
+	fmt.Printf("🔧 Getting credentials for cluster %s in %s...\n", cluster.Name, cluster.Location)
 
 	if err := internal.ConfigureKubectl(projectID, cluster); err != nil {
 		return fmt.Errorf("failed to switch cluster: %w", err)
@@ -223,4 +224,34 @@ func isCurrentCluster(cluster internal.ClusterInfo, currentContext string) bool
 	// kubectl context format is typically gke_PROJECT_ZONE_CLUSTER-NAME
 	// We'll check if the context contains the cluster name
 	return strings.Contains(currentContext, cluster.Name)
-}
\ No newline at end of file
+}
+
+// resolveCluster finds a cluster in the project by name, defaulting to the cluster of the
+// current kubectl context
+func resolveCluster(projectID, name string) (*internal.ClusterInfo, error) {
+	if name == "" {
+		if err := ensureCluster(projectID); err != nil {
+			return nil, err
+		}
+		context, err := internal.GetCurrentCluster()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current cluster: %w", err)
+		}
+		location, clusterName, ok := internal.ParseClusterContext(context)
+		if !ok {
+			return nil, fmt.Errorf("current context %s is not a GKE cluster", context)
+		}
+		return &internal.ClusterInfo{Name: clusterName, Location: location}, nil
+	}
+
+	clusters, err := internal.GetGKEClusters(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clusters: %w", err)
+	}
+	for i := range clusters {
+		if clusters[i].Name == name {
+			return &clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cluster '%s' not found, see 'gcpeasy cluster list'", name)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var clusterDeleteCmd = &cobra.Command{
+	Use:   "delete <cluster-name>",
+	Short: "Delete a GKE cluster",
+	Long: `Delete a GKE cluster in the current project.
+
+The cluster name must be typed to confirm. Clusters in protected environments are never
+deleted, and clusters still running pods in application namespaces are refused unless
+--force is given. The cluster's kubectl contexts are removed afterwards.
+
+With --cleanup, load balancer forwarding rules and persistent disks the cluster created
+and left behind are listed and can be deleted too.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		cleanup, _ := cmd.Flags().GetBool("cleanup")
+		if err := deleteCluster(args[0], force, cleanup); err != nil {
+			fmt.Printf("Error deleting cluster: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	clusterDeleteCmd.Flags().Bool("force", false, "Delete even if application namespaces still have pods")
+	clusterDeleteCmd.Flags().Bool("cleanup", false, "Offer to delete load balancers and disks left behind by the cluster")
+	clusterCmd.AddCommand(clusterDeleteCmd)
+}
+
+func deleteCluster(name string, force, cleanup bool) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Environment(currentProject).Protected {
+		fmt.Printf("🛡️  %s is a protected environment; refusing to delete cluster %s.\n", currentProject, name)
+		return nil
+	}
+
+	cluster, err := resolveCluster(currentProject, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Inspecting cluster %s in %s...\n", cluster.Name, cluster.Location)
+	context, err := internal.EnsureClusterContext(currentProject, *cluster)
+	if err != nil {
+		return err
+	}
+
+	usage, err := internal.AppNamespaceUsage(context)
+	if err != nil {
+		fmt.Printf("⚠️  Could not list workloads in the cluster: %v\n", err)
+		if !force {
+			fmt.Println("💡 Use --force to delete it anyway")
+			return nil
+		}
+	} else if len(usage) > 0 {
+		namespaces := make([]string, 0, len(usage))
+		for ns := range usage {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+
+		fmt.Println("⚠️  Application namespaces still have running pods:")
+		for _, ns := range namespaces {
+			fmt.Printf("   %-30s %d pods\n", ns, usage[ns])
+		}
+		if !force {
+			fmt.Println("💡 Remove the workloads first, or use --force to delete the cluster anyway")
+			return nil
+		}
+	}
+
+	var footprint *internal.ClusterFootprint
+	if cleanup {
+		footprint, err = internal.CaptureClusterFootprint(context)
+		if err != nil {
+			fmt.Printf("⚠️  Could not record load balancers and disks, cleanup will be skipped: %v\n", err)
+		}
+	}
+
+	if labels, err := internal.GetClusterLabels(currentProject, *cluster); err == nil {
+		warnManaged("cluster "+cluster.Name, labels, nil)
+	}
+
+	fmt.Println()
+	fmt.Printf("This will permanently delete cluster %s (%s) in %s.\n", cluster.Name, cluster.Location, currentProject)
+	internal.EmitEvent(internal.EventPrompt, map[string]any{"prompt": "confirm-name", "message": "Type the cluster name to confirm"})
+	fmt.Print("Type the cluster name to confirm: ")
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != cluster.Name {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	fmt.Printf("🗑️  Deleting cluster %s (this can take several minutes)...\n", cluster.Name)
+	err = runNotified("cluster delete", fmt.Sprintf("%s (%s)", cluster.Name, cluster.Location), func() error {
+		return internal.DeleteCluster(currentProject, *cluster)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted cluster %s\n", cluster.Name)
+
+	removed, err := internal.RemoveClusterContexts(currentProject, *cluster)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to remove kubectl contexts: %v\n", err)
+	}
+	for _, name := range removed {
+		fmt.Printf("🧹 Removed kubectl context %s\n", name)
+	}
+	internal.InvalidatePreflightCache()
+
+	if footprint != nil {
+		return cleanupOrphans(currentProject, footprint)
+	}
+	return nil
+}
+
+func cleanupOrphans(projectID string, footprint *internal.ClusterFootprint) error {
+	fmt.Println("🔍 Looking for load balancers and disks left behind...")
+	orphans, err := internal.FindOrphanedResources(projectID, footprint)
+	if err != nil {
+		return fmt.Errorf("failed to look for orphaned resources: %w", err)
+	}
+	if len(footprint.RetainedDisks) > 0 {
+		fmt.Printf("💾 Keeping %d disk(s) of PersistentVolumes with reclaim policy Retain:\n", len(footprint.RetainedDisks))
+		for _, disk := range sortedKeys(footprint.RetainedDisks) {
+			fmt.Printf("   %s (%s)\n", disk, footprint.RetainedDisks[disk])
+		}
+	}
+	if len(orphans) == 0 {
+		fmt.Println("✅ Nothing was left behind")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("%-16s %-34s %-16s %s\n", "KIND", "NAME", "LOCATION", "BELONGED TO")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, orphan := range orphans {
+		fmt.Printf("%-16s %-34s %-16s %s\n", orphan.Kind, truncate(orphan.Name, 34), orphan.Location, orphan.Owner)
+	}
+	fmt.Println()
+
+	if !confirm(fmt.Sprintf("Delete these %d resources?", len(orphans))) {
+		fmt.Println("Left in place.")
+		return nil
+	}
+
+	failed := 0
+	for _, orphan := range orphans {
+		if err := internal.DeleteOrphanedResource(projectID, orphan); err != nil {
+			fmt.Printf("❌ %s %s: %v\n", orphan.Kind, orphan.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Deleted %s %s\n", orphan.Kind, orphan.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d resources could not be deleted", failed, len(orphans))
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var clusterMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance [cluster-name]",
+	Short: "Show maintenance windows and GKE operations",
+	Long:  "Show the cluster's maintenance window and exclusions, node pool auto-upgrade and auto-repair settings, and scheduled, in-progress and recent GKE operations such as upgrades and node repairs. Defaults to the current cluster.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+		if err := showClusterMaintenance(name, since); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error showing maintenance: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	clusterMaintenanceCmd.Flags().String("since", "7d", "How far back to show finished operations (e.g. 24h, 30d)")
+	clusterCmd.AddCommand(clusterMaintenanceCmd)
+}
+
+func showClusterMaintenance(name, sinceFlag string) error {
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	cluster, err := resolveCluster(currentProject, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Checking maintenance for cluster %s (%s)...\n", cluster.Name, cluster.Location)
+	maintenance, err := internal.GetClusterMaintenance(currentProject, *cluster)
+	if err != nil {
+		return fmt.Errorf("failed to describe cluster: %w", err)
+	}
+	operations, err := internal.GetClusterOperations(currentProject, *cluster, since)
+	if err != nil {
+		return fmt.Errorf("failed to list operations: %w", err)
+	}
+
+	now := time.Now()
+	fmt.Println()
+	fmt.Printf("Release channel: %s\n", orDash(maintenance.ReleaseChannel))
+	fmt.Printf("Control plane:   %s\n", orDash(maintenance.MasterVersion))
+	if maintenance.Window != "" {
+		fmt.Printf("Window:          %s\n", maintenance.Window)
+	} else {
+		fmt.Println("Window:          " + internal.Colorize(internal.ColorYellow, "none (GKE may perform maintenance at any time)"))
+	}
+
+	if len(maintenance.Exclusions) > 0 {
+		fmt.Println()
+		fmt.Println("📋 Maintenance exclusions:")
+		fmt.Printf("%-28s %-18s %-18s %-24s %s\n", "NAME", "START", "END", "SCOPE", "STATE")
+		fmt.Println(strings.Repeat("-", 100))
+		for _, e := range maintenance.Exclusions {
+			state := ""
+			switch {
+			case e.Active(now):
+				state = internal.Colorize(internal.ColorGreen, "active")
+			case now.Before(e.Start):
+				state = "upcoming"
+			default:
+				state = "expired"
+			}
+			fmt.Printf("%-28s %-18s %-18s %-24s %s\n", truncate(e.Name, 28), formatMaintenanceTime(e.Start), formatMaintenanceTime(e.End), e.Scope, state)
+		}
+	}
+
+	if len(maintenance.NodePools) > 0 {
+		fmt.Println()
+		fmt.Println("📋 Node pools:")
+		fmt.Printf("%-28s %-22s %-16s %-14s %s\n", "NAME", "VERSION", "STATUS", "AUTO-UPGRADE", "AUTO-REPAIR")
+		fmt.Println(strings.Repeat("-", 95))
+		for _, pool := range maintenance.NodePools {
+			fmt.Printf("%-28s %-22s %-16s %-14s %s\n", truncate(pool.Name, 28), pool.Version, pool.Status, onOff(pool.AutoUpgrade), onOff(pool.AutoRepair))
+		}
+		if maintenance.MasterVersion != "" {
+			for _, pool := range maintenance.NodePools {
+				if pool.Version != maintenance.MasterVersion && pool.AutoUpgrade {
+					fmt.Printf("💡 Node pool %s (%s) is behind the control plane and will be upgraded in a maintenance window\n", pool.Name, pool.Version)
+				}
+			}
+		}
+	}
+
+	fmt.Println()
+	if len(operations) == 0 {
+		fmt.Printf("✅ No operations in progress or in the last %s\n", sinceFlag)
+		return nil
+	}
+
+	fmt.Printf("📋 Operations (in progress, and finished in the last %s):\n", sinceFlag)
+	fmt.Printf("%-26s %-10s %-36s %-18s %s\n", "TYPE", "STATUS", "TARGET", "STARTED", "DURATION")
+	fmt.Println(strings.Repeat("-", 105))
+	running := 0
+	for _, op := range operations {
+		end := op.End
+		if op.InProgress() {
+			end = now
+			running++
+		}
+		duration := "-"
+		if !op.Start.IsZero() {
+			duration = end.Sub(op.Start).Round(time.Second).String()
+		}
+		line := fmt.Sprintf("%-26s %-10s %-36s %-18s %s", op.Type, op.Status, truncate(op.Target, 36), formatMaintenanceTime(op.Start), duration)
+		if op.InProgress() {
+			line = internal.Colorize(internal.ColorYellow, line)
+		}
+		fmt.Println(line)
+		if op.InProgress() && op.Detail != "" {
+			fmt.Printf("   %s\n", op.Detail)
+		}
+	}
+
+	if running > 0 {
+		fmt.Println()
+		fmt.Printf("⚠️  %d operation(s) in progress; nodes may be drained and restarted until they finish\n", running)
+	}
+	return nil
+}
+
+func formatMaintenanceTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
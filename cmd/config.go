@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Secret and ConfigMap commands",
+	Long:  "Commands for the Secrets and ConfigMaps workloads in application namespaces use.",
+}
+
+var configRolloutCmd = &cobra.Command{
+	Use:   "rollout <secret|configmap> <name>",
+	Short: "Restart the deployments using a Secret or ConfigMap",
+	Long: `Find the deployments whose pods mount a Secret or ConfigMap as a volume or read it into
+environment variables, and trigger a rolling restart of exactly those, e.g. after
+rotating a secret. Environment variables are only read when a container starts, and
+many applications only read mounted files once.
+
+The object can be given as namespace/name or a name, which must then exist in only one
+application namespace (or the namespace chosen with -n or 'ns select').`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if err := rolloutConfig(args[0], args[1], dryRun); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error restarting deployments: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	configRolloutCmd.Flags().Bool("dry-run", false, "Only show the deployments that would be restarted")
+	addNamespaceFlag(configCmd)
+	configCmd.AddCommand(configRolloutCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func rolloutConfig(kindArg, target string, dryRun bool) error {
+	kind, err := internal.ParseConfigKind(kindArg)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	namespace, name, qualified := strings.Cut(target, "/")
+	if !qualified {
+		namespace, name = "", target
+		namespaces, err := internal.FindConfigObject(kind, name)
+		if err != nil {
+			return fmt.Errorf("failed to find %s %s: %w", kind, name, err)
+		}
+		switch len(namespaces) {
+		case 0:
+			return fmt.Errorf("%s %s not found", kind, name)
+		case 1:
+			namespace = namespaces[0]
+		default:
+			return fmt.Errorf("%s %s exists in several namespaces (%s), use namespace/name", kind, name, strings.Join(namespaces, ", "))
+		}
+	}
+
+	fmt.Printf("🔍 Looking for deployments using %s %s/%s...\n", kind, namespace, name)
+	consumers, err := internal.FindConfigConsumers(kind, namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	fmt.Println()
+
+	if len(consumers) == 0 {
+		fmt.Printf("✅ No deployments in %s use %s %s\n", namespace, kind, name)
+		return nil
+	}
+
+	fmt.Printf("%-35s %-8s %s\n", "DEPLOYMENT", "READY", "USES")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, c := range consumers {
+		d := c.Deployment
+		fmt.Printf("%-35s %-8s %s\n",
+			truncate(d.Metadata.Name, 35),
+			fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.DesiredReplicas()),
+			strings.Join(c.Uses, ", "))
+	}
+	for _, c := range consumers {
+		d := c.Deployment
+		warnManaged("deployment "+d.Metadata.Name, d.Metadata.Labels, d.Metadata.Annotations)
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Printf("📋 %d deployment(s) would be restarted (dry run)\n", len(consumers))
+		return nil
+	}
+
+	if !confirmProtected(currentProject, fmt.Sprintf("restart the deployments using %s %s", kind, name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Restart %d deployment(s)? Their pods are replaced one rollout at a time", len(consumers))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	var failed []string
+	err = runNotified("config rollout", fmt.Sprintf("%d deployment(s) using %s %s/%s", len(consumers), kind, namespace, name), func() error {
+		for _, c := range consumers {
+			if err := internal.RestartDeployment(c.Deployment); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				failed = append(failed, c.Deployment.Metadata.Name)
+				continue
+			}
+			fmt.Printf("🔄 Restarting %s\n", c.Deployment.Metadata.Name)
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to restart %s", strings.Join(failed, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ Restarted %d deployment(s)\n", len(consumers))
+	fmt.Printf("💡 Watch a rollout with: kubectl rollout status deployment/<name> -n %s\n", namespace)
+	return nil
+}
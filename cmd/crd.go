@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var crdCmd = &cobra.Command{
+	Use:   "crd",
+	Short: "Custom resource definition commands",
+	Long:  "Commands for discovering the custom resource definitions installed in the current cluster.",
+}
+
+var crdListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List custom resource definitions",
+	Long:  "List the CRDs installed in the cluster, grouped by API group, with the names usable in 'gcpeasy cr get'.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listCRDs(); err != nil {
+			fmt.Printf("Error listing CRDs: %v\n", err)
+		}
+	},
+}
+
+var crCmd = &cobra.Command{
+	Use:   "cr",
+	Short: "Custom resource commands",
+	Long:  "Commands for inspecting instances of custom resources such as Certificates or ScaledObjects.",
+}
+
+var crGetCmd = &cobra.Command{
+	Use:   "get <kind>",
+	Short: "List instances of a custom resource with their status conditions",
+	Long:  "List instances of a custom resource kind with their status conditions. The kind may be given as kind, plural, short name or kind.group.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if err := getCustomResources(args[0], namespace); err != nil {
+			fmt.Printf("Error getting custom resources: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	crGetCmd.Flags().StringP("namespace", "n", "", "Namespace to list (default: all namespaces)")
+	crdCmd.AddCommand(crdListCmd)
+	crCmd.AddCommand(crGetCmd)
+	rootCmd.AddCommand(crdCmd)
+	rootCmd.AddCommand(crCmd)
+}
+
+func listCRDs() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Discovering custom resource definitions...")
+	crds, err := internal.GetCRDs()
+	if err != nil {
+		return fmt.Errorf("failed to get CRDs: %w", err)
+	}
+
+	if len(crds) == 0 {
+		fmt.Println("No custom resource definitions found.")
+		return nil
+	}
+
+	fmt.Println()
+	group := ""
+	for _, c := range crds {
+		if c.Spec.Group != group {
+			if group != "" {
+				fmt.Println()
+			}
+			group = c.Spec.Group
+			fmt.Printf("📦 %s\n", group)
+			fmt.Printf("   %-30s %-30s %-12s %-10s %s\n", "KIND", "PLURAL", "SCOPE", "VERSION", "SHORT NAMES")
+		}
+		fmt.Printf("   %-30s %-30s %-12s %-10s %s\n",
+			truncate(c.Spec.Names.Kind, 30),
+			truncate(c.Spec.Names.Plural, 30),
+			c.Spec.Scope,
+			c.StorageVersion(),
+			orDash(strings.Join(c.Spec.Names.ShortNames, ",")),
+		)
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %d CRDs\n", len(crds))
+	fmt.Println("💡 Use 'gcpeasy cr get <kind>' to list instances")
+	return nil
+}
+
+func getCustomResources(kind, namespace string) error {
+	if !setupCluster() {
+		return nil
+	}
+
+	crd, err := internal.FindCRD(kind)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Listing %s...\n", crd.Resource())
+	resources, err := internal.GetCustomResources(*crd, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", crd.Resource(), err)
+	}
+
+	if len(resources) == 0 {
+		fmt.Printf("No %s found.\n", crd.Spec.Names.Plural)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("%-20s %-40s %-8s %-8s %s\n", "NAMESPACE", "NAME", "READY", "AGE", "CONDITIONS")
+	fmt.Println(strings.Repeat("-", 110))
+
+	var unhealthy []string
+	for _, r := range resources {
+		ready := "-"
+		if c, ok := r.Condition("Ready"); ok {
+			ready = c.Status
+		}
+
+		var conditions []string
+		for _, c := range r.Status.Conditions {
+			conditions = append(conditions, fmt.Sprintf("%s=%s", c.Type, c.Status))
+			if !c.Healthy() {
+				detail := c.Reason
+				if c.Message != "" {
+					detail += ": " + c.Message
+				}
+				unhealthy = append(unhealthy, fmt.Sprintf("%s/%s %s=%s (%s)", orDash(r.Metadata.Namespace), r.Metadata.Name, c.Type, c.Status, detail))
+			}
+		}
+
+		readyCol := fmt.Sprintf("%-8s", ready)
+		switch ready {
+		case "True":
+			readyCol = internal.Colorize(internal.ColorGreen, readyCol)
+		case "False":
+			readyCol = internal.Colorize(internal.ColorRed, readyCol)
+		}
+
+		fmt.Printf("%-20s %-40s %s %-8s %s\n",
+			truncate(orDash(r.Metadata.Namespace), 20),
+			truncate(r.Metadata.Name, 40),
+			readyCol,
+			r.Age(),
+			orDash(strings.Join(conditions, ", ")),
+		)
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %d %s\n", len(resources), crd.Spec.Names.Plural)
+
+	if len(unhealthy) > 0 {
+		fmt.Println()
+		fmt.Println("⚠️  Unhealthy conditions:")
+		for _, u := range unhealthy {
+			fmt.Printf("   %s\n", u)
+		}
+	}
+	return nil
+}
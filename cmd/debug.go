@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Connectivity debugging commands",
+	Long:  "Commands for diagnosing connectivity problems from inside the cluster.",
+}
+
+var debugDNSCmd = &cobra.Command{
+	Use:   "dns <hostname>",
+	Short: "Compare DNS resolution in the cluster and locally",
+	Long: `Resolve a hostname from a throwaway pod in the cluster and from the local machine, compare
+the answers, and check the health of the cluster DNS (kube-dns/CoreDNS and NodeLocal DNSCache).
+
+In the cluster the name is resolved like applications resolve it, through the pod's search
+domains and ndots setting, so "works locally, NXDOMAIN in the cluster" can be reproduced.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("pod-namespace")
+		if err := debugDNS(args[0], namespace); err != nil {
+			fmt.Printf("Error debugging DNS: %v\n", err)
+		}
+	},
+}
+
+var debugEgressCmd = &cobra.Command{
+	Use:   "egress <host:port>",
+	Short: "Test outbound connectivity from the cluster and check Cloud NAT",
+	Long: `Open a TCP connection to host:port from a throwaway pod in the cluster, report the public
+source IP outbound traffic appears to come from (a Cloud NAT gateway or a node's external
+IP), and check the Cloud NAT metrics for port exhaustion and dropped packets, which are the
+usual reason outbound API calls time out under load.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("pod-namespace")
+		since, _ := cmd.Flags().GetString("since")
+		if err := debugEgress(args[0], namespace, since); err != nil {
+			fmt.Printf("Error debugging egress: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	debugDNSCmd.Flags().String("pod-namespace", "default", "Namespace to run the throwaway pod in (search domains depend on it)")
+	debugEgressCmd.Flags().String("pod-namespace", "default", "Namespace to run the throwaway pod in")
+	debugEgressCmd.Flags().String("since", "1h", "Time window for the Cloud NAT metrics (e.g. 30m, 6h, 1d)")
+	debugCmd.AddCommand(debugDNSCmd)
+	debugCmd.AddCommand(debugEgressCmd)
+	rootCmd.AddCommand(debugCmd)
+}
+
+func debugDNS(host, namespace string) error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Printf("🔍 Resolving %s locally...\n", host)
+	local := internal.LookupHostLocal(host)
+
+	fmt.Printf("🔍 Resolving %s from a throwaway pod in namespace %s...\n", host, namespace)
+	cluster := internal.LookupHostInCluster(namespace, host)
+	if cluster.Err != nil && cluster.Status == "" {
+		fmt.Printf("⚠️  Could not run the DNS check pod: %v\n", cluster.Err)
+	}
+
+	fmt.Println()
+	fmt.Printf("%-10s %-10s %-10s %s\n", "FROM", "STATUS", "TIME", "ADDRESSES")
+	fmt.Println(strings.Repeat("-", 80))
+	printDNSLookup("local", local)
+	if cluster.Err == nil || cluster.Status != "" {
+		printDNSLookup("cluster", cluster)
+	}
+	if len(cluster.Nameservers) > 0 {
+		fmt.Println()
+		fmt.Printf("📋 Pod resolver: nameserver %s, ndots %s\n", strings.Join(cluster.Nameservers, ", "), orDash(cluster.Ndots))
+		fmt.Printf("   search %s\n", strings.Join(cluster.Search, " "))
+	}
+
+	fmt.Println()
+	explainDNSDifference(host, local, cluster)
+
+	fmt.Println()
+	fmt.Println("🔍 Checking cluster DNS...")
+	dnsPods, nodeLocal, err := internal.GetClusterDNSPods()
+	if err != nil {
+		return fmt.Errorf("failed to get DNS pods: %w", err)
+	}
+	reportDNSPods("kube-dns/CoreDNS", dnsPods, true)
+	reportDNSPods("NodeLocal DNSCache", nodeLocal, false)
+	return nil
+}
+
+func printDNSLookup(from string, lookup internal.DNSLookup) {
+	status := orDash(lookup.Status)
+	switch lookup.Status {
+	case "NOERROR":
+		status = internal.Colorize(internal.ColorGreen, fmt.Sprintf("%-10s", status))
+	case "":
+	default:
+		status = internal.Colorize(internal.ColorRed, fmt.Sprintf("%-10s", status))
+	}
+	addresses := "-"
+	if lookup.Resolved() {
+		addresses = strings.Join(lookup.Addresses, ", ")
+	}
+	fmt.Printf("%-10s %-10s %-10s %s\n", from, status, lookup.Elapsed.Round(time.Millisecond), addresses)
+}
+
+// explainDNSDifference prints the likely cause of a mismatch between local and in-cluster
+// resolution
+func explainDNSDifference(host string, local, cluster internal.DNSLookup) {
+	dots := strings.Count(strings.TrimSuffix(host, "."), ".")
+	switch {
+	case cluster.Status == "" && cluster.Err != nil:
+		fmt.Println("⚠️  No in-cluster answer to compare with")
+	case local.Resolved() && cluster.Resolved():
+		if slices.Equal(local.Addresses, cluster.Addresses) {
+			fmt.Println("✅ The cluster and the local machine get the same answer")
+		} else {
+			fmt.Println("⚠️  The cluster and the local machine get different answers")
+			fmt.Println("💡 This is expected for split-horizon names (a Cloud DNS private zone or internal load balancer); otherwise check which zone the cluster's VPC uses")
+		}
+		if dots < 4 && !strings.HasSuffix(host, ".") && cluster.Elapsed > 500*time.Millisecond {
+			fmt.Printf("💡 Slow in-cluster lookup: with ndots %s the search domains are tried first. Use %s. (trailing dot) in configuration to skip them\n", orDash(cluster.Ndots), host)
+		}
+	case local.Resolved() && !cluster.Resolved():
+		fmt.Printf("❌ Resolves locally but %s in the cluster\n", orDash(cluster.Status))
+		fmt.Println("💡 Likely causes:")
+		fmt.Println("   - The name comes from a private zone, /etc/hosts or VPN DNS on your machine that the cluster's VPC can't see (attach the Cloud DNS private zone to the VPC or add a forwarding zone)")
+		fmt.Println("   - A Cloud DNS response policy or stub domain in the kube-dns ConfigMap overrides it")
+		if cluster.Status == "TIMEOUT" || cluster.Status == "SERVFAIL" {
+			fmt.Println("   - The cluster DNS or its upstream isn't answering (see the DNS pods below, and egress to 169.254.169.254:53)")
+		}
+	case !local.Resolved() && cluster.Resolved():
+		if dots == 0 || strings.HasSuffix(host, ".cluster.local") || strings.HasSuffix(host, ".svc") {
+			fmt.Println("✅ Resolves in the cluster only; expected for Kubernetes service names")
+		} else {
+			fmt.Println("⚠️  Resolves in the cluster only, e.g. from a private zone attached to the cluster's VPC")
+		}
+	default:
+		fmt.Printf("❌ Doesn't resolve locally (%s) or in the cluster (%s)\n", orDash(local.Status), orDash(cluster.Status))
+	}
+}
+
+func reportDNSPods(kind string, pods []internal.ClusterDNSPod, required bool) {
+	if len(pods) == 0 {
+		if required {
+			fmt.Printf("❌ No %s pods found in kube-system\n", kind)
+		} else {
+			fmt.Printf("   %s: not enabled\n", kind)
+		}
+		return
+	}
+
+	ready, restarts := 0, 0
+	for _, p := range pods {
+		if p.Ready {
+			ready++
+		}
+		restarts += p.Restarts
+	}
+	icon := "✅"
+	if ready < len(pods) {
+		icon = "⚠️ "
+	}
+	fmt.Printf("%s %s: %d/%d ready, %d restart(s)\n", icon, kind, ready, len(pods), restarts)
+	for _, p := range pods {
+		if p.Ready && p.Restarts == 0 {
+			continue
+		}
+		detail := fmt.Sprintf("%d restart(s)", p.Restarts)
+		if p.LastReason != "" {
+			detail += ", last: " + p.LastReason
+		}
+		if !p.Ready {
+			detail = "not ready, " + detail
+		}
+		fmt.Printf("   %s on %s: %s\n", p.Name, p.Node, detail)
+	}
+}
+
+// natPortWarning is the port utilization above which NAT port exhaustion is likely
+const natPortWarning = 0.8
+
+func debugEgress(target, namespace, sinceFlag string) error {
+	host, portText, err := net.SplitHostPort(target)
+	if err != nil {
+		return fmt.Errorf("expected host:port, got %q", target)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || port <= 0 || port > 65535 {
+		return fmt.Errorf("invalid port %q", portText)
+	}
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	fmt.Printf("🔍 Connecting to %s from a throwaway pod in namespace %s...\n", target, namespace)
+	probe, err := internal.ProbeEgress(namespace, host, port)
+	if err != nil {
+		return fmt.Errorf("failed to run the egress probe pod: %w", err)
+	}
+	if probe.Connected {
+		fmt.Printf("✅ Connected to %s in %s\n", target, orDash(formatProbeTime(probe.Elapsed)))
+	} else {
+		fmt.Printf("❌ Could not connect to %s: %s\n", target, orDash(probe.Error))
+	}
+
+	gateways, err := internal.GetNATGateways(currentProject)
+	if err != nil {
+		fmt.Printf("⚠️  Could not list Cloud NAT gateways: %v\n", err)
+	}
+	nodeIPs, err := internal.GetNodeExternalIPs()
+	if err != nil {
+		fmt.Printf("⚠️  Could not list node addresses: %v\n", err)
+	}
+
+	fmt.Println()
+	switch {
+	case probe.SourceIP == "":
+		fmt.Println("🌐 Source IP: unknown (no route to the internet, or api.ipify.org is blocked)")
+	default:
+		fmt.Printf("🌐 Source IP: %s (%s)\n", probe.SourceIP, describeSourceIP(probe.SourceIP, gateways, nodeIPs))
+	}
+
+	fmt.Println()
+	fmt.Printf("🔍 Checking Cloud NAT metrics for the last %s...\n", sinceFlag)
+	usage, err := internal.GetNATUsage(currentProject, since)
+	if err != nil {
+		fmt.Printf("⚠️  Could not read Cloud NAT metrics: %v\n", err)
+	} else if len(usage) == 0 {
+		fmt.Println("   No Cloud NAT metrics in this project")
+	} else {
+		printNATUsage(usage)
+	}
+
+	fmt.Println()
+	explainEgress(probe, gateways, usage, len(nodeIPs) > 0)
+	return nil
+}
+
+func formatProbeTime(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// describeSourceIP says where an observed public source IP comes from
+func describeSourceIP(ip string, gateways []internal.NATGateway, nodeIPs map[string]string) string {
+	for _, gw := range gateways {
+		for _, natIP := range gw.IPs {
+			if natIP == ip {
+				return fmt.Sprintf("Cloud NAT %s on router %s in %s", gw.Name, gw.Router, gw.Region)
+			}
+		}
+	}
+	if node, ok := nodeIPs[ip]; ok {
+		return fmt.Sprintf("external IP of node %s", node)
+	}
+	return "not a Cloud NAT or node IP of this project; traffic may leave through a proxy or another NAT"
+}
+
+func printNATUsage(usage []internal.NATUsage) {
+	fmt.Printf("%-25s %-15s %-14s %-14s %-18s %s\n", "GATEWAY", "REGION", "DROPPED (OOR)", "DROPPED (EIM)", "PEAK/ALLOC PORTS", "IP ALLOCATION")
+	fmt.Println(strings.Repeat("-", 105))
+	for _, u := range usage {
+		ports := "-"
+		if u.MinAllocatedPorts > 0 {
+			ports = fmt.Sprintf("%.0f/%.0f (%.0f%%)", u.PeakPortUsage, u.MinAllocatedPorts, u.PortUtilization()*100)
+		}
+		allocation := "ok"
+		if u.AllocationFailed {
+			allocation = internal.Colorize(internal.ColorRed, "FAILED")
+		}
+		fmt.Printf("%-25s %-15s %-14.0f %-14.0f %-18s %s\n", truncate(u.Gateway, 25), u.Region, u.DroppedOutOfResources, u.DroppedEndpointConflict, ports, allocation)
+	}
+}
+
+// explainEgress prints the likely cause of failed or flaky outbound connections
+func explainEgress(probe internal.EgressProbe, gateways []internal.NATGateway, usage []internal.NATUsage, nodesHaveExternalIPs bool) {
+	exhausted, conflicts, allocationFailed := false, false, false
+	for _, u := range usage {
+		exhausted = exhausted || u.DroppedOutOfResources > 0 || u.PortUtilization() >= natPortWarning
+		conflicts = conflicts || u.DroppedEndpointConflict > 0
+		allocationFailed = allocationFailed || u.AllocationFailed
+	}
+
+	if probe.Connected && !exhausted && !conflicts && !allocationFailed {
+		fmt.Println("✅ No egress problems found")
+		return
+	}
+
+	fmt.Println("💡 Findings:")
+	if !probe.Connected {
+		switch {
+		case strings.Contains(probe.Error, "bad address"):
+			fmt.Println("   - The hostname doesn't resolve in the cluster; try 'gcpeasy debug dns'")
+		case strings.Contains(probe.Error, "refused"):
+			fmt.Println("   - The destination refused the connection: it was reached, but nothing listens on that port (or a firewall rejects it)")
+		case len(gateways) == 0 && !nodesHaveExternalIPs && probe.SourceIP == "":
+			fmt.Println("   - Nodes have no external IPs and there is no Cloud NAT: private clusters need Cloud NAT for internet egress")
+		case probe.TimedOut():
+			fmt.Println("   - The connection timed out: check VPC egress firewall rules, the destination's IP allowlist (it must include the source IP above) and Cloud NAT")
+		}
+	}
+	if exhausted {
+		fmt.Println("   - Cloud NAT is running out of ports per VM: raise the minimum ports per VM, enable dynamic port allocation or add NAT IPs")
+	}
+	if conflicts {
+		fmt.Println("   - Packets were dropped by endpoint-independent mapping conflicts: consider disabling endpoint-independent mapping")
+	}
+	if allocationFailed {
+		fmt.Println("   - Cloud NAT failed to allocate IPs: add NAT IPs or switch to automatic allocation")
+	}
+}
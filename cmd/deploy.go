@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployCmd = &cobra.Command{
+	Use:     "deploy",
+	Aliases: []string{"deployment"},
+	Short:   "Deployment commands",
+	Long:    "Commands for inspecting and tuning Deployments in application namespaces.",
+}
+
+func init() {
+	addNamespaceFlag(deployCmd)
+	rootCmd.AddCommand(deployCmd)
+}
+
+// selectDeployment resolves a deployment given as "namespace/name" or a name, or prompts
+// for one when target is empty
+func selectDeployment(target string) (*internal.Deployment, error) {
+	deployments, err := internal.GetDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if target != "" {
+		return internal.FindDeployment(deployments, target)
+	}
+	return internal.SelectDeployment(deployments)
+}
+
+// finishDeploymentChange watches the rollout of a changed deployment unless noWait is set,
+// then prints its replica readiness
+func finishDeploymentChange(d *internal.Deployment, noWait bool, timeout time.Duration) error {
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	if !noWait {
+		fmt.Println()
+		fmt.Printf("🔄 Watching the rollout of %s...\n", name)
+		if err := internal.WatchRollout(*d, timeout); err != nil {
+			return err
+		}
+	}
+
+	current, err := internal.GetDeployment(d.Metadata.Namespace, d.Metadata.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	fmt.Println()
+	ready, desired := current.Status.ReadyReplicas, current.DesiredReplicas()
+	readiness := fmt.Sprintf("%s: %d/%d ready, %d up to date, %d available", name, ready, desired, current.Status.UpdatedReplicas, current.Status.AvailableReplicas)
+	if ready == desired {
+		fmt.Printf("✅ %s\n", readiness)
+	} else {
+		fmt.Printf("⏳ %s\n", readiness)
+		fmt.Printf("💡 Follow it with: gcpeasy pod list --watch --selector '%s'\n", current.Spec.Selector.String())
+	}
+	return nil
+}
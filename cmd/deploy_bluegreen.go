@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployBlueGreenCmd = &cobra.Command{
+	Use:   "bluegreen <service> [image:tag]",
+	Short: "Deploy a new version next to the old one and switch a Service to it",
+	Long: `Blue/green deployment without a service mesh. The Service's selector includes the
+gcpeasy.io/slot label, which is blue or green; the deployment whose pods carry the active
+slot receives all traffic. To set it up, add gcpeasy.io/slot: blue to the selector and pod
+template labels of the current deployment and to the Service's selector.
+
+With an image, the idle slot's deployment is created or replaced as a copy of the active
+one running the new image, with the same replicas (named after the active deployment with
+a -blue or -green suffix). Once its rollout completes, the smoke check runs against one of
+its pods and, if it passes, the Service is switched to it. The old deployment keeps
+running so --rollback can switch back instantly.
+
+The smoke check is --check-path, an HTTP GET that must return a 2xx or 3xx status, and/or
+--check-cmd, a local shell command that must exit 0 and can reach the pod at
+$GCPEASY_SMOKE_URL. Both go through a port-forward to the Service's target port.
+
+The service can be given as namespace/name or a name.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rollback, _ := cmd.Flags().GetBool("rollback")
+		var err error
+		switch {
+		case rollback && len(args) == 2:
+			err = fmt.Errorf("--rollback doesn't take an image")
+		case rollback:
+			err = rollbackBlueGreen(args[0])
+		case len(args) == 1:
+			err = fmt.Errorf("an image is required, e.g. gcpeasy deploy bluegreen %s app:1.4.2 (or --rollback)", args[0])
+		default:
+			var opts blueGreenOptions
+			opts.Container, _ = cmd.Flags().GetString("container")
+			opts.CheckPath, _ = cmd.Flags().GetString("check-path")
+			opts.CheckCmd, _ = cmd.Flags().GetString("check-cmd")
+			opts.Timeout, _ = cmd.Flags().GetDuration("timeout")
+			err = deployBlueGreen(args[0], args[1], opts)
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error in blue/green deployment: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployBlueGreenCmd.Flags().StringP("container", "c", "", "Container to update (default: the only one, or the one running the same image)")
+	deployBlueGreenCmd.Flags().String("check-path", "", "HTTP path the new version must answer with a 2xx or 3xx status before switching (e.g. /healthz)")
+	deployBlueGreenCmd.Flags().String("check-cmd", "", "Shell command that must succeed before switching; $GCPEASY_SMOKE_URL points at the new version")
+	deployBlueGreenCmd.Flags().Duration("timeout", 10*time.Minute, "How long to wait for the new version's rollout")
+	deployBlueGreenCmd.Flags().Bool("rollback", false, "Switch the Service back to the other slot")
+	deployCmd.AddCommand(deployBlueGreenCmd)
+}
+
+// blueGreenOptions are the flags of `deploy bluegreen`
+type blueGreenOptions struct {
+	Container string
+	CheckPath string
+	CheckCmd  string
+	Timeout   time.Duration
+}
+
+// blueGreenTarget finds the Service and the deployments of its slots
+func blueGreenTarget(target string) (*internal.Service, map[string]*internal.Deployment, error) {
+	svc, err := internal.FindService(target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if svc.ActiveSlot() != internal.SlotBlue && svc.ActiveSlot() != internal.SlotGreen {
+		return nil, nil, fmt.Errorf("the selector of service %s has no %s: blue or green label; add it to the Service's selector and the pod labels of its deployment", svc.Metadata.Name, internal.SlotLabel)
+	}
+	slots, err := internal.SlotDeployments(*svc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if slots[svc.ActiveSlot()] == nil {
+		return nil, nil, fmt.Errorf("no deployment has pods labeled %s=%s for service %s", internal.SlotLabel, svc.ActiveSlot(), svc.Metadata.Name)
+	}
+	return svc, slots, nil
+}
+
+func deployBlueGreen(target, image string, opts blueGreenOptions) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	svc, slots, err := blueGreenTarget(target)
+	if err != nil {
+		return err
+	}
+	activeSlot := svc.ActiveSlot()
+	idleSlot := internal.OtherSlot(activeSlot)
+	active := slots[activeSlot]
+	idleName := internal.SlotDeploymentName(*active, idleSlot)
+	if idle := slots[idleSlot]; idle != nil {
+		idleName = idle.Metadata.Name
+	}
+
+	ref := internal.ParseImageRef(image)
+	if ref.Tag == "" && ref.Digest == "" {
+		return fmt.Errorf("%s has no tag, use image:tag", image)
+	}
+	container, err := imageContainer(*active, opts.Container, ref.Name)
+	if err != nil {
+		return err
+	}
+	// name:tag keeps the repository of the image the container runs
+	if current := internal.ParseImageRef(container.Image); ref.Repository == "" && current.Name == ref.Name {
+		ref.Repository = current.Repository
+	}
+	image = ref.String()
+
+	name := svc.Metadata.Namespace + "/" + svc.Metadata.Name
+	fmt.Println()
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📋 Service %s:\n", name)
+	fmt.Printf("   %-6s %-35s %s (live)\n", activeSlot, active.Metadata.Name, container.Image)
+	fmt.Printf("   %-6s %-35s %s\n", idleSlot, idleName, internal.Colorize(internal.ColorGreen, image))
+	if opts.CheckPath == "" && opts.CheckCmd == "" {
+		fmt.Println("⚠️  No smoke check given (--check-path, --check-cmd); traffic switches as soon as the rollout completes")
+	}
+	warnManaged("service "+name, svc.Metadata.Labels, svc.Metadata.Annotations)
+	warnManaged("deployment "+active.Metadata.Name, active.Metadata.Labels, active.Metadata.Annotations)
+	if idle := slots[idleSlot]; idle != nil {
+		warnManaged("deployment "+idle.Metadata.Name, idle.Metadata.Labels, idle.Metadata.Annotations)
+	}
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "switch "+svc.Metadata.Name+" to "+image) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Deploy %s to %s (%d pod(s)) and switch %s to it?", image, idleName, active.DesiredReplicas(), svc.Metadata.Name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	cause := fmt.Sprintf("gcpeasy deploy bluegreen %s=%s", container.Name, image)
+	if account := getActiveAccount(); account != "" {
+		cause += " by " + account
+	}
+	err = runNotified("deploy bluegreen", fmt.Sprintf("%s to %s (%s)", name, image, idleSlot), func() error {
+		if err := internal.DeploySlot(*active, idleSlot, idleName, container.Name, image, cause); err != nil {
+			return err
+		}
+		idle := internal.Deployment{Metadata: internal.ObjectMeta{Name: idleName, Namespace: svc.Metadata.Namespace}}
+		fmt.Println()
+		fmt.Printf("🔄 Watching the rollout of %s...\n", idleName)
+		if err := internal.WatchRollout(idle, opts.Timeout); err != nil {
+			return err
+		}
+
+		if opts.CheckPath != "" || opts.CheckCmd != "" {
+			if err := smokeCheckSlot(*svc, *active, idleSlot, opts); err != nil {
+				fmt.Printf("💡 %s keeps serving; inspect the new version with: gcpeasy pod logs -n %s\n", active.Metadata.Name, svc.Metadata.Namespace)
+				return fmt.Errorf("smoke check failed, not switching: %w", err)
+			}
+		}
+
+		fmt.Printf("🔄 Switching %s to %s...\n", svc.Metadata.Name, idleSlot)
+		return internal.SwitchSlot(*svc, idleSlot)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ %s now sends traffic to %s (%s)\n", name, idleName, image)
+	fmt.Printf("💡 %s keeps running; switch back instantly with: gcpeasy deploy bluegreen %s --rollback\n", active.Metadata.Name, name)
+	fmt.Printf("💡 Once satisfied, free its resources with: kubectl scale deployment/%s -n %s --replicas=0\n", active.Metadata.Name, svc.Metadata.Namespace)
+	return nil
+}
+
+// smokeCheckSlot port-forwards to a pod of a slot and runs the configured checks against it
+func smokeCheckSlot(svc internal.Service, d internal.Deployment, slot string, opts blueGreenOptions) error {
+	pod, err := internal.SlotPod(svc, slot)
+	if err != nil {
+		return err
+	}
+	port, err := internal.TargetContainerPort(svc, d)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔌 Port-forwarding to %s:%d...\n", pod, port)
+	forward, err := internal.StartPortForward(pod, 0, port)
+	if err != nil {
+		return err
+	}
+	defer forward.Close()
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", forward.LocalPort)
+
+	if opts.CheckPath != "" {
+		path := opts.CheckPath
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		fmt.Printf("🔍 GET %s\n", path)
+		client := http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Get(baseURL + path)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("GET %s returned %s", path, resp.Status)
+		}
+		fmt.Printf("✅ GET %s returned %s\n", path, resp.Status)
+	}
+
+	if opts.CheckCmd != "" {
+		fmt.Printf("🔍 Running: %s\n", opts.CheckCmd)
+		cmd := exec.Command("sh", "-c", opts.CheckCmd)
+		cmd.Env = append(os.Environ(), "GCPEASY_SMOKE_URL="+baseURL)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %w", opts.CheckCmd, err)
+		}
+		fmt.Println("✅ Smoke check command passed")
+	}
+	return nil
+}
+
+func rollbackBlueGreen(target string) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	svc, slots, err := blueGreenTarget(target)
+	if err != nil {
+		return err
+	}
+	activeSlot := svc.ActiveSlot()
+	previousSlot := internal.OtherSlot(activeSlot)
+	previous := slots[previousSlot]
+	if previous == nil {
+		return fmt.Errorf("service %s has no %s deployment to switch back to", svc.Metadata.Name, previousSlot)
+	}
+	if previous.Status.ReadyReplicas == 0 {
+		return fmt.Errorf("%s has no ready pods; scale it up first with: kubectl scale deployment/%s -n %s --replicas=%d",
+			previous.Metadata.Name, previous.Metadata.Name, previous.Metadata.Namespace, slots[activeSlot].DesiredReplicas())
+	}
+
+	name := svc.Metadata.Namespace + "/" + svc.Metadata.Name
+	fmt.Println()
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📋 Service %s:\n", name)
+	for _, slot := range []string{activeSlot, previousSlot} {
+		d := slots[slot]
+		images := make([]string, 0, len(d.Spec.Template.Spec.Containers))
+		for _, c := range d.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		marker := ""
+		if slot == activeSlot {
+			marker = " (live)"
+		}
+		fmt.Printf("   %-6s %-35s %d/%d ready  %s%s\n", slot, d.Metadata.Name, d.Status.ReadyReplicas, d.DesiredReplicas(), strings.Join(images, ", "), marker)
+	}
+	warnManaged("service "+name, svc.Metadata.Labels, svc.Metadata.Annotations)
+	warnManaged("deployment "+previous.Metadata.Name, previous.Metadata.Labels, previous.Metadata.Annotations)
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "roll back "+svc.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Switch %s back to %s?", svc.Metadata.Name, previous.Metadata.Name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("deploy bluegreen rollback", fmt.Sprintf("%s to %s (%s)", name, previous.Metadata.Name, previousSlot), func() error {
+		return internal.SwitchSlot(*svc, previousSlot)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s now sends traffic to %s\n", name, previous.Metadata.Name)
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var deployCostCmd = &cobra.Command{
+	Use:   "cost [name]",
+	Short: "Estimate the monthly cost of a deployment",
+	Long: `Estimate what a deployment costs per month in the current environment from its CPU and
+memory requests, its desired replica count and the price of the nodes its pods run on.
+
+Prices are approximate us-central1 list prices per vCPU and GiB for the node's machine
+family, discounted for Spot and preemptible nodes, or Autopilot pod prices on Autopilot
+clusters. Set cost_rates for an environment in the config file to use your own rates
+(e.g. with committed use discounts). On Standard clusters this is the requested share
+of the nodes; unrequested node capacity is billed too.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		if err := showDeploymentCost(target); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error estimating cost: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployCmd.AddCommand(deployCostCmd)
+}
+
+func showDeploymentCost(target string) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+
+	var configured *internal.CostRates
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	} else {
+		configured = cfg.Environment(currentProject).CostRates
+	}
+
+	fmt.Printf("🔍 Estimating cost of %s/%s...\n", d.Metadata.Namespace, d.Metadata.Name)
+	cost, err := internal.EstimateDeploymentCost(*d, configured)
+	if err != nil {
+		return fmt.Errorf("failed to get pods and nodes: %w", err)
+	}
+	fmt.Println()
+
+	fmt.Printf("📋 %s/%s in %s\n", d.Metadata.Namespace, d.Metadata.Name, currentProject)
+	fmt.Printf("   Requests per pod: %s CPU, %s memory\n", formatCPUOrDash(cost.CPU), memoryOrDash(cost.Memory))
+	fmt.Printf("   Replicas:         %d\n", cost.Replicas)
+	fmt.Println()
+
+	if len(cost.Pricing) > 0 {
+		label := "PODS"
+		if cost.Assumed {
+			label = "NODES"
+		}
+		fmt.Printf("   %-20s %-12s %-6s %-12s %-12s %s\n", "MACHINE TYPE", "CAPACITY", label, "VCPU/HOUR", "GIB/HOUR", "PRICING")
+		fmt.Println("   " + strings.Repeat("-", 90))
+		for i, p := range cost.Pricing {
+			fmt.Printf("   %-20s %-12s %-6d $%-11.5f $%-11.5f %s\n",
+				orDash(p.MachineType), p.Capacity, cost.PodsByPricing[i], p.Rates.CPUHour, p.Rates.MemoryGBHour, p.Source)
+		}
+		fmt.Println()
+	}
+
+	if cost.Assumed {
+		fmt.Println("⚠️  No running pods found; priced at the cluster's most common node type.")
+	}
+	if cost.CPU == 0 && cost.Memory == 0 {
+		fmt.Println("⚠️  The deployment sets no resource requests, so its cost can't be attributed.")
+		fmt.Println("💡 Use 'gcpeasy deploy rightsize' to derive requests from usage.")
+		return nil
+	}
+
+	fmt.Printf("💰 ~$%.2f per pod per month, ~$%.2f per month for %d replica(s)\n", cost.PodMonthly, cost.Monthly(), cost.Replicas)
+	if configured == nil {
+		fmt.Println("💡 Estimate based on approximate list prices; set cost_rates in the config file for your own rates.")
+	}
+	return nil
+}
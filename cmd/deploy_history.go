@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployHistoryCmd = &cobra.Command{
+	Use:   "history [name]",
+	Short: "Show a deployment's rollout revisions",
+	Long: `List the revisions kept in a deployment's revision history, newest first, with when they
+were rolled out, their images and change cause, like 'kubectl rollout history'. The
+revision the deployment currently runs is marked.
+
+The deployment can be given as namespace/name or a name, or is chosen from the list.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		if err := deploymentHistory(target); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error getting rollout history: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployCmd.AddCommand(deployHistoryCmd)
+}
+
+func deploymentHistory(target string) error {
+	if !setupCluster() {
+		return nil
+	}
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+	revisions, err := internal.GetRevisions(*d)
+	if err != nil {
+		return fmt.Errorf("failed to get revisions: %w", err)
+	}
+	if len(revisions) == 0 {
+		fmt.Printf("❌ No revisions found for %s/%s\n", d.Metadata.Namespace, d.Metadata.Name)
+		return nil
+	}
+
+	fmt.Printf("📋 %d revision(s) of %s/%s:\n", len(revisions), d.Metadata.Namespace, d.Metadata.Name)
+	fmt.Println()
+	fmt.Printf("  %-9s %-12s %-9s %-40s %s\n", "REVISION", "AGE", "REPLICAS", "IMAGES", "CHANGE-CAUSE")
+	fmt.Println("  " + strings.Repeat("-", 100))
+	for _, r := range revisions {
+		var images []string
+		for _, image := range r.Images {
+			images = append(images, shortImage(image))
+		}
+		row := fmt.Sprintf("  %-9d %-12s %-9d %-40s %s", r.Number, internal.FormatDuration(time.Since(r.Created))+" ago",
+			r.Replicas, truncate(strings.Join(images, ","), 40), orDash(r.ChangeCause))
+		if r.Current {
+			row = internal.Colorize(internal.ColorGreen, row+" (current)")
+		}
+		fmt.Println(row)
+	}
+	fmt.Println()
+	fmt.Printf("💡 Roll back with: gcpeasy deploy rollback %s/%s\n", d.Metadata.Namespace, d.Metadata.Name)
+	return nil
+}
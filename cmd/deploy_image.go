@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deploySetImageCmd = &cobra.Command{
+	Use:   "set-image <name> <image:tag>",
+	Short: "Update a deployment's container image",
+	Long: `Update the image of one of a deployment's containers, after showing the cluster, the
+current and new image and asking for confirmation.
+
+The image may be a full reference or name:tag, which keeps the repository of the current
+image when the name is the same. The container is chosen with --container; by default it
+is the only container, or the one running an image with the same name. The change is
+recorded in the kubernetes.io/change-cause annotation shown by rollout history. Use
+--wait to watch the rollout until it completes, and --smoke to also run the environment's
+smoke checks afterwards (see 'gcpeasy smoke'), exiting with status 1 if they fail. Use
+--emit-iac yaml or --emit-iac terraform to print the change as a manifest or Terraform snippet.
+
+The deployment can be given as namespace/name or a name.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		container, _ := cmd.Flags().GetString("container")
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		smoke, _ := cmd.Flags().GetBool("smoke")
+		emitFormat, _ := cmd.Flags().GetString("emit-iac")
+		if err := setDeploymentImage(args[0], args[1], container, wait, timeout, smoke, emitFormat); err != nil {
+			if errors.Is(err, errSmokeFailed) {
+				exitWithCode(1)
+			}
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error setting image: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deploySetImageCmd.Flags().StringP("container", "c", "", "Container to update (default: the only one, or the one running the same image)")
+	deploySetImageCmd.Flags().Bool("wait", false, "Watch the rollout until it completes")
+	deploySetImageCmd.Flags().Duration("timeout", 10*time.Minute, "How long to watch the rollout with --wait")
+	deploySetImageCmd.Flags().Bool("smoke", false, "Run the environment's smoke checks after the rollout (implies --wait)")
+	addEmitIaCFlag(deploySetImageCmd)
+	deployCmd.AddCommand(deploySetImageCmd)
+}
+
+func setDeploymentImage(target, image, containerName string, wait bool, timeout time.Duration, smoke bool, emitFormat string) error {
+	if err := checkIaCFormat(emitFormat); err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+
+	ref := internal.ParseImageRef(image)
+	if ref.Tag == "" && ref.Digest == "" {
+		return fmt.Errorf("%s has no tag, use image:tag", image)
+	}
+	container, err := imageContainer(*d, containerName, ref.Name)
+	if err != nil {
+		return err
+	}
+	// name:tag keeps the repository of the image the container runs
+	if current := internal.ParseImageRef(container.Image); ref.Repository == "" && current.Name == ref.Name {
+		ref.Repository = current.Repository
+	}
+
+	return updateDeploymentImage(currentProject, d, container, ref.String(), wait || smoke, timeout, smoke, emitFormat)
+}
+
+// imageContainer picks the container of a deployment to update to an image: the named
+// one, the only one, or the one running an image with the same name
+func imageContainer(d internal.Deployment, containerName, imageName string) (internal.Container, error) {
+	containers := d.Spec.Template.Spec.Containers
+	var names []string
+	for _, c := range containers {
+		if containerName != "" && c.Name == containerName {
+			return c, nil
+		}
+		names = append(names, c.Name)
+	}
+	if containerName != "" {
+		return internal.Container{}, fmt.Errorf("%s has no container named %s (containers: %s)", d.Metadata.Name, containerName, strings.Join(names, ", "))
+	}
+
+	if len(containers) == 1 {
+		return containers[0], nil
+	}
+	if c, ok := internal.ContainerForImage(d, imageName); ok {
+		return c, nil
+	}
+	return internal.Container{}, fmt.Errorf("no container in %s runs %s, use --container (containers: %s)", d.Metadata.Name, imageName, strings.Join(names, ", "))
+}
+
+// updateDeploymentImage shows where and how a container's image changes, asks for
+// confirmation and updates it, recording who changed it in the change-cause annotation.
+// With smoke, the environment's smoke checks run once the rollout completes. With
+// emitFormat, the equivalent IaC snippet is printed at the end.
+func updateDeploymentImage(projectID string, d *internal.Deployment, container internal.Container, image string, wait bool, timeout time.Duration, smoke bool, emitFormat string) error {
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	if container.Image == image {
+		fmt.Printf("✅ %s already runs %s\n", name, image)
+		return nil
+	}
+
+	// The cluster is shown so an update in the wrong context is noticed before confirming
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📋 %s (container %s):\n", name, container.Name)
+	fmt.Printf("   %s\n", internal.Colorize(internal.ColorRed, "- "+container.Image))
+	fmt.Printf("   %s\n", internal.Colorize(internal.ColorGreen, "+ "+image))
+	warnManaged("deployment "+name, d.Metadata.Labels, d.Metadata.Annotations)
+	fmt.Println()
+
+	if !confirmProtected(projectID, "update "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Update %s to %s? This rolls out %d new pod(s)", d.Metadata.Name, image, d.DesiredReplicas())) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	cause := fmt.Sprintf("gcpeasy deploy set-image %s=%s", container.Name, image)
+	if account := getActiveAccount(); account != "" {
+		cause += " by " + account
+	}
+	err := runNotified("deploy set-image", fmt.Sprintf("%s to %s", name, image), func() error {
+		return internal.SetDeploymentImage(*d, container.Name, image, cause)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Updated %s to %s\n", name, image)
+	defer emitIaC(emitFormat, func(format string) (string, error) {
+		return internal.DeploymentImageIaC(format, *d, container.Name, image)
+	})
+
+	if !wait {
+		fmt.Printf("💡 Watch the rollout with: kubectl rollout status deployment/%s -n %s\n", d.Metadata.Name, d.Metadata.Namespace)
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("🔄 Watching the rollout of %s...\n", name)
+	if err := internal.WatchRollout(*d, timeout); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Rollout of %s complete\n", name)
+
+	if !smoke {
+		return nil
+	}
+	fmt.Println()
+	// Retries give load balancers and caches a moment to pick up the new pods
+	return runSmokeChecks(projectID, smokeOptions{Retries: 2, RetryDelay: 10 * time.Second})
+}
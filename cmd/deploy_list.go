@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deployments with their replica readiness",
+	Long: `List the Deployments in application namespaces, or in the namespace given with -n or
+chosen with 'gcpeasy ns select', with their ready, up-to-date and available replicas.
+Deployments with fewer ready replicas than desired are highlighted.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listDeployments(); err != nil {
+			fmt.Printf("Error listing deployments: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployCmd.AddCommand(deployListCmd)
+}
+
+func listDeployments() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	deployments, err := internal.GetDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if len(deployments) == 0 {
+		fmt.Println("❌ No deployments found")
+		return nil
+	}
+
+	fmt.Printf("📋 Found %d deployment(s):\n", len(deployments))
+	fmt.Println()
+	fmt.Printf("%-15s %-35s %-8s %-11s %-10s %-10s %s\n", "NAMESPACE", "NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE", "IMAGES")
+	fmt.Println(strings.Repeat("-", 120))
+
+	degraded := 0
+	for _, d := range deployments {
+		var images []string
+		for _, c := range d.Spec.Template.Spec.Containers {
+			images = append(images, shortImage(c.Image))
+		}
+		age := "-"
+		if created, err := time.Parse(time.RFC3339, d.Metadata.CreationTimestamp); err == nil {
+			age = internal.FormatDuration(time.Since(created))
+		}
+		row := fmt.Sprintf("%-15s %-35s %-8s %-11d %-10d %-10s %s",
+			truncate(d.Metadata.Namespace, 15),
+			truncate(d.Metadata.Name, 35),
+			fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.DesiredReplicas()),
+			d.Status.UpdatedReplicas,
+			d.Status.AvailableReplicas,
+			age,
+			truncate(strings.Join(images, ","), 40))
+		if d.Status.ReadyReplicas < d.DesiredReplicas() {
+			degraded++
+			row = internal.Colorize(internal.ColorYellow, row)
+		}
+		fmt.Println(row)
+	}
+
+	fmt.Println()
+	if degraded > 0 {
+		fmt.Printf("⚠️  %d deployment(s) have fewer ready replicas than desired\n", degraded)
+	}
+	fmt.Println("💡 Use 'gcpeasy deploy restart' or 'gcpeasy deploy scale' to act on a deployment")
+	return nil
+}
+
+// shortImage drops the repository from an image and shortens its digest, e.g. web:1.4.2
+// or web@sha256:3f9a1c2b7d4e
+func shortImage(image string) string {
+	ref := internal.ParseImageRef(image)
+	switch {
+	case ref.Tag != "":
+		return ref.Name + ":" + ref.Tag
+	case len(ref.Digest) > 19:
+		return ref.Name + "@" + ref.Digest[:19]
+	case ref.Digest != "":
+		return ref.Name + "@" + ref.Digest
+	}
+	return ref.Name
+}
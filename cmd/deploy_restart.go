@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployRestartCmd = &cobra.Command{
+	Use:   "restart [name]",
+	Short: "Restart a deployment's pods with a rolling restart",
+	Long: `Replace all pods of a deployment one rollout at a time, as with 'kubectl rollout
+restart', then watch the rollout and print the deployment's replica readiness.
+
+The deployment can be given as namespace/name or a name, or is chosen from the list. Use
+--yes to skip the confirmation; protected environments still require typing the project ID.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if err := restartDeployment(target, yes, noWait, timeout); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error restarting deployment: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployRestartCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	deployRestartCmd.Flags().Bool("no-wait", false, "Don't watch the rollout")
+	deployRestartCmd.Flags().Duration("timeout", 10*time.Minute, "How long to watch the rollout")
+	deployCmd.AddCommand(deployRestartCmd)
+}
+
+func restartDeployment(target string, skipConfirm, noWait bool, timeout time.Duration) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+
+	fmt.Printf("🎯 %s (%d/%d ready)\n", name, d.Status.ReadyReplicas, d.DesiredReplicas())
+	// Each step of the rollout takes down at least one of the old pods
+	warnDisruptionBudgets(d.Metadata.Namespace, d.Spec.Template.Metadata.Labels, 1)
+	warnManaged("deployment "+name, d.Metadata.Labels, d.Metadata.Annotations)
+	fmt.Println()
+	if !confirmProtected(currentProject, "restart "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !skipConfirm && !confirm(fmt.Sprintf("Restart %s? Its %d pod(s) are replaced one rollout at a time", name, d.DesiredReplicas())) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("deploy restart", name, func() error {
+		return internal.RestartDeployment(*d)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🔄 Restarting %s\n", name)
+	return finishDeploymentChange(d, noWait, timeout)
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var deployRightsizeCmd = &cobra.Command{
+	Use:   "rightsize [name]",
+	Short: "Suggest resource requests and limits from usage",
+	Long: `Compare a deployment's CPU and memory requests and limits with the usage percentiles of
+its containers from Cloud Monitoring (default: the last 7 days) and propose new values.
+
+Requests cover p95 usage with 15% headroom and the memory limit leaves 25% headroom over
+peak usage. A CPU limit is only proposed when one is already set. Changes of 10% or less
+are left alone. Use --patch to print the changes as a kubectl patch, or --apply to apply
+them after confirmation (this rolls out new pods).
+
+The deployment can be given as namespace/name or a name; without one, shows an
+interactive selection.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		patch, _ := cmd.Flags().GetBool("patch")
+		apply, _ := cmd.Flags().GetBool("apply")
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		if err := rightsizeDeployment(target, since, patch, apply); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error rightsizing deployment: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployRightsizeCmd.Flags().String("since", "7d", "Usage window to base suggestions on (e.g. 3d, 14d)")
+	deployRightsizeCmd.Flags().Bool("patch", false, "Print the suggested changes as a kubectl patch command")
+	deployRightsizeCmd.Flags().Bool("apply", false, "Apply the suggested changes after confirmation")
+	deployCmd.AddCommand(deployRightsizeCmd)
+}
+
+func rightsizeDeployment(target, sinceFlag string, printPatch, apply bool) error {
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Fetching %s of usage for %s/%s from Cloud Monitoring...\n", sinceFlag, d.Metadata.Namespace, d.Metadata.Name)
+	clusterName := ""
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		_, clusterName, _ = internal.ParseClusterContext(context)
+	}
+	cpu, memory, err := internal.GetDeploymentUsage(currentProject, clusterName, *d, since)
+	if err != nil {
+		return err
+	}
+	suggestions := internal.RightsizeDeployment(*d, cpu, memory)
+	fmt.Println()
+
+	fmt.Printf("%-20s %-8s %-9s %-9s %-9s %-9s %-9s %s\n", "CONTAINER", "RESOURCE", "P50", "P95", "MAX", "TYPE", "CURRENT", "SUGGESTED")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, r := range suggestions {
+		if !r.HasData() {
+			fmt.Printf("%-20s %s\n", truncate(r.Container, 20), "no usage data in this window")
+			continue
+		}
+		printRightsizeRow(r.Container, "cpu", r.CPU, formatCPUOrDash, []rightsizeValue{
+			{"request", r.CPURequest, r.SuggestedCPURequest, r.CPURequestCapped},
+			{"limit", r.CPULimit, r.SuggestedCPULimit, false},
+		})
+		printRightsizeRow("", "memory", r.Memory, memoryOrDash, []rightsizeValue{
+			{"request", r.MemoryRequest, r.SuggestedMemoryRequest, r.MemoryRequestCapped},
+			{"limit", r.MemoryLimit, r.SuggestedMemoryLimit, false},
+		})
+	}
+	fmt.Println()
+	fmt.Println("💡 Requests cover p95 usage with 15% headroom; memory limits leave 25% over peak usage.")
+
+	patch, err := internal.RightsizePatch(suggestions)
+	if err != nil {
+		return err
+	}
+	if patch == nil {
+		fmt.Println("✅ Resources already match usage, nothing to change")
+		return nil
+	}
+
+	if printPatch {
+		fmt.Println()
+		fmt.Printf("kubectl patch deployment %s -n %s --type strategic -p %s\n", d.Metadata.Name, d.Metadata.Namespace, shellQuote(string(patch)))
+	}
+	if !apply {
+		if !printPatch {
+			fmt.Println("💡 Use --patch to print the change, or --apply to apply it")
+		}
+		return nil
+	}
+
+	fmt.Println()
+	warnManaged("deployment "+d.Metadata.Namespace+"/"+d.Metadata.Name, d.Metadata.Labels, d.Metadata.Annotations)
+	if !confirmProtected(currentProject, "update resources of "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Apply these resources to %s/%s? This rolls out %d new pod(s)", d.Metadata.Namespace, d.Metadata.Name, d.DesiredReplicas())) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("deploy rightsize", fmt.Sprintf("%s/%s", d.Metadata.Namespace, d.Metadata.Name), func() error {
+		return internal.PatchDeployment(*d, patch)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Updated resources of %s/%s\n", d.Metadata.Namespace, d.Metadata.Name)
+	return nil
+}
+
+// rightsizeValue is a current and suggested request or limit; capped requests were
+// lowered to the limit
+type rightsizeValue struct {
+	kind      string
+	current   float64
+	suggested float64
+	capped    bool
+}
+
+func printRightsizeRow(container, resource string, usage internal.UsagePercentiles, format func(float64) string, values []rightsizeValue) {
+	for i, v := range values {
+		name, p50, p95, peak := truncate(container, 20), format(usage.P50), format(usage.P95), format(usage.Max)
+		if i > 0 {
+			name, resource, p50, p95, peak = "", "", "", "", ""
+		}
+
+		suggested := format(v.suggested)
+		if v.capped || internal.SignificantChange(v.current, v.suggested) {
+			color := internal.ColorGreen
+			if v.suggested > v.current && v.current > 0 {
+				color = internal.ColorYellow
+			}
+			suggested = internal.Colorize(color, suggested)
+			if v.capped {
+				suggested += " (capped at the limit)"
+			}
+		} else {
+			suggested = "(keep)"
+		}
+		fmt.Printf("%-20s %-8s %-9s %-9s %-9s %-9s %-9s %s\n", name, resource, p50, p95, peak, v.kind, format(v.current), suggested)
+	}
+}
+
+func formatCPUOrDash(cores float64) string {
+	if cores == 0 {
+		return "-"
+	}
+	return internal.FormatCPU(cores)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployRollbackCmd = &cobra.Command{
+	Use:   "rollback [name]",
+	Short: "Roll a deployment back to an earlier revision",
+	Long: `Roll a deployment back to an earlier revision, as with 'kubectl rollout undo', then watch
+the rollout with a progress bar. The revision is chosen from the deployment's revision
+history (the previous one by default), or given with --to-revision.
+
+The deployment can be given as namespace/name or a name, or is chosen from the list. Use
+--yes to skip the confirmation; protected environments still require typing the project
+ID.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		revision, _ := cmd.Flags().GetInt("to-revision")
+		yes, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if err := rollbackDeployment(target, revision, yes, noWait, timeout); err != nil {
+			if errors.Is(err, errRolloutFailed) {
+				exitWithCode(1)
+			}
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error rolling back: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployRollbackCmd.Flags().Int("to-revision", 0, "Revision to roll back to (default: chosen from the history)")
+	deployRollbackCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	deployRollbackCmd.Flags().Bool("no-wait", false, "Don't watch the rollout")
+	deployRollbackCmd.Flags().Duration("timeout", 10*time.Minute, "How long to watch the rollout")
+	deployCmd.AddCommand(deployRollbackCmd)
+}
+
+func rollbackDeployment(target string, toRevision int, skipConfirm, noWait bool, timeout time.Duration) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	revisions, err := internal.GetRevisions(*d)
+	if err != nil {
+		return fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	var current, selected *internal.Revision
+	for i := range revisions {
+		if revisions[i].Current {
+			current = &revisions[i]
+		}
+		if revisions[i].Number == toRevision {
+			selected = &revisions[i]
+		}
+	}
+	switch {
+	case toRevision == 0:
+		if selected, err = internal.SelectRevision(revisions); err != nil {
+			return err
+		}
+		fmt.Println()
+	case selected == nil:
+		return fmt.Errorf("revision %d of %s not found in its revision history", toRevision, name)
+	case selected.Current:
+		fmt.Printf("✅ %s already runs revision %d\n", name, toRevision)
+		return nil
+	}
+
+	fmt.Printf("📋 Rolling back %s to revision %d:\n", name, selected.Number)
+	if current != nil {
+		fmt.Printf("   %s\n", internal.Colorize(internal.ColorRed, fmt.Sprintf("- revision %d: %s", current.Number, strings.Join(current.Images, ", "))))
+	}
+	fmt.Printf("   %s\n", internal.Colorize(internal.ColorGreen, fmt.Sprintf("+ revision %d: %s", selected.Number, strings.Join(selected.Images, ", "))))
+	warnManaged("deployment "+name, d.Metadata.Labels, d.Metadata.Annotations)
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "roll back "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !skipConfirm && !confirm(fmt.Sprintf("Roll back %s? This rolls out %d new pod(s)", name, d.DesiredReplicas())) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("deploy rollback", fmt.Sprintf("%s to revision %d", name, selected.Number), func() error {
+		return internal.RollbackDeployment(*d, selected.Number)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🔄 Rolled back %s to revision %d\n", name, selected.Number)
+	if noWait {
+		return nil
+	}
+	fmt.Println()
+	return watchRolloutProgress(d, timeout)
+}
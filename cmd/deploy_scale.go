@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployScaleCmd = &cobra.Command{
+	Use:   "scale [name] --replicas <n>",
+	Short: "Change the number of replicas of a deployment",
+	Long: `Scale a deployment to the given number of replicas, as with 'kubectl scale', then wait
+for the rollout and print the deployment's replica readiness. A HorizontalPodAutoscaler
+targeting the deployment is reported, as it will override the replica count. Use
+--emit-iac yaml or --emit-iac terraform to print the change as a manifest or Terraform snippet.
+
+The deployment can be given as namespace/name or a name, or is chosen from the list. Use
+--yes to skip the confirmation; protected environments still require typing the project
+ID.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		replicas, _ := cmd.Flags().GetInt("replicas")
+		yes, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		emitFormat, _ := cmd.Flags().GetString("emit-iac")
+		if err := scaleDeployment(target, replicas, yes, noWait, timeout, emitFormat); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error scaling deployment: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployScaleCmd.Flags().Int("replicas", -1, "Number of replicas")
+	deployScaleCmd.MarkFlagRequired("replicas")
+	deployScaleCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	deployScaleCmd.Flags().Bool("no-wait", false, "Don't wait for the rollout")
+	deployScaleCmd.Flags().Duration("timeout", 10*time.Minute, "How long to wait for the rollout")
+	addEmitIaCFlag(deployScaleCmd)
+	deployCmd.AddCommand(deployScaleCmd)
+}
+
+func scaleDeployment(target string, replicas int, skipConfirm, noWait bool, timeout time.Duration, emitFormat string) error {
+	if replicas < 0 {
+		return fmt.Errorf("--replicas must be 0 or more")
+	}
+	if err := checkIaCFormat(emitFormat); err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	if d.DesiredReplicas() == replicas {
+		fmt.Printf("✅ %s already has %d replica(s)\n", name, replicas)
+		return nil
+	}
+
+	fmt.Printf("🎯 %s: %d → %d replica(s) (%d ready)\n", name, d.DesiredReplicas(), replicas, d.Status.ReadyReplicas)
+	if hpa, err := internal.DeploymentAutoscaler(*d); err != nil {
+		fmt.Printf("⚠️  Warning: could not check for an autoscaler: %v\n", err)
+	} else if hpa != nil {
+		fmt.Printf("⚠️  HorizontalPodAutoscaler %s scales %s between %d and %d replicas and will override this\n", hpa.Name, d.Metadata.Name, hpa.MinReplicas, hpa.MaxReplicas)
+	}
+	if replicas < d.DesiredReplicas() {
+		warnDisruptionBudgets(d.Metadata.Namespace, d.Spec.Template.Metadata.Labels, d.DesiredReplicas()-replicas)
+	}
+	warnManaged("deployment "+name, d.Metadata.Labels, d.Metadata.Annotations)
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "scale "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	prompt := fmt.Sprintf("Scale %s to %d replica(s)?", name, replicas)
+	if replicas == 0 {
+		prompt = fmt.Sprintf("Scale %s to 0 replicas? This stops all of its pods", name)
+	}
+	if !skipConfirm && !confirm(prompt) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("deploy scale", fmt.Sprintf("%s to %d replica(s)", name, replicas), func() error {
+		return internal.ScaleDeployment(*d, replicas)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🔄 Scaled %s to %d replica(s)\n", name, replicas)
+	defer emitIaC(emitFormat, func(format string) (string, error) {
+		return internal.DeploymentReplicasIaC(format, *d, replicas)
+	})
+	return finishDeploymentChange(d, noWait, timeout)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// errRolloutFailed is returned when a watched rollout fails or times out; the reason has
+// already been printed
+var errRolloutFailed = errors.New("rollout failed")
+
+var deployStatusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Watch a deployment's rollout with a progress bar",
+	Long: `Show the rollout progress of a deployment as a live progress bar until all replicas are
+updated and available, like 'kubectl rollout status'. Exits with status 1 when the rollout
+exceeds its progress deadline or --timeout passes, so it can gate a pipeline.
+
+The deployment can be given as namespace/name or a name, or is chosen from the list.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if err := deploymentStatus(target, timeout); err != nil {
+			if errors.Is(err, errRolloutFailed) {
+				exitWithCode(1)
+			}
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error getting rollout status: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	deployStatusCmd.Flags().Duration("timeout", 10*time.Minute, "How long to wait for the rollout")
+	deployCmd.AddCommand(deployStatusCmd)
+}
+
+func deploymentStatus(target string, timeout time.Duration) error {
+	if !setupCluster() {
+		return nil
+	}
+	d, err := selectDeployment(target)
+	if err != nil {
+		return err
+	}
+	revision := d.Metadata.Annotations[internal.RevisionAnnotation]
+	fmt.Printf("🎯 %s/%s, revision %s\n", d.Metadata.Namespace, d.Metadata.Name, orDash(revision))
+	return watchRolloutProgress(d, timeout)
+}
+
+// watchRolloutProgress polls a deployment until its rollout completes, fails or timeout
+// passes, drawing a progress bar of its updated and available replicas. Without a
+// terminal a line is printed whenever the progress changes.
+func watchRolloutProgress(d *internal.Deployment, timeout time.Duration) error {
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	interactive := internal.StdoutIsTerminal()
+	started := time.Now()
+	last := ""
+	for {
+		current, err := internal.GetDeployment(d.Metadata.Namespace, d.Metadata.Name)
+		if err != nil {
+			if interactive {
+				fmt.Println()
+			}
+			return fmt.Errorf("failed to get %s: %w", name, err)
+		}
+		done, failed, message := current.RolloutState()
+		elapsed := time.Since(started).Round(time.Second)
+		line := fmt.Sprintf("%s %s", rolloutBar(*current), message)
+
+		if interactive {
+			fmt.Printf("\r\033[K%s (%s)", line, elapsed)
+		} else if line != last {
+			fmt.Printf("   %6s  %s\n", elapsed, line)
+		}
+		last = line
+
+		if done || failed || elapsed > timeout {
+			if interactive {
+				fmt.Println()
+			}
+			fmt.Println()
+			switch {
+			case done:
+				fmt.Printf("✅ Rollout of %s complete\n", name)
+				return nil
+			case failed:
+				fmt.Printf("❌ Rollout of %s failed: %s\n", name, message)
+			default:
+				fmt.Printf("❌ Rollout of %s not complete after %s: %s\n", name, timeout, message)
+			}
+			fmt.Printf("💡 Check the pods with: gcpeasy pod list --status --selector '%s'\n", current.Spec.Selector.String())
+			fmt.Printf("💡 Roll back with: gcpeasy deploy rollback %s\n", name)
+			return errRolloutFailed
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// rolloutBar draws the share of desired replicas that are updated and available, e.g.
+// [████████░░░░░░░░] 2/4
+func rolloutBar(d internal.Deployment) string {
+	const width = 20
+	desired := d.DesiredReplicas()
+	ready := min(d.Status.UpdatedReplicas, d.Status.AvailableReplicas)
+	filled := width
+	if desired > 0 {
+		filled = min(width, ready*width/desired)
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("[%s] %d/%d", internal.Colorize(internal.ColorGreen, bar), ready, desired)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <path>",
+	Short: "Diff live cluster state against local manifests",
+	Long: `Compare local manifests (plain YAML files or a kustomize directory) against the live
+objects in the current cluster and print a colored field-level diff. Only fields set in the
+local manifests are compared, so server-defaulted fields are not reported as drift.
+Secret values are never printed: each key of a Secret's data or stringData is only
+reported as added or changed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if err := runDiff(args[0], namespace); err != nil {
+			fmt.Printf("Error diffing manifests: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringP("namespace", "n", "", "Namespace for manifests without one (default: current context namespace)")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(path, namespace string) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
+
+	fmt.Printf("📄 Loading manifests from %s...\n", path)
+	manifests, err := internal.LoadManifests(path)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		fmt.Println("❌ No Kubernetes manifests found")
+		return nil
+	}
+
+	if namespace == "" {
+		namespace = internal.GetContextNamespace()
+	}
+
+	fmt.Printf("🔍 Comparing %d object(s) against the live cluster...\n", len(manifests))
+	fmt.Println()
+
+	var created, changed, unchanged, failed int
+	for _, m := range manifests {
+		ns := m.Namespace()
+		if ns == "" {
+			ns = namespace
+		}
+		label := fmt.Sprintf("%s %s/%s", m.Kind(), ns, m.Name())
+
+		live, err := internal.GetLiveObject(m, ns)
+		if err != nil {
+			fmt.Printf("⚠️  %s: %v\n", label, err)
+			failed++
+			continue
+		}
+		if live == nil {
+			fmt.Println(internal.Colorize(internal.ColorGreen, "+ "+label+" (not in cluster)"))
+			created++
+			continue
+		}
+
+		var changes []internal.FieldChange
+		if m.Kind() == "Secret" {
+			changes = internal.DiffSecret(m.Object, live)
+		} else {
+			changes = internal.DiffDesired(m.Object, live)
+		}
+		if len(changes) == 0 {
+			unchanged++
+			continue
+		}
+
+		fmt.Println(internal.Colorize(internal.ColorYellow, "~ "+label))
+		internal.PrintFieldChanges(changes, "    ")
+		changed++
+	}
+
+	fmt.Println()
+	fmt.Printf("📋 %d changed, %d missing from cluster, %d unchanged", changed, created, unchanged)
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+	return nil
+}
@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
 	"os"
 	"os/exec"
 	"strconv"
@@ -17,10 +17,7 @@ type Environment struct {
 	ProjectID string
 }
 
-type GCPProject struct {
-	ProjectID string `json:"projectId"`
-	Name      string `json:"name"`
-}
+type GCPProject = internal.Project
 
 var envCmd = &cobra.Command{
 	Use:   "env",
@@ -125,24 +122,36 @@ func listEnvironments(showStatus bool) error {
 }
 
 func getGCPProjects() ([]GCPProject, error) {
-	cmd := exec.Command("gcloud", "projects", "list", "--format=json")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list GCP projects: %w", err)
-	}
+	return internal.GetProjects()
+}
 
-	var projects []GCPProject
-	if err := json.Unmarshal(output, &projects); err != nil {
-		return nil, fmt.Errorf("failed to parse projects JSON: %w", err)
-	}
+// restoreKubeconfig removes the private kubeconfig used with --project
+var restoreKubeconfig func()
 
-	return projects, nil
+// applyProjectOverride points this invocation, and the gcloud and kubectl commands it runs,
+// at projectID. Cluster context switches go to a private kubeconfig so other terminals
+// keep their context.
+func applyProjectOverride(projectID string) {
+	os.Setenv("GCPEASY_PROJECT", projectID)
+	os.Setenv("CLOUDSDK_CORE_PROJECT", projectID)
+
+	restore, err := internal.IsolateKubeconfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: cluster context changes will affect other terminals: %v\n", err)
+		return
+	}
+	restoreKubeconfig = restore
 }
 
 func getCurrentProject() string {
-	cmd := exec.Command("gcloud", "config", "get-value", "project")
-	output, _ := cmd.Output()
-	return strings.TrimSpace(string(output))
+	// GCPEASY_PROJECT overrides the gcloud project for a single invocation (used by history rerun)
+	if project := os.Getenv("GCPEASY_PROJECT"); project != "" {
+		invocation.project = project
+		return project
+	}
+
+	invocation.project = internal.GetCurrentProject()
+	return invocation.project
 }
 
 func getProjectStatus(projectID string) string {
@@ -171,6 +180,12 @@ func isAuthenticated() bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
+func getActiveAccount() string {
+	cmd := exec.Command("gcloud", "auth", "list", "--filter=status:ACTIVE", "--format=value(account)")
+	output, _ := cmd.Output()
+	return strings.TrimSpace(string(output))
+}
+
 func selectEnvironment(identifier string) error {
 	if !isAuthenticated() {
 		fmt.Println("❌ Not authenticated with Google Cloud")
@@ -267,11 +282,33 @@ func selectEnvironmentInteractive() error {
 func switchToProject(projectID string) error {
 	fmt.Printf("Switching to project: %s\n", projectID)
 
-	cmd := exec.Command("gcloud", "config", "set", "project", projectID)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to switch project: %w", err)
+	if err := internal.SetCurrentProject(projectID); err != nil {
+		return err
 	}
 
 	fmt.Printf("✅ Successfully switched to project: %s\n", projectID)
 	return nil
-}
\ No newline at end of file
+}
+
+// useEnvironment points this invocation at a configured environment named by its project
+// ID or a unique part of one (e.g. "staging"), and returns its project ID. An empty name
+// keeps the current project.
+func useEnvironment(name string) (string, error) {
+	currentProject := getCurrentProject()
+	if name == "" {
+		return currentProject, nil
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	projectID, err := cfg.ResolveEnvironment(name)
+	if err != nil {
+		return "", err
+	}
+	if projectID != currentProject {
+		applyProjectOverride(projectID)
+	}
+	return projectID, nil
+}
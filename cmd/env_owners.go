@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var envOwnersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Show who owns the current environment",
+	Long: `Show who to ask about the current project: its Essential Contacts (including those
+inherited from folders and the organization), its labels, with ownership labels such as
+team or owner first, the members that can change its IAM policy, and who changed the
+IAM policy recently.
+
+Each section is shown as far as your account can read it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		if err := showOwners(since); err != nil {
+			fmt.Printf("Error looking up owners: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	envOwnersCmd.Flags().String("since", "30d", "How far back to look for IAM policy changes (e.g. 7d, 90d)")
+	envCmd.AddCommand(envOwnersCmd)
+}
+
+func showOwners(sinceFlag string) error {
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Looking up the owners of project: %s\n", currentProject)
+	fmt.Println()
+
+	fmt.Println("📇 Essential Contacts")
+	if contacts, err := internal.GetEssentialContacts(currentProject); err != nil {
+		fmt.Printf("   ⚠️  Could not read contacts: %v\n", err)
+	} else if len(contacts) == 0 {
+		fmt.Println("   None set")
+	} else {
+		for _, c := range contacts {
+			fmt.Printf("   %-40s %s\n", c.Email, internal.Colorize(internal.ColorGray, strings.ToLower(strings.Join(c.Categories, ", "))))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("🏷️  Labels")
+	if labels, err := internal.GetProjectLabels(currentProject); err != nil {
+		fmt.Printf("   ⚠️  Could not read labels: %v\n", err)
+	} else if len(labels) == 0 {
+		fmt.Println("   None set")
+	} else {
+		var other []string
+		for _, key := range sortedKeys(labels) {
+			if internal.IsOwnershipLabel(key) {
+				fmt.Printf("   %s=%s\n", key, internal.Colorize(internal.ColorCyan, labels[key]))
+			} else {
+				other = append(other, key)
+			}
+		}
+		for _, key := range other {
+			fmt.Printf("   %s=%s\n", key, labels[key])
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("🔑 IAM admins")
+	if admins, err := internal.GetProjectAdmins(currentProject); err != nil {
+		fmt.Printf("   ⚠️  Could not read the IAM policy: %v\n", err)
+	} else if len(admins) == 0 {
+		fmt.Println("   None granted on the project (admins may be inherited from folders or the organization)")
+	} else {
+		for _, a := range admins {
+			var roles []string
+			for _, r := range a.Roles {
+				roles = append(roles, strings.TrimPrefix(r, "roles/"))
+			}
+			fmt.Printf("   %-50s %s\n", a.Member, internal.Colorize(internal.ColorGray, strings.Join(roles, ", ")))
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("📜 IAM policy changes in the last %s\n", internal.FormatDuration(since))
+	if changes, err := internal.RecentIAMChanges(currentProject, since); err != nil {
+		fmt.Printf("   ⚠️  Could not read the audit log: %v\n", err)
+	} else if len(changes) == 0 {
+		fmt.Println("   None")
+	} else {
+		// One line per principal, with their latest change and how many they made
+		counts := make(map[string]int)
+		var order []string
+		latest := make(map[string]time.Time)
+		for _, c := range changes {
+			if counts[c.Principal] == 0 {
+				order = append(order, c.Principal)
+				latest[c.Principal] = c.Time
+			}
+			counts[c.Principal]++
+		}
+		for _, principal := range order {
+			fmt.Printf("   %-50s %d change(s), last %s ago\n", principal, counts[principal], internal.FormatDuration(time.Since(latest[principal])))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var envPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Scale the current environment's deployments to zero",
+	Long: `Scale every deployment in application namespaces of the current environment to zero,
+recording its replicas in the gcpeasy.io/paused-replicas annotation so 'env resume' can
+restore them. Useful for on-demand test environments that only need to run while used.
+
+Deployments labeled gcpeasy.io/keep-running=true are left running. Deployments scaled
+down by 'schedule scale' are paused with the replicas the schedule would restore, and the
+scheduled scale-up leaves paused deployments alone. Protected environments can't be paused.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pauseEnvironment(); err != nil {
+			fmt.Printf("Error pausing environment: %v\n", err)
+		}
+	},
+}
+
+var envResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Restore the deployments of a paused environment",
+	Long:  "Scale the deployments paused with 'env pause' back to their recorded replicas.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resumeEnvironment(); err != nil {
+			fmt.Printf("Error resuming environment: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	addNamespaceFlag(envPauseCmd)
+	addNamespaceFlag(envResumeCmd)
+	envCmd.AddCommand(envPauseCmd)
+	envCmd.AddCommand(envResumeCmd)
+}
+
+func pauseEnvironment() error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Environment(currentProject).Protected {
+		return fmt.Errorf("%s is a protected environment and can't be paused", currentProject)
+	}
+
+	deployments, err := internal.GetDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	var running []internal.Deployment
+	for _, d := range deployments {
+		if internal.PausedReplicas(d) == 0 || d.Metadata.Labels[internal.KeepRunningLabel] == "true" {
+			continue
+		}
+		running = append(running, d)
+	}
+	fmt.Println()
+	if len(running) == 0 {
+		fmt.Println("✅ No running deployments to pause")
+		return nil
+	}
+
+	pods := 0
+	fmt.Printf("%-15s %-35s %s\n", "NAMESPACE", "DEPLOYMENT", "REPLICAS")
+	fmt.Println(strings.Repeat("-", 60))
+	for _, d := range running {
+		replicas := fmt.Sprint(internal.PausedReplicas(d))
+		if d.DesiredReplicas() == 0 {
+			replicas += " (scaled down by schedule)"
+		}
+		fmt.Printf("%-15s %-35s %s\n", truncate(d.Metadata.Namespace, 15), truncate(d.Metadata.Name, 35), replicas)
+		pods += d.DesiredReplicas()
+	}
+	fmt.Println()
+	for _, d := range running {
+		warnDisruptionBudgets(d.Metadata.Namespace, d.Spec.Template.Metadata.Labels, d.DesiredReplicas())
+		warnManaged("deployment "+d.Metadata.Namespace+"/"+d.Metadata.Name, d.Metadata.Labels, d.Metadata.Annotations)
+	}
+
+	if !confirm(fmt.Sprintf("Pause %s? This scales %d deployment(s) and %d pod(s) to zero", currentProject, len(running), pods)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("env pause", fmt.Sprintf("%d deployment(s), %d pod(s)", len(running), pods), func() error {
+		var failed []string
+		for _, d := range running {
+			if err := internal.PauseDeployment(d); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				failed = append(failed, d.Metadata.Name)
+				continue
+			}
+			fmt.Printf("⏸️  Paused %s/%s\n", d.Metadata.Namespace, d.Metadata.Name)
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to pause %s", strings.Join(failed, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ Paused %s\n", currentProject)
+	fmt.Println("💡 Resume it with: gcpeasy env resume")
+	return nil
+}
+
+func resumeEnvironment() error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	deployments, err := internal.GetDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	var paused []internal.Deployment
+	for _, d := range deployments {
+		if _, ok := d.Metadata.Annotations[internal.PausedAnnotation]; ok {
+			paused = append(paused, d)
+		}
+	}
+	fmt.Println()
+	if len(paused) == 0 {
+		fmt.Println("✅ No paused deployments")
+		return nil
+	}
+
+	fmt.Printf("%-15s %-35s %s\n", "NAMESPACE", "DEPLOYMENT", "REPLICAS")
+	fmt.Println(strings.Repeat("-", 60))
+	for _, d := range paused {
+		fmt.Printf("%-15s %-35s %s\n", truncate(d.Metadata.Namespace, 15), truncate(d.Metadata.Name, 35), d.Metadata.Annotations[internal.PausedAnnotation])
+	}
+	fmt.Println()
+
+	if !confirmDefaultYes(fmt.Sprintf("Resume %d deployment(s) in %s?", len(paused), currentProject)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("env resume", fmt.Sprintf("%d deployment(s)", len(paused)), func() error {
+		var failed []string
+		for _, d := range paused {
+			if err := internal.ResumeDeployment(d); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				failed = append(failed, d.Metadata.Name)
+				continue
+			}
+			fmt.Printf("▶️  Resumed %s/%s (%s replicas)\n", d.Metadata.Namespace, d.Metadata.Name, d.Metadata.Annotations[internal.PausedAnnotation])
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to resume %s", strings.Join(failed, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ Resumed %s\n", currentProject)
+	return nil
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var previewEnv string
+
+var envPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Branch preview environment commands",
+	Long: `Create and delete per-branch preview environments, each in its own namespace, from a
+manifest template configured for the environment:
+
+  environments:
+    my-project-staging:
+      preview:
+        template: preview.yaml   # relative to the config directory
+        namespace_prefix: preview-
+        url: https://{{BRANCH}}.preview.example.com
+
+{{NAMESPACE}}, {{BRANCH}} and {{IMAGE_TAG}} in the template and URL are replaced with the
+preview's namespace, the branch name as a DNS label, and the image tag.`,
+}
+
+var envPreviewCreateCmd = &cobra.Command{
+	Use:   "create <branch>",
+	Short: "Create or update the preview environment of a branch",
+	Long: `Create a namespace for the branch, apply the configured manifest template to it with
+the branch's image tag, wait for its deployments to become available and print its URLs.
+The image tag defaults to the branch name as a DNS label (e.g. feature-login for
+feature/login); use --tag for another one, such as a commit SHA. Running it again
+re-applies the template, e.g. after a new image is built.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tag, _ := cmd.Flags().GetString("tag")
+		timeout, _ := cmd.Flags().GetString("timeout")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		if err := createPreview(args[0], tag, timeout, !noWait); err != nil {
+			fmt.Printf("Error creating preview: %v\n", err)
+		}
+	},
+}
+
+var envPreviewDeleteCmd = &cobra.Command{
+	Use:   "delete <branch>",
+	Short: "Delete the preview environment of a branch",
+	Long:  "Delete a branch's preview namespace and everything in it. Only namespaces created by 'env preview create' can be deleted.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := deletePreview(args[0]); err != nil {
+			fmt.Printf("Error deleting preview: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	envPreviewCmd.PersistentFlags().StringVar(&previewEnv, "env", "", "Environment to use, by project ID or a unique part of one such as \"staging\" (default: current project)")
+	envPreviewCreateCmd.Flags().String("tag", "", "Image tag to deploy (default: the branch name as a DNS label)")
+	envPreviewCreateCmd.Flags().String("timeout", "5m", "How long to wait for the deployments to become available")
+	envPreviewCreateCmd.Flags().Bool("no-wait", false, "Don't wait for the deployments to become available")
+	envPreviewCmd.AddCommand(envPreviewCreateCmd)
+	envPreviewCmd.AddCommand(envPreviewDeleteCmd)
+	envCmd.AddCommand(envPreviewCmd)
+}
+
+// previewEnvironment switches to the environment selected with --env and returns its settings
+func previewEnvironment() (string, internal.EnvironmentConfig, error) {
+	projectID, err := useEnvironment(previewEnv)
+	if err != nil {
+		return "", internal.EnvironmentConfig{}, err
+	}
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return "", internal.EnvironmentConfig{}, err
+	}
+	return projectID, cfg.Environment(projectID), nil
+}
+
+func createPreview(branch, tag, timeout string, wait bool) error {
+	projectID, env, err := previewEnvironment()
+	if err != nil {
+		return err
+	}
+	if env.Protected {
+		return fmt.Errorf("%s is a protected environment; previews are for non-production environments", projectID)
+	}
+	previewCfg := env.Preview
+	if previewCfg.Template == "" {
+		path, _ := internal.ConfigPath()
+		return fmt.Errorf("no preview template configured for %s; set environments.%s.preview.template in %s", projectID, projectID, path)
+	}
+	preview, err := internal.NewPreview(previewCfg, branch, tag)
+	if err != nil {
+		return err
+	}
+	template, err := internal.LoadPreviewTemplate(previewCfg)
+	if err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	existing, err := internal.GetPreviewNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to get preview namespaces: %w", err)
+	}
+	fmt.Println()
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📁 Namespace: %s\n", preview.Namespace)
+	fmt.Printf("🏷️  Image tag: %s\n", preview.ImageTag)
+	if ns, ok := existing[preview.Slug]; ok {
+		fmt.Println("🔄 The preview exists and will be updated")
+		warnManaged("namespace "+ns.Name, ns.Labels, ns.Annotations)
+	}
+	fmt.Println()
+
+	err = runNotified("env preview create", fmt.Sprintf("%s (%s, tag %s)", branch, preview.Namespace, preview.ImageTag), func() error {
+		return internal.CreatePreview(preview, template)
+	})
+	if err != nil {
+		return err
+	}
+
+	if wait {
+		fmt.Println()
+		fmt.Printf("🔄 Waiting up to %s for the deployments to become available...\n", timeout)
+		if err := internal.WaitForPreview(preview, timeout); err != nil {
+			fmt.Printf("⚠️  The preview isn't ready yet: %v\n", err)
+			fmt.Printf("💡 Check its pods with: gcpeasy pod list -n %s\n", preview.Namespace)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ Preview of %s is in namespace %s\n", branch, preview.Namespace)
+	urls, err := internal.PreviewURLs(preview.Namespace)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not get the preview's URLs: %v\n", err)
+	}
+	if previewCfg.URL != "" {
+		urls = append([]string{preview.Expand(previewCfg.URL)}, urls...)
+	}
+	for _, url := range urls {
+		fmt.Printf("🌐 %s\n", url)
+	}
+	fmt.Printf("💡 Delete it with: gcpeasy env preview delete %s\n", branch)
+	return nil
+}
+
+func deletePreview(branch string) error {
+	projectID, env, err := previewEnvironment()
+	if err != nil {
+		return err
+	}
+	preview, err := internal.NewPreview(env.Preview, branch, "")
+	if err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	existing, err := internal.GetPreviewNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to get preview namespaces: %w", err)
+	}
+	ns, ok := existing[preview.Slug]
+	if !ok {
+		fmt.Printf("❌ No preview found for %s\n", branch)
+		return nil
+	}
+	preview.Namespace = ns.Name
+	warnManaged("namespace "+ns.Name, ns.Labels, ns.Annotations)
+
+	if !confirmProtected(projectID, "delete a preview environment") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Delete namespace %s and everything in it?", preview.Namespace)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("env preview delete", fmt.Sprintf("%s (%s)", branch, preview.Namespace), func() error {
+		return internal.DeletePreview(preview)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleting preview namespace %s\n", preview.Namespace)
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var firestoreCmd = &cobra.Command{
+	Use:   "firestore",
+	Short: "Firestore commands",
+	Long:  "Commands for reading Firestore (native mode) documents in the current project.",
+}
+
+var firestoreGetCmd = &cobra.Command{
+	Use:   "get <collection/document>",
+	Short: "Print a document as JSON",
+	Long: `Print the fields of a document as JSON, e.g. to check a record while debugging:
+
+  gcpeasy firestore get users/alice
+  gcpeasy firestore get users/alice/orders/1042 | jq .status
+
+Integers are printed as numbers, timestamps and references as strings.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, _ := cmd.Flags().GetString("database")
+		if err := getFirestoreDocument(args[0], database); err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting document: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+var firestoreQueryCmd = &cobra.Command{
+	Use:   "query <collection>",
+	Short: "List the documents of a collection matching field filters",
+	Long: `List the documents of a collection, or of a subcollection such as users/alice/orders,
+that match all --where filters:
+
+  gcpeasy firestore query users --where status==active --where 'age>=21'
+  gcpeasy firestore query orders --where 'customer="42"' --limit 5 -o json
+
+Filters compare a field (use dots for nested fields) with ==, !=, <, <=, > or >=. Values
+are numbers, true, false or null when they look like one, otherwise strings; quote a value
+to force a string. Range filters on one field and inequality filters on several fields
+may need a composite index, which the error message links to.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, _ := cmd.Flags().GetString("database")
+		where, _ := cmd.Flags().GetStringArray("where")
+		limit, _ := cmd.Flags().GetInt("limit")
+		output, _ := cmd.Flags().GetString("output")
+		if output != "text" && output != "json" {
+			fmt.Printf("Error: unknown output format %q (use text or json)\n", output)
+			exitWithCode(2)
+		}
+		if err := queryFirestore(args[0], database, where, limit, output == "json"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error querying documents: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	firestoreCmd.PersistentFlags().String("database", internal.FirestoreDefaultDatabase, "Firestore database ID")
+	firestoreQueryCmd.Flags().StringArray("where", nil, "Field filter, e.g. status==active (repeatable, all must match)")
+	firestoreQueryCmd.Flags().Int("limit", 20, "Maximum number of documents, 0 for all")
+	firestoreQueryCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	firestoreCmd.AddCommand(firestoreGetCmd)
+	firestoreCmd.AddCommand(firestoreQueryCmd)
+	rootCmd.AddCommand(firestoreCmd)
+}
+
+func getFirestoreDocument(path, database string) error {
+	out, restore := machineOutput()
+	defer restore()
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	doc, err := internal.GetFirestoreDocument(currentProject, database, path)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(doc.Fields, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
+
+func queryFirestore(collection, database string, where []string, limit int, asJSON bool) error {
+	var filters []internal.FirestoreFilter
+	for _, w := range where {
+		f, err := internal.ParseFirestoreFilter(w)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, f)
+	}
+
+	out := os.Stdout
+	if asJSON {
+		var restore func()
+		out, restore = machineOutput()
+		defer restore()
+	}
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Querying %s in project: %s\n", collection, currentProject)
+	docs, err := internal.QueryFirestore(currentProject, database, collection, filters, limit)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if docs == nil {
+			docs = []internal.FirestoreDocument{}
+		}
+		data, err := json.MarshalIndent(docs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Println()
+	if len(docs) == 0 {
+		fmt.Println("❌ No matching documents")
+		return nil
+	}
+	fmt.Printf("📋 Found %d document(s):\n", len(docs))
+	for _, doc := range docs {
+		data, err := json.MarshalIndent(doc.Fields, "   ", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		fmt.Printf("📄 %s\n", doc.Path)
+		fmt.Printf("   %s\n", data)
+	}
+	if limit > 0 && len(docs) == limit {
+		fmt.Println()
+		fmt.Printf("💡 Showing the first %d documents, use --limit to see more\n", limit)
+	}
+	return nil
+}
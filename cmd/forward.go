@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var forwardCmd = &cobra.Command{
+	Use:   "forward [target]",
+	Short: "Port-forward to a service or pod",
+	Long: `Port-forward local ports to a service or pod in application namespaces, e.g.:
+
+  gcpeasy forward              # pick from a list of services and pods
+  gcpeasy forward web          # a service or pod named web
+  gcpeasy forward svc/web      # only look at services (or pod/<name> for pods)
+  gcpeasy forward web --port 9090
+
+The target's ports are detected from the service or its containers, and all TCP ports are
+forwarded unless --port picks one. Each port is forwarded from the same local port, or
+from 8000 plus the port for privileged ports (80 → 8080, 443 → 8443), falling back to a
+free port when that one is taken. Press Ctrl+C to stop.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, _ := cmd.Flags().GetString("port")
+		localPort, _ := cmd.Flags().GetInt("local-port")
+		target := ""
+		if len(args) == 1 {
+			target = args[0]
+		}
+		if err := forward(target, port, localPort); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error port-forwarding: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	forwardCmd.Flags().String("port", "", "Only forward this port, by number or name (required when the target has no detectable ports)")
+	forwardCmd.Flags().Int("local-port", 0, "Local port to use with --port (default: the same port, or a free one)")
+	addNamespaceFlag(forwardCmd)
+	addSelectorFlag(forwardCmd)
+	rootCmd.AddCommand(forwardCmd)
+}
+
+func forward(target, port string, localPort int) error {
+	if localPort != 0 && port == "" {
+		return fmt.Errorf("--local-port requires --port")
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Searching for services and pods...")
+	targets, err := internal.GetForwardTargets()
+	if err != nil {
+		return fmt.Errorf("failed to get services and pods: %w", err)
+	}
+	fmt.Println()
+
+	var selected *internal.ForwardTarget
+	if target != "" {
+		selected, err = resolveForwardTarget(targets, target)
+	} else {
+		selected, err = internal.SelectForwardTarget(targets)
+	}
+	if err != nil {
+		return err
+	}
+
+	ports, err := forwardPorts(*selected, port)
+	if err != nil {
+		return err
+	}
+
+	var mappings []string
+	taken := make(map[int]bool)
+	fmt.Println()
+	fmt.Printf("🔌 Forwarding to %s/%s:\n", selected.Namespace, selected.Resource())
+	for _, p := range ports {
+		local := localPort
+		if local == 0 {
+			if local, err = internal.LocalPortFor(p.Port, taken); err != nil {
+				return err
+			}
+		} else if !internal.LocalPortFree(local) {
+			return fmt.Errorf("local port %d is already in use", local)
+		}
+		taken[local] = true
+		mappings = append(mappings, fmt.Sprintf("%d:%d", local, p.Port))
+
+		name := ""
+		if p.Name != "" {
+			name = " (" + p.Name + ")"
+		}
+		fmt.Printf("🌐 http://localhost:%d → %d%s\n", local, p.Port, name)
+	}
+	fmt.Println()
+	fmt.Println("💡 Press Ctrl+C to stop")
+	fmt.Println()
+
+	return internal.RunPortForward(*selected, mappings)
+}
+
+// resolveForwardTarget finds the target named name, "namespace/name", "svc/name" or
+// "pod/name"
+func resolveForwardTarget(targets []internal.ForwardTarget, target string) (*internal.ForwardTarget, error) {
+	var matches []int
+	for i, t := range targets {
+		if target == t.Name || target == t.Namespace+"/"+t.Name || target == t.Resource() ||
+			(t.Kind == "svc" && target == "service/"+t.Name) {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no service or running pod named %s", target)
+	case 1:
+		return &targets[matches[0]], nil
+	default:
+		var names []string
+		for _, i := range matches {
+			names = append(names, targets[i].Namespace+"/"+targets[i].Resource())
+		}
+		return nil, fmt.Errorf("%s matches several targets (%s), use namespace/name, svc/<name> or pod/<name>", target, strings.Join(names, ", "))
+	}
+}
+
+// forwardPorts returns the target's ports to forward: all of them, or the one chosen
+// with --port. A port number the target doesn't declare is forwarded as given.
+func forwardPorts(t internal.ForwardTarget, port string) ([]internal.ForwardPort, error) {
+	if port == "" {
+		if len(t.Ports) == 0 {
+			return nil, fmt.Errorf("no ports detected on %s, use --port to choose one", t.Resource())
+		}
+		return t.Ports, nil
+	}
+
+	for _, p := range t.Ports {
+		if p.Name == port || strconv.Itoa(p.Port) == port {
+			return []internal.ForwardPort{p}, nil
+		}
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return nil, fmt.Errorf("%s has no port named %s", t.Resource(), port)
+	}
+	return []internal.ForwardPort{{Port: n}}, nil
+}
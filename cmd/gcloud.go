@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var gcloudCmd = &cobra.Command{
+	Use:   "g -- <gcloud args...>",
+	Short: "Run gcloud scoped to the current environment",
+	Long: `Run gcloud with --project set to the current gcpeasy environment, and
+--impersonate-service-account when one is configured for it, so ad-hoc gcloud
+commands can't accidentally target the wrong project.
+
+Example:
+  gcpeasy g -- compute instances list --filter="status=RUNNING"`,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 && args[0] == "--" {
+			args = args[1:]
+		} else if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+			args = nil
+		}
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+
+		exitCode, err := runScopedGcloud(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running gcloud: %v\n", err)
+			exitWithCode(1)
+		}
+		if exitCode != 0 {
+			exitWithCode(exitCode)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcloudCmd)
+}
+
+func runScopedGcloud(args []string) (int, error) {
+	currentProject := getCurrentProject()
+	if currentProject == "" {
+		return 0, fmt.Errorf("no GCP project selected, run 'gcpeasy env select' first")
+	}
+
+	scoped, err := scopeGcloudArgs(args, currentProject)
+	if err != nil {
+		return 0, err
+	}
+
+	// Keep stdout clean for piping; the scope note goes to stderr
+	fmt.Fprintf(os.Stderr, "🔒 gcloud %s\n", strings.Join(scoped, " "))
+
+	gcloud := exec.Command("gcloud", scoped...)
+	gcloud.Stdin = os.Stdin
+	gcloud.Stdout = os.Stdout
+	gcloud.Stderr = os.Stderr
+	err = gcloud.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// scopeGcloudArgs appends --project and the configured impersonation account to a gcloud
+// invocation, refusing an explicit --project that points somewhere else
+func scopeGcloudArgs(args []string, projectID string) ([]string, error) {
+	hasProject, impersonating := false, false
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		value, isProject := strings.CutPrefix(arg, "--project=")
+		if arg == "--project" && i+1 < len(args) {
+			value, isProject = args[i+1], true
+		}
+		if isProject {
+			if value != projectID {
+				return nil, fmt.Errorf("--project %s does not match the current environment %s, switch with 'gcpeasy env select'", value, projectID)
+			}
+			hasProject = true
+		}
+		if arg == "--impersonate-service-account" || strings.HasPrefix(arg, "--impersonate-service-account=") {
+			impersonating = true
+		}
+	}
+
+	var extra []string
+	if !hasProject {
+		extra = append(extra, "--project="+projectID)
+	}
+	if !impersonating {
+		if cfg, err := internal.LoadConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+		} else if sa := cfg.Environment(projectID).ImpersonateServiceAccount; sa != "" {
+			extra = append(extra, "--impersonate-service-account="+sa)
+		}
+	}
+
+	// Flags must come before a positional "--" separator to reach gcloud itself
+	for i, arg := range args {
+		if arg == "--" {
+			scoped := append(append(append([]string{}, args[:i]...), extra...), args[i:]...)
+			return scoped, nil
+		}
+	}
+	return append(append([]string{}, args...), extra...), nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var gitopsCmd = &cobra.Command{
+	Use:   "gitops",
+	Short: "GitOps integration commands",
+	Long:  "Commands for inspecting ArgoCD and Flux resources in the current cluster.",
+}
+
+var gitopsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show GitOps sync and health status",
+	Long:  "Detect ArgoCD Applications or Flux Kustomizations in the current cluster and report their sync/health status and last reconcile errors.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runGitopsStatus(); err != nil {
+			fmt.Printf("Error getting GitOps status: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	gitopsCmd.AddCommand(gitopsStatusCmd)
+	rootCmd.AddCommand(gitopsCmd)
+}
+
+func runGitopsStatus() error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
+
+	fmt.Println("🔍 Detecting GitOps controllers...")
+
+	var statuses []internal.GitOpsStatus
+	found := false
+
+	if internal.HasAPIResource(internal.ArgoApplicationResource) {
+		found = true
+		apps, err := internal.GetArgoApplications()
+		if err != nil {
+			return fmt.Errorf("failed to get ArgoCD applications: %w", err)
+		}
+		fmt.Printf("✅ ArgoCD detected (%d application(s))\n", len(apps))
+		statuses = append(statuses, apps...)
+	}
+
+	if internal.HasAPIResource(internal.FluxKustomizationResource) {
+		found = true
+		kustomizations, err := internal.GetFluxKustomizations()
+		if err != nil {
+			return fmt.Errorf("failed to get Flux kustomizations: %w", err)
+		}
+		fmt.Printf("✅ Flux detected (%d kustomization(s))\n", len(kustomizations))
+		statuses = append(statuses, kustomizations...)
+	}
+
+	if !found {
+		fmt.Println("❌ No ArgoCD Applications or Flux Kustomizations found in this cluster")
+		return nil
+	}
+	fmt.Println()
+
+	fmt.Printf("%-7s %-15s %-30s %-10s %-12s %-13s %-20s\n",
+		"TOOL", "NAMESPACE", "NAME", "SYNC", "HEALTH", "REVISION", "LAST RECONCILE")
+	fmt.Println(strings.Repeat("-", 112))
+
+	unhealthy := 0
+	for _, s := range statuses {
+		line := fmt.Sprintf("%-7s %-15s %-30s %-10s %-12s %-13s %-20s",
+			s.Tool,
+			truncate(s.Namespace, 15),
+			truncate(s.Name, 30),
+			s.Sync,
+			s.Health,
+			s.Revision,
+			s.LastReconcile)
+		if s.Healthy() {
+			fmt.Println(line)
+			continue
+		}
+		unhealthy++
+		fmt.Println(internal.Colorize(internal.ColorRed, line))
+		if s.Message != "" {
+			fmt.Printf("        ↳ %s\n", truncate(strings.ReplaceAll(s.Message, "\n", " "), 100))
+		}
+	}
+
+	fmt.Println()
+	if unhealthy == 0 {
+		fmt.Println("✅ Everything is in sync with git")
+	} else {
+		fmt.Printf("⚠️  %d resource(s) out of sync or unhealthy\n", unhealthy)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// invocation collects what the current command resolved so it can be recorded in the audit trail
+var invocation struct {
+	recorded bool
+	args     []string
+	redacted bool
+	project  string
+	pod      string
+}
+
+// recordableCommands lists the commands whose full arguments are recorded in the history
+// and in events; their arguments and flags only name resources. Other commands may take
+// secrets (headers, request bodies, commands, --env values), so only their command path
+// and the names of the flags they were given are recorded.
+var recordableCommands = map[string]bool{
+	"access verify":         true,
+	"certs list":            true,
+	"chaos kill":            true,
+	"cleanup":               true,
+	"cleanup gcp":           true,
+	"cluster delete":        true,
+	"cluster list":          true,
+	"cluster maintenance":   true,
+	"cluster select":        true,
+	"config rollout":        true,
+	"cr get":                true,
+	"crd list":              true,
+	"deploy cost":           true,
+	"deploy history":        true,
+	"deploy list":           true,
+	"deploy restart":        true,
+	"deploy rightsize":      true,
+	"deploy rollback":       true,
+	"deploy scale":          true,
+	"deploy set-image":      true,
+	"deploy status":         true,
+	"env list":              true,
+	"env owners":            true,
+	"env pause":             true,
+	"env preview create":    true,
+	"env preview delete":    true,
+	"env resume":            true,
+	"env select":            true,
+	"gitops status":         true,
+	"iam purge-keys":        true,
+	"iam rotate-key":        true,
+	"images promote":        true,
+	"job list":              true,
+	"job logs":              true,
+	"job run":               true,
+	"network egress-ips":    true,
+	"network firewall list": true,
+	"network info":          true,
+	"nodes preemptions":     true,
+	"ns quotas":             true,
+	"ns select":             true,
+	"pdb list":              true,
+	"pod describe":          true,
+	"pod events":            true,
+	"pod list":              true,
+	"pod oomkills":          true,
+	"pod pin":               true,
+	"pod restart":           true,
+	"pod unpin":             true,
+	"policies list":         true,
+	"report inventory":      true,
+	"routes list":           true,
+	"schedule status":       true,
+	"security report":       true,
+	"snapshot create":       true,
+	"timeline":              true,
+	"trace-ref":             true,
+	"vm list":               true,
+	"vm start":              true,
+	"vm stop":               true,
+}
+
+// canRecordArgs reports whether the command's full arguments may be recorded
+func canRecordArgs(cmd *cobra.Command) bool {
+	return recordableCommands[strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")]
+}
+
+// redactedArgs returns the command path and the names of the flags the command was given,
+// without positional arguments or flag values
+func redactedArgs(cmd *cobra.Command) []string {
+	args := strings.Fields(cmd.CommandPath())[1:]
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		args = append(args, "--"+f.Name)
+	})
+	return args
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Command history commands",
+	Long:  "Commands for browsing and replaying previous gcpeasy invocations from the audit trail.",
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List previous invocations",
+	Long:  "List previous gcpeasy invocations, most recent first, with the environment and pod they targeted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		if err := listHistory(limit); err != nil {
+			fmt.Printf("Error listing history: %v\n", err)
+		}
+	},
+}
+
+var historyRerunCmd = &cobra.Command{
+	Use:   "rerun [n]",
+	Short: "Re-execute a previous invocation",
+	Long: `Re-execute a previous gcpeasy invocation (default: the most recent one) against the
+environment and pod it originally resolved. If the pod no longer exists, a pod from the
+same workload is selected instead. --yes is dropped from the recorded command, so
+confirmation and protected-environment prompts are shown again. Only commands whose
+arguments just name resources, e.g. 'deploy scale' or 'pod restart', are recorded in full;
+others, e.g. 'pod exec' or 'iap curl', are recorded without argument and flag values and
+can't be re-run.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		n := 1
+		if len(args) == 1 {
+			num, err := strconv.Atoi(args[0])
+			if err != nil || num < 1 {
+				fmt.Printf("Invalid history number: %s\n", args[0])
+				return
+			}
+			n = num
+		}
+		if err := rerunHistory(n); err != nil {
+			fmt.Printf("Error re-running command: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	historyListCmd.Flags().IntP("limit", "l", 20, "Number of entries to show")
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyRerunCmd)
+	rootCmd.AddCommand(historyCmd)
+}
+
+// beginInvocation marks the running command for recording; history commands themselves are skipped
+func beginInvocation(cmd *cobra.Command) {
+	if strings.HasPrefix(cmd.CommandPath(), cmd.Root().Name()+" history") || cmd.Name() == "help" {
+		return
+	}
+	invocation.recorded = true
+	if !canRecordArgs(cmd) {
+		invocation.args = redactedArgs(cmd)
+		invocation.redacted = true
+		return
+	}
+	invocation.args = os.Args[1:]
+}
+
+// recordPodTarget notes the pod the current command operates on
+func recordPodTarget(pod string) {
+	invocation.pod = pod
+	internal.EmitEvent(internal.EventPodSelected, map[string]any{"pod": pod})
+}
+
+// recordInvocation appends the current invocation to the audit trail
+func recordInvocation() {
+	if !invocation.recorded {
+		return
+	}
+	entry := internal.HistoryEntry{
+		Time:     time.Now(),
+		Args:     invocation.args,
+		Project:  invocation.project,
+		Pod:      invocation.pod,
+		Redacted: invocation.redacted,
+	}
+	if invocation.pod != "" {
+		entry.Context, _ = internal.GetCurrentCluster()
+	}
+	if err := internal.AppendHistory(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to record history: %v\n", err)
+	}
+}
+
+func listHistory(limit int) error {
+	entries, err := internal.LoadHistory()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No history recorded yet.")
+		return nil
+	}
+
+	for i := 0; i < len(entries) && i < limit; i++ {
+		e := entries[len(entries)-1-i]
+		fmt.Printf("%3d. %s  %s\n", i+1, e.Time.Local().Format("2006-01-02 15:04"), e.Command())
+		var details []string
+		if e.Project != "" {
+			details = append(details, "env: "+e.Project)
+		}
+		if e.Pod != "" {
+			details = append(details, "pod: "+e.Pod)
+		}
+		if len(details) > 0 {
+			fmt.Printf("     %s\n", strings.Join(details, ", "))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Use 'gcpeasy history rerun <n>' to run an entry again")
+	return nil
+}
+
+func rerunHistory(n int) error {
+	entries, err := internal.LoadHistory()
+	if err != nil {
+		return err
+	}
+	if n > len(entries) {
+		fmt.Printf("History entry %d not found (%d recorded).\n", n, len(entries))
+		return nil
+	}
+	entry := entries[len(entries)-n]
+	if entry.Redacted {
+		return fmt.Errorf("the arguments of '%s' weren't recorded, run it again yourself", entry.Command())
+	}
+	args := withoutYes(entry.Args)
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate gcpeasy binary: %w", err)
+	}
+
+	fmt.Printf("🔁 Re-running: gcpeasy %s\n", strings.Join(args, " "))
+	env := os.Environ()
+	if entry.Project != "" {
+		fmt.Printf("   Environment: %s\n", entry.Project)
+		env = append(env, "GCPEASY_PROJECT="+entry.Project)
+	}
+	if entry.Pod != "" {
+		fmt.Printf("   Pod: %s\n", entry.Pod)
+		env = append(env, "GCPEASY_POD="+entry.Pod)
+	}
+	fmt.Println()
+
+	cmd := exec.Command(self, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
+
+// withoutYes drops --yes and -y from recorded arguments, so a re-run asks for confirmation
+// again; arguments after "--" belong to the command being run and are kept
+func withoutYes(args []string) []string {
+	var kept []string
+	for i, arg := range args {
+		if arg == "--" {
+			return append(kept, args[i:]...)
+		}
+		if arg == "-y" || arg == "--yes" || strings.HasPrefix(arg, "--yes=") {
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// addEmitIaCFlag adds --emit-iac, which takes the format of the snippet to print. It always
+// needs a value: with an optional one, "--emit-iac terraform" would read terraform as an argument.
+func addEmitIaCFlag(cmd *cobra.Command) {
+	cmd.Flags().String("emit-iac", "", "After the change, print an equivalent snippet to codify it: yaml (Kubernetes manifest) or terraform")
+}
+
+// checkIaCFormat validates the --emit-iac format before anything is changed
+func checkIaCFormat(format string) error {
+	if format != "" && !internal.ValidIaCFormat(format) {
+		return fmt.Errorf("unsupported --emit-iac format %q (use yaml or terraform)", format)
+	}
+	return nil
+}
+
+// emitIaC prints the snippet equivalent to a change made, if --emit-iac was given
+func emitIaC(format string, snippet func(format string) (string, error)) {
+	if format == "" {
+		return
+	}
+	code, err := snippet(format)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to generate the %s snippet: %v\n", format, err)
+		return
+	}
+	label := "Kubernetes YAML"
+	if format == internal.IaCTerraform {
+		label = "Terraform"
+	}
+	fmt.Println()
+	fmt.Printf("📄 Equivalent %s, to codify this change:\n", label)
+	fmt.Println()
+	fmt.Print(code)
+}
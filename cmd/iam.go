@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var iamCmd = &cobra.Command{
+	Use:   "iam",
+	Short: "IAM commands",
+	Long:  "Commands for managing service accounts in the current project.",
+}
+
+var iamRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key <service-account>",
+	Short: "Replace a service account key with a new one",
+	Long: `Rotate a user-managed key of a service account, given as its email or account ID:
+
+  1. create a new key
+  2. store it: in a Kubernetes Secret (--k8s-secret), as a new Secret Manager version
+     (--secret-manager) and/or in a local file (--output)
+  3. check the new key authenticates, in a throwaway gcloud configuration
+  4. disable the old key and schedule its deletion after --grace (default: 7d)
+
+The old key is only disabled once the new one is stored and works, so a failure leaves it
+active. Disabled keys can be re-enabled until they are deleted with 'gcpeasy iam
+purge-keys'. The key to rotate is chosen when the account has several.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts rotateKeyOptions
+		opts.Account = args[0]
+		opts.KeyID, _ = cmd.Flags().GetString("key")
+		opts.Secret, _ = cmd.Flags().GetString("k8s-secret")
+		opts.SecretKey, _ = cmd.Flags().GetString("secret-key")
+		opts.SecretManager, _ = cmd.Flags().GetString("secret-manager")
+		opts.Output, _ = cmd.Flags().GetString("output")
+		opts.Grace, _ = cmd.Flags().GetString("grace")
+		opts.Yes, _ = cmd.Flags().GetBool("yes")
+		if err := rotateKey(opts); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error rotating key: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+var iamPurgeKeysCmd = &cobra.Command{
+	Use:   "purge-keys",
+	Short: "Delete rotated keys whose grace period has passed",
+	Long: `Delete the old keys disabled by 'gcpeasy iam rotate-key' once their grace period has
+passed. Keys that were re-enabled in the meantime are kept and no longer scheduled.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		if err := purgeKeys(yes); err != nil {
+			fmt.Printf("Error deleting keys: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	iamRotateKeyCmd.Flags().String("key", "", "ID of the key to replace (default: the account's only active key, or chosen)")
+	iamRotateKeyCmd.Flags().String("k8s-secret", "", "Kubernetes Secret to store the new key in, as namespace/name")
+	iamRotateKeyCmd.Flags().String("secret-key", "key.json", "Key within the Kubernetes Secret")
+	iamRotateKeyCmd.Flags().String("secret-manager", "", "Secret Manager secret to add the new key to as a new version")
+	iamRotateKeyCmd.Flags().StringP("output", "o", "", "File to save the new key to")
+	iamRotateKeyCmd.Flags().String("grace", "7d", "How long the old key stays disabled before it may be deleted")
+	iamRotateKeyCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	iamPurgeKeysCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	iamCmd.AddCommand(iamRotateKeyCmd)
+	iamCmd.AddCommand(iamPurgeKeysCmd)
+	rootCmd.AddCommand(iamCmd)
+}
+
+type rotateKeyOptions struct {
+	Account       string
+	KeyID         string
+	Secret        string
+	SecretKey     string
+	SecretManager string
+	Output        string
+	Grace         string
+	Yes           bool
+}
+
+func rotateKey(opts rotateKeyOptions) error {
+	grace, err := internal.ParseDuration(opts.Grace)
+	if err != nil {
+		return err
+	}
+	if opts.Secret == "" && opts.SecretManager == "" && opts.Output == "" {
+		return fmt.Errorf("nowhere to store the new key: use --k8s-secret, --secret-manager and/or --output")
+	}
+	secretNamespace, secretName, ok := strings.Cut(opts.Secret, "/")
+	if opts.Secret != "" && (!ok || secretNamespace == "" || secretName == "") {
+		return fmt.Errorf("invalid --k8s-secret %q: use namespace/name", opts.Secret)
+	}
+	if opts.Output != "" {
+		if _, err := os.Stat(opts.Output); err == nil {
+			return fmt.Errorf("%s already exists", opts.Output)
+		}
+	}
+
+	var currentProject string
+	if opts.Secret != "" {
+		if !setupCluster() {
+			return nil
+		}
+		currentProject = getCurrentProject()
+	} else if currentProject = requireProject(); currentProject == "" {
+		return nil
+	}
+	account := internal.ServiceAccountEmail(currentProject, opts.Account)
+
+	fmt.Printf("🔍 Looking up the keys of %s...\n", account)
+	keys, err := internal.GetServiceAccountKeys(currentProject, account)
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+	old, err := selectOldKey(keys, opts.KeyID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("🔐 Rotating a key of %s\n", account)
+	if old != nil {
+		fmt.Printf("   Old key:  %s (created %s ago), disabled once the new key works\n", old.ID(), internal.FormatDuration(time.Since(old.Created())))
+	} else {
+		fmt.Println("   Old key:  none active, only a new key is created")
+	}
+	if opts.Secret != "" {
+		fmt.Printf("   Secret:   %s (key %s)\n", opts.Secret, opts.SecretKey)
+	}
+	if opts.SecretManager != "" {
+		fmt.Printf("   Secret Manager: %s (new version)\n", opts.SecretManager)
+	}
+	if opts.Output != "" {
+		fmt.Printf("   File:     %s\n", opts.Output)
+	}
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "rotate a key of "+account) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !opts.Yes && !confirm("Rotate the key?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return runNotified("iam rotate-key", account, func() error {
+		return rotateKeySteps(currentProject, account, old, opts, secretNamespace, secretName, grace)
+	})
+}
+
+// rotateKeySteps creates, stores and verifies the new key, then disables the old one
+func rotateKeySteps(projectID, account string, old *internal.ServiceAccountKey, opts rotateKeyOptions, secretNamespace, secretName string, grace time.Duration) error {
+	dir, err := os.MkdirTemp("", "gcpeasy-key-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key.json")
+
+	newID, err := internal.CreateServiceAccountKey(projectID, account, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to create a key: %w", err)
+	}
+	fmt.Printf("✅ Created key %s\n", newID)
+
+	// Until the old key is disabled, failures leave the old key active. The new key's
+	// only other copy is in dir, removed on return, so it is deleted again unless it was
+	// already stored somewhere; otherwise every failed run would leak a live key.
+	var stored []string
+	keepOld := func(err error) error {
+		if len(stored) > 0 {
+			fmt.Printf("⚠️  The new key %s is stored in %s; the old key is still active\n", newID, strings.Join(stored, ", "))
+			return err
+		}
+		if delErr := internal.DeleteServiceAccountKey(projectID, account, newID); delErr != nil {
+			fmt.Printf("⚠️  Warning: failed to delete the new key %s, delete it yourself: %v\n", newID, delErr)
+		} else {
+			fmt.Printf("🧹 Deleted the new key %s; the old key is still active\n", newID)
+		}
+		return err
+	}
+	keyData, err := os.ReadFile(keyFile)
+	if err != nil {
+		return keepOld(err)
+	}
+	if opts.Output != "" {
+		if err := os.WriteFile(opts.Output, keyData, 0600); err != nil {
+			return keepOld(fmt.Errorf("failed to save the key: %w", err))
+		}
+		stored = append(stored, opts.Output)
+		fmt.Printf("✅ Saved the key to %s\n", opts.Output)
+	}
+	if opts.Secret != "" {
+		if err := internal.UpdateSecretKey(secretNamespace, secretName, opts.SecretKey, keyData); err != nil {
+			return keepOld(fmt.Errorf("failed to update secret %s: %w", opts.Secret, err))
+		}
+		stored = append(stored, "secret "+opts.Secret)
+		fmt.Printf("✅ Updated secret %s\n", opts.Secret)
+	}
+	if opts.SecretManager != "" {
+		if err := internal.AddSecretVersion(projectID, opts.SecretManager, keyFile); err != nil {
+			return keepOld(fmt.Errorf("failed to add a version to %s: %w", opts.SecretManager, err))
+		}
+		stored = append(stored, opts.SecretManager)
+		fmt.Printf("✅ Added a new version to %s\n", opts.SecretManager)
+	}
+
+	fmt.Println("⏳ Checking the new key authenticates (new keys can take a minute)...")
+	if err := internal.VerifyServiceAccountKey(keyFile, 2*time.Minute); err != nil {
+		return keepOld(err)
+	}
+	fmt.Println("✅ The new key authenticates")
+
+	if old != nil {
+		if err := internal.DisableServiceAccountKey(projectID, account, old.ID()); err != nil {
+			return fmt.Errorf("failed to disable the old key %s: %w", old.ID(), err)
+		}
+		fmt.Printf("✅ Disabled the old key %s\n", old.ID())
+		deletion, err := internal.ScheduleKeyDeletion(projectID, account, old.ID(), grace)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: could not schedule the deletion of %s, delete it yourself once it's no longer needed: %v\n", old.ID(), err)
+		} else {
+			fmt.Printf("🗓️  It can be deleted with 'gcpeasy iam purge-keys' after %s\n", deletion.DeleteAfter.Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("💡 Re-enable it if something breaks: gcloud iam service-accounts keys enable %s --iam-account %s\n", old.ID(), account)
+	}
+	if opts.Secret != "" {
+		fmt.Printf("💡 Restart the deployments using the secret: gcpeasy config rollout secret %s -n %s\n", secretName, secretNamespace)
+	}
+	return nil
+}
+
+// selectOldKey picks the key to rotate: the one with the given ID, the only active key, or
+// one chosen by the user. It returns nil when the account has no active keys.
+func selectOldKey(keys []internal.ServiceAccountKey, keyID string) (*internal.ServiceAccountKey, error) {
+	var active []internal.ServiceAccountKey
+	for _, k := range keys {
+		if keyID != "" && k.ID() == keyID {
+			if k.Disabled {
+				return nil, fmt.Errorf("key %s is already disabled", keyID)
+			}
+			return &k, nil
+		}
+		if !k.Disabled {
+			active = append(active, k)
+		}
+	}
+	if keyID != "" {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+
+	switch len(active) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &active[0], nil
+	}
+	return internal.SelectServiceAccountKey(active)
+}
+
+func purgeKeys(skipConfirm bool) error {
+	deletions, err := internal.LoadKeyDeletions()
+	if err != nil {
+		return err
+	}
+	var due, pending []internal.KeyDeletion
+	for _, d := range deletions {
+		if time.Now().After(d.DeleteAfter) {
+			due = append(due, d)
+		} else {
+			pending = append(pending, d)
+		}
+	}
+	if len(due) == 0 {
+		fmt.Printf("✅ No keys due for deletion (%d scheduled)\n", len(pending))
+		return nil
+	}
+
+	fmt.Printf("📋 %d key(s) due for deletion:\n", len(due))
+	for _, d := range due {
+		fmt.Printf("   %s of %s (disabled %s ago)\n", d.KeyID, d.Account, internal.FormatDuration(time.Since(d.DisabledAt)))
+	}
+	fmt.Println()
+	if !skipConfirm && !confirm("Delete these keys permanently?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	remaining := pending
+	failed := 0
+	err = runNotified("iam purge-keys", fmt.Sprintf("%d key(s)", len(due)), func() error {
+		for _, d := range due {
+			keys, err := internal.GetServiceAccountKeys(d.ProjectID, d.Account)
+			if err != nil {
+				fmt.Printf("❌ %s: failed to list keys: %v\n", d.KeyID, err)
+				remaining = append(remaining, d)
+				failed++
+				continue
+			}
+			var key *internal.ServiceAccountKey
+			for i := range keys {
+				if keys[i].ID() == d.KeyID {
+					key = &keys[i]
+				}
+			}
+			switch {
+			case key == nil:
+				fmt.Printf("✅ %s was already deleted\n", d.KeyID)
+			case !key.Disabled:
+				fmt.Printf("⚠️  %s was re-enabled, keeping it\n", d.KeyID)
+			default:
+				if err := internal.DeleteServiceAccountKey(d.ProjectID, d.Account, d.KeyID); err != nil {
+					fmt.Printf("❌ %s: %v\n", d.KeyID, err)
+					remaining = append(remaining, d)
+					failed++
+					continue
+				}
+				fmt.Printf("✅ Deleted %s of %s\n", d.KeyID, d.Account)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d keys could not be deleted", failed, len(due))
+		}
+		return nil
+	})
+	if saveErr := internal.SaveKeyDeletions(remaining); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
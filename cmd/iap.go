@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var iapCmd = &cobra.Command{
+	Use:   "iap",
+	Short: "Identity-Aware Proxy commands",
+	Long:  "Commands for working with applications protected by Identity-Aware Proxy (IAP).",
+}
+
+var iapCurlCmd = &cobra.Command{
+	Use:   "curl <url>",
+	Short: "Send a request to an IAP-protected URL",
+	Long: `Obtain an identity token for the environment's IAP OAuth client ID and send an HTTP
+request with it, printing the response body to stdout and its status and headers to stderr.
+
+The client ID comes from --client-id or iap_client_id in the environment's config. gcloud
+can only mint identity tokens for IAP as a service account, so user accounts need the
+environment's impersonate_service_account (or --impersonate-service-account).
+
+Example:
+  gcpeasy iap curl https://internal.example.com/healthz
+  gcpeasy iap curl -X POST -H "Content-Type: application/json" -d '{"ok":true}' https://internal.example.com/api`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := iapCurlOptions{URL: args[0]}
+		opts.ClientID, _ = cmd.Flags().GetString("client-id")
+		opts.ServiceAccount, _ = cmd.Flags().GetString("impersonate-service-account")
+		opts.Method, _ = cmd.Flags().GetString("request")
+		opts.Headers, _ = cmd.Flags().GetStringArray("header")
+		opts.Data, _ = cmd.Flags().GetString("data")
+		opts.Silent, _ = cmd.Flags().GetBool("silent")
+
+		// Only the response body goes to stdout, so it can be piped
+		out, restore := machineOutput()
+		defer restore()
+		status, err := iapCurl(opts, out)
+		if err != nil {
+			fmt.Printf("Error sending request: %v\n", err)
+			exitWithCode(1)
+		}
+		// No status means the request wasn't sent, e.g. without a selected project
+		if status == 0 || status >= 400 {
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	iapCurlCmd.Flags().String("client-id", "", "IAP OAuth client ID (default: iap_client_id from the config)")
+	iapCurlCmd.Flags().String("impersonate-service-account", "", "Service account to mint the token as (default: impersonate_service_account from the config)")
+	iapCurlCmd.Flags().StringP("request", "X", "", "HTTP method (default: GET, or POST with --data)")
+	iapCurlCmd.Flags().StringArrayP("header", "H", nil, "Extra header as \"Name: value\" (repeatable)")
+	iapCurlCmd.Flags().StringP("data", "d", "", "Request body; @file reads it from a file")
+	iapCurlCmd.Flags().BoolP("silent", "s", false, "Only print the response body")
+	iapCmd.AddCommand(iapCurlCmd)
+	rootCmd.AddCommand(iapCmd)
+}
+
+type iapCurlOptions struct {
+	URL            string
+	ClientID       string
+	ServiceAccount string
+	Method         string
+	Headers        []string
+	Data           string
+	Silent         bool
+}
+
+// iapCurl sends the request, writes the response body to out and returns the response
+// status code
+func iapCurl(opts iapCurlOptions, out io.Writer) (int, error) {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return 0, nil
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	environment := cfg.Environment(currentProject)
+	if opts.ClientID == "" {
+		if opts.ClientID, err = internal.ResolveSecret(environment.IAPClientID); err != nil {
+			return 0, fmt.Errorf("failed to read iap_client_id: %w", err)
+		}
+	}
+	if opts.ClientID == "" {
+		fmt.Println("💡 Pass --client-id or set iap_client_id for the environment in the config file")
+		return 0, fmt.Errorf("no IAP client ID configured for this environment")
+	}
+	if opts.ServiceAccount == "" {
+		opts.ServiceAccount = environment.ImpersonateServiceAccount
+	}
+
+	var body io.Reader
+	if opts.Data != "" {
+		data := []byte(opts.Data)
+		if file, ok := strings.CutPrefix(opts.Data, "@"); ok {
+			if data, err = os.ReadFile(file); err != nil {
+				return 0, err
+			}
+		}
+		body = bytes.NewReader(data)
+		if opts.Method == "" {
+			opts.Method = http.MethodPost
+		}
+	}
+	if opts.Method == "" {
+		opts.Method = http.MethodGet
+	}
+
+	if !opts.Silent {
+		if opts.ServiceAccount != "" {
+			fmt.Printf("🔑 Getting an identity token as %s...\n", opts.ServiceAccount)
+		} else {
+			fmt.Println("🔑 Getting an identity token...")
+		}
+	}
+	token, err := internal.IdentityToken(opts.ClientID, opts.ServiceAccount)
+	if err != nil {
+		if opts.ServiceAccount == "" {
+			fmt.Println("💡 User accounts can't mint IAP tokens directly; set impersonate_service_account for the environment or pass --impersonate-service-account")
+		}
+		return 0, fmt.Errorf("failed to get identity token: %w", err)
+	}
+
+	resp, err := internal.IAPRequest(opts.Method, opts.URL, token, opts.Headers, body)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if !opts.Silent {
+		printResponseHead(resp)
+	}
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		var indented bytes.Buffer
+		if json.Indent(&indented, respBody, "", "  ") == nil {
+			respBody = indented.Bytes()
+		}
+	}
+	out.Write(respBody)
+	if len(respBody) > 0 && respBody[len(respBody)-1] != '\n' {
+		fmt.Fprintln(out)
+	}
+
+	if !opts.Silent {
+		switch {
+		case resp.StatusCode >= 300 && resp.StatusCode < 400 && strings.Contains(resp.Header.Get("Location"), "accounts.google.com"):
+			fmt.Println("💡 IAP redirected to sign-in: the token was not accepted. Check that --client-id matches the IAP OAuth client of this backend")
+		case resp.StatusCode == http.StatusUnauthorized:
+			fmt.Println("💡 The token was rejected. Check that the client ID matches the IAP OAuth client of this backend")
+		case resp.StatusCode == http.StatusForbidden:
+			fmt.Println("💡 Access denied. The account needs the IAP-secured Web App User role (roles/iap.httpsResourceAccessor) on this backend")
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// printResponseHead prints the status line and headers, sorted by name
+func printResponseHead(resp *http.Response) {
+	status := fmt.Sprintf("%s %s", resp.Proto, resp.Status)
+	switch {
+	case resp.StatusCode >= 400:
+		status = internal.Colorize(internal.ColorRed, status)
+	case resp.StatusCode >= 300:
+		status = internal.Colorize(internal.ColorYellow, status)
+	default:
+		status = internal.Colorize(internal.ColorGreen, status)
+	}
+	fmt.Println(status)
+
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			fmt.Printf("%s: %s\n", internal.Colorize(internal.ColorCyan, name), value)
+		}
+	}
+	fmt.Println()
+}
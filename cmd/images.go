@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Container image commands",
+	Long:  "Commands for working with container images in Artifact Registry.",
+}
+
+var imagesPromoteCmd = &cobra.Command{
+	Use:   "promote <image:tag>",
+	Short: "Promote an image between environments",
+	Long: `Make an image tested in one environment available in another, e.g.:
+
+  gcpeasy images promote app:1.4.2 --from staging --to prod
+
+--from and --to name environments by project ID, or a unique part of one such as "prod".
+Each environment's Artifact Registry repository is read from image_repository in the
+config file. The image is copied between repositories, or only tagged when both are the
+same. Without --to, --tag adds a tag in the source repository (e.g. --tag prod). A full
+image reference (us-docker.pkg.dev/project/repo/app:1.4.2) needs no --from.
+
+With --deploy <deployment>, the deployment in the target environment is updated to the
+promoted image after showing the change and asking for confirmation, as with deploy
+set-image. Use --wait to watch the rollout.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts promoteOptions
+		opts.From, _ = cmd.Flags().GetString("from")
+		opts.To, _ = cmd.Flags().GetString("to")
+		opts.Tag, _ = cmd.Flags().GetString("tag")
+		opts.Deploy, _ = cmd.Flags().GetString("deploy")
+		opts.Container, _ = cmd.Flags().GetString("container")
+		opts.Wait, _ = cmd.Flags().GetBool("wait")
+		if err := promoteImage(args[0], opts); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error promoting image: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	imagesPromoteCmd.Flags().String("from", "", "Environment to promote from (default: the repository in the image reference)")
+	imagesPromoteCmd.Flags().String("to", "", "Environment to promote to (default: the source repository)")
+	imagesPromoteCmd.Flags().String("tag", "", "Tag to give the promoted image (default: the source tag)")
+	imagesPromoteCmd.Flags().String("deploy", "", "Deployment in the target environment to update to the promoted image")
+	imagesPromoteCmd.Flags().StringP("container", "c", "", "Container to update with --deploy (default: the only one, or the one running the same image)")
+	imagesPromoteCmd.Flags().Bool("wait", false, "Watch the rollout of --deploy until it completes")
+	imagesCmd.AddCommand(imagesPromoteCmd)
+	rootCmd.AddCommand(imagesCmd)
+}
+
+// promoteOptions are the flags of `images promote`
+type promoteOptions struct {
+	From      string
+	To        string
+	Tag       string
+	Deploy    string
+	Container string
+	Wait      bool
+}
+
+func promoteImage(image string, opts promoteOptions) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// repository resolves an environment name to its project ID and image repository
+	repository := func(flag, env string) (string, string, error) {
+		projectID, err := cfg.ResolveEnvironment(env)
+		if err != nil {
+			return "", "", err
+		}
+		repo := cfg.Environment(projectID).ImageRepository
+		if repo == "" {
+			return "", "", fmt.Errorf("no image_repository configured for %s (--%s %s)", projectID, flag, env)
+		}
+		return projectID, repo, nil
+	}
+
+	src := internal.ParseImageRef(image)
+	if src.Tag == "" && src.Digest == "" {
+		return fmt.Errorf("%s has no tag, use image:tag", image)
+	}
+	if opts.From != "" {
+		_, repo, err := repository("from", opts.From)
+		if err != nil {
+			return err
+		}
+		if src.Repository != "" && src.Repository != repo {
+			return fmt.Errorf("%s is not in the %s repository (%s)", image, opts.From, repo)
+		}
+		src.Repository = repo
+	}
+	if src.Repository == "" {
+		return fmt.Errorf("use --from or a full image reference to say which repository %s is in", image)
+	}
+
+	dst := internal.ImageRef{Repository: src.Repository, Name: src.Name, Tag: src.Tag}
+	targetProject := currentProject
+	if opts.To != "" {
+		if targetProject, dst.Repository, err = repository("to", opts.To); err != nil {
+			return err
+		}
+	}
+	if opts.Tag != "" {
+		dst.Tag = opts.Tag
+	}
+	if dst.Tag == "" {
+		return fmt.Errorf("use --tag to name the promoted image")
+	}
+	if dst.Repository == src.Repository && dst.Tag == src.Tag {
+		return fmt.Errorf("source and destination are the same, use --to or --tag")
+	}
+
+	fmt.Printf("🔍 Resolving %s...\n", src)
+	digest, err := internal.ImageDigest(src.String())
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", src, err)
+	}
+	fmt.Println()
+	fmt.Printf("📦 %s\n", src)
+	fmt.Printf("   %s\n", internal.Colorize(internal.ColorGray, digest))
+	fmt.Printf(" → %s\n", internal.Colorize(internal.ColorGreen, dst.String()))
+	if existing, err := internal.ImageDigest(dst.String()); err == nil {
+		if existing == digest {
+			fmt.Println("✅ Already promoted")
+			return promoteDeployment(targetProject, currentProject, dst, opts)
+		}
+		fmt.Printf("⚠️  %s currently points at %s and will be moved\n", dst, existing)
+	}
+	fmt.Println()
+
+	if !confirmProtected(targetProject, "promote "+src.Name+" to "+dst.String()) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Promote %s to %s?", src, dst)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("images promote", fmt.Sprintf("%s → %s", src, dst), func() error {
+		return internal.PromoteImage(src, dst)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Promoted %s to %s\n", src, dst)
+
+	return promoteDeployment(targetProject, currentProject, dst, opts)
+}
+
+// promoteDeployment updates the --deploy deployment in the target environment to the
+// promoted image
+func promoteDeployment(targetProject, currentProject string, image internal.ImageRef, opts promoteOptions) error {
+	if opts.Deploy == "" {
+		return nil
+	}
+	fmt.Println()
+
+	if targetProject != currentProject {
+		applyProjectOverride(targetProject)
+		// Switch to the target environment's cluster even with --skip-checks, which would
+		// otherwise leave kubectl on the source environment's cluster
+		if err := internal.SetupClusterIfNeeded(targetProject); err != nil {
+			return fmt.Errorf("failed to setup cluster: %w", err)
+		}
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	d, err := selectDeployment(opts.Deploy)
+	if err != nil {
+		return err
+	}
+
+	container, err := imageContainer(*d, opts.Container, image.Name)
+	if err != nil {
+		return err
+	}
+	return updateDeploymentImage(targetProject, d, container, image.String(), opts.Wait, 10*time.Minute, false, "")
+}
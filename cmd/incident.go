@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Incident response commands",
+	Long:  "Commands for responding to incidents in the current environment.",
+}
+
+var incidentStartCmd = &cobra.Command{
+	Use:   "start [name]",
+	Short: "Gather an overview of the environment's health into an incident folder",
+	Long: `Show a combined view of the current environment for the start of an incident: open
+Cloud Monitoring alerts, failing pods, warning events, recent error logs from Cloud Logging,
+and the latest deploys.
+
+Everything gathered is also written to an incident folder for the postmortem, with a
+summary.md and the full data as JSON. The folder is incident-<name> (or
+incident-<timestamp> without a name) in the current directory unless --dir is set.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts incidentOptions
+		if len(args) == 1 {
+			opts.Name = args[0]
+		}
+		opts.Since, _ = cmd.Flags().GetString("since")
+		opts.Dir, _ = cmd.Flags().GetString("dir")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		if err := startIncident(opts); err != nil {
+			fmt.Printf("Error starting incident: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	incidentStartCmd.Flags().String("since", "1h", "How far back to look for error logs and events (e.g. 30m, 6h)")
+	incidentStartCmd.Flags().String("dir", "", "Folder to write to (default: incident-<name or timestamp>)")
+	incidentStartCmd.Flags().Int("limit", 500, "Maximum number of error log entries to gather")
+	addNamespaceFlag(incidentCmd)
+	incidentCmd.AddCommand(incidentStartCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
+
+type incidentOptions struct {
+	Name  string
+	Since string
+	Dir   string
+	Limit int
+}
+
+// incidentShown caps how many items of each section are printed; the files have all of them
+const incidentShown = 15
+
+// failingPod is a failing pod as written to the incident folder
+type failingPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Problem   string `json:"problem"`
+	Restarts  int    `json:"restarts"`
+	Node      string `json:"node"`
+}
+
+func startIncident(opts incidentOptions) error {
+	since, err := internal.ParseDuration(opts.Since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+	context, err := internal.GetCurrentCluster()
+	if err != nil {
+		return fmt.Errorf("failed to get current cluster: %w", err)
+	}
+	_, cluster, _ := internal.ParseClusterContext(context)
+
+	started := time.Now()
+	dir := opts.Dir
+	if dir == "" {
+		name := opts.Name
+		if name == "" {
+			name = started.Format("20060102-150405")
+		}
+		dir = "incident-" + name
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var summary strings.Builder
+	title := "Incident"
+	if opts.Name != "" {
+		title += " " + opts.Name
+	}
+	fmt.Fprintf(&summary, "# %s\n\n", title)
+	fmt.Fprintf(&summary, "- Started: %s\n", started.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&summary, "- Project: %s\n", currentProject)
+	fmt.Fprintf(&summary, "- Cluster: %s\n", context)
+	if namespace := internal.NamespaceScope(); namespace != "" {
+		fmt.Fprintf(&summary, "- Namespace: %s\n", namespace)
+	}
+	fmt.Fprintf(&summary, "- Window: last %s\n", opts.Since)
+
+	fmt.Println()
+	fmt.Printf("🚨 Incident view of %s (%s), last %s\n", currentProject, cluster, opts.Since)
+
+	var problems []string
+	record := func(section string, err error) {
+		fmt.Printf("⚠️  Warning: could not get %s: %v\n", section, err)
+		problems = append(problems, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	// Open alerts
+	printIncidentSection("🔔 Open alerts")
+	fmt.Fprintf(&summary, "\n## Open alerts\n\n")
+	if alerts, err := internal.GetOpenAlerts(currentProject); err != nil {
+		record("open alerts", err)
+	} else {
+		writeIncidentFile(dir, "alerts.json", alerts)
+		if len(alerts) == 0 {
+			fmt.Println("✅ No open alerts")
+		}
+		for i, a := range alerts {
+			line := fmt.Sprintf("%s  %s", a.OpenTime.Local().Format("15:04:05"), a.Policy.DisplayName)
+			if a.Policy.Severity != "" && a.Policy.Severity != "SEVERITY_UNSPECIFIED" {
+				line += " [" + a.Policy.Severity + "]"
+			}
+			if i < incidentShown {
+				fmt.Println("   " + line)
+			}
+			fmt.Fprintf(&summary, "- %s\n", line)
+		}
+		printIncidentMore(len(alerts), "alerts.json")
+	}
+
+	// Failing pods
+	printIncidentSection("💥 Failing pods")
+	fmt.Fprintf(&summary, "\n## Failing pods\n\n")
+	if pods, err := internal.GetApplicationPods(); err != nil {
+		record("pods", err)
+	} else {
+		var failing []failingPod
+		for _, p := range pods {
+			if problem, ok := internal.PodProblem(p); ok {
+				failing = append(failing, failingPod{
+					Namespace: p.Metadata.Namespace,
+					Name:      p.Metadata.Name,
+					Problem:   problem,
+					Restarts:  internal.PodRestarts(p),
+					Node:      p.Spec.NodeName,
+				})
+			}
+		}
+		writeIncidentFile(dir, "failing-pods.json", failing)
+		if len(failing) == 0 {
+			fmt.Printf("✅ All %d pod(s) healthy\n", len(pods))
+		} else {
+			fmt.Printf("%-50s %-45s %-8s\n", "POD", "PROBLEM", "RESTARTS")
+			fmt.Println(strings.Repeat("-", 105))
+		}
+		for i, p := range failing {
+			if i < incidentShown {
+				fmt.Printf("%-50s %-45s %-8d\n", truncate(p.Namespace+"/"+p.Name, 50), truncate(p.Problem, 45), p.Restarts)
+			}
+			fmt.Fprintf(&summary, "- %s/%s: %s (%d restarts)\n", p.Namespace, p.Name, p.Problem, p.Restarts)
+		}
+		printIncidentMore(len(failing), "failing-pods.json")
+	}
+
+	// Warning events
+	printIncidentSection("⚠️  Warning events")
+	fmt.Fprintf(&summary, "\n## Warning events\n\n")
+	if events, err := internal.GetWarningEvents(since); err != nil {
+		record("events", err)
+	} else {
+		writeIncidentFile(dir, "warning-events.json", events)
+		if len(events) == 0 {
+			fmt.Println("✅ No warning events")
+		}
+		// The newest are the most relevant on screen
+		for i, e := range events {
+			object := fmt.Sprintf("%s/%s", strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name)
+			line := fmt.Sprintf("%s  %s  %s  %s", e.Time().Local().Format("15:04:05"), e.Reason, e.Metadata.Namespace+"/"+object, e.Message)
+			if e.Count > 1 {
+				line += fmt.Sprintf(" (x%d)", e.Count)
+			}
+			if i >= len(events)-incidentShown {
+				fmt.Println("   " + truncate(line, 150))
+			}
+			fmt.Fprintf(&summary, "- %s\n", line)
+		}
+		printIncidentMore(len(events), "warning-events.json")
+	}
+
+	// Error logs
+	printIncidentSection("📜 Error logs")
+	fmt.Fprintf(&summary, "\n## Error logs\n\n")
+	query := internal.ApplicationLogQuery(cluster, since, opts.Limit)
+	query.Namespace = internal.NamespaceScope()
+	query.MinSeverity = "ERROR"
+	if entries, err := internal.ReadContainerLogs(currentProject, query); err != nil {
+		record("error logs", err)
+	} else {
+		if err := writeIncidentLogs(dir, entries); err != nil {
+			record("error logs", err)
+		}
+		if len(entries) == 0 {
+			fmt.Println("✅ No error logs")
+		}
+		counts := make(map[string]int)
+		for i, e := range entries {
+			counts[e.Namespace+"/"+e.Container]++
+			if i >= len(entries)-incidentShown {
+				fmt.Println("   " + truncate(fmt.Sprintf("%s  %s/%s  %s", e.Time.Local().Format("15:04:05"), e.Namespace, e.Pod, e.Text), 150))
+			}
+		}
+		for _, source := range sortedKeys(counts) {
+			fmt.Fprintf(&summary, "- %s: %d error(s)\n", source, counts[source])
+		}
+		if len(entries) == opts.Limit {
+			fmt.Printf("⚠️  Reached the limit of %d entries (use --limit)\n", opts.Limit)
+		}
+		printIncidentMore(len(entries), "error-logs.jsonl")
+	}
+
+	// Last deploys
+	printIncidentSection("🚀 Latest deploys")
+	fmt.Fprintf(&summary, "\n## Latest deploys\n\n")
+	if deploys, err := internal.GetLastDeploys(); err != nil {
+		record("deploys", err)
+	} else {
+		writeIncidentFile(dir, "deploys.json", deploys)
+		if len(deploys) == 0 {
+			fmt.Println("No deployments found")
+		} else {
+			last := deploys[0]
+			fmt.Printf("Last deploy: %s/%s %s ago\n", last.Namespace, last.Deployment, internal.FormatDuration(time.Since(last.Time)))
+		}
+		for i, d := range deploys {
+			line := fmt.Sprintf("%s  %s/%s revision %s", d.Time.Local().Format("2006-01-02 15:04"), d.Namespace, d.Deployment, orDash(d.Revision))
+			if d.ChangeCause != "" {
+				line += " (" + d.ChangeCause + ")"
+			}
+			if i < 5 {
+				fmt.Println("   " + line)
+			}
+			fmt.Fprintf(&summary, "- %s\n", line)
+		}
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintf(&summary, "\n## Not gathered\n\n")
+		for _, p := range problems {
+			fmt.Fprintf(&summary, "- %s\n", p)
+		}
+	}
+	fmt.Fprintf(&summary, "\n## Timeline\n\n<!-- Add notes as the incident unfolds -->\n")
+	if err := os.WriteFile(filepath.Join(dir, "summary.md"), []byte(summary.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("📁 Saved to %s/ (summary.md and the full data)\n", dir)
+	return nil
+}
+
+func printIncidentSection(title string) {
+	fmt.Println()
+	fmt.Println(internal.Colorize(internal.ColorCyan, title))
+}
+
+func printIncidentMore(total int, file string) {
+	if total > incidentShown {
+		fmt.Printf("   ... %d more in %s\n", total-incidentShown, file)
+	}
+}
+
+// writeIncidentFile writes data as indented JSON to a file in the incident folder
+func writeIncidentFile(dir, name string, data any) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, name), encoded, 0644)
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to write %s: %v\n", name, err)
+	}
+}
+
+func writeIncidentLogs(dir string, entries []internal.ContainerLogEntry) error {
+	f, err := os.Create(filepath.Join(dir, "error-logs.jsonl"))
+	if err != nil {
+		return err
+	}
+	if err := internal.WriteLogEntriesJSON(f, entries); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
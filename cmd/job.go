@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Kubernetes Job and CronJob commands",
+	Long:  "Commands for inspecting Kubernetes Jobs and running CronJobs in application namespaces.",
+}
+
+var jobListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List jobs and cronjobs",
+	Long: `List Kubernetes Jobs in application namespaces with completion status, duration and
+failures, followed by CronJobs with their schedule and when they last ran and succeeded.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listJobs(); err != nil {
+			fmt.Printf("Error listing jobs: %v\n", err)
+		}
+	},
+}
+
+var jobLogsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "View logs of a job's pods",
+	Long:  "Aggregate logs from every pod a job created, including completed and failed attempts.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		follow, _ := cmd.Flags().GetBool("follow")
+		if err := viewJobLogs(args[0], namespace, follow); err != nil {
+			fmt.Printf("Error viewing job logs: %v\n", err)
+		}
+	},
+}
+
+var jobRunCmd = &cobra.Command{
+	Use:   "run <cronjob>",
+	Short: "Run a cronjob now",
+	Long: `Create a Job from a CronJob's template right away, as with 'kubectl create job
+--from=cronjob/<name>', follow its pod's logs and report whether it completed or failed,
+with the exit code. gcpeasy exits with the job's exit code when it fails.
+
+Use --yes to skip the confirmation; protected environments still require typing the
+project ID.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		yes, _ := cmd.Flags().GetBool("yes")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		err := runCronJob(args[0], namespace, yes, timeout)
+		var failed *jobFailedError
+		switch {
+		case err == nil:
+		case errors.As(err, &failed):
+			exitWithCode(failed.exitCode)
+		case strings.Contains(err.Error(), "cancelled by user"):
+			fmt.Println("Cancelled.")
+		default:
+			fmt.Printf("Error running cronjob: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	jobRunCmd.Flags().StringP("namespace", "n", "", "Namespace of the cronjob (default: search application namespaces)")
+	jobRunCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	jobRunCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the job to finish")
+	jobLogsCmd.Flags().StringP("namespace", "n", "", "Namespace of the job (default: search application namespaces)")
+	jobLogsCmd.Flags().BoolP("follow", "f", false, "Follow logs of running pods")
+	jobCmd.AddCommand(jobListCmd)
+	jobCmd.AddCommand(jobLogsCmd)
+	jobCmd.AddCommand(jobRunCmd)
+	rootCmd.AddCommand(jobCmd)
+}
+
+func listJobs() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Gathering jobs...")
+	fmt.Println()
+
+	jobs, err := internal.GetApplicationJobs()
+	if err != nil {
+		return fmt.Errorf("failed to get jobs: %w", err)
+	}
+	cronJobs, err := internal.GetApplicationCronJobs()
+	if err != nil {
+		return fmt.Errorf("failed to get cronjobs: %w", err)
+	}
+
+	if len(jobs) == 0 && len(cronJobs) == 0 {
+		fmt.Println("No jobs or cronjobs found in application namespaces.")
+		return nil
+	}
+	if len(jobs) == 0 {
+		fmt.Println("No jobs found in application namespaces.")
+	} else {
+		printJobTable(jobs)
+	}
+	if len(cronJobs) > 0 {
+		fmt.Println()
+		printCronJobTable(cronJobs)
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Use 'gcpeasy job logs <name>' to see a job's output")
+	if len(cronJobs) > 0 {
+		fmt.Println("💡 Use 'gcpeasy job run <cronjob>' to run a cronjob now")
+	}
+	return nil
+}
+
+func printJobTable(jobs []internal.JobInfo) {
+	fmt.Printf("%-15s %-40s %-10s %-12s %-7s %-10s %-8s\n",
+		"NAMESPACE", "NAME", "STATUS", "COMPLETIONS", "FAILED", "DURATION", "AGE")
+	fmt.Println(strings.Repeat("-", 108))
+
+	for _, j := range jobs {
+		line := fmt.Sprintf("%-15s %-40s %-10s %-12s %-7d %-10s %-8s",
+			truncate(j.Namespace, 15),
+			truncate(j.Name, 40),
+			j.Status,
+			j.Completions,
+			j.Failed,
+			orDash(j.Duration),
+			j.Age)
+		if j.Status == "Failed" {
+			fmt.Println(internal.Colorize(internal.ColorRed, line))
+			if j.Message != "" {
+				fmt.Printf("    ↳ %s\n", truncate(j.Message, 100))
+			}
+			continue
+		}
+		fmt.Println(line)
+	}
+}
+
+func printCronJobTable(cronJobs []internal.CronJobInfo) {
+	fmt.Printf("%-15s %-40s %-15s %-10s %-7s %-14s %-14s\n",
+		"NAMESPACE", "CRONJOB", "SCHEDULE", "SUSPENDED", "ACTIVE", "LAST SCHEDULE", "LAST SUCCESS")
+	fmt.Println(strings.Repeat("-", 121))
+
+	for _, c := range cronJobs {
+		suspended := "no"
+		if c.Suspended {
+			suspended = "yes"
+		}
+		line := fmt.Sprintf("%-15s %-40s %-15s %-10s %-7d %-14s %-14s",
+			truncate(c.Namespace, 15),
+			truncate(c.Name, 40),
+			truncate(c.Schedule, 15),
+			suspended,
+			c.Active,
+			orDash(c.LastSchedule),
+			orDash(c.LastSuccess))
+		if c.Suspended {
+			line = internal.Colorize(internal.ColorGray, line)
+		}
+		fmt.Println(line)
+	}
+}
+
+func viewJobLogs(name, namespace string, follow bool) error {
+	if !setupCluster() {
+		return nil
+	}
+
+	if namespace == "" {
+		ns, err := internal.FindJobNamespace(name)
+		if err != nil {
+			return err
+		}
+		namespace = ns
+	}
+
+	fmt.Printf("📋 Viewing logs for job: %s/%s\n", namespace, name)
+	fmt.Println()
+	return internal.StreamJobLogs(namespace, name, follow)
+}
+
+// jobFailedError reports a job that ran but failed, with the exit code gcpeasy exits with
+type jobFailedError struct {
+	exitCode int
+}
+
+func (e *jobFailedError) Error() string {
+	return fmt.Sprintf("job failed with exit code %d", e.exitCode)
+}
+
+func runCronJob(cronJob, namespace string, skipConfirm bool, timeout time.Duration) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	if namespace == "" {
+		ns, err := internal.FindCronJobNamespace(cronJob)
+		if err != nil {
+			return err
+		}
+		namespace = ns
+	}
+
+	fmt.Printf("🎯 cronjob %s/%s\n", namespace, cronJob)
+	fmt.Println()
+	if !confirmProtected(currentProject, "run cronjob "+cronJob) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !skipConfirm && !confirm(fmt.Sprintf("Run %s now?", cronJob)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return runNotified("job run", namespace+"/"+cronJob, func() error {
+		jobName, err := internal.TriggerCronJob(namespace, cronJob)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🚀 Created job %s/%s\n", namespace, jobName)
+
+		fmt.Println("⏳ Waiting for the job's pod to start...")
+		if _, err := internal.WaitForJobPod(namespace, jobName, 5*time.Minute); err != nil {
+			return err
+		}
+		fmt.Println()
+		if err := internal.StreamJobLogs(namespace, jobName, true); err != nil {
+			fmt.Printf("⚠️  Failed to follow logs: %v\n", err)
+		}
+
+		job, err := internal.WaitForJob(namespace, jobName, timeout)
+		if err != nil {
+			return err
+		}
+		code, ok, err := internal.JobExitCode(namespace, jobName)
+		if err != nil {
+			fmt.Printf("⚠️  Could not read the exit code: %v\n", err)
+		}
+		exit := "unknown exit code"
+		if ok {
+			exit = fmt.Sprintf("exit code %d", code)
+		}
+
+		if job.Status == "Complete" {
+			fmt.Printf("✅ Job %s completed in %s (%s)\n", jobName, orDash(job.Duration), exit)
+			return nil
+		}
+		fmt.Printf("❌ Job %s failed after %s (%s)\n", jobName, orDash(job.Duration), exit)
+		if job.Message != "" {
+			fmt.Printf("   %s\n", job.Message)
+		}
+		if !ok || code == 0 {
+			code = 1
+		}
+		return &jobFailedError{exitCode: code}
+	})
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Run a load test against a service from inside the cluster",
+	Long: `Run a load generator (fortio) as a Job next to a service and send it a steady rate of
+HTTP requests, e.g.:
+
+  gcpeasy loadtest --service web --url /healthz --rps 100 --duration 2m
+
+While the test runs, the CPU and memory of the service's pods are sampled with kubectl top.
+At the end, the latency percentiles, achieved rate and errors are reported alongside that
+usage, and the Job is deleted. Without --service, you pick one of the services in scope.
+--url is a path on the service, or a full http(s) URL to load-test another address.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts loadtestOptions
+		opts.Service, _ = cmd.Flags().GetString("service")
+		opts.Port, _ = cmd.Flags().GetString("port")
+		opts.URL, _ = cmd.Flags().GetString("url")
+		opts.RPS, _ = cmd.Flags().GetInt("rps")
+		opts.Connections, _ = cmd.Flags().GetInt("connections")
+		opts.Duration, _ = cmd.Flags().GetDuration("duration")
+		if err := runLoadTest(opts); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error running load test: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	loadtestCmd.Flags().String("service", "", "Service to load-test, as name or namespace/name")
+	loadtestCmd.Flags().String("port", "", "Service port to send requests to, by number or name (default: its first port)")
+	loadtestCmd.Flags().String("url", "/", "Path to request, or a full URL")
+	loadtestCmd.Flags().Int("rps", 100, "Requests per second")
+	loadtestCmd.Flags().Int("connections", 8, "Number of parallel connections")
+	loadtestCmd.Flags().Duration("duration", 2*time.Minute, "How long to send requests")
+	addNamespaceFlag(loadtestCmd)
+	rootCmd.AddCommand(loadtestCmd)
+}
+
+type loadtestOptions struct {
+	Service     string
+	Port        string
+	URL         string
+	RPS         int
+	Connections int
+	Duration    time.Duration
+}
+
+// loadtestSampleInterval is how often the service's pods are sampled during a load test
+const loadtestSampleInterval = 10 * time.Second
+
+func runLoadTest(opts loadtestOptions) error {
+	if opts.RPS < 1 {
+		return fmt.Errorf("--rps must be at least 1")
+	}
+	if opts.Connections < 1 {
+		return fmt.Errorf("--connections must be at least 1")
+	}
+	if opts.Duration < time.Second {
+		return fmt.Errorf("--duration must be at least 1s")
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	var svc *internal.Service
+	var err error
+	if opts.Service != "" {
+		svc, err = internal.FindService(opts.Service)
+	} else {
+		var services []internal.Service
+		services, err = internal.GetApplicationServices()
+		if err != nil {
+			return fmt.Errorf("failed to get services: %w", err)
+		}
+		fmt.Println()
+		svc, err = internal.SelectService(services)
+	}
+	if err != nil {
+		return err
+	}
+	test, err := internal.NewLoadTest(*svc, opts.URL, opts.Port, opts.RPS, opts.Connections, opts.Duration)
+	if err != nil {
+		return err
+	}
+	selector := svc.PodSelector()
+
+	fmt.Println()
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("🌐 Target: %s\n", test.URL)
+	fmt.Printf("📋 %d requests/s over %d connection(s) for %s\n", test.RPS, test.Connections, test.Duration)
+	fmt.Println()
+
+	if !confirmProtected(currentProject, "run a load test") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm(fmt.Sprintf("Send about %d requests to %s/%s?", test.RPS*int(test.Duration.Seconds()), svc.Metadata.Namespace, svc.Metadata.Name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	detail := fmt.Sprintf("%s at %d rps for %s", test.URL, test.RPS, test.Duration)
+	if err := runNotified("loadtest", detail, func() error { return internal.StartLoadTest(test) }); err != nil {
+		return err
+	}
+	defer func() {
+		if err := internal.DeleteLoadTest(test); err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
+		}
+	}()
+
+	fmt.Println()
+	fmt.Printf("🔄 Running job %s/%s (press Ctrl+C to stop watching; the job still ends on its own)...\n", test.Namespace, test.Name)
+	usage, status, err := watchLoadTest(test, selector)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	logs, err := internal.LoadTestLogs(test)
+	if err != nil {
+		return fmt.Errorf("failed to get the load generator's output: %w", err)
+	}
+	if status.Status != "Complete" {
+		fmt.Printf("❌ Load test %s", strings.ToLower(status.Status))
+		if status.Message != "" {
+			fmt.Printf(": %s", status.Message)
+		}
+		fmt.Println()
+		fmt.Println(strings.TrimSpace(logs))
+		return nil
+	}
+	result, err := internal.ParseLoadTestResult(logs)
+	if err != nil {
+		return err
+	}
+
+	printLoadTestResult(result, test)
+	printLoadTestUsage(usage, selector)
+	return nil
+}
+
+// watchLoadTest waits for the load test's Job to finish, sampling the usage of the pods
+// matching selector meanwhile
+func watchLoadTest(test internal.LoadTest, selector string) (map[string]*internal.UsageStats, internal.JobInfo, error) {
+	usage := make(map[string]*internal.UsageStats)
+	started := time.Now()
+	deadline := started.Add(test.Duration + 5*time.Minute)
+	topWarned := false
+
+	for {
+		status, err := internal.GetJob(test.Namespace, test.Name)
+		if err != nil {
+			return nil, status, fmt.Errorf("failed to get job %s: %w", test.Name, err)
+		}
+		if status.Status == "Complete" || status.Status == "Failed" {
+			return usage, status, nil
+		}
+		if time.Now().After(deadline) {
+			status.Status = "Timed out"
+			return usage, status, nil
+		}
+
+		sample, err := internal.GetPodUsage(test.Namespace, selector)
+		if err != nil && !topWarned {
+			fmt.Printf("⚠️  Warning: could not sample pod usage: %v\n", err)
+			topWarned = true
+		}
+		var cpu, memory float64
+		for pod, u := range sample {
+			if usage[pod] == nil {
+				usage[pod] = &internal.UsageStats{}
+			}
+			usage[pod].Add(u)
+			cpu += u.CPU
+			memory += u.Memory
+		}
+		elapsed := time.Since(started).Round(time.Second)
+		if len(sample) > 0 {
+			fmt.Printf("   %6s  %-8s %d pod(s) using %s CPU, %s memory\n", elapsed, status.Status, len(sample), internal.FormatCPU(cpu), internal.FormatMemory(memory))
+		} else {
+			fmt.Printf("   %6s  %s\n", elapsed, status.Status)
+		}
+		time.Sleep(loadtestSampleInterval)
+	}
+}
+
+func printLoadTestResult(result *internal.LoadTestResult, test internal.LoadTest) {
+	hist := result.DurationHistogram
+	fmt.Printf("✅ Sent %d requests in %s (%.1f/s of %d/s requested)\n", hist.Count, result.ActualDuration.Round(time.Second), result.ActualQPS, test.RPS)
+	if failed := result.Errors(); failed > 0 {
+		fmt.Printf("❌ %d error(s) (%.1f%%)\n", failed, 100*float64(failed)/float64(max(hist.Count, 1)))
+	}
+
+	var codes []string
+	for code := range result.RetCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	var counts []string
+	for _, code := range codes {
+		counts = append(counts, fmt.Sprintf("%s×%d", code, result.RetCodes[code]))
+	}
+	if len(counts) > 0 {
+		fmt.Printf("📋 Status codes: %s\n", strings.Join(counts, ", "))
+	}
+
+	fmt.Println()
+	fmt.Println("Latency:")
+	for _, p := range internal.LoadTestPercentiles {
+		if latency, ok := result.Latency(p); ok {
+			fmt.Printf("   p%-5g %s\n", p, formatLatency(latency))
+		}
+	}
+	fmt.Printf("   %-6s %s\n", "avg", formatLatency(time.Duration(hist.Avg*float64(time.Second))))
+	fmt.Printf("   %-6s %s\n", "max", formatLatency(time.Duration(hist.Max*float64(time.Second))))
+}
+
+func printLoadTestUsage(usage map[string]*internal.UsageStats, selector string) {
+	fmt.Println()
+	if len(usage) == 0 {
+		fmt.Printf("⚠️  No usage samples for pods matching %s (is metrics-server available?)\n", selector)
+		return
+	}
+
+	var pods []string
+	for pod := range usage {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+
+	fmt.Printf("Pod usage during the run (%s):\n", selector)
+	fmt.Printf("%-50s %-10s %-10s %-10s\n", "POD", "CPU AVG", "CPU MAX", "MEM MAX")
+	fmt.Println(strings.Repeat("-", 83))
+	for _, pod := range pods {
+		u := usage[pod]
+		fmt.Printf("%-50s %-10s %-10s %-10s\n", truncate(pod, 50), internal.FormatCPU(u.CPUAvg()), internal.FormatCPU(u.CPUMax), internal.FormatMemory(u.MemoryMax))
+	}
+}
+
+// formatLatency rounds a latency to a readable precision
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
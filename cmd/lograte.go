@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"sync"
+	"time"
+)
+
+// rateStatus writes log output while keeping a lines/sec and errors/sec status line at the
+// bottom of the terminal. When the output isn't a terminal, only error spikes are reported.
+type rateStatus struct {
+	mu       sync.Mutex
+	out      io.Writer
+	monitor  *internal.LogRateMonitor
+	terminal bool
+	status   string
+	spiking  bool
+}
+
+func newRateStatus(out io.Writer, monitor *internal.LogRateMonitor) *rateStatus {
+	return &rateStatus{out: out, monitor: monitor, terminal: internal.StdoutIsTerminal()}
+}
+
+// Write prints log output above the status line
+func (s *rateStatus) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.clear()
+	n, err := s.out.Write(p)
+	s.draw()
+	return n, err
+}
+
+// run refreshes the status line every second until ctx is cancelled
+func (s *rateStatus) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.clear()
+			s.mu.Unlock()
+			return
+		case <-ticker.C:
+			s.refresh()
+		}
+	}
+}
+
+func (s *rateStatus) refresh() {
+	rates := s.monitor.Rates()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rates.Spike && !s.spiking {
+		s.clear()
+		fmt.Fprintln(s.out, internal.Colorize(internal.ColorRed, fmt.Sprintf("⚠️  Error spike: %.1f errors/s (baseline %.1f/s)", rates.Errors, rates.Baseline)))
+	} else if !rates.Spike && s.spiking {
+		s.clear()
+		fmt.Fprintln(s.out, internal.Colorize(internal.ColorGreen, fmt.Sprintf("✅ Error rate back to %.1f errors/s", rates.Errors)))
+	}
+	s.spiking = rates.Spike
+
+	status := fmt.Sprintf("📈 %.1f lines/s · %.1f errors/s", rates.Lines, rates.Errors)
+	switch {
+	case rates.Spike:
+		status = internal.Colorize(internal.ColorRed, status+" · SPIKE")
+	case rates.Errors > 0:
+		status = internal.Colorize(internal.ColorYellow, status)
+	}
+	s.clear()
+	s.status = status
+	s.draw()
+}
+
+// clear erases the status line; the caller holds the lock
+func (s *rateStatus) clear() {
+	if s.terminal && s.status != "" {
+		fmt.Fprint(s.out, "\r\033[K")
+	}
+}
+
+// draw prints the status line without a newline; the caller holds the lock
+func (s *rateStatus) draw() {
+	if s.terminal && s.status != "" {
+		fmt.Fprint(s.out, s.status)
+	}
+}
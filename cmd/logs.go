@@ -2,45 +2,374 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"os"
+	"regexp"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var logsCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [pod]",
 	Short: "View pod logs (shortcut for 'pod logs')",
 	Long:  "View logs from application pods. This is a shortcut for 'gcpeasy pod logs'.",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		follow, _ := cmd.Flags().GetBool("follow")
-		errorOnly, _ := cmd.Flags().GetBool("error")
-		warnOnly, _ := cmd.Flags().GetBool("warn")
-		infoOnly, _ := cmd.Flags().GetBool("info")
-		debugOnly, _ := cmd.Flags().GetBool("debug")
-		allPods, _ := cmd.Flags().GetBool("all")
-
-		var level string
-		if errorOnly {
-			level = "error"
-		} else if warnOnly {
-			level = "warn"
-		} else if infoOnly {
-			level = "info"
-		} else if debugOnly {
-			level = "debug"
-		}
-
-		if err := runPodLogs(follow, level, allPods); err != nil {
+		if len(args) == 1 {
+			podArgument = args[0]
+		}
+		if err := runPodLogs(logOptionsFromFlags(cmd)); err != nil {
 			fmt.Printf("Error viewing logs: %v\n", err)
 		}
 	},
 }
 
 func init() {
-	logsCmd.Flags().BoolP("follow", "f", false, "Follow logs in real-time")
-	logsCmd.Flags().BoolP("error", "e", false, "Show only error logs")
-	logsCmd.Flags().BoolP("warn", "w", false, "Show only warning logs")
-	logsCmd.Flags().BoolP("info", "i", false, "Show only info logs")
-	logsCmd.Flags().BoolP("debug", "d", false, "Show only debug logs")
+	addLogFlags(logsCmd)
 	logsCmd.Flags().BoolP("all", "a", false, "View logs for all application pods")
+	addNamespaceFlag(logsCmd)
+	addPodTargetFlags(logsCmd)
+	addSelectorFlag(logsCmd)
 	rootCmd.AddCommand(logsCmd)
 }
+
+// logOptions are the settings shared by the log viewing commands
+type logOptions struct {
+	Follow bool
+	// Level is a --level value: a minimum level or a comma-separated list of levels
+	Level string
+	// Grep is a regular expression lines must match, or must not match with InvertGrep
+	Grep       string
+	InvertGrep bool
+	Since      string
+	// Tail is the number of recent lines to show: 0 for the default, -1 for all
+	Tail       int
+	Timestamps bool
+	// Previous shows the logs of the previous instance of a restarted container
+	Previous bool
+	// OutputFile also saves the log lines, without colors, to a file
+	OutputFile string
+	JSON       bool
+	All        bool
+	// StrictLevel only matches the level against a parsed severity field, dropping unstructured lines
+	StrictLevel bool
+	// Rate shows a lines/sec and errors/sec status line while following
+	Rate bool
+
+	// output receives the log lines, os.Stdout unless a status line is shown
+	output io.Writer
+	// monitor counts lines for the rate status line, nil when it's off
+	monitor *internal.LogRateMonitor
+	// multi is set for each pod's stream when viewing several pods, whose header is
+	// printed once for all of them
+	multi bool
+
+	// changed records which options were given as flags, so environment defaults don't override them
+	changed map[string]bool
+}
+
+// addLogFlags registers the flags shared by the log viewing commands
+func addLogFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("follow", "f", false, "Follow logs in real-time")
+	cmd.Flags().StringP("level", "l", "", "Minimum level to show (e.g. warn), or a list of levels (e.g. warn,error)")
+	cmd.Flags().BoolP("error", "e", false, "Show error logs (alias for --level error)")
+	cmd.Flags().BoolP("warn", "w", false, "Show warning and error logs (alias for --level warn)")
+	cmd.Flags().BoolP("info", "i", false, "Show info logs and above (alias for --level info)")
+	cmd.Flags().BoolP("debug", "d", false, "Show all levels (alias for --level debug)")
+	for _, alias := range []string{"error", "warn", "info", "debug"} {
+		cmd.MarkFlagsMutuallyExclusive("level", alias)
+	}
+	cmd.Flags().String("grep", "", "Only show lines matching a regular expression (e.g. a request ID)")
+	cmd.Flags().Bool("invert-grep", false, "Only show lines that don't match --grep")
+	cmd.Flags().String("since", "", "Only show logs newer than a relative duration (e.g. 10m, 1h)")
+	cmd.Flags().Int("tail", 0, fmt.Sprintf("Number of recent lines to show, -1 for all (default: %d, or %d when following; all with --since)", defaultTailLines, followTailLines))
+	cmd.Flags().Bool("timestamps", false, "Prefix each line with its timestamp")
+	cmd.Flags().BoolP("previous", "p", false, "Show the logs of the previous container instance, e.g. to see why it crashed")
+	cmd.Flags().StringP("output", "o", "", "Also save the logs to a file (e.g. crash.log), including pod prefixes")
+	cmd.Flags().Bool("json", false, "Render JSON log lines as readable text")
+	cmd.Flags().Bool("strict-level", false, "Filter by level using only structured severity fields, dropping unstructured lines")
+	cmd.Flags().Bool("rate", false, "While following, show lines/sec and errors/sec and warn on error spikes")
+}
+
+// logOptionsFromFlags reads the log flags registered by addLogFlags
+func logOptionsFromFlags(cmd *cobra.Command) logOptions {
+	flags := cmd.Flags()
+	opts := logOptions{changed: make(map[string]bool)}
+
+	opts.Follow, _ = flags.GetBool("follow")
+	opts.Grep, _ = flags.GetString("grep")
+	opts.InvertGrep, _ = flags.GetBool("invert-grep")
+	opts.Since, _ = flags.GetString("since")
+	opts.Tail, _ = flags.GetInt("tail")
+	opts.Timestamps, _ = flags.GetBool("timestamps")
+	opts.Previous, _ = flags.GetBool("previous")
+	opts.OutputFile, _ = flags.GetString("output")
+	opts.JSON, _ = flags.GetBool("json")
+	opts.StrictLevel, _ = flags.GetBool("strict-level")
+	opts.Rate, _ = flags.GetBool("rate")
+	if flags.Lookup("all") != nil {
+		opts.All, _ = flags.GetBool("all")
+	}
+
+	// The old per-level flags are aliases; combining them selects each of their levels
+	var aliases []string
+	for _, level := range []string{"error", "warn", "info", "debug"} {
+		if on, _ := flags.GetBool(level); on {
+			aliases = append(aliases, level)
+		}
+	}
+	opts.Level, _ = flags.GetString("level")
+	if opts.Level == "" {
+		opts.Level = strings.Join(aliases, ",")
+	}
+	opts.changed["level"] = opts.Level != ""
+	for _, name := range []string{"follow", "since", "tail", "timestamps", "json"} {
+		opts.changed[name] = flags.Changed(name)
+	}
+	return opts
+}
+
+// applyEnvironmentDefaults fills in options that weren't given as flags from the
+// environment's configured log defaults, reporting the ones it applied
+func (o *logOptions) applyEnvironmentDefaults(projectID string) {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+	defaults := cfg.Environment(projectID).Logs
+
+	var applied []string
+	if defaults.Follow != nil && !o.changed["follow"] {
+		o.Follow = *defaults.Follow
+		applied = append(applied, fmt.Sprintf("follow=%t", o.Follow))
+	}
+	if defaults.Since != "" && !o.changed["since"] {
+		o.Since = defaults.Since
+		applied = append(applied, "since="+o.Since)
+	}
+	if defaults.Tail != 0 && !o.changed["tail"] {
+		o.Tail = defaults.Tail
+		applied = append(applied, fmt.Sprintf("tail=%d", o.Tail))
+	}
+	if defaults.Timestamps != nil && !o.changed["timestamps"] {
+		o.Timestamps = *defaults.Timestamps
+		applied = append(applied, fmt.Sprintf("timestamps=%t", o.Timestamps))
+	}
+	if defaults.JSON != nil && !o.changed["json"] {
+		o.JSON = *defaults.JSON
+		applied = append(applied, fmt.Sprintf("json=%t", o.JSON))
+	}
+	if defaults.Level != "" && !o.changed["level"] {
+		o.Level = defaults.Level
+		applied = append(applied, "level="+o.Level)
+	}
+
+	if len(applied) > 0 {
+		fmt.Printf("⚙️  Using log defaults for %s: %s\n", projectID, strings.Join(applied, ", "))
+	}
+}
+
+// Without --tail, only recent lines are shown so long-lived pods don't print their whole history
+const (
+	defaultTailLines = 500
+	followTailLines  = 100
+)
+
+// tailLines is the number of recent lines to request from kubectl, 0 for all. --since
+// bounds the logs by itself, so no default applies with it.
+func (o logOptions) tailLines() int {
+	switch {
+	case o.Tail < 0:
+		return 0
+	case o.Tail > 0:
+		return o.Tail
+	case o.Since != "":
+		return 0
+	case o.Follow && !o.Previous:
+		return followTailLines
+	default:
+		return defaultTailLines
+	}
+}
+
+// logStreamOptions converts the options to the ones passed to kubectl logs
+func (o logOptions) logStreamOptions() internal.LogStreamOptions {
+	return internal.LogStreamOptions{Follow: o.Follow, Since: o.Since, Tail: o.tailLines(), Timestamps: o.Timestamps, Previous: o.Previous}
+}
+
+// grepPattern compiles the --grep expression, nil when it isn't set
+func (o logOptions) grepPattern() (*regexp.Regexp, error) {
+	if o.Grep == "" {
+		if o.InvertGrep {
+			return nil, fmt.Errorf("--invert-grep requires --grep")
+		}
+		return nil, nil
+	}
+	pattern, err := regexp.Compile(o.Grep)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --grep expression: %w", err)
+	}
+	return pattern, nil
+}
+
+// printLogFilters reports the level and --grep filters applied to the logs
+func printLogFilters(opts logOptions) {
+	if opts.Level != "" {
+		printLevelFilter(opts.Level)
+	}
+	if opts.Grep != "" {
+		if opts.InvertGrep {
+			fmt.Printf("🔍 Hiding lines matching: %s\n", opts.Grep)
+		} else {
+			fmt.Printf("🔍 Showing lines matching: %s\n", opts.Grep)
+		}
+	}
+}
+
+// logLineFilter builds the per-line processing shared by the log viewers: counting lines
+// for the rate monitor, level and --grep filtering with highlighting of matches, and JSON
+// rendering
+func logLineFilter(opts logOptions) (func(line string) (string, bool), error) {
+	var levels internal.LevelFilter
+	var levelPattern *regexp.Regexp
+	var levelKeywords string
+	if opts.Level != "" {
+		var err error
+		if levels, err = internal.ParseLevelFilter(opts.Level); err != nil {
+			return nil, err
+		}
+		var patterns []string
+		for _, level := range levels.Levels() {
+			patterns = append(patterns, getLogLevelPatterns(level)...)
+		}
+		levelKeywords = strings.Join(patterns, "|")
+		levelPattern = regexp.MustCompile("(?i)" + levelKeywords)
+	}
+	grep, err := opts.grepPattern()
+	if err != nil {
+		return nil, err
+	}
+	// Matches of an inverted --grep are never shown, so only a plain one is highlighted
+	var grepHighlight, grepWhole, bothHighlight *regexp.Regexp
+	if grep != nil && !opts.InvertGrep {
+		grepHighlight = grep
+		grepWhole = regexp.MustCompile("^(?:" + opts.Grep + ")$")
+		if levelPattern != nil {
+			bothHighlight = regexp.MustCompile("(?:" + opts.Grep + ")|(?i:" + levelKeywords + ")")
+		}
+	}
+	errorPattern := regexp.MustCompile(strings.Join(getLogLevelPatterns("error"), "|"))
+	highlightColor := func(match string) string {
+		if grepWhole != nil && grepWhole.MatchString(match) {
+			return internal.ColorMagenta
+		}
+		for _, keyword := range getLogLevelPatterns("error") {
+			if strings.EqualFold(match, keyword) {
+				return internal.ColorRed
+			}
+		}
+		for _, keyword := range getLogLevelPatterns("warn") {
+			if strings.EqualFold(match, keyword) {
+				return internal.ColorYellow
+			}
+		}
+		return internal.ColorCyan
+	}
+
+	return func(line string) (string, bool) {
+		parsed := internal.ParseLogLine(line)
+		if opts.monitor != nil {
+			severity := internal.NormalizeSeverity(parsed.Severity)
+			opts.monitor.Observe(severity == "error" || (severity == "" && errorPattern.MatchString(line)))
+		}
+		if levels != nil && !matchesLogLevel(parsed, levels, levelPattern, opts.StrictLevel) {
+			return "", false
+		}
+		if grep != nil && grep.MatchString(line) == opts.InvertGrep {
+			return "", false
+		}
+		if opts.JSON {
+			return parsed.Render(), true
+		}
+		// Highlight the --grep matches and the keywords an unstructured line was matched by
+		switch {
+		case levelPattern != nil && parsed.Severity == "" && bothHighlight != nil:
+			return internal.HighlightMatches(line, bothHighlight, highlightColor), true
+		case levelPattern != nil && parsed.Severity == "":
+			return internal.HighlightMatches(line, levelPattern, highlightColor), true
+		case grepHighlight != nil:
+			return internal.HighlightMatches(line, grepHighlight, highlightColor), true
+		}
+		return line, true
+	}, nil
+}
+
+// cloudLoggingDefaultSince is how far back logs of a deleted pod are read without --since
+const cloudLoggingDefaultSince = "24h"
+
+// viewCloudLoggingLogs reads the logs of a pod that no longer exists from Cloud Logging.
+// target is "namespace/pod" or just a pod name.
+func viewCloudLoggingLogs(projectID, target string, opts logOptions) error {
+	// Cloud Logging doesn't record which instance of a container wrote each line
+	if opts.Previous {
+		return fmt.Errorf("pod %s is not running and its logs come from Cloud Logging, which can't select the previous container instance; run again without --previous", target)
+	}
+
+	namespace, podName, found := strings.Cut(target, "/")
+	if !found {
+		namespace, podName = "", target
+	}
+
+	sinceFlag := opts.Since
+	if sinceFlag == "" {
+		sinceFlag = cloudLoggingDefaultSince
+	}
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	query := internal.ContainerLogQuery{Namespace: namespace, Pod: podName, Since: since}
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		_, query.Cluster, _ = internal.ParseClusterContext(context)
+	}
+
+	fmt.Printf("☁️  Pod %s is not running; reading its logs from Cloud Logging (last %s)...\n", target, sinceFlag)
+	if opts.Follow {
+		fmt.Println("💡 Following isn't possible for a pod that no longer exists, showing its logs instead")
+	}
+	printLogFilters(opts)
+	fmt.Println()
+
+	entries, err := internal.ReadContainerLogs(projectID, query)
+	if err != nil {
+		return fmt.Errorf("failed to read Cloud Logging: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("❌ No logs found for %s in the last %s\n", target, sinceFlag)
+		fmt.Println("💡 Use --since to search further back")
+		return nil
+	}
+
+	if n := opts.tailLines(); n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.Line()
+	}
+
+	filter, err := logLineFilter(opts)
+	if err != nil {
+		return err
+	}
+	out := opts.output
+	if out == nil {
+		out = os.Stdout
+	}
+	return internal.FilterLines(strings.NewReader(strings.Join(lines, "\n")), out, filter)
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var logsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export application logs to Cloud Storage, BigQuery or an archive",
+	Long: `Capture the logs of all application pods in the current cluster for a time window and
+store them durably, e.g. for incident response.
+
+Use --to gs://bucket/path/ to upload newline-delimited JSON from Cloud Logging to Cloud
+Storage (a file name is generated when the path ends in /), or --to-bq dataset.table to
+append the entries to a BigQuery table.
+
+Use --bucket gs://bucket/path/ or --archive file.tar.gz for a compressed archive with one
+file per container and a manifest.json of the pods, containers and time ranges it covers.
+Archives are collected from the running pods with kubectl logs, or from Cloud Logging with
+--cloud-logging, which also covers pods that no longer exist.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts logExportOptions
+		opts.Since, _ = cmd.Flags().GetString("since")
+		opts.To, _ = cmd.Flags().GetString("to")
+		opts.ToBQ, _ = cmd.Flags().GetString("to-bq")
+		opts.Bucket, _ = cmd.Flags().GetString("bucket")
+		opts.Archive, _ = cmd.Flags().GetString("archive")
+		opts.CloudLogging, _ = cmd.Flags().GetBool("cloud-logging")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		if err := exportLogs(opts); err != nil {
+			fmt.Printf("Error exporting logs: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	logsExportCmd.Flags().String("since", "1h", "Time window to export (e.g. 30m, 2h, 1d)")
+	logsExportCmd.Flags().String("to", "", "Cloud Storage destination (gs://bucket/path/)")
+	logsExportCmd.Flags().String("to-bq", "", "BigQuery destination table (dataset.table)")
+	logsExportCmd.Flags().String("bucket", "", "Upload a compressed archive to Cloud Storage (gs://bucket/path/)")
+	logsExportCmd.Flags().String("archive", "", "Save a compressed archive to a local file (e.g. incident-123.tar.gz)")
+	logsExportCmd.Flags().Bool("cloud-logging", false, "Collect the archive from Cloud Logging instead of the running pods")
+	logsExportCmd.Flags().Int("limit", 50000, "Maximum number of log entries to export")
+	logsCmd.AddCommand(logsExportCmd)
+}
+
+// logExportOptions are the flags of `logs export`
+type logExportOptions struct {
+	Since        string
+	To           string
+	ToBQ         string
+	Bucket       string
+	Archive      string
+	CloudLogging bool
+	Limit        int
+}
+
+func exportLogs(opts logExportOptions) error {
+	destinations := 0
+	for _, d := range []string{opts.To, opts.ToBQ, opts.Bucket, opts.Archive} {
+		if d != "" {
+			destinations++
+		}
+	}
+	if destinations != 1 {
+		return fmt.Errorf("specify exactly one of --to gs://..., --to-bq dataset.table, --bucket gs://... or --archive file.tar.gz")
+	}
+	if opts.Bucket != "" && !strings.HasPrefix(opts.Bucket, "gs://") {
+		return fmt.Errorf("--bucket must be a gs:// URL: %s", opts.Bucket)
+	}
+	since, err := internal.ParseDuration(opts.Since)
+	if err != nil {
+		return err
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+	if err := ensureCluster(currentProject); err != nil {
+		return err
+	}
+	context, err := internal.GetCurrentCluster()
+	if err != nil {
+		return fmt.Errorf("failed to get current cluster: %w", err)
+	}
+	_, cluster, _ := internal.ParseClusterContext(context)
+
+	if opts.Bucket != "" || opts.Archive != "" {
+		return exportLogArchive(currentProject, cluster, since, opts)
+	}
+
+	fmt.Printf("🔍 Reading application logs for the last %s from Cloud Logging...\n", opts.Since)
+	entries, err := internal.ReadContainerLogs(currentProject, internal.ApplicationLogQuery(cluster, since, opts.Limit))
+	if err != nil {
+		return fmt.Errorf("failed to read Cloud Logging: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("❌ No application logs found in the last %s\n", opts.Since)
+		return nil
+	}
+
+	pods := make(map[string]bool)
+	for _, entry := range entries {
+		pods[entry.Namespace+"/"+entry.Pod] = true
+	}
+	fmt.Printf("📋 %d entries from %d pod(s), %s to %s\n", len(entries), len(pods),
+		entries[0].Time.Local().Format("2006-01-02 15:04:05"), entries[len(entries)-1].Time.Local().Format("2006-01-02 15:04:05"))
+	if len(entries) == opts.Limit {
+		fmt.Printf("⚠️  Reached the limit of %d entries; the oldest logs in the window were left out (use --limit)\n", opts.Limit)
+	}
+
+	file, err := os.CreateTemp("", "gcpeasy-logs-*.jsonl")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := internal.WriteLogEntriesJSON(file, entries); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write logs: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	destination := opts.ToBQ
+	if opts.To != "" {
+		destination = opts.To
+		if strings.HasSuffix(opts.To, "/") {
+			destination += fmt.Sprintf("%s-%s.jsonl", currentProject, time.Now().UTC().Format("20060102T150405Z"))
+		}
+	}
+
+	fmt.Printf("📤 Exporting to %s...\n", destination)
+	err = runNotified("logs export", fmt.Sprintf("%d entries (%s) to %s", len(entries), opts.Since, destination), func() error {
+		if opts.ToBQ != "" {
+			return internal.LoadIntoBigQuery(currentProject, file.Name(), opts.ToBQ)
+		}
+		return internal.UploadToGCS(currentProject, file.Name(), destination)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Exported %d log entries to %s\n", len(entries), destination)
+	return nil
+}
+
+// exportLogArchive collects the logs into a compressed archive with a manifest, saved
+// locally or uploaded to Cloud Storage
+func exportLogArchive(projectID, cluster string, since time.Duration, opts logExportOptions) error {
+	createdAt := time.Now().UTC()
+	path := opts.Archive
+	if opts.Bucket != "" {
+		path = filepath.Join(os.TempDir(), fmt.Sprintf("gcpeasy-logs-%s-%s.tar.gz", projectID, createdAt.Format("20060102T150405Z")))
+		defer os.Remove(path)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	manifest := internal.LogArchiveManifest{Project: projectID, Cluster: cluster, Source: "pods", Since: opts.Since, CreatedAt: createdAt}
+	if opts.CloudLogging {
+		manifest.Source = "cloud-logging"
+	}
+	archive := internal.NewLogArchive(file, manifest)
+
+	if opts.CloudLogging {
+		fmt.Printf("🔍 Reading application logs for the last %s from Cloud Logging...\n", opts.Since)
+		entries, err := internal.ReadContainerLogs(projectID, internal.ApplicationLogQuery(cluster, since, opts.Limit))
+		if err != nil {
+			return fmt.Errorf("failed to read Cloud Logging: %w", err)
+		}
+		if len(entries) == opts.Limit {
+			fmt.Printf("⚠️  Reached the limit of %d entries; the oldest logs in the window were left out (use --limit)\n", opts.Limit)
+		}
+		if err := archive.AddEntries(entries); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	} else {
+		pods, err := internal.GetApplicationPods()
+		if err != nil {
+			return fmt.Errorf("failed to get pods: %w", err)
+		}
+		fmt.Printf("🔍 Collecting the last %s of logs from %d pod(s)...\n", opts.Since, len(pods))
+		for _, pod := range pods {
+			if pod.Status.Phase == "Pending" {
+				continue
+			}
+			for _, c := range pod.Spec.Containers {
+				log, err := internal.ContainerLogs(pod.Metadata.Namespace, pod.Metadata.Name, c.Name, since)
+				if err != nil {
+					fmt.Printf("⚠️  %s/%s (%s): %v\n", pod.Metadata.Namespace, pod.Metadata.Name, c.Name, err)
+					continue
+				}
+				if err := archive.Add(pod.Metadata.Namespace, pod.Metadata.Name, c.Name, log); err != nil {
+					return fmt.Errorf("failed to write archive: %w", err)
+				}
+			}
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	files := archive.Manifest.Files
+	if len(files) == 0 {
+		fmt.Printf("❌ No application logs found in the last %s\n", opts.Since)
+		os.Remove(path)
+		return nil
+	}
+	lines := 0
+	fmt.Println()
+	fmt.Printf("%-15s %-40s %-15s %8s  %-20s %-20s\n", "NAMESPACE", "POD", "CONTAINER", "LINES", "FROM", "TO")
+	fmt.Println(strings.Repeat("-", 125))
+	for _, f := range files {
+		fmt.Printf("%-15s %-40s %-15s %8d  %-20s %-20s\n",
+			truncate(f.Namespace, 15), truncate(f.Pod, 40), truncate(f.Container, 15), f.Lines, orDash(formatLogTime(f.From)), orDash(formatLogTime(f.To)))
+		lines += f.Lines
+	}
+	fmt.Println()
+
+	if opts.Archive != "" {
+		fmt.Printf("✅ Saved %d lines from %d container(s) to %s\n", lines, len(files), opts.Archive)
+		return nil
+	}
+
+	destination := opts.Bucket
+	if strings.HasSuffix(destination, "/") {
+		destination += filepath.Base(path)
+	}
+	fmt.Printf("📤 Uploading to %s...\n", destination)
+	err = runNotified("logs export", fmt.Sprintf("%d lines from %d container(s) (%s) to %s", lines, len(files), opts.Since, destination), func() error {
+		return internal.UploadToGCS(projectID, path, destination)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Exported %d lines from %d container(s) to %s\n", lines, len(files), destination)
+	return nil
+}
+
+// formatLogTime shortens an RFC3339 log timestamp to local time with second precision
+func formatLogTime(timestamp string) string {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
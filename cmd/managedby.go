@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+)
+
+// warnManaged warns that an object about to be changed is managed by a tool listed in the
+// managed_by config, which will revert the change or report it as drift. subject names the
+// object, e.g. "deployment web".
+func warnManaged(subject string, labels, annotations map[string]string) {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return
+	}
+	managers := cfg.RespectedManagers(labels, annotations)
+	if len(managers) == 0 {
+		return
+	}
+
+	fmt.Printf("⚠️  %s is managed by %s: manual changes will be reverted or show up as drift\n", subject, strings.Join(managers, " and "))
+	for _, manager := range managers {
+		if hint := cfg.ManagerHint(manager); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Application metrics commands",
+	Long:  "Commands for inspecting the metrics exposed by application pods.",
+}
+
+var metricsScrapeCmd = &cobra.Command{
+	Use:   "scrape [pod]",
+	Short: "Fetch and pretty-print a pod's Prometheus metrics",
+	Long: `Port-forward to a pod's metrics port, fetch its Prometheus endpoint and print the metric families.
+
+The port and path come from the pod's prometheus.io/port and prometheus.io/path annotations,
+or a container port named "metrics". Use --port and --path to override them, and --grep to
+show only the metric families matching a regular expression.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, _ := cmd.Flags().GetString("path")
+		port, _ := cmd.Flags().GetInt("port")
+		grep, _ := cmd.Flags().GetString("grep")
+		if len(args) == 1 {
+			podArgument = args[0]
+		}
+		if err := scrapeMetrics(port, path, grep); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error scraping metrics: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	metricsScrapeCmd.Flags().String("path", "", "Metrics path (default from annotations, else /metrics)")
+	metricsScrapeCmd.Flags().Int("port", 0, "Metrics port (default from annotations or a port named 'metrics')")
+	metricsScrapeCmd.Flags().String("grep", "", "Only show metric families matching this regular expression")
+	metricsCmd.AddCommand(metricsScrapeCmd)
+	rootCmd.AddCommand(metricsCmd)
+}
+
+func scrapeMetrics(port int, path, grep string) error {
+	var filter *regexp.Regexp
+	if grep != "" {
+		var err error
+		if filter, err = regexp.Compile(grep); err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return err
+	}
+
+	pod, err := internal.GetPod(selectedPod)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	detectedPort, detectedPath, found := internal.MetricsEndpoint(pod)
+	if port == 0 {
+		if !found {
+			fmt.Printf("❌ Could not find a metrics port on %s\n", selectedPod)
+			fmt.Println("💡 Use --port to choose the port to scrape")
+			return nil
+		}
+		port = detectedPort
+	}
+	if path == "" {
+		path = detectedPath
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	fmt.Printf("🔌 Port-forwarding to %s:%d...\n", selectedPod, port)
+	forward, err := internal.StartPortForward(selectedPod, 0, port)
+	if err != nil {
+		return err
+	}
+	defer forward.Close()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", forward.LocalPort, path)
+	fmt.Printf("📡 Fetching %s%s\n", selectedPod, path)
+	body, err := fetchMetrics(url)
+	if err != nil {
+		return err
+	}
+
+	families := internal.ParseMetrics(body)
+	shown := 0
+	fmt.Println()
+	for _, family := range families {
+		if filter != nil && !filter.MatchString(family.Name) {
+			continue
+		}
+		printMetricFamily(family)
+		shown++
+	}
+
+	if shown == 0 {
+		if filter != nil {
+			fmt.Printf("No metric families match %q (%d scraped).\n", grep, len(families))
+		} else {
+			fmt.Println("The endpoint returned no metrics.")
+		}
+		return nil
+	}
+	fmt.Printf("📋 %d of %d metric families\n", shown, len(families))
+	return nil
+}
+
+func fetchMetrics(url string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read metrics: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metrics endpoint returned %s", resp.Status)
+	}
+	return string(body), nil
+}
+
+func printMetricFamily(family internal.MetricFamily) {
+	header := internal.Colorize(internal.ColorGreen, family.Name)
+	if family.Type != "" {
+		header += fmt.Sprintf(" (%s)", family.Type)
+	}
+	fmt.Println(header)
+	if family.Help != "" {
+		fmt.Printf("  %s\n", family.Help)
+	}
+
+	width := 0
+	for _, sample := range family.Samples {
+		if n := len(sample.String()); n > width {
+			width = n
+		}
+	}
+	for _, sample := range family.Samples {
+		fmt.Printf("    %-*s  %s\n", width, sample.String(), sample.Value)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "VPC network commands",
+	Long:  "Commands for inspecting VPC networking in the current GCP project.",
+}
+
+var networkFirewallCmd = &cobra.Command{
+	Use:   "firewall",
+	Short: "Firewall rule commands",
+	Long:  "Commands for inspecting VPC firewall rules.",
+}
+
+var networkFirewallListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List firewall rules affecting the project's VMs and cluster nodes",
+	Long: `List VPC firewall rules that apply to the VMs and GKE nodes of the current project, with
+a readable allow/deny summary. Use --target tag:<tag> or sa:<email> to check a specific target,
+and --port to answer "is this port even open?".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		target, _ := cmd.Flags().GetString("target")
+		port, _ := cmd.Flags().GetInt("port")
+		all, _ := cmd.Flags().GetBool("all")
+		if err := listFirewallRules(target, port, all); err != nil {
+			fmt.Printf("Error listing firewall rules: %v\n", err)
+		}
+	},
+}
+
+var networkEgressIPsCmd = &cobra.Command{
+	Use:   "egress-ips",
+	Short: "Show outbound IP addresses",
+	Long:  "Report the Cloud NAT addresses and VM external IPs that the project's clusters and VMs use for outbound traffic, for third-party allowlisting.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showEgressIPs(); err != nil {
+			fmt.Printf("Error discovering egress IPs: %v\n", err)
+		}
+	},
+}
+
+var networkInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show VPC, subnet and IP range utilization",
+	Long:  "Show each cluster's VPC, subnet, pod/service secondary ranges with utilization, and Private Google Access status, so IP-range exhaustion is visible before pods fail to schedule.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showNetworkInfo(); err != nil {
+			fmt.Printf("Error getting network info: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	networkFirewallListCmd.Flags().String("target", "", "Only rules applying to a target: tag:<network-tag> or sa:<service-account>")
+	networkFirewallListCmd.Flags().Int("port", 0, "Only rules covering this port")
+	networkFirewallListCmd.Flags().Bool("all", false, "Include rules that target no instance in the project")
+	networkFirewallCmd.AddCommand(networkFirewallListCmd)
+	networkCmd.AddCommand(networkFirewallCmd)
+	networkCmd.AddCommand(networkEgressIPsCmd)
+	networkCmd.AddCommand(networkInfoCmd)
+	rootCmd.AddCommand(networkCmd)
+}
+
+func listFirewallRules(target string, port int, all bool) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Fetching firewall rules for project: %s\n", currentProject)
+	rules, err := internal.GetFirewallRules(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	var tags, serviceAccounts []string
+	switch {
+	case target != "":
+		kind, value, ok := strings.Cut(target, ":")
+		if !ok {
+			// A bare value may be either a tag or a service account
+			tags = []string{target}
+			serviceAccounts = []string{target}
+		} else if kind == "tag" {
+			tags = []string{value}
+		} else if kind == "sa" {
+			serviceAccounts = []string{value}
+		} else {
+			return fmt.Errorf("invalid --target %q (use tag:<tag> or sa:<email>)", target)
+		}
+	case !all:
+		vms, err := internal.GetVMs(currentProject)
+		if err != nil {
+			return fmt.Errorf("failed to list VMs: %w", err)
+		}
+		for _, vm := range vms {
+			tags = append(tags, vm.Tags...)
+			serviceAccounts = append(serviceAccounts, vm.ServiceAccounts...)
+		}
+		fmt.Printf("🔍 Matching rules against %d VM(s) and GKE node(s)\n", len(vms))
+	}
+	fmt.Println()
+
+	var matched []internal.FirewallRule
+	for _, r := range rules {
+		if !all && !r.Targets(tags, serviceAccounts) {
+			continue
+		}
+		if port > 0 && !r.MatchesPort(port) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	if len(matched) == 0 {
+		fmt.Println("No matching firewall rules found.")
+		if port > 0 {
+			fmt.Printf("⚠️  Nothing explicitly allows port %d; the implied deny-ingress rule applies\n", port)
+		}
+		return nil
+	}
+
+	fmt.Printf("%-30s %-12s %-8s %-5s %s\n", "NAME", "NETWORK", "DIR", "PRI", "SUMMARY")
+	fmt.Println(strings.Repeat("-", 110))
+
+	for _, r := range matched {
+		line := fmt.Sprintf("%-30s %-12s %-8s %-5d %s",
+			truncate(r.Name, 30),
+			truncate(r.Network, 12),
+			r.Direction,
+			r.Priority,
+			r.Summary())
+		switch {
+		case r.Disabled:
+			fmt.Println(internal.Colorize(internal.ColorGray, line+" (disabled)"))
+		case r.Action() == "DENY":
+			fmt.Println(internal.Colorize(internal.ColorRed, line))
+		default:
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("📋 %d rule(s); lower priority numbers win when rules conflict\n", len(matched))
+	return nil
+}
+
+func showEgressIPs() error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Discovering egress IPs in project: %s\n", currentProject)
+	fmt.Println()
+
+	gateways, err := internal.GetNATGateways(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list Cloud NAT gateways: %w", err)
+	}
+	addresses, err := internal.GetStaticAddresses(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list static addresses: %w", err)
+	}
+	vms, err := internal.GetVMs(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list VMs: %w", err)
+	}
+	clusters, err := internal.GetGKEClusters(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	static := make(map[string]string)
+	for _, a := range addresses {
+		static[a.Address] = a.Name
+	}
+	describeIP := func(ip string) string {
+		if name, ok := static[ip]; ok {
+			return fmt.Sprintf("%s (static: %s)", ip, name)
+		}
+		return ip + " (ephemeral - may change)"
+	}
+
+	fmt.Println("🌐 Cloud NAT gateways:")
+	if len(gateways) == 0 {
+		fmt.Println("   None")
+	}
+	for _, gw := range gateways {
+		allocation := "auto-allocated"
+		if gw.Manual {
+			allocation = "manual"
+		}
+		fmt.Printf("   %s/%s (%s, network %s, %s)\n", gw.Router, gw.Name, gw.Region, gw.Network, allocation)
+		for _, ip := range gw.IPs {
+			fmt.Printf("     - %s\n", describeIP(ip))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("☸️  Clusters:")
+	if len(clusters) == 0 {
+		fmt.Println("   None")
+	}
+	for _, c := range clusters {
+		network, err := internal.GetClusterNetwork(currentProject, c)
+		if err != nil {
+			fmt.Printf("   %s: ⚠️  %v\n", c.Name, err)
+			continue
+		}
+		region := internal.LocationRegion(c.Location)
+
+		if network.PrivateNodes {
+			var natIPs []string
+			for _, gw := range gateways {
+				if gw.Region == region && gw.Network == network.Network {
+					natIPs = append(natIPs, gw.IPs...)
+				}
+			}
+			if len(natIPs) == 0 {
+				fmt.Printf("   %s: private nodes with no Cloud NAT in %s - outbound internet traffic is blocked\n", c.Name, region)
+				continue
+			}
+			fmt.Printf("   %s: private nodes → Cloud NAT in %s: %s\n", c.Name, region, strings.Join(natIPs, ", "))
+			continue
+		}
+
+		var nodeIPs []string
+		for _, vm := range vms {
+			if strings.HasPrefix(vm.Name, "gke-"+truncateClusterPrefix(c.Name)) && vm.ExternalIP != "" {
+				nodeIPs = append(nodeIPs, vm.ExternalIP)
+			}
+		}
+		fmt.Printf("   %s: public nodes egress from each node's external IP (%d node IP(s), change as nodes are replaced)\n", c.Name, len(nodeIPs))
+		for _, ip := range nodeIPs {
+			fmt.Printf("     - %s\n", describeIP(ip))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("🖥️  VMs with external IPs:")
+	found := false
+	for _, vm := range vms {
+		if vm.ExternalIP == "" || strings.HasPrefix(vm.Name, "gke-") {
+			continue
+		}
+		found = true
+		fmt.Printf("   %-30s %s\n", vm.Name, describeIP(vm.ExternalIP))
+	}
+	if !found {
+		fmt.Println("   None (VMs without external IPs egress through Cloud NAT in their region)")
+	}
+
+	return nil
+}
+
+// truncateClusterPrefix mirrors how GKE shortens cluster names in node VM names
+func truncateClusterPrefix(name string) string {
+	if len(name) > 20 {
+		return name[:20]
+	}
+	return name
+}
+
+func showNetworkInfo() error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	clusters, err := internal.GetGKEClusters(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	if len(clusters) == 0 {
+		fmt.Println("No GKE clusters found.")
+		return nil
+	}
+
+	for _, c := range clusters {
+		fmt.Println()
+		fmt.Printf("☸️  Cluster %s (%s)\n", c.Name, c.Location)
+
+		network, err := internal.GetClusterNetwork(currentProject, c)
+		if err != nil {
+			fmt.Printf("   ⚠️  %v\n", err)
+			continue
+		}
+
+		subnet, err := internal.GetSubnet(currentProject, internal.LocationRegion(c.Location), network.Subnetwork)
+		if err != nil {
+			fmt.Printf("   ⚠️  failed to describe subnet %s: %v\n", network.Subnetwork, err)
+			continue
+		}
+
+		var usage *internal.ClusterIPUsage
+		if contextName, err := internal.EnsureClusterContext(currentProject, c); err == nil {
+			usage, err = internal.GetClusterIPUsage(contextName)
+			if err != nil {
+				fmt.Printf("   ⚠️  failed to read cluster IP usage: %v\n", err)
+			}
+		}
+
+		pga := "✅ enabled"
+		if !subnet.PrivateIPGoogleAccess {
+			pga = "❌ disabled"
+		}
+		nodes := "public"
+		if network.PrivateNodes {
+			nodes = "private"
+		}
+
+		fmt.Printf("   VPC:                   %s\n", network.Network)
+		fmt.Printf("   Subnet:                %s\n", subnet.Name)
+		fmt.Printf("   Nodes:                 %s\n", nodes)
+		fmt.Printf("   Private Google Access: %s\n", pga)
+		fmt.Println()
+
+		podCIDR := subnet.SecondaryRange(network.PodRangeName)
+		if podCIDR == "" {
+			podCIDR = network.PodCIDR
+		}
+		serviceCIDR := subnet.SecondaryRange(network.ServiceRangeName)
+		if serviceCIDR == "" {
+			serviceCIDR = network.ServiceCIDR
+		}
+
+		fmt.Printf("   %-10s %-20s %-20s %s\n", "RANGE", "NAME", "CIDR", "UTILIZATION")
+		nodeUsed, podUsed, svcUsed := -1, -1, -1
+		if usage != nil {
+			nodeUsed, podUsed, svcUsed = usage.Nodes, usage.PodAddressesUsed, usage.ServiceClusterIPs
+		}
+		printRangeUsage("nodes", subnet.Name, subnet.IPCIDRRange, nodeUsed)
+		printRangeUsage("pods", network.PodRangeName, podCIDR, podUsed)
+		printRangeUsage("services", network.ServiceRangeName, serviceCIDR, svcUsed)
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Pod range usage counts the CIDR block reserved for every node, which is what runs out first")
+	return nil
+}
+
+func printRangeUsage(label, name, cidr string, used int) {
+	size := internal.CIDRSize(cidr)
+	utilization := "unknown"
+	if used >= 0 && size > 0 {
+		pct := float64(used) * 100 / float64(size)
+		utilization = fmt.Sprintf("%d / %d (%.1f%%)", used, size, pct)
+		switch {
+		case pct >= 90:
+			utilization = internal.Colorize(internal.ColorRed, utilization+" ⚠️  nearly exhausted")
+		case pct >= 75:
+			utilization = internal.Colorize(internal.ColorYellow, utilization)
+		}
+	}
+	fmt.Printf("   %-10s %-20s %-20s %s\n", label, truncate(orDash(name), 20), orDash(cidr), utilization)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var nodesCmd = &cobra.Command{
+	Use:     "nodes",
+	Aliases: []string{"node"},
+	Short:   "Kubernetes node commands",
+	Long:    "Commands for inspecting the nodes of the current cluster.",
+}
+
+var nodesPreemptionsCmd = &cobra.Command{
+	Use:   "preemptions",
+	Short: "Show recent Spot/preemptible node preemptions",
+	Long:  "Report recent Compute Engine preemptions of Spot and preemptible nodes and the application pods and workloads they disrupted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		if err := showPreemptions(since); err != nil {
+			fmt.Printf("Error showing preemptions: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	nodesPreemptionsCmd.Flags().String("since", "24h", "How far back to look for preemptions (e.g. 6h, 7d)")
+	nodesCmd.AddCommand(nodesPreemptionsCmd)
+	rootCmd.AddCommand(nodesCmd)
+}
+
+// preemptionMatchWindow is how far apart a preemption and a pod's termination can be and
+// still be attributed to each other
+const preemptionMatchWindow = 10 * time.Minute
+
+func showPreemptions(sinceFlag string) error {
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-since)
+
+	if !setupCluster() {
+		return nil
+	}
+
+	capacity, err := internal.GetNodeCapacityTypes()
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+	interruptible := 0
+	for _, c := range capacity {
+		if c != internal.CapacityStandard {
+			interruptible++
+		}
+	}
+	fmt.Printf("🖥️  %d of %d nodes are Spot or preemptible\n", interruptible, len(capacity))
+
+	fmt.Printf("🔍 Looking for preemptions in the last %s...\n", sinceFlag)
+	preemptions, err := internal.GetPreemptions(getCurrentProject(), cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to list preemptions: %w", err)
+	}
+	// Only the VMs backing this cluster's nodes are relevant
+	var clusterPreemptions []internal.Preemption
+	for _, p := range preemptions {
+		if _, ok := capacity[p.Node]; ok {
+			clusterPreemptions = append(clusterPreemptions, p)
+		}
+	}
+
+	if len(clusterPreemptions) == 0 {
+		fmt.Println("✅ No preemptions found")
+		return nil
+	}
+
+	shutdownPods, err := internal.FindShutdownPods(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	// Pods shut down on a preempted node around the time of its preemption; pods shut
+	// down for other reasons, e.g. node upgrades, aren't counted
+	var disruptedPods []internal.ShutdownPod
+	counted := make(map[string]bool)
+	fmt.Println()
+	fmt.Printf("📋 %d preemption(s):\n", len(clusterPreemptions))
+	fmt.Printf("%-18s %-45s %-16s %s\n", "TIME", "NODE", "ZONE", "DISRUPTED PODS")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, p := range clusterPreemptions {
+		var disrupted []string
+		for _, pod := range shutdownPods {
+			if pod.Node == p.Node && absDuration(pod.Time.Sub(p.Time)) <= preemptionMatchWindow {
+				disrupted = append(disrupted, pod.Pod)
+				if !counted[pod.Pod] {
+					counted[pod.Pod] = true
+					disruptedPods = append(disruptedPods, pod)
+				}
+			}
+		}
+		fmt.Printf("%-18s %-45s %-16s %d\n", p.Time.Local().Format("2006-01-02 15:04"), truncate(p.Node, 45), p.Zone, len(disrupted))
+		for _, pod := range disrupted {
+			fmt.Printf("   %s\n", pod)
+		}
+	}
+
+	if len(disruptedPods) > 0 {
+		counts := make(map[string]int)
+		for _, pod := range disruptedPods {
+			namespace, _, _ := strings.Cut(pod.Pod, "/")
+			workload := pod.Workload
+			if workload == "" {
+				workload = "(unmanaged pod)"
+			}
+			counts[namespace+"/"+workload]++
+		}
+		workloads := make([]string, 0, len(counts))
+		for w := range counts {
+			workloads = append(workloads, w)
+		}
+		sort.Slice(workloads, func(i, j int) bool {
+			if counts[workloads[i]] != counts[workloads[j]] {
+				return counts[workloads[i]] > counts[workloads[j]]
+			}
+			return workloads[i] < workloads[j]
+		})
+
+		fmt.Println()
+		fmt.Println("📋 Disrupted workloads:")
+		fmt.Printf("%-60s %s\n", "WORKLOAD", "PODS TERMINATED")
+		fmt.Println(strings.Repeat("-", 80))
+		for _, w := range workloads {
+			fmt.Printf("%-60s %d\n", truncate(w, 60), counts[w])
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Spread replicas across standard nodes or add a PodDisruptionBudget for workloads that can't tolerate preemption")
+	return nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var nodesShellCmd = &cobra.Command{
+	Use:   "shell [node]",
+	Short: "Open a privileged debug shell on a node",
+	Long: `Open a shell in a privileged debugging pod on a node with 'kubectl debug node/...', to
+investigate disk pressure, kubelet problems and the like. The node's root filesystem is
+mounted at /host; with --chroot the shell runs in it, with the node's own tools (journalctl,
+crictl). The node is chosen from the cluster's nodes when not given, showing their problems.
+
+The debugging pod is deleted when the shell exits. Protected environments require typing
+the project ID, and their session_limits end the shell like any other session.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		node := ""
+		if len(args) == 1 {
+			node = args[0]
+		}
+		image, _ := cmd.Flags().GetString("image")
+		chroot, _ := cmd.Flags().GetBool("chroot")
+		namespace, _ := cmd.Flags().GetString("pod-namespace")
+		if err := nodeShell(node, namespace, image, chroot); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error opening node shell: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	nodesShellCmd.Flags().String("image", internal.NodeShellImage, "Image of the debugging pod")
+	nodesShellCmd.Flags().Bool("chroot", false, "Run the shell in the node's root filesystem")
+	nodesShellCmd.Flags().String("pod-namespace", "default", "Namespace to create the debugging pod in")
+	nodesCmd.AddCommand(nodesShellCmd)
+}
+
+func nodeShell(name, namespace, image string, chroot bool) error {
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+	var opts sessionOptions
+	if err := opts.applyEnvironment(currentProject); err != nil {
+		return err
+	}
+
+	nodes, err := internal.GetNodes()
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+	var node *internal.Node
+	if name == "" {
+		if node, err = internal.SelectNode(nodes); err != nil {
+			return err
+		}
+	} else {
+		for i := range nodes {
+			if nodes[i].Metadata.Name == name {
+				node = &nodes[i]
+			}
+		}
+		if node == nil {
+			return fmt.Errorf("node %s not found", name)
+		}
+	}
+	name = node.Metadata.Name
+
+	if !confirmProtected(currentProject, "open a root shell on node "+name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remember existing debugging pods to only delete the one created here
+	existing, err := internal.NodeDebuggerPods(namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get pods: %w", err)
+	}
+	known := make(map[string]bool)
+	for _, p := range existing {
+		known[p] = true
+	}
+
+	if problems := node.Problems(); len(problems) > 0 {
+		fmt.Printf("⚠️  %s: %s\n", name, strings.Join(problems, ", "))
+	}
+	fmt.Printf("🚀 Opening a shell on node %s (%s, kubelet %s)...\n", name, node.CapacityType(), orDash(node.Status.NodeInfo.KubeletVersion))
+	if chroot {
+		fmt.Println("(Running in the node's root filesystem; type 'exit' to disconnect)")
+	} else {
+		fmt.Println("(The node's root filesystem is at /host; type 'exit' to disconnect)")
+	}
+	if opts.limits.enabled() {
+		fmt.Printf("⏱️  Protected environment: %s\n", opts.limits)
+	}
+	fmt.Println()
+	shellErr := runNodeShell(internal.NodeShellCommand(namespace, name, image, chroot), opts.limits)
+	var exitErr *exec.ExitError
+	if errors.As(shellErr, &exitErr) {
+		// The shell's exit status, or kubectl's after printing its own error
+		shellErr = nil
+	}
+
+	created, err := internal.NodeDebuggerPods(namespace, name)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not find the debugging pod to delete it: %v\n", err)
+		return shellErr
+	}
+	for _, p := range created {
+		if known[p] {
+			continue
+		}
+		if err := internal.DeleteNodeDebuggerPod(namespace, p); err != nil {
+			fmt.Printf("⚠️  Warning: failed to delete debugging pod %s/%s: %v\n", namespace, p, err)
+		} else {
+			fmt.Printf("🧹 Deleted debugging pod %s/%s\n", namespace, p)
+		}
+	}
+	return shellErr
+}
+
+// runNodeShell runs the kubectl debug session, ending it when it exceeds the limits
+func runNodeShell(cmd *exec.Cmd, limits sessionLimits) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if !limits.enabled() {
+		return cmd.Wait()
+	}
+	var deadline time.Time
+	if limits.MaxDuration > 0 {
+		deadline = time.Now().Add(limits.MaxDuration)
+	}
+	watch := watchSession(cmd, limits, deadline)
+	err := cmd.Wait()
+	if reason := watch.stop(); reason != "" {
+		fmt.Println()
+		fmt.Printf("⏱️  Session ended: %s\n", reason)
+		return nil
+	}
+	return err
+}
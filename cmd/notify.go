@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Operation notification commands",
+	Long: `Commands for the Slack/webhook notifications gcpeasy posts around mutating operations.
+
+Notifications are configured in the gcpeasy config file:
+
+  notifications:
+    slack_webhook: https://hooks.slack.com/services/...
+    webhook_url: https://example.com/gcpeasy-events
+    headers:
+      Authorization: Bearer <token>`,
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a test notification",
+	Long:  "Post a test message to the configured Slack webhook and/or HTTP endpoint.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runNotifyTest(); err != nil {
+			fmt.Printf("Error sending notification: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyTestCmd)
+	rootCmd.AddCommand(notifyCmd)
+}
+
+func runNotifyTest() error {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Notifications.Enabled() {
+		path, _ := internal.ConfigPath()
+		fmt.Println("❌ No notification endpoints configured")
+		fmt.Printf("Add a 'notifications' section to %s\n", path)
+		return nil
+	}
+
+	fmt.Println("📣 Sending test notification...")
+	err = internal.Notify(cfg.Notifications, internal.Notification{
+		Operation: "notify test",
+		Status:    internal.NotifySucceeded,
+		Project:   getCurrentProject(),
+		User:      getActiveAccount(),
+		Detail:    "Test notification from gcpeasy",
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Notification sent")
+	return nil
+}
+
+// runNotified wraps a mutating operation with start/success/failure notifications.
+// Notification failures are reported as warnings and never fail the operation itself.
+func runNotified(operation, detail string, op func() error) error {
+	fn := func() error {
+		return internal.EmitStep(operation, op)
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil || !cfg.Notifications.Enabled() {
+		return fn()
+	}
+
+	n := internal.Notification{
+		Operation: operation,
+		Project:   getCurrentProject(),
+		User:      getActiveAccount(),
+		Detail:    detail,
+	}
+
+	send := func(status string, opErr error) {
+		n.Status = status
+		if opErr != nil {
+			n.Error = opErr.Error()
+		}
+		if err := internal.Notify(cfg.Notifications, n); err != nil {
+			fmt.Printf("⚠️  Warning: failed to send notification: %v\n", err)
+		}
+	}
+
+	send(internal.NotifyStarted, nil)
+	if err := fn(); err != nil {
+		send(internal.NotifyFailed, err)
+		return err
+	}
+	send(internal.NotifySucceeded, nil)
+	return nil
+}
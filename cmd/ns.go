@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var nsCmd = &cobra.Command{
+	Use:     "ns",
+	Aliases: []string{"namespace"},
+	Short:   "Namespace commands",
+	Long:    "Commands for inspecting Kubernetes namespaces in the current cluster.",
+}
+
+var nsQuotasCmd = &cobra.Command{
+	Use:   "quotas",
+	Short: "Show resource quotas and limit ranges",
+	Long:  "Show ResourceQuotas (with current consumption) and LimitRanges for the active namespace, to diagnose pods that won't schedule because the namespace quota is exhausted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if err := showNamespaceQuotas(namespace); err != nil {
+			fmt.Printf("Error showing quotas: %v\n", err)
+		}
+	},
+}
+
+var nsSelectCmd = &cobra.Command{
+	Use:   "select [namespace]",
+	Short: "Scope pod commands to a namespace",
+	Long:  "Choose the namespace that pod list, logs, shell and rails console work in for the current project, instead of all non-system namespaces. The choice is saved in namespaces.json in the config directory; -n/--namespace overrides it for a single command. Without an argument, shows an interactive selection. Use --all to go back to all namespaces.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		namespace := ""
+		if len(args) == 1 {
+			namespace = args[0]
+		}
+		if err := selectNamespace(namespace, all); err != nil {
+			if err.Error() == "cancelled by user" {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error selecting namespace: %v\n", err)
+		}
+	},
+}
+
+// namespaceFlag is the -n/--namespace value of the pod commands, overriding the namespace
+// chosen with 'ns select'
+var namespaceFlag string
+
+// selectorFlag is the --selector value of the pod commands, a kubectl label selector
+var selectorFlag string
+
+// addSelectorFlag registers --selector on commands that discover application pods
+func addSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&selectorFlag, "selector", "", "Only use pods matching a label selector (e.g. app=web), as with kubectl -l")
+}
+
+// addNamespaceFlag registers -n/--namespace on commands that discover application pods,
+// and their subcommands
+func addNamespaceFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&namespaceFlag, "namespace", "n", "", "Only use pods in this namespace (default: the namespace chosen with 'ns select', or all)")
+}
+
+func init() {
+	nsQuotasCmd.Flags().StringP("namespace", "n", "", "Namespace to inspect (default: current context namespace)")
+	nsSelectCmd.Flags().Bool("all", false, "Clear the selection and use all application namespaces")
+	nsCmd.AddCommand(nsQuotasCmd)
+	nsCmd.AddCommand(nsSelectCmd)
+	rootCmd.AddCommand(nsCmd)
+}
+
+func showNamespaceQuotas(namespace string) error {
+	if !setupCluster() {
+		return nil
+	}
+
+	if namespace == "" {
+		namespace = internal.GetContextNamespace()
+	}
+
+	fmt.Printf("🔍 Inspecting quotas in namespace: %s\n", namespace)
+	fmt.Println()
+
+	quotas, err := internal.GetResourceQuotas(namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get resource quotas: %w", err)
+	}
+	limitRanges, err := internal.GetLimitRanges(namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get limit ranges: %w", err)
+	}
+
+	if len(quotas) == 0 {
+		fmt.Println("No ResourceQuotas in this namespace.")
+	}
+
+	for _, q := range quotas {
+		fmt.Printf("📊 ResourceQuota %s\n", q.Metadata.Name)
+		fmt.Printf("   %-32s %-12s %-12s %s\n", "RESOURCE", "USED", "HARD", "USAGE")
+
+		resources := make([]string, 0, len(q.Status.Hard))
+		for r := range q.Status.Hard {
+			resources = append(resources, r)
+		}
+		sort.Strings(resources)
+
+		for _, r := range resources {
+			used := q.Status.Used[r]
+			if used == "" {
+				used = "0"
+			}
+			hard := q.Status.Hard[r]
+			fmt.Printf("   %-32s %-12s %-12s %s\n", truncate(r, 32), used, hard, usageBar(internal.QuotaUsage(used, hard)))
+		}
+		fmt.Println()
+	}
+
+	if len(limitRanges) == 0 {
+		fmt.Println("No LimitRanges in this namespace.")
+		return nil
+	}
+
+	for _, lr := range limitRanges {
+		fmt.Printf("📏 LimitRange %s\n", lr.Metadata.Name)
+		for _, l := range lr.Spec.Limits {
+			fmt.Printf("   %s:\n", l.Type)
+			printLimitMap("default limit", l.Default)
+			printLimitMap("default request", l.DefaultRequest)
+			printLimitMap("min", l.Min)
+			printLimitMap("max", l.Max)
+			printLimitMap("max limit/request", l.MaxLimitRequestRatio)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// usageBar renders a percentage as a small colored bar
+func usageBar(pct float64) string {
+	if pct < 0 {
+		return "-"
+	}
+	filled := int(pct / 10)
+	if filled > 10 {
+		filled = 10
+	}
+	bar := fmt.Sprintf("[%s%s] %5.1f%%", strings.Repeat("█", filled), strings.Repeat("░", 10-filled), pct)
+	switch {
+	case pct >= 100:
+		return internal.Colorize(internal.ColorRed, bar+" exhausted")
+	case pct >= 80:
+		return internal.Colorize(internal.ColorYellow, bar)
+	default:
+		return bar
+	}
+}
+
+func printLimitMap(label string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, values[k]))
+	}
+	fmt.Printf("     %-18s %s\n", label+":", strings.Join(parts, ", "))
+}
+
+// applyPodScope limits pod discovery to the -n/--namespace flag or the namespace selected
+// for the project, and to the --selector labels
+func applyPodScope(projectID string) {
+	namespace := namespaceFlag
+	if namespace == "" {
+		selected, err := internal.LoadSelectedNamespace(projectID)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: could not read the selected namespace: %v\n", err)
+		}
+		namespace = selected
+	}
+	internal.SetNamespaceScope(namespace)
+	if namespace != "" {
+		fmt.Printf("📁 Namespace: %s\n", namespace)
+	}
+
+	internal.SetPodSelector(selectorFlag)
+	if selectorFlag != "" {
+		fmt.Printf("🏷️  Selector: %s\n", selectorFlag)
+	}
+}
+
+func selectNamespace(namespace string, all bool) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if all {
+		if err := internal.SaveSelectedNamespace(currentProject, ""); err != nil {
+			return fmt.Errorf("failed to save selection: %w", err)
+		}
+		fmt.Println("✅ Pod commands will use all application namespaces")
+		return nil
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
+
+	namespaces, err := internal.GetApplicationNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	if namespace == "" {
+		current, _ := internal.LoadSelectedNamespace(currentProject)
+		namespace, err = internal.SelectNamespace(namespaces, current)
+		if err != nil {
+			return err
+		}
+	} else if !slices.Contains(namespaces, namespace) {
+		fmt.Printf("❌ Namespace '%s' not found among the application namespaces.\n", namespace)
+		if len(namespaces) > 0 {
+			fmt.Printf("💡 Available: %s\n", strings.Join(namespaces, ", "))
+		}
+		return nil
+	}
+
+	if err := internal.SaveSelectedNamespace(currentProject, namespace); err != nil {
+		return fmt.Errorf("failed to save selection: %w", err)
+	}
+	if namespace == "" {
+		fmt.Println("✅ Pod commands will use all application namespaces")
+	} else {
+		fmt.Printf("✅ Pod commands will use namespace: %s\n", namespace)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var pdbCmd = &cobra.Command{
+	Use:   "pdb",
+	Short: "PodDisruptionBudget commands",
+	Long:  "Commands for inspecting PodDisruptionBudgets in application namespaces.",
+}
+
+var pdbListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List PodDisruptionBudgets",
+	Long:  "List PodDisruptionBudgets in application namespaces with their budget, healthy pod counts and currently allowed disruptions.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listPDBs(); err != nil {
+			fmt.Printf("Error listing PodDisruptionBudgets: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	pdbCmd.AddCommand(pdbListCmd)
+	rootCmd.AddCommand(pdbCmd)
+}
+
+func listPDBs() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Gathering PodDisruptionBudgets...")
+	fmt.Println()
+
+	pdbs, err := internal.GetPodDisruptionBudgets()
+	if err != nil {
+		return fmt.Errorf("failed to get PodDisruptionBudgets: %w", err)
+	}
+
+	if len(pdbs) == 0 {
+		fmt.Println("No PodDisruptionBudgets found in application namespaces.")
+		return nil
+	}
+
+	fmt.Printf("%-15s %-35s %-22s %-9s %-9s %-8s\n",
+		"NAMESPACE", "NAME", "BUDGET", "HEALTHY", "EXPECTED", "ALLOWED")
+	fmt.Println(strings.Repeat("-", 104))
+
+	for _, p := range pdbs {
+		line := fmt.Sprintf("%-15s %-35s %-22s %-9s %-9d %-8d",
+			truncate(p.Metadata.Namespace, 15),
+			truncate(p.Metadata.Name, 35),
+			p.Budget(),
+			fmt.Sprintf("%d/%d", p.Status.CurrentHealthy, p.Status.DesiredHealthy),
+			p.Status.ExpectedPods,
+			p.Status.DisruptionsAllowed)
+		if p.Status.DisruptionsAllowed == 0 {
+			fmt.Println(internal.Colorize(internal.ColorYellow, line+"  ⚠️  blocks voluntary disruptions"))
+			continue
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// warnDisruptionBudgets prints a warning when disrupting the given number of pods with these
+// labels would exceed a PodDisruptionBudget. It returns true if any budget would be violated.
+func warnDisruptionBudgets(namespace string, podLabels map[string]string, disruptions int) bool {
+	pdbs, err := internal.MatchingPDBs(namespace, podLabels)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not check PodDisruptionBudgets: %v\n", err)
+		return false
+	}
+	counts := make(map[string]int)
+	for _, p := range pdbs {
+		counts[p.Metadata.Namespace+"/"+p.Metadata.Name] = disruptions
+	}
+	return reportDisruptionBudgets(pdbs, counts)
+}
+
+// warnPodDisruptionBudgets is warnDisruptionBudgets for deleting a set of pods, which may
+// have different labels: each budget is checked against the pods it covers
+func warnPodDisruptionBudgets(pods []internal.Pod) bool {
+	var pdbs []internal.PodDisruptionBudget
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		matched, err := internal.MatchingPDBs(pod.Metadata.Namespace, pod.Metadata.Labels)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: could not check PodDisruptionBudgets: %v\n", err)
+			return false
+		}
+		for _, p := range matched {
+			key := p.Metadata.Namespace + "/" + p.Metadata.Name
+			if counts[key] == 0 {
+				pdbs = append(pdbs, p)
+			}
+			counts[key]++
+		}
+	}
+	return reportDisruptionBudgets(pdbs, counts)
+}
+
+// reportDisruptionBudgets warns about each budget whose disruptions, keyed by
+// namespace/name, exceed what it allows
+func reportDisruptionBudgets(pdbs []internal.PodDisruptionBudget, disruptions map[string]int) bool {
+	violated := false
+	for _, p := range pdbs {
+		n := disruptions[p.Metadata.Namespace+"/"+p.Metadata.Name]
+		if n <= p.Status.DisruptionsAllowed {
+			continue
+		}
+		violated = true
+		fmt.Printf("⚠️  PodDisruptionBudget %s/%s (%s) allows %d disruption(s); this operation disrupts %d\n",
+			p.Metadata.Namespace, p.Metadata.Name, p.Budget(), p.Status.DisruptionsAllowed, n)
+	}
+	if violated {
+		fmt.Println("   Evictions (e.g. node drains) will be blocked and availability may drop below the budget.")
+	}
+	return violated
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var pinNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+var podPinCmd = &cobra.Command{
+	Use:   "pin [name-pattern]",
+	Short: "Pin a favorite pod target",
+	Long: `Pin a pod name pattern as a favorite for the current environment. Pinned pods are
+listed first in pod pickers and can be referenced as @name wherever a pod is named,
+as the pod argument or with --pod, e.g. 'gcpeasy pod logs @web -f' or
+'gcpeasy pod shell --pod @web'. Patterns match pod names as substrings, or as globs
+when they contain * ? or [. Without arguments, lists the current pins.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		if len(args) == 0 {
+			err = listPins()
+		} else {
+			name, _ := cmd.Flags().GetString("as")
+			err = addPin(args[0], name)
+		}
+		if err != nil {
+			fmt.Printf("Error managing pins: %v\n", err)
+		}
+	},
+}
+
+var podUnpinCmd = &cobra.Command{
+	Use:   "unpin <name>",
+	Short: "Remove a pinned pod target",
+	Long:  "Remove a pin from the current environment.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removePin(strings.TrimPrefix(args[0], "@")); err != nil {
+			fmt.Printf("Error removing pin: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podPinCmd.Flags().String("as", "", "Name to reference the pin by (default: derived from the pattern)")
+	podCmd.AddCommand(podPinCmd)
+	podCmd.AddCommand(podUnpinCmd)
+}
+
+// pinReference returns the pin named as @name by the pod argument or --pod, if any
+func pinReference() (string, bool) {
+	for _, target := range []string{podArgument, podPattern} {
+		if name, ok := strings.CutPrefix(target, "@"); ok && pinNamePattern.MatchString(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func pinProject() (string, error) {
+	currentProject := getCurrentProject()
+	if currentProject == "" {
+		return "", fmt.Errorf("no GCP project selected, run 'gcpeasy env select' first")
+	}
+	return currentProject, nil
+}
+
+func listPins() error {
+	currentProject, err := pinProject()
+	if err != nil {
+		return err
+	}
+
+	pins, err := internal.LoadPins(currentProject)
+	if err != nil {
+		return err
+	}
+	if len(pins) == 0 {
+		fmt.Printf("No pins for %s.\n", currentProject)
+		fmt.Println("💡 Use 'gcpeasy pod pin <name-pattern>' to add one")
+		return nil
+	}
+
+	fmt.Printf("⭐ Pins for %s:\n", currentProject)
+	for _, p := range pins {
+		fmt.Printf("   @%-20s %s\n", p.Name, p.Pattern)
+	}
+	return nil
+}
+
+func addPin(pattern, name string) error {
+	currentProject, err := pinProject()
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = strings.Trim(strings.NewReplacer("*", "", "?", "", "[", "", "]", "", "/", "-").Replace(pattern), "-.")
+	}
+	name = strings.TrimPrefix(name, "@")
+	if !pinNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid pin name %q, use --as with letters, digits, '.', '_' or '-'", name)
+	}
+
+	if err := internal.SavePin(currentProject, internal.Pin{Name: name, Pattern: pattern}); err != nil {
+		return fmt.Errorf("failed to save pin: %w", err)
+	}
+	fmt.Printf("✅ Pinned %s as @%s in %s\n", pattern, name, currentProject)
+	return nil
+}
+
+func removePin(name string) error {
+	currentProject, err := pinProject()
+	if err != nil {
+		return err
+	}
+
+	removed, err := internal.RemovePin(currentProject, name)
+	if err != nil {
+		return fmt.Errorf("failed to remove pin: %w", err)
+	}
+	if !removed {
+		fmt.Printf("❌ No pin named @%s in %s\n", name, currentProject)
+		return nil
+	}
+	fmt.Printf("✅ Removed @%s\n", name)
+	return nil
+}
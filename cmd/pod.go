@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"gcpeasy/internal"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
 	"os"
-	"os/exec"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -20,39 +25,33 @@ var podCmd = &cobra.Command{
 var podListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List application pods",
-	Long:  "List all application pods in the current cluster. Use --status for detailed status information.",
+	Long:  "List all application pods in the current cluster. Use --status for detailed status information, or --watch to keep refreshing the status table and highlight pods that changed since the last refresh, e.g. while a deploy rolls out.",
 	Run: func(cmd *cobra.Command, args []string) {
 		showStatus, _ := cmd.Flags().GetBool("status")
-		if err := listPods(showStatus); err != nil {
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		var err error
+		if watch {
+			err = watchPods(interval)
+		} else {
+			err = listPods(showStatus)
+		}
+		if err != nil {
 			fmt.Printf("Error listing pods: %v\n", err)
 		}
 	},
 }
 
 var podLogsCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [pod]",
 	Short: "View pod logs",
-	Long:  "View logs from application pods. Use -f to follow logs in real-time. Use -e/--error or -w/--warn to filter by log level.",
+	Long:  "View logs from application pods. Use -f to follow logs in real-time. Use --level to filter by log level: a single level is a minimum (--level warn shows warnings and errors), a comma-separated list selects exactly those levels (--level warn,error). -e/-w/-i/-d are aliases for --level error/warn/info/debug. Use --pod <name|regex> to choose the pod without prompting, adding --first when the pattern matches several pods.",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		follow, _ := cmd.Flags().GetBool("follow")
-		errorOnly, _ := cmd.Flags().GetBool("error")
-		warnOnly, _ := cmd.Flags().GetBool("warn")
-		infoOnly, _ := cmd.Flags().GetBool("info")
-		debugOnly, _ := cmd.Flags().GetBool("debug")
-		allPods, _ := cmd.Flags().GetBool("all")
-
-		var level string
-		if errorOnly {
-			level = "error"
-		} else if warnOnly {
-			level = "warn"
-		} else if infoOnly {
-			level = "info"
-		} else if debugOnly {
-			level = "debug"
+		if len(args) == 1 {
+			podArgument = args[0]
 		}
-
-		if err := runPodLogs(follow, level, allPods); err != nil {
+		if err := runPodLogs(logOptionsFromFlags(cmd)); err != nil {
 			fmt.Printf("Error viewing logs: %v\n", err)
 		}
 	},
@@ -61,9 +60,31 @@ var podLogsCmd = &cobra.Command{
 var podShellCmd = &cobra.Command{
 	Use:   "shell",
 	Short: "Open shell on selected pod",
-	Long:  "Connect to a shell on a selected application pod in the current GCP environment. Tries bash, zsh, sh in order of preference.",
+	Long: `Connect to a shell on a selected application pod in the current GCP environment.
+
+Before connecting, a single non-interactive exec checks which shells a container has, so
+sidecars without a shell (and distroless images) are skipped and the first application
+container with a shell is used unless --container is given. The shell is chosen from
+--shell, then the shell configured for the container image (image_shells) or environment
+(shell) in the config file, then bash, zsh, ash, sh in order of preference. When no container
+has a shell, an ephemeral debug container (--debug-image, default busybox) sharing the
+target container's processes is offered instead. Use --user to switch user (e.g. root, via su or sudo), --workdir to start in a
+directory and --env KEY=VALUE to set variables, in addition to the environment's
+session_env from the config file. Use --pod <name|regex> (and --first) to choose the pod
+without prompting, e.g. from scripts. Use --all --tmux to open a shell in every application pod, one tmux pane each;
+tmux panes can't be time-boxed, so --all isn't available in environments with session limits.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := runPodShell(); err != nil {
+		allPods, _ := cmd.Flags().GetBool("all")
+		useTmux, _ := cmd.Flags().GetBool("tmux")
+		syncPanes, _ := cmd.Flags().GetBool("sync")
+
+		var err error
+		if allPods {
+			err = runPodShellAll(shellOptionsFromFlags(cmd), useTmux, syncPanes)
+		} else {
+			err = runPodShell(shellOptionsFromFlags(cmd))
+		}
+		if err != nil {
 			fmt.Printf("Error accessing shell: %v\n", err)
 		}
 	},
@@ -71,12 +92,20 @@ var podShellCmd = &cobra.Command{
 
 func init() {
 	podListCmd.Flags().BoolP("status", "s", false, "Show detailed status information")
-	podLogsCmd.Flags().BoolP("follow", "f", false, "Follow logs in real-time")
-	podLogsCmd.Flags().BoolP("error", "e", false, "Show only error logs")
-	podLogsCmd.Flags().BoolP("warn", "w", false, "Show only warning logs")
-	podLogsCmd.Flags().BoolP("info", "i", false, "Show only info logs")
-	podLogsCmd.Flags().BoolP("debug", "d", false, "Show only debug logs")
+	podListCmd.Flags().BoolP("watch", "w", false, "Keep refreshing the status table until Ctrl+C")
+	podListCmd.Flags().Duration("interval", 5*time.Second, "How often --watch refreshes")
+	addLogFlags(podLogsCmd)
 	podLogsCmd.Flags().BoolP("all", "a", false, "View logs for all application pods")
+	podShellCmd.Flags().BoolP("all", "a", false, "Open a shell in every application pod (requires --tmux)")
+	podShellCmd.Flags().Bool("tmux", false, "Open shells in tmux panes")
+	podShellCmd.Flags().Bool("sync", false, "Synchronize input across tmux panes")
+	addShellFlags(podShellCmd)
+	addPodTargetFlags(podLogsCmd)
+	addPodTargetFlags(podShellCmd)
+	addSelectorFlag(podListCmd)
+	addSelectorFlag(podLogsCmd)
+	addSelectorFlag(podShellCmd)
+	addNamespaceFlag(podCmd)
 
 	podCmd.AddCommand(podListCmd)
 	podCmd.AddCommand(podLogsCmd)
@@ -85,29 +114,15 @@ func init() {
 }
 
 func listPods(showStatus bool) error {
-	// Check if user is authenticated
-	fmt.Println("🔍 Checking authentication...")
-	if !isAuthenticated() {
-		fmt.Println("❌ Not authenticated with Google Cloud")
-		fmt.Println("Please run 'gcpeasy login' first to authenticate.")
-		return nil
-	}
-	fmt.Println("✅ Authenticated")
-
-	// Get current project
-	fmt.Println("🔍 Getting current project...")
-	currentProject := getCurrentProject()
+	currentProject := requireProject()
 	if currentProject == "" {
-		fmt.Println("❌ No GCP project selected")
-		fmt.Println("Please run 'gcpeasy env select' to choose an environment.")
 		return nil
 	}
-	fmt.Printf("✅ Current project: %s\n", currentProject)
 
 	fmt.Printf("🔍 Looking for application pods in project: %s\n", currentProject)
 
 	// Setup cluster if kubectl is not configured
-	if err := internal.SetupClusterIfNeeded(currentProject); err != nil {
+	if err := ensureCluster(currentProject); err != nil {
 		if strings.Contains(err.Error(), "cancelled by user") {
 			fmt.Println("Cancelled.")
 			return nil
@@ -135,20 +150,12 @@ func listPods(showStatus bool) error {
 
 	if showStatus {
 		// Print detailed status table
-		fmt.Printf("%-15s %-35s %-12s %-8s %-8s %-10s %-20s\n",
-			"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "AGE", "NODE")
-		fmt.Println(strings.Repeat("-", 110))
-
-		for _, pod := range pods {
-			fmt.Printf("%-15s %-35s %-12s %-8s %-8s %-10s %-20s\n",
-				truncate(pod.Namespace, 15),
-				truncate(pod.Name, 35),
-				pod.Status,
-				pod.Ready,
-				pod.Restarts,
-				pod.Age,
-				truncate(pod.Node, 20))
+		capacity, err := internal.GetNodeCapacityTypes()
+		if err != nil {
+			fmt.Printf("⚠️  Warning: failed to get node capacity types: %v\n", err)
 		}
+
+		printPodStatusTable(pods, capacity, nil)
 	} else {
 		// Print simple list
 		fmt.Printf("%-15s %-35s\n", "NAMESPACE", "NAME")
@@ -167,31 +174,61 @@ func listPods(showStatus bool) error {
 	return nil
 }
 
-func runPodLogs(follow bool, level string, allPods bool) error {
-	// Check if user is authenticated
-	fmt.Println("🔍 Checking authentication...")
-	if !isAuthenticated() {
-		fmt.Println("❌ Not authenticated with Google Cloud")
-		fmt.Println("Please run 'gcpeasy login' first to authenticate.")
+func runPodLogs(opts logOptions) error {
+	currentProject := requireProject()
+	if currentProject == "" {
 		return nil
 	}
-	fmt.Println("✅ Authenticated")
+	opts.applyEnvironmentDefaults(currentProject)
+	if opts.Level != "" {
+		if _, err := internal.ParseLevelFilter(opts.Level); err != nil {
+			return err
+		}
+	}
+	if _, err := opts.grepPattern(); err != nil {
+		return err
+	}
+	// A previous container instance has stopped, so its logs can't be followed
+	if opts.Previous && opts.Follow {
+		fmt.Println("💡 --follow doesn't apply to --previous, showing the previous container's logs")
+		opts.Follow = false
+	}
+	if opts.Rate {
+		if opts.Follow {
+			opts.monitor = internal.NewLogRateMonitor()
+			status := newRateStatus(os.Stdout, opts.monitor)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go status.run(ctx)
+			opts.output = status
+		} else {
+			fmt.Println("💡 --rate only applies when following logs (-f)")
+		}
+	}
+	if opts.OutputFile != "" {
+		file, err := os.Create(opts.OutputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			file.Close()
+			fmt.Printf("📁 Logs saved to %s\n", opts.OutputFile)
+		}()
 
-	// Get current project
-	fmt.Println("🔍 Getting current project...")
-	currentProject := getCurrentProject()
-	if currentProject == "" {
-		fmt.Println("❌ No GCP project selected")
-		fmt.Println("Please run 'gcpeasy env select' to choose an environment.")
-		return nil
+		// Tee below the rate status line, so it isn't saved
+		out := opts.output
+		if out == nil {
+			out = os.Stdout
+		}
+		opts.output = io.MultiWriter(out, internal.NewPlainWriter(file))
+		fmt.Printf("📁 Saving logs to %s\n", opts.OutputFile)
 	}
-	fmt.Printf("✅ Current project: %s\n", currentProject)
 
 	fmt.Printf("🔍 Looking for application pods in project: %s\n", currentProject)
 
-	if allPods {
+	if opts.All {
 		// Setup cluster if kubectl is not configured
-		if err := internal.SetupClusterIfNeeded(currentProject); err != nil {
+		if err := ensureCluster(currentProject); err != nil {
 			if strings.Contains(err.Error(), "cancelled by user") {
 				fmt.Println("Cancelled.")
 				return nil
@@ -217,10 +254,13 @@ func runPodLogs(follow bool, level string, allPods bool) error {
 		}
 		fmt.Println()
 
-		return viewMultiplePodLogs(pods, follow, level)
+		return viewMultiplePodLogs(pods, opts)
 	}
 
-	selectedPod, err := internal.SetupClusterAndSelectPod(currentProject)
+	selectedPod, err := selectTargetPod(currentProject)
+	if errors.Is(err, errNoPodMatch) && podPattern == "" {
+		return viewCloudLoggingLogs(currentProject, podArgument, opts)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "cancelled by user") {
 			fmt.Println("Cancelled.")
@@ -230,35 +270,104 @@ func runPodLogs(follow bool, level string, allPods bool) error {
 	}
 
 	fmt.Printf("📋 Viewing logs for pod: %s\n", selectedPod)
-	return viewPodLogs(selectedPod, follow, level)
+	suggestPreviousLogs(selectedPod, opts.Previous)
+	err = viewPodLogs(selectedPod, opts)
+	if errors.Is(err, errPodGone) {
+		return viewCloudLoggingLogs(currentProject, selectedPod, opts)
+	}
+	return err
+}
+
+// suggestPreviousLogs points out restarted containers, whose previous instance's logs show
+// why they crashed, or says which container --previous applies to
+func suggestPreviousLogs(podNameWithNamespace string, previous bool) {
+	pod, err := internal.GetPod(podNameWithNamespace)
+	if err != nil {
+		return
+	}
+
+	var restarted []string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount == 0 {
+			continue
+		}
+		detail := fmt.Sprintf("%s: %d restart(s)", cs.Name, cs.RestartCount)
+		if term := cs.LastState.Terminated; term != nil && term.Reason != "" {
+			detail += fmt.Sprintf(", last %s (exit code %d)", term.Reason, term.ExitCode)
+		}
+		restarted = append(restarted, detail)
+	}
+
+	switch {
+	case len(restarted) == 0 && previous:
+		fmt.Println("⚠️  No container in this pod has restarted, so there are no previous logs")
+	case len(restarted) > 0 && !previous:
+		fmt.Printf("⚠️  Restarted containers: %s\n", strings.Join(restarted, "; "))
+		fmt.Println("💡 Use --previous (-p) to see the logs from before the last restart")
+	}
+}
+
+// podPrefixColors tell the pods apart in multi-pod log output; red is left for errors
+var podPrefixColors = []string{internal.ColorCyan, internal.ColorGreen, internal.ColorMagenta, internal.ColorBlue, internal.ColorYellow}
+
+// printLogStreamHeader reports the filters and what is being fetched; source describes
+// where the logs come from, e.g. " from multiple pods"
+func printLogStreamHeader(opts logOptions, source string) {
+	printLogFilters(opts)
+
+	tail := opts.tailLines()
+	switch {
+	case opts.Follow:
+		fmt.Printf("🔄 Following logs%s (press Ctrl+C to stop)...\n", source)
+	case tail > 0 && opts.Tail == 0:
+		fmt.Printf("📋 Fetching the last %d lines%s (use --tail -1 for all)...\n", tail, source)
+	default:
+		fmt.Printf("📋 Fetching logs%s...\n", source)
+	}
+	fmt.Println()
 }
 
-func viewMultiplePodLogs(pods []string, follow bool, level string) error {
+func viewMultiplePodLogs(pods []string, opts logOptions) error {
 	if len(pods) == 0 {
 		return fmt.Errorf("no pods provided")
 	}
 
-	if level != "" {
-		fmt.Printf("📋 Filtering logs by level: %s\n", strings.ToUpper(level))
+	out := opts.output
+	if out == nil {
+		out = os.Stdout
+	}
+	width := 0
+	for _, pod := range pods {
+		width = max(width, len(pod))
+	}
+	podPrefix := func(i int) string {
+		return internal.Colorize(podPrefixColors[i%len(podPrefixColors)], fmt.Sprintf("[%-*s]", width, pods[i])) + " "
 	}
 
-	if follow {
-		fmt.Println("🔄 Following logs from multiple pods (press Ctrl+C to stop)...")
-	} else {
-		fmt.Println("📋 Fetching logs from multiple pods...")
+	if !opts.Follow {
+		printLogStreamHeader(opts, " from multiple pods, merged by time")
+		return viewMergedPodLogs(pods, opts, out, podPrefix)
 	}
-	fmt.Println()
+	printLogStreamHeader(opts, " from multiple pods")
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 	errCh := make(chan error, len(pods))
 
-	for _, pod := range pods {
+	for i, pod := range pods {
 		p := pod
+		// Each pod's lines are tagged with its name in its own color, whole lines at a time
+		w := internal.NewPrefixWriter(out, &mu, podPrefix(i))
+		podOpts := opts
+		podOpts.output = w
+		podOpts.multi = true
 		wg.Add(1)
 
 		go func() {
 			defer wg.Done()
-			if err := viewPodLogs(p, follow, level); err != nil {
+			err := viewPodLogs(p, podOpts)
+			w.Flush()
+			if err != nil {
 				errCh <- fmt.Errorf("%s: %w", p, err)
 			}
 		}()
@@ -279,29 +388,105 @@ func viewMultiplePodLogs(pods []string, follow bool, level string) error {
 	return firstErr
 }
 
-func runPodShell() error {
-	// Check if user is authenticated
-	fmt.Println("🔍 Checking authentication...")
-	if !isAuthenticated() {
-		fmt.Println("❌ Not authenticated with Google Cloud")
-		fmt.Println("Please run 'gcpeasy login' first to authenticate.")
-		return nil
+// viewMergedPodLogs fetches the logs of several pods with their timestamps and prints them
+// as one chronologically ordered stream, to follow requests across replicas
+func viewMergedPodLogs(pods []string, opts logOptions, out io.Writer, podPrefix func(i int) string) error {
+	streamOpts := opts.logStreamOptions()
+	streamOpts.Timestamps = true
+
+	logs := make([]string, len(pods))
+	errs := make([]error, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var stdout, stderr strings.Builder
+			if err := internal.StreamPodLogs(context.Background(), pod, streamOpts, &stdout, &stderr); err != nil {
+				if msg := strings.TrimSpace(stderr.String()); msg != "" {
+					err = errors.New(msg)
+				}
+				errs[i] = fmt.Errorf("%s: %w", pod, err)
+			}
+			logs[i] = stdout.String()
+		}()
+	}
+	wg.Wait()
+
+	var firstErr error
+	for _, err := range errs {
+		if err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
 	}
-	fmt.Println("✅ Authenticated")
 
-	// Get current project
-	fmt.Println("🔍 Getting current project...")
-	currentProject := getCurrentProject()
+	filter, err := logLineFilter(opts)
+	if err != nil {
+		return err
+	}
+	index := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		index[pod] = i
+	}
+	for _, entry := range internal.MergeLogs(pods, logs) {
+		line, keep := filter(entry.Line)
+		if !keep {
+			continue
+		}
+		if opts.Timestamps && entry.Timestamp != "" {
+			line = entry.Timestamp + " " + line
+		}
+		if _, err := fmt.Fprintln(out, podPrefix(index[entry.Source])+line); err != nil {
+			return err
+		}
+	}
+	return firstErr
+}
+
+// shellOptions are the `pod shell` settings for a single shell
+type shellOptions struct {
+	sessionOptions
+	// Shell overrides the configured or detected shell
+	Shell string
+	// DebugImage is the image of the debug container offered when no container has a shell
+	DebugImage string
+}
+
+func addShellFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("container", "c", "", "Container to open the shell in (default: the first application container with a shell)")
+	cmd.Flags().String("user", "", "User to run the shell as (e.g. root, when the container allows it)")
+	cmd.Flags().String("workdir", "", "Directory to start the shell in")
+	cmd.Flags().String("shell", "", "Shell to run (e.g. /bin/ash), instead of the configured or detected one")
+	cmd.Flags().String("debug-image", internal.DebugContainerImage, "Image of the debug container offered when no container has a shell")
+	addSessionEnvFlag(cmd)
+}
+
+func shellOptionsFromFlags(cmd *cobra.Command) shellOptions {
+	var opts shellOptions
+	opts.Container, _ = cmd.Flags().GetString("container")
+	opts.User, _ = cmd.Flags().GetString("user")
+	opts.Workdir, _ = cmd.Flags().GetString("workdir")
+	opts.Shell, _ = cmd.Flags().GetString("shell")
+	opts.DebugImage, _ = cmd.Flags().GetString("debug-image")
+	opts.Env, _ = cmd.Flags().GetStringArray("env")
+	return opts
+}
+
+func runPodShell(opts shellOptions) error {
+	currentProject := requireProject()
 	if currentProject == "" {
-		fmt.Println("❌ No GCP project selected")
-		fmt.Println("Please run 'gcpeasy env select' to choose an environment.")
 		return nil
 	}
-	fmt.Printf("✅ Current project: %s\n", currentProject)
+	if err := opts.applyEnvironment(currentProject); err != nil {
+		return err
+	}
 
 	fmt.Printf("🔍 Looking for application pods in project: %s\n", currentProject)
 
-	selectedPod, err := internal.SetupClusterAndSelectPod(currentProject)
+	selectedPod, err := selectTargetPod(currentProject)
 	if err != nil {
 		if strings.Contains(err.Error(), "cancelled by user") {
 			fmt.Println("Cancelled.")
@@ -311,107 +496,424 @@ func runPodShell() error {
 	}
 
 	fmt.Printf("🚀 Opening shell in pod: %s\n", selectedPod)
-	return connectToShell(selectedPod)
+	return connectToShell(currentProject, selectedPod, opts)
 }
 
-func viewPodLogs(podNameWithNamespace string, follow bool, level string) error {
-	parts := strings.Split(podNameWithNamespace, "/")
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid pod format: %s", podNameWithNamespace)
+// selectTargetPod sets up the cluster and chooses the pod to operate on. When GCPEASY_POD
+// is set (by history rerun) that pod, or a replacement from the same workload, is used
+// instead of prompting.
+func selectTargetPod(projectID string) (string, error) {
+	if err := ensureCluster(projectID); err != nil {
+		return "", err
 	}
 
-	namespace := parts[0]
-	podName := parts[1]
+	fmt.Println("🔍 Searching for application pods...")
+	pods, err := internal.FindApplicationPods()
+	if err != nil {
+		return "", fmt.Errorf("failed to find application pods: %w", err)
+	}
+	if len(pods) == 0 {
+		if podPattern != "" {
+			return "", fmt.Errorf("%w %q", errNoPodMatch, podPattern)
+		}
+		if podArgument != "" {
+			return "", fmt.Errorf("%w %q", errNoPodMatch, podArgument)
+		}
+		fmt.Println("❌ No pods found")
+		fmt.Println("Make sure your application is deployed and running.")
+		return "", fmt.Errorf("no pods found")
+	}
 
-	if level != "" {
-		fmt.Printf("📋 Filtering logs by level: %s\n", strings.ToUpper(level))
+	pins, err := internal.LoadPins(projectID)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to load pins: %v\n", err)
 	}
 
-	if follow {
-		fmt.Println("🔄 Following logs (press Ctrl+C to stop)...")
+	var pod string
+	if pin, ok := pinReference(); ok {
+		pod, err = resolvePinReference(pods, pins, pin)
+	} else if podPattern != "" {
+		pod, err = resolvePodPattern(pods, podPattern, firstMatch)
+	} else if podArgument != "" {
+		pod, err = resolveNamedPod(pods, podArgument)
+	} else if target := os.Getenv("GCPEASY_POD"); target != "" {
+		pod, err = resolveRecordedPod(pods, pins, target)
 	} else {
-		fmt.Println("📋 Fetching logs...")
+		pod, err = internal.SelectPod(pods, pins...)
 	}
-	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+
+	recordPodTarget(pod)
+	return pod, nil
+}
+
+// podArgument is a pod named as a positional argument by commands that accept one
+var podArgument string
+
+// podPattern is the --pod value: an exact pod name or a regular expression matched against
+// "namespace/pod", selecting the pod without prompting
+var podPattern string
+
+// firstMatch is --first: use the first of several pods matching podPattern or podArgument
+// instead of failing
+var firstMatch bool
+
+// addPodTargetFlags registers --pod and --first on commands that operate on a single pod
+func addPodTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&podPattern, "pod", "", "Pod to use without prompting: an exact pod name or a regular expression")
+	cmd.Flags().BoolVar(&firstMatch, "first", false, "Use the first matching pod when several match instead of failing")
+}
+
+// errNoPodMatch is returned when no running pod matches podArgument or podPattern
+var errNoPodMatch = errors.New("no running pod matches")
+
+// errPodGone is returned when a pod was deleted while its logs were being read
+var errPodGone = errors.New("pod no longer exists")
+
+// resolveNamedPod matches a pod given on the command line as "namespace/pod", a pod
+// name, or a unique part of one
+func resolveNamedPod(pods []string, name string) (string, error) {
+	if pod, ok := internal.ResolvePod(pods, name); ok {
+		return pod, nil
+	}
+
+	var matches []string
+	for _, pod := range pods {
+		if _, podName, _ := strings.Cut(pod, "/"); podName == name {
+			return pod, nil
+		}
+		if strings.Contains(pod, name) {
+			matches = append(matches, pod)
+		}
+	}
+	return pickMatch(name, matches)
+}
+
+// resolvePodPattern matches a pod given with --pod by exact name ("pod" or "namespace/pod"),
+// then as a regular expression against "namespace/pod"
+func resolvePodPattern(pods []string, pattern string, first bool) (string, error) {
+	for _, pod := range pods {
+		if _, podName, _ := strings.Cut(pod, "/"); pod == pattern || podName == pattern {
+			return pod, nil
+		}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid --pod pattern: %w", err)
+	}
+	var matches []string
+	for _, pod := range pods {
+		if re.MatchString(pod) {
+			matches = append(matches, pod)
+		}
+	}
+	return pickMatch(pattern, matches)
+}
 
-	// Build kubectl logs command
-	args := []string{"logs", podName, "-n", namespace}
-	if follow {
-		args = append(args, "-f")
+// pickMatch returns the only pod matching a name or pattern, or the first one with --first
+func pickMatch(target string, matches []string) (string, error) {
+	switch {
+	case len(matches) == 0:
+		return "", fmt.Errorf("%w %q", errNoPodMatch, target)
+	case len(matches) == 1:
+		return matches[0], nil
+	case firstMatch:
+		sort.Strings(matches)
+		fmt.Printf("💡 %q matches %d pods, using %s (--first)\n", target, len(matches), matches[0])
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches %d pods (%s), be more specific or use --first", target, len(matches), strings.Join(matches, ", "))
 	}
+}
 
-	cmd := exec.Command("kubectl", args...)
+// resolvePinReference picks a running pod matching the pin named on the command line
+func resolvePinReference(pods []string, pins []internal.Pin, name string) (string, error) {
+	for _, pin := range pins {
+		if pin.Name != name {
+			continue
+		}
+		matched := internal.PinnedPods(pods, pin)
+		if len(matched) == 0 {
+			return "", fmt.Errorf("no running pod matches @%s (%s)", pin.Name, pin.Pattern)
+		}
+		fmt.Printf("⭐ Using %s for @%s\n", matched[0], pin.Name)
+		return matched[0], nil
+	}
+	return "", fmt.Errorf("no pin named @%s in this environment, see 'gcpeasy pod pin'", name)
+}
 
-	// If filtering by level, pipe through grep
-	if level != "" {
-		grepPatterns := getLogLevelPatterns(level)
-		if len(grepPatterns) > 0 {
-			// Use grep to filter logs
-			grepArgs := []string{"-E", "-i", strings.Join(grepPatterns, "|")}
+// resolveRecordedPod re-resolves a pod recorded in history, falling back to the picker
+func resolveRecordedPod(pods []string, pins []internal.Pin, target string) (string, error) {
+	pod, ok := internal.ResolvePod(pods, target)
+	if !ok {
+		fmt.Printf("⚠️  Pod %s no longer exists and no replacement was found\n", target)
+		return internal.SelectPod(pods, pins...)
+	}
+	if pod != target {
+		fmt.Printf("🔄 Pod %s no longer exists, using %s from the same workload\n", target, pod)
+	}
+	return pod, nil
+}
 
-			kubectlCmd := exec.Command("kubectl", args...)
-			grepCmd := exec.Command("grep", grepArgs...)
+func runPodShellAll(opts shellOptions, useTmux, syncPanes bool) error {
+	if !useTmux {
+		fmt.Println("❌ --all opens one shell per pod and requires --tmux")
+		return nil
+	}
+	if projectFlag != "" {
+		// Panes start in the tmux server's environment, without this invocation's project
+		// and private kubeconfig, so they would connect to the wrong cluster
+		fmt.Println("❌ --project can't be used with --tmux; select the environment with 'gcpeasy env select' first")
+		return nil
+	}
 
-			// Pipe kubectl output to grep
-			grepCmd.Stdin, _ = kubectlCmd.StdoutPipe()
-			grepCmd.Stdout = os.Stdout
-			grepCmd.Stderr = os.Stderr
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+	if err := opts.applyEnvironment(currentProject); err != nil {
+		return err
+	}
+	if opts.limits.enabled() {
+		fmt.Printf("❌ tmux panes can't be time-boxed, so --all isn't available in %s (session limits: %s)\n", currentProject, opts.limits)
+		fmt.Println("💡 Open shells one at a time with 'gcpeasy pod shell'")
+		return nil
+	}
 
-			kubectlCmd.Stderr = os.Stderr
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
 
-			if err := kubectlCmd.Start(); err != nil {
-				return err
-			}
-			if err := grepCmd.Start(); err != nil {
-				return err
-			}
+	fmt.Println("🔍 Gathering pod list...")
+	pods, err := internal.FindApplicationPods()
+	if err != nil {
+		return fmt.Errorf("failed to find application pods: %w", err)
+	}
 
-			if err := kubectlCmd.Wait(); err != nil {
-				return err
-			}
-			return grepCmd.Wait()
+	if len(pods) == 0 {
+		fmt.Println("❌ No application pods found")
+		fmt.Println("Make sure your applications are deployed and running.")
+		return nil
+	}
+
+	fmt.Printf("🚀 Opening shells in %d pod(s):\n", len(pods))
+	for _, p := range pods {
+		fmt.Printf(" - %s\n", p)
+	}
+	fmt.Println()
+	if !confirmProtected(currentProject, fmt.Sprintf("open shells in %d pods", len(pods))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return openTmuxShells(pods, opts.Env, syncPanes)
+}
+
+func viewPodLogs(podNameWithNamespace string, opts logOptions) error {
+	if !strings.Contains(podNameWithNamespace, "/") {
+		return fmt.Errorf("invalid pod format: %s", podNameWithNamespace)
+	}
+
+	if !opts.multi {
+		printLogStreamHeader(opts, "")
+	}
+	streamOpts := opts.logStreamOptions()
+
+	out := opts.output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	// Keep kubectl's errors to recognize a pod that was deleted in the meantime
+	var stderrBuf strings.Builder
+	stderr := io.MultiWriter(os.Stderr, &stderrBuf)
+	podGone := func(err error) error {
+		if err != nil && strings.Contains(stderrBuf.String(), "NotFound") {
+			return fmt.Errorf("%w: %s", errPodGone, podNameWithNamespace)
 		}
+		return err
+	}
+
+	// No filtering, rendering or counting, stream kubectl output directly
+	if opts.Level == "" && opts.Grep == "" && !opts.JSON && opts.monitor == nil {
+		return podGone(internal.StreamPodLogs(context.Background(), podNameWithNamespace, streamOpts, out, stderr))
+	}
+
+	filter, err := logLineFilter(opts)
+	if err != nil {
+		return err
 	}
 
-	// No filtering, run kubectl directly
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	pr, pw := io.Pipe()
+	streamErr := make(chan error, 1)
+	go func() {
+		err := internal.StreamPodLogs(context.Background(), podNameWithNamespace, streamOpts, pw, stderr)
+		pw.Close()
+		streamErr <- err
+	}()
+
+	filterErr := internal.FilterLines(pr, out, filter)
+	// Unblock kubectl if we stopped reading early
+	pr.CloseWithError(filterErr)
+	if err := <-streamErr; err != nil {
+		return podGone(err)
+	}
+	return filterErr
 }
 
-func connectToShell(podNameWithNamespace string) error {
-	parts := strings.Split(podNameWithNamespace, "/")
-	if len(parts) != 2 {
+func connectToShell(projectID, podNameWithNamespace string, opts shellOptions) error {
+	if !strings.Contains(podNameWithNamespace, "/") {
 		return fmt.Errorf("invalid pod format: %s", podNameWithNamespace)
 	}
 
-	namespace := parts[0]
-	podName := parts[1]
+	container, shell, err := findShell(projectID, podNameWithNamespace, opts)
+	if errors.Is(err, errNoShell) {
+		return offerDebugContainer(projectID, podNameWithNamespace, opts, err)
+	}
+	if err != nil {
+		return err
+	}
+	opts.Container = container
 
-	fmt.Println("🎯 Connecting to shell...")
+	fmt.Printf("🎯 Connecting to %s in container %s...\n", shell, container)
 	fmt.Println("(Type 'exit' or press Ctrl+D to disconnect)")
 	fmt.Println()
 
-	// Try shells in order of preference: bash, zsh, sh
-	shells := []string{"/bin/bash", "/bin/zsh", "/bin/sh"}
+	outcome, err := runInteractiveExec(podNameWithNamespace, opts.sessionOptions, shell)
+	if err != nil {
+		return err
+	}
+	if outcome == execUnavailable {
+		return fmt.Errorf("shell %s is not available in container %s", shell, container)
+	}
+	return nil
+}
 
-	for _, shell := range shells {
-		fmt.Printf("Trying: %s\n", shell)
+// findShell probes the pod's containers, application containers first, for a shell and
+// returns the first container that has one. --shell and --container restrict the search;
+// otherwise the configured shell for the container's image or environment is preferred.
+func findShell(projectID, podNameWithNamespace string, opts shellOptions) (string, string, error) {
+	pod, err := internal.GetPod(podNameWithNamespace)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	containers := internal.ShellContainers(pod)
+	if opts.Container != "" {
+		c, ok := pod.Container(opts.Container)
+		if !ok {
+			return "", "", fmt.Errorf("container %s not found in pod %s", opts.Container, podNameWithNamespace)
+		}
+		containers = []internal.Container{c}
+	}
 
-		cmd := exec.Command("kubectl", "exec", "-it", podName, "-n", namespace, "--", shell)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		cfg = &internal.Config{}
+	}
 
-		err := cmd.Run()
-		if err == nil {
-			return nil
+	fmt.Println("🔍 Looking for a shell...")
+	for _, c := range containers {
+		shells := internal.KnownShells
+		if opts.Shell != "" {
+			shells = []string{opts.Shell}
+		} else if preferred := cfg.PreferredShell(projectID, c.Image); preferred != "" {
+			shells = append([]string{preferred}, shells...)
 		}
 
-		fmt.Printf("Shell %s not available, trying next option...\n", shell)
+		available, err := internal.ProbeShells(podNameWithNamespace, c.Name, shells)
+		if err != nil {
+			fmt.Printf("⚠️  Could not check container %s: %v\n", c.Name, err)
+			continue
+		}
+		if len(available) > 0 {
+			return c.Name, available[0], nil
+		}
 	}
 
-	return fmt.Errorf("no suitable shell found in pod")
+	if opts.Shell != "" {
+		return "", "", fmt.Errorf("shell %s is not available in pod %s", opts.Shell, podNameWithNamespace)
+	}
+	fmt.Println("💡 The images look non-interactive (e.g. distroless or scratch). Use --shell if the shell is in an unusual location")
+	return "", "", fmt.Errorf("%w in any container of pod %s", errNoShell, podNameWithNamespace)
+}
+
+// errNoShell means none of the pod's containers has a shell
+var errNoShell = errors.New("no shell found")
+
+// offerDebugContainer offers an ephemeral debug container sharing the process namespace of
+// the container that has no shell, e.g. a distroless one. noShell is returned if declined.
+func offerDebugContainer(projectID, podNameWithNamespace string, opts shellOptions, noShell error) error {
+	if opts.limits.enabled() {
+		fmt.Println("💡 Debug containers can't be time-boxed, so they aren't offered in environments with session limits")
+		return noShell
+	}
+
+	target := opts.Container
+	if target == "" {
+		pod, err := internal.GetPod(podNameWithNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		containers := internal.ShellContainers(pod)
+		if len(containers) == 0 {
+			return noShell
+		}
+		target = containers[0].Name
+	}
+	image := opts.DebugImage
+	if image == "" {
+		image = internal.DebugContainerImage
+	}
+
+	fmt.Println()
+	fmt.Printf("An ephemeral debug container (%s) can be added to the pod, sharing the processes of container %s.\n", image, target)
+	fmt.Println("It stays in the pod's spec until the pod is replaced.")
+	if !confirm("Open a debug container?") {
+		return noShell
+	}
+	if !confirmProtected(projectID, "add a debug container to "+podNameWithNamespace) {
+		return fmt.Errorf("cancelled by user")
+	}
+	if opts.User != "" || opts.Workdir != "" {
+		fmt.Println("⚠️  --user and --workdir don't apply to debug containers")
+	}
+
+	fmt.Printf("🔧 Starting debug container in pod %s, targeting container %s...\n", podNameWithNamespace, target)
+	fmt.Println("💡 The target's processes are visible with ps, and its filesystem is under /proc/<pid>/root")
+	fmt.Println("(Type 'exit' or press Ctrl+D to disconnect)")
+	fmt.Println()
+	return internal.DebugContainerShell(podNameWithNamespace, target, image, opts.Env)
+}
+
+// matchesLogLevel checks a line's parsed severity against the selected levels, falling back
+// to matching the levels' keywords anywhere in unstructured lines unless strict is set.
+// When every level is selected, unstructured lines are shown whatever they contain.
+func matchesLogLevel(line internal.LogLine, levels internal.LevelFilter, pattern *regexp.Regexp, strict bool) bool {
+	if severity := internal.NormalizeSeverity(line.Severity); severity != "" {
+		return levels.Allows(severity)
+	}
+	if strict || pattern == nil {
+		return false
+	}
+	return levels.All() || pattern.MatchString(line.Raw)
+}
+
+// printLevelFilter reports the levels a --level value selects
+func printLevelFilter(spec string) {
+	levels, err := internal.ParseLevelFilter(spec)
+	if err != nil {
+		return
+	}
+	fmt.Printf("📋 Filtering logs by level: %s\n", strings.ToUpper(strings.Join(levels.Levels(), ", ")))
 }
 
 func getLogLevelPatterns(level string) []string {
@@ -429,6 +931,32 @@ func getLogLevelPatterns(level string) []string {
 	}
 }
 
+// printPodStatusTable prints the detailed pod table of `pod list --status`. When color is
+// set, rows it returns a color for are highlighted with it.
+func printPodStatusTable(pods []internal.PodInfo, capacity map[string]string, color func(internal.PodInfo) string) {
+	fmt.Printf("%-15s %-35s %-12s %-8s %-8s %-10s %-20s %-12s\n",
+		"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "AGE", "NODE", "CAPACITY")
+	fmt.Println(strings.Repeat("-", 123))
+
+	for _, pod := range pods {
+		row := fmt.Sprintf("%-15s %-35s %-12s %-8s %-8s %-10s %-20s %-12s",
+			truncate(pod.Namespace, 15),
+			truncate(pod.Name, 35),
+			pod.Status,
+			pod.Ready,
+			pod.Restarts,
+			pod.Age,
+			truncate(pod.Node, 20),
+			orDash(capacity[pod.Node]))
+		if color != nil {
+			if c := color(pod); c != "" {
+				row = internal.Colorize(c, row)
+			}
+		}
+		fmt.Println(row)
+	}
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podCpCmd = &cobra.Command{
+	Use:   "cp <src> <dst>",
+	Short: "Copy files to and from a pod",
+	Long: `Copy a file or directory between your machine and the selected pod with kubectl cp.
+Write the pod side as :<path>, or <pod>:<path> (or @pin:<path>) to name the pod:
+
+  gcpeasy pod cp :/tmp/heap.hprof .
+  gcpeasy pod cp web:/var/log/app ./logs
+  gcpeasy pod cp ./debug.sh :/tmp/
+
+A pod path ending in / copies into that directory under the local name. kubectl cp needs
+tar in the container; for images without it, single files are copied with cat instead.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		container, _ := cmd.Flags().GetString("container")
+		if err := runPodCp(args[0], args[1], container); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error copying: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podCpCmd.Flags().StringP("container", "c", "", "Container to copy from or to (default: the pod's default container)")
+	addPodTargetFlags(podCpCmd)
+	addSelectorFlag(podCpCmd)
+	podCmd.AddCommand(podCpCmd)
+}
+
+// podPathPattern matches the pod side of a copy: [pod]:path
+var podPathPattern = regexp.MustCompile(`^(@[A-Za-z0-9._-]+|[a-z0-9][-a-z0-9.]*)?:(.+)$`)
+
+func runPodCp(src, dst, container string) error {
+	srcPod := podPathPattern.FindStringSubmatch(src)
+	dstPod := podPathPattern.FindStringSubmatch(dst)
+	if (srcPod == nil) == (dstPod == nil) {
+		return fmt.Errorf("exactly one of <src> and <dst> must be in the pod, written as :<path> or <pod>:<path>")
+	}
+	remote := srcPod
+	if remote == nil {
+		remote = dstPod
+	}
+	if remote[1] != "" {
+		podArgument = remote[1]
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return err
+	}
+	namespace, podName, ok := strings.Cut(selectedPod, "/")
+	if !ok {
+		return fmt.Errorf("invalid pod format: %s", selectedPod)
+	}
+
+	if srcPod != nil {
+		return copyFromPod(namespace, podName, container, srcPod[2], dst)
+	}
+	return copyToPod(namespace, podName, container, src, dstPod[2])
+}
+
+func copyFromPod(namespace, podName, container, remote, local string) error {
+	if info, err := os.Stat(local); err == nil && info.IsDir() {
+		local = filepath.Join(local, path.Base(remote))
+	}
+
+	fmt.Printf("📥 Copying %s/%s:%s to %s...\n", namespace, podName, remote, local)
+	started := time.Now()
+	stop := showCopyProgress(0, func() int64 { return localSize(local) })
+	err := internal.CopyFromPod(namespace, podName, container, remote, local)
+	stop()
+
+	if errors.Is(err, internal.ErrNoTar) {
+		fmt.Println("⚠️  tar is not installed in the container, copying with cat instead (single files only)")
+		err = streamFromPod(namespace, podName, container, remote, local)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Copied %s to %s in %s\n", internal.FormatBytes(localSize(local)), local, internal.FormatDuration(time.Since(started)))
+	return nil
+}
+
+func streamFromPod(namespace, podName, container, remote, local string) error {
+	f, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	counter := &byteCounter{w: f}
+	stop := showCopyProgress(0, counter.Count)
+	err = internal.StreamFromPod(namespace, podName, container, remote, counter)
+	stop()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(local)
+	}
+	return err
+}
+
+func copyToPod(namespace, podName, container, local, remote string) error {
+	info, err := os.Stat(local)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(remote, "/") {
+		remote += filepath.Base(local)
+	}
+	size := localSize(local)
+
+	fmt.Printf("📤 Copying %s (%s) to %s/%s:%s...\n", local, internal.FormatBytes(size), namespace, podName, remote)
+	started := time.Now()
+	stop := showCopyProgress(size, nil)
+	err = internal.CopyToPod(namespace, podName, container, local, remote)
+	stop()
+
+	if errors.Is(err, internal.ErrNoTar) {
+		if info.IsDir() {
+			return fmt.Errorf("copying a directory needs tar in the container; copy single files instead")
+		}
+		fmt.Println("⚠️  tar is not installed in the container, copying with cat instead")
+		err = streamToPod(namespace, podName, container, local, remote, size)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Copied %s to %s/%s:%s in %s\n", internal.FormatBytes(size), namespace, podName, remote, internal.FormatDuration(time.Since(started)))
+	return nil
+}
+
+func streamToPod(namespace, podName, container, local, remote string, size int64) error {
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	counter := &byteCounter{r: f}
+	stop := showCopyProgress(size, counter.Count)
+	defer stop()
+	return internal.StreamToPod(namespace, podName, container, remote, counter)
+}
+
+// byteCounter counts the bytes read from r or written to w
+type byteCounter struct {
+	r io.Reader
+	w io.Writer
+	n atomic.Int64
+}
+
+func (c *byteCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func (c *byteCounter) Count() int64 {
+	return c.n.Load()
+}
+
+// showCopyProgress keeps a status line with the bytes copied so far (and the share of
+// total when known) and the elapsed time until the returned function is called. Without
+// copied only the elapsed time is shown. Nothing is shown when stdout isn't a terminal.
+func showCopyProgress(total int64, copied func() int64) func() {
+	if !internal.StdoutIsTerminal() {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	started := time.Now()
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Print("\r\033[K")
+				return
+			case <-ticker.C:
+				status := "⏳ Copying..."
+				if copied != nil {
+					n := copied()
+					status = "⏳ " + internal.FormatBytes(n)
+					if total > 0 {
+						status += fmt.Sprintf(" of %s (%d%%)", internal.FormatBytes(total), min(n*100/total, 100))
+					}
+				}
+				fmt.Printf("\r\033[K%s, %s", status, internal.FormatDuration(time.Since(started)))
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// localSize is the size of a file, or of all files in a directory; 0 if it doesn't exist
+func localSize(p string) int64 {
+	var size int64
+	filepath.WalkDir(p, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
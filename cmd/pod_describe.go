@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podDescribeCmd = &cobra.Command{
+	Use:   "describe [pod]",
+	Short: "Show a readable summary of a pod",
+	Long: `Show a concise summary of the selected pod: its status, containers with their images,
+state, restarts, resource requests and limits and probes, the pod's conditions, probe
+failures and recent events. Events are only kept by Kubernetes for about an hour.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			podArgument = args[0]
+		}
+		events, _ := cmd.Flags().GetInt("events")
+		if err := describePod(events); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error describing pod: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podDescribeCmd.Flags().Int("events", 10, "Number of recent events to show")
+	addPodTargetFlags(podDescribeCmd)
+	addSelectorFlag(podDescribeCmd)
+	podCmd.AddCommand(podDescribeCmd)
+}
+
+func describePod(eventCount int) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return err
+	}
+	pod, err := internal.GetPod(selectedPod)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	events, err := internal.GetPodEvents(pod.Metadata.Namespace, pod.Metadata.Name)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not get events: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Println(internal.Colorize(internal.ColorCyan, "📋 Pod "+selectedPod))
+	status := pod.Status.Phase
+	if problem, ok := internal.PodProblem(*pod); ok {
+		status = internal.Colorize(internal.ColorRed, problem)
+	} else if pod.Ready() {
+		status = internal.Colorize(internal.ColorGreen, status+", ready")
+	}
+	printDescribeField("Status", status)
+	if pod.Status.Message != "" {
+		printDescribeField("Message", pod.Status.Message)
+	}
+	printDescribeField("Node", orDash(pod.Spec.NodeName))
+	printDescribeField("IP", orDash(pod.Status.PodIP))
+	if started, err := time.Parse(time.RFC3339, pod.Status.StartTime); err == nil {
+		printDescribeField("Started", internal.FormatDuration(time.Since(started))+" ago ("+started.Local().Format("2006-01-02 15:04")+")")
+	}
+	for _, ref := range pod.Metadata.OwnerReferences {
+		if ref.Controller {
+			printDescribeField("Owner", ref.Kind+"/"+ref.Name)
+		}
+	}
+	printDescribeField("Service account", orDash(pod.Spec.ServiceAccountName))
+	printDescribeField("QoS class", orDash(pod.Status.QOSClass))
+
+	statuses := make(map[string]internal.ContainerStatus)
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statuses[cs.Name] = cs
+	}
+	if len(pod.Spec.InitContainers) > 0 {
+		fmt.Println()
+		fmt.Println(internal.Colorize(internal.ColorCyan, "Init containers:"))
+		for _, c := range pod.Spec.InitContainers {
+			printDescribeContainer(c, statuses)
+		}
+	}
+	fmt.Println()
+	fmt.Println(internal.Colorize(internal.ColorCyan, "Containers:"))
+	for _, c := range pod.Spec.Containers {
+		printDescribeContainer(c, statuses)
+	}
+
+	fmt.Println()
+	fmt.Println(internal.Colorize(internal.ColorCyan, "Conditions:"))
+	conditions := pod.Status.Conditions
+	sort.SliceStable(conditions, func(i, k int) bool { return conditions[i].LastTransitionTime < conditions[k].LastTransitionTime })
+	for _, c := range conditions {
+		line := fmt.Sprintf("  ✅ %s", c.Type)
+		if !c.Healthy() {
+			line = internal.Colorize(internal.ColorRed, fmt.Sprintf("  ❌ %s", c.Type))
+			if c.Reason != "" {
+				line += "  " + c.Reason
+			}
+			if c.Message != "" {
+				line += ": " + c.Message
+			}
+		}
+		if t, err := time.Parse(time.RFC3339, c.LastTransitionTime); err == nil {
+			line += internal.Colorize(internal.ColorGray, " (since "+internal.FormatDuration(time.Since(t))+" ago)")
+		}
+		fmt.Println(line)
+	}
+
+	if failures := internal.ProbeFailures(events); len(failures) > 0 {
+		fmt.Println()
+		fmt.Println(internal.Colorize(internal.ColorCyan, "Probe failures:"))
+		for _, f := range failures {
+			fmt.Printf("  ⚠️  %s probe of %s failed %d time(s), last %s ago: %s\n",
+				f.Probe, orDash(f.Container), f.Count, internal.FormatDuration(time.Since(f.Last)), truncate(f.Message, 120))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(internal.Colorize(internal.ColorCyan, "Recent events:"))
+	if len(events) == 0 {
+		fmt.Println("  No events in the last hour")
+	}
+	if len(events) > eventCount {
+		events = events[len(events)-eventCount:]
+	}
+	for _, e := range events {
+		printPodEvent(e)
+	}
+	return nil
+}
+
+func printDescribeField(name, value string) {
+	fmt.Printf("   %-16s %s\n", name+":", value)
+}
+
+func printDescribeContainer(c internal.Container, statuses map[string]internal.ContainerStatus) {
+	header := "  " + c.Name
+	if cs, ok := statuses[c.Name]; ok {
+		state := internal.DescribeContainerState(cs.State)
+		switch {
+		case cs.State.Running != nil && cs.Ready:
+			state = internal.Colorize(internal.ColorGreen, "✅ "+state)
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			state = "✅ " + state
+		default:
+			state = internal.Colorize(internal.ColorRed, "❌ "+state+" (not ready)")
+		}
+		header += "  " + state + fmt.Sprintf(", %d restart(s)", cs.RestartCount)
+		fmt.Println(header)
+		if cs.LastState.Terminated != nil {
+			printContainerField("Last exit", internal.DescribeContainerState(cs.LastState))
+		}
+	} else {
+		fmt.Println(header)
+	}
+
+	printContainerField("Image", c.Image)
+	printContainerField("Requests", describeResources(c.Resources.Requests))
+	printContainerField("Limits", describeResources(c.Resources.Limits))
+	if len(c.Ports) > 0 {
+		var ports []string
+		for _, p := range c.Ports {
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = "TCP"
+			}
+			port := fmt.Sprintf("%d/%s", p.ContainerPort, protocol)
+			if p.Name != "" {
+				port += " (" + p.Name + ")"
+			}
+			ports = append(ports, port)
+		}
+		printContainerField("Ports", strings.Join(ports, ", "))
+	}
+	for _, probe := range []struct {
+		name  string
+		probe *internal.Probe
+	}{{"Startup", c.StartupProbe}, {"Liveness", c.LivenessProbe}, {"Readiness", c.ReadinessProbe}} {
+		if probe.probe != nil {
+			printContainerField(probe.name, internal.DescribeProbe(probe.probe))
+		}
+	}
+}
+
+func printContainerField(name, value string) {
+	fmt.Printf("      %-11s %s\n", name+":", value)
+}
+
+// describeResources renders requests or limits as "cpu 100m, memory 256Mi"
+func describeResources(resources map[string]string) string {
+	if len(resources) == 0 {
+		return "-"
+	}
+	var parts []string
+	for _, name := range sortedKeys(resources) {
+		parts = append(parts, name+" "+resources[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var podEditCmd = &cobra.Command{
+	Use:   "edit <path>",
+	Short: "Edit a file inside a pod",
+	Long: `Copy a file out of the selected pod, open it in $EDITOR, show a diff of your changes and
+copy it back after confirmation. Useful for tweaking configuration inside a pod during an incident;
+changes are lost when the pod is replaced.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runPodEdit(args[0]); err != nil {
+			fmt.Printf("Error editing file: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podCmd.AddCommand(podEditCmd)
+}
+
+func runPodEdit(path string) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return err
+	}
+
+	parts := strings.Split(selectedPod, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid pod format: %s", selectedPod)
+	}
+	namespace, podName := parts[0], parts[1]
+
+	fmt.Printf("📥 Copying %s from pod %s...\n", path, selectedPod)
+	var stderr bytes.Buffer
+	readCmd := exec.Command("kubectl", "exec", podName, "-n", namespace, "--", "cat", path)
+	readCmd.Stderr = &stderr
+	original, err := readCmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gcpeasy-edit-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	localPath := filepath.Join(tmpDir, filepath.Base(path))
+	if err := os.WriteFile(localPath, original, 0600); err != nil {
+		return err
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	fmt.Printf("📝 Opening %s in %s...\n", path, editor)
+	editCmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", localPath)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	edited, err := os.ReadFile(localPath)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(original, edited) {
+		fmt.Println("No changes made.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("📋 Changes to %s:\n", path)
+	internal.PrintLineDiff(internal.LineDiff(splitLines(string(original)), splitLines(string(edited))), 3)
+	fmt.Println()
+
+	if !confirm(fmt.Sprintf("Copy changes back to %s in %s?", path, selectedPod)) {
+		fmt.Println("Discarded changes.")
+		return nil
+	}
+
+	stderr.Reset()
+	writeCmd := exec.Command("kubectl", "exec", "-i", podName, "-n", namespace, "--", "sh", "-c", `cat > "$1"`, "sh", path)
+	writeCmd.Stdin = bytes.NewReader(edited)
+	writeCmd.Stderr = &stderr
+	if err := writeCmd.Run(); err != nil {
+		return fmt.Errorf("failed to write %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+
+	fmt.Printf("✅ Updated %s in pod %s\n", path, selectedPod)
+	fmt.Println("⚠️  This change only lives in the running pod and is lost when it restarts")
+	return nil
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podEventsCmd = &cobra.Command{
+	Use:   "events [pod]",
+	Short: "Show the recent events of a pod",
+	Long: `Show the Kubernetes events recorded for the selected pod, oldest first: scheduling
+failures, image pulls, probe failures, back-offs and so on. Container terminations from the
+pod's status, such as OOMKilled, are included as they aren't reported as events.
+
+Kubernetes only keeps events for about an hour.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			podArgument = args[0]
+		}
+		warnings, _ := cmd.Flags().GetBool("warnings")
+		if err := showPodEvents(warnings); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error getting events: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podEventsCmd.Flags().BoolP("warnings", "w", false, "Only show warnings")
+	addPodTargetFlags(podEventsCmd)
+	addSelectorFlag(podEventsCmd)
+	podCmd.AddCommand(podEventsCmd)
+}
+
+func showPodEvents(warningsOnly bool) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return err
+	}
+	pod, err := internal.GetPod(selectedPod)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	events, err := internal.GetPodEvents(pod.Metadata.Namespace, pod.Metadata.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	events = append(events, terminationEvents(*pod)...)
+	sort.SliceStable(events, func(i, k int) bool { return events[i].Time().Before(events[k].Time()) })
+
+	var shown []internal.KubeEvent
+	for _, e := range events {
+		if !warningsOnly || e.Type == "Warning" {
+			shown = append(shown, e)
+		}
+	}
+
+	fmt.Println()
+	if len(shown) == 0 {
+		kind := "events"
+		if warningsOnly {
+			kind = "warning events"
+		}
+		fmt.Printf("✅ No %s for %s in the last hour\n", kind, selectedPod)
+		return nil
+	}
+	fmt.Printf("📋 Events for %s (%d):\n", selectedPod, len(shown))
+	fmt.Println()
+	fmt.Printf("  %-12s %-8s %-20s %s\n", "AGE", "TYPE", "REASON", "MESSAGE")
+	for _, e := range shown {
+		printPodEvent(e)
+	}
+	return nil
+}
+
+// terminationEvents turns the last termination of each container into an event, so
+// crashes and OOMKills show up alongside the pod's events
+func terminationEvents(p internal.Pod) []internal.KubeEvent {
+	var events []internal.KubeEvent
+	for _, c := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+		for _, state := range []internal.ContainerState{c.State, c.LastState} {
+			t := state.Terminated
+			if t == nil || t.ExitCode == 0 {
+				continue
+			}
+			var e internal.KubeEvent
+			e.Type = "Warning"
+			e.Reason = t.Reason
+			if e.Reason == "" {
+				e.Reason = "Terminated"
+			}
+			e.Message = fmt.Sprintf("Container %s exited with code %d", c.Name, t.ExitCode)
+			e.LastTimestamp = t.FinishedAt
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// printPodEvent prints an event as a row of the AGE, TYPE, REASON and MESSAGE columns,
+// highlighting warnings
+func printPodEvent(e internal.KubeEvent) {
+	line := fmt.Sprintf("  %-12s %-8s %-20s %s", internal.FormatDuration(time.Since(e.Time()))+" ago", e.Type, e.Reason, strings.ReplaceAll(e.Message, "\n", " "))
+	if e.Count > 1 {
+		line += fmt.Sprintf(" (x%d)", e.Count)
+	}
+	line = truncate(line, 160)
+	if e.Type == "Warning" {
+		line = internal.Colorize(internal.ColorYellow, line)
+	}
+	fmt.Println(line)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var podExecCmd = &cobra.Command{
+	Use:   "exec [pod] -- <command> [args...]",
+	Short: "Run a one-off command in a pod",
+	Long: `Run a non-interactive command in the selected pod with kubectl exec, e.g.:
+
+  gcpeasy pod exec -- env
+  gcpeasy pod exec --pod web -c app -- ls -la /app
+  gcpeasy pod exec web -- sh -c 'echo $HOSTNAME'
+
+The command's output is passed through and gcpeasy exits with its exit code, so it can be
+used in scripts; gcpeasy's own messages go to stderr, so stdout is only the command's
+output. Use -i to pass stdin to the command (e.g. to pipe a file into it). Use --env to set
+environment variables for the command, in addition to the environment's session_env.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 0 || dash == len(args) {
+			return fmt.Errorf("give the command after --, e.g. gcpeasy pod exec -- env")
+		}
+		if dash > 1 {
+			return fmt.Errorf("expected at most one pod before --, got %d", dash)
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		dash := cmd.ArgsLenAtDash()
+		if dash == 1 {
+			podArgument = args[0]
+		}
+		container, _ := cmd.Flags().GetString("container")
+		stdin, _ := cmd.Flags().GetBool("stdin")
+		env, _ := cmd.Flags().GetStringArray("env")
+		opts := sessionOptions{Container: container, Env: env}
+		exitCode, err := runPodExec(args[dash:], opts, stdin)
+		if err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error running command: %v\n", err)
+			exitWithCode(1)
+		}
+		if exitCode != 0 {
+			exitWithCode(exitCode)
+		}
+	},
+}
+
+func init() {
+	podExecCmd.Flags().StringP("container", "c", "", "Container to run the command in (default: the pod's default container)")
+	podExecCmd.Flags().BoolP("stdin", "i", false, "Pass stdin to the command")
+	addSessionEnvFlag(podExecCmd)
+	addPodTargetFlags(podExecCmd)
+	addSelectorFlag(podExecCmd)
+	podCmd.AddCommand(podExecCmd)
+}
+
+// runPodExec runs command in the selected pod and returns its exit code
+func runPodExec(command []string, opts sessionOptions, stdin bool) (int, error) {
+	out, restore := machineOutput()
+	defer restore()
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return 1, nil
+	}
+	if err := opts.applyEnvironment(currentProject); err != nil {
+		return 0, err
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return 0, err
+	}
+	namespace, podName, ok := strings.Cut(selectedPod, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid pod format: %s", selectedPod)
+	}
+
+	args := []string{"exec", podName, "-n", namespace}
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	}
+	if stdin {
+		args = append(args, "-i")
+	}
+	args = append(args, "--")
+	args = append(args, opts.wrap(command)...)
+
+	fmt.Printf("🚀 Running in %s: %s\n", selectedPod, strings.Join(command, " "))
+	run := exec.Command("kubectl", args...)
+	if stdin {
+		run.Stdin = os.Stdin
+	}
+	run.Stdout = out
+	run.Stderr = os.Stderr
+	err = run.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podOOMKillsCmd = &cobra.Command{
+	Use:   "oomkills",
+	Short: "Find OOMKilled and crash-looping containers",
+	Long:  "Scan application pods for OOMKilled terminations and restart spikes, correlate them with memory limits and peak usage from Cloud Monitoring, and suggest limit adjustments.",
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		if err := showOOMKills(since); err != nil {
+			fmt.Printf("Error scanning for OOMKills: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podOOMKillsCmd.Flags().String("since", "24h", "How far back to look for terminations (e.g. 6h, 7d)")
+	podCmd.AddCommand(podOOMKillsCmd)
+}
+
+func showOOMKills(sinceFlag string) error {
+	since, err := internal.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Printf("🔍 Scanning for OOMKills and restart spikes in the last %s...\n", sinceFlag)
+	offenders, err := internal.FindOOMOffenders(since)
+	if err != nil {
+		return fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	if len(offenders) == 0 {
+		fmt.Println("✅ No OOMKills or restart spikes found")
+		return nil
+	}
+
+	fmt.Println("🔍 Fetching memory usage from Cloud Monitoring...")
+	clusterName := ""
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		_, clusterName, _ = internal.ParseClusterContext(context)
+	}
+	peaks, err := internal.GetPeakMemoryUsage(getCurrentProject(), clusterName, since)
+	if err != nil {
+		fmt.Printf("⚠️  Could not fetch memory usage, suggestions are based on limits only: %v\n", err)
+	}
+	for i := range offenders {
+		o := &offenders[i]
+		o.PeakUsage = peaks[fmt.Sprintf("%s/%s/%s", o.Namespace, o.Pod, o.Container)]
+		o.SuggestedLimit = internal.SuggestMemoryLimit(*o)
+	}
+	fmt.Println()
+
+	fmt.Printf("%-4s %-15s %-35s %-15s %-10s %-9s %-9s %-9s %-10s %s\n", "#", "NAMESPACE", "POD", "CONTAINER", "REASON", "RESTARTS", "LIMIT", "PEAK", "SUGGEST", "LAST")
+	fmt.Println(strings.Repeat("-", 135))
+
+	for i, o := range offenders {
+		reason := o.LastReason
+		if o.OOMKilled {
+			reason = internal.Colorize(internal.ColorRed, fmt.Sprintf("%-10s", reason))
+		}
+		fmt.Printf("%-4d %-15s %-35s %-15s %-10s %-9d %-9s %-9s %-10s %s ago\n",
+			i+1,
+			truncate(o.Namespace, 15),
+			truncate(o.Pod, 35),
+			truncate(o.Container, 15),
+			reason,
+			o.Restarts,
+			memoryOrDash(o.MemoryLimit),
+			memoryOrDash(o.PeakUsage),
+			memoryOrDash(o.SuggestedLimit),
+			internal.FormatDuration(time.Since(o.LastTerminated)),
+		)
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Suggested limits add 25% headroom over peak usage, and at least 50% over the limit an OOMKilled container hit.")
+	for _, o := range offenders {
+		if o.OOMKilled && o.MemoryLimit == 0 {
+			fmt.Printf("⚠️  %s/%s (%s) was OOMKilled without a memory limit: the node ran out of memory, consider setting requests.\n", o.Namespace, o.Pod, o.Container)
+		}
+	}
+	return nil
+}
+
+func memoryOrDash(bytes float64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return internal.FormatMemory(bytes)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podRestartCmd = &cobra.Command{
+	Use:   "restart [pod]",
+	Short: "Delete a pod and wait for its replacement",
+	Long: `Delete the selected pod so its controller (ReplicaSet, StatefulSet, DaemonSet or Job)
+creates a replacement, then wait for the replacement to be running and print its name.
+Pods without a controller aren't restarted, as nothing would replace them.
+
+PodDisruptionBudgets the deletion would exceed are reported before asking for
+confirmation. Use --yes to skip the confirmation; protected environments still require
+typing the project ID.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			podArgument = args[0]
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if err := restartPod(yes, timeout); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error restarting pod: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	podRestartCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	podRestartCmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the replacement to be running")
+	addPodTargetFlags(podRestartCmd)
+	addSelectorFlag(podRestartCmd)
+	podCmd.AddCommand(podRestartCmd)
+}
+
+func restartPod(skipConfirm bool, timeout time.Duration) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		return err
+	}
+	pod, err := internal.GetPod(selectedPod)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	owner, ok := internal.ControllerOf(*pod)
+	if !ok {
+		return fmt.Errorf("%s isn't managed by a controller, so deleting it would not create a replacement", selectedPod)
+	}
+
+	// Remember the controller's current pods to tell the replacement apart
+	siblings, err := internal.PodsOfController(pod.Metadata.Namespace, owner)
+	if err != nil {
+		return fmt.Errorf("failed to get pods of %s/%s: %w", owner.Kind, owner.Name, err)
+	}
+	known := make(map[string]bool)
+	for _, p := range siblings {
+		known[p.Metadata.UID] = true
+	}
+
+	fmt.Printf("🎯 Pod %s (%s/%s, node %s)\n", selectedPod, owner.Kind, owner.Name, orDash(pod.Spec.NodeName))
+	fmt.Println()
+	warnDisruptionBudgets(pod.Metadata.Namespace, pod.Metadata.Labels, 1)
+	warnManaged("pod "+selectedPod, pod.Metadata.Labels, pod.Metadata.Annotations)
+
+	if !confirmProtected(currentProject, "restart pod "+pod.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !skipConfirm && !confirm(fmt.Sprintf("Delete %s so %s/%s replaces it?", selectedPod, owner.Kind, owner.Name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	deletedAt := time.Now()
+	err = runNotified("pod restart", selectedPod, func() error {
+		return internal.DeletePod(*pod)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🔄 Deleted %s, waiting for the replacement to be running (up to %s)...\n", selectedPod, timeout)
+
+	var replacement *internal.Pod
+	phase := ""
+	for {
+		elapsed := time.Since(deletedAt).Round(time.Second)
+		if replacement, err = internal.ReplacementPod(*pod, known); err != nil {
+			return fmt.Errorf("failed to get replacement pod: %w", err)
+		}
+		if replacement != nil && replacement.Status.Phase != phase {
+			phase = replacement.Status.Phase
+			fmt.Printf("   %6s  %s/%s %s\n", elapsed, replacement.Metadata.Namespace, replacement.Metadata.Name, phase)
+		}
+		if replacement != nil && phase == "Running" {
+			break
+		}
+		if elapsed > timeout {
+			fmt.Println()
+			if replacement == nil {
+				fmt.Printf("❌ No replacement pod after %s\n", timeout)
+			} else {
+				fmt.Printf("❌ Replacement %s/%s not running after %s\n", replacement.Metadata.Namespace, replacement.Metadata.Name, timeout)
+				if problem, ok := internal.PodProblem(*replacement); ok {
+					fmt.Printf("   %s\n", problem)
+				}
+			}
+			fmt.Println("💡 Check the pods with: gcpeasy pod list --status")
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	newPod := replacement.Metadata.Namespace + "/" + replacement.Metadata.Name
+	fmt.Println()
+	if replacement.Ready() {
+		fmt.Printf("✅ %s is running and ready\n", newPod)
+	} else {
+		fmt.Printf("✅ %s is running (not ready yet)\n", newPod)
+		fmt.Printf("💡 Follow its startup with: gcpeasy pod logs %s -f\n", replacement.Metadata.Name)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var podRunScriptCmd = &cobra.Command{
+	Use:   "run-script <local-file>",
+	Short: "Run a local script inside a pod",
+	Long: `Upload a local script to a temporary path in the selected pod, execute it while streaming
+its output, and remove it afterwards. The interpreter is inferred from the shebang or file
+extension unless --interpreter is given (e.g. --interpreter "bundle exec ruby").`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interpreter, _ := cmd.Flags().GetString("interpreter")
+		exitCode, err := runPodScript(args[0], interpreter)
+		if err != nil {
+			fmt.Printf("Error running script: %v\n", err)
+			exitWithCode(1)
+		}
+		if exitCode != 0 {
+			exitWithCode(exitCode)
+		}
+	},
+}
+
+var scriptInterpreters = map[string]string{
+	".rb": "ruby",
+	".py": "python3",
+	".sh": "sh",
+	".js": "node",
+	".pl": "perl",
+}
+
+func init() {
+	podRunScriptCmd.Flags().String("interpreter", "", "Command used to run the script (default: inferred)")
+	podCmd.AddCommand(podRunScriptCmd)
+}
+
+func runPodScript(localPath, interpreter string) (int, error) {
+	script, err := os.ReadFile(localPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+
+	if interpreter == "" {
+		interpreter = inferInterpreter(localPath, script)
+	}
+	if interpreter == "" {
+		return 0, fmt.Errorf("cannot infer an interpreter for %s, use --interpreter", localPath)
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return 0, nil
+	}
+
+	selectedPod, err := selectTargetPod(currentProject)
+	if err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	parts := strings.Split(selectedPod, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid pod format: %s", selectedPod)
+	}
+	namespace, podName := parts[0], parts[1]
+
+	remotePath := fmt.Sprintf("/tmp/gcpeasy-%d-%s", time.Now().UnixNano(), filepath.Base(localPath))
+
+	fmt.Printf("📤 Uploading %s to %s:%s...\n", localPath, selectedPod, remotePath)
+	var stderr bytes.Buffer
+	upload := exec.Command("kubectl", "exec", "-i", podName, "-n", namespace, "--", "sh", "-c", `cat > "$1"`, "sh", remotePath)
+	upload.Stdin = bytes.NewReader(script)
+	upload.Stderr = &stderr
+	if err := upload.Run(); err != nil {
+		return 0, fmt.Errorf("failed to upload script: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	defer func() {
+		if err := exec.Command("kubectl", "exec", podName, "-n", namespace, "--", "rm", "-f", remotePath).Run(); err != nil {
+			fmt.Printf("⚠️  Warning: failed to remove %s from pod\n", remotePath)
+			return
+		}
+		fmt.Printf("🧹 Removed %s from pod\n", remotePath)
+	}()
+
+	fmt.Printf("🚀 Running: %s %s\n", interpreter, remotePath)
+	fmt.Println()
+
+	run := exec.Command("kubectl", "exec", podName, "-n", namespace, "--", "sh", "-c", interpreter+` "$1"`, "sh", remotePath)
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	err = run.Run()
+	fmt.Println()
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		fmt.Printf("❌ Script exited with code %d\n", exitErr.ExitCode())
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Println("✅ Script completed successfully")
+	return 0, nil
+}
+
+// inferInterpreter picks an interpreter from the script's shebang or file extension
+func inferInterpreter(path string, script []byte) string {
+	if bytes.HasPrefix(script, []byte("#!")) {
+		line := string(script[2:])
+		if i := strings.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return scriptInterpreters[strings.ToLower(filepath.Ext(path))]
+}
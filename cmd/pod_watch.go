@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"sort"
+	"strings"
+	"time"
+)
+
+// watchPods refreshes the `pod list --status` table every interval until interrupted,
+// highlighting pods that are new (green) or changed status, readiness or restarts (yellow)
+// since the previous refresh and listing the pods that went away
+func watchPods(interval time.Duration) error {
+	if interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
+
+	capacity, err := internal.GetNodeCapacityTypes()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to get node capacity types: %v\n", err)
+	}
+
+	var previous map[string]internal.PodInfo
+	for {
+		pods, err := internal.GetDetailedPodInfo()
+		if err != nil {
+			return fmt.Errorf("failed to get pod information: %w", err)
+		}
+
+		current := make(map[string]internal.PodInfo, len(pods))
+		for _, pod := range pods {
+			current[pod.Namespace+"/"+pod.Name] = pod
+		}
+		var gone []string
+		for key := range previous {
+			if _, ok := current[key]; !ok {
+				gone = append(gone, key)
+			}
+		}
+
+		if internal.StdoutIsTerminal() {
+			// Clear the screen and move the cursor home, like watch(1)
+			fmt.Print("\033[H\033[2J")
+		} else if previous != nil {
+			fmt.Println()
+		}
+		fmt.Printf("📋 %d application pod(s) in %s at %s (every %s, Ctrl+C to stop)\n",
+			len(pods), currentProject, time.Now().Format("15:04:05"), interval)
+		fmt.Println()
+		printPodStatusTable(pods, capacity, func(pod internal.PodInfo) string {
+			if previous == nil {
+				return ""
+			}
+			before, ok := previous[pod.Namespace+"/"+pod.Name]
+			switch {
+			case !ok:
+				return internal.ColorGreen
+			case before.Status != pod.Status || before.Ready != pod.Ready || before.Restarts != pod.Restarts:
+				return internal.ColorYellow
+			}
+			return ""
+		})
+		if len(gone) > 0 {
+			fmt.Println()
+			sort.Strings(gone)
+			for _, key := range gone {
+				fmt.Println(internal.Colorize(internal.ColorGray, "  "+key+" (gone)"))
+			}
+		}
+
+		previous = current
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var policiesCmd = &cobra.Command{
+	Use:   "policies",
+	Short: "Organization policy commands",
+	Long:  "Commands for inspecting the organization policies that apply to the current project.",
+}
+
+var policiesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the organization policy constraints enforced on the project",
+	Long: `List the organization policy constraints in effect on the current project, whether set on
+the project or inherited from its folders and organization, e.g. disabled external IPs,
+restricted resource locations or disabled service account key creation.
+
+Enforced constraints often explain "permission denied" or "invalid argument" errors that
+IAM roles don't. Use --all to also list constraints that don't restrict anything.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		output, _ := cmd.Flags().GetString("output")
+		if err := listPolicies(all, output); err != nil {
+			fmt.Printf("Error listing policies: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	policiesListCmd.Flags().Bool("all", false, "Also list constraints that don't restrict anything")
+	policiesListCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	policiesCmd.AddCommand(policiesListCmd)
+	rootCmd.AddCommand(policiesCmd)
+}
+
+func listPolicies(all bool, output string) error {
+	output = strings.ToLower(output)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unsupported output format %q (use text or json)", output)
+	}
+
+	out := os.Stdout
+	if output == "json" {
+		var restore func()
+		out, restore = machineOutput()
+		defer restore()
+	}
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Checking organization policies on project: %s\n", currentProject)
+	policies, err := internal.GetOrgPolicies(currentProject)
+	if err != nil {
+		return err
+	}
+	shown := []internal.OrgPolicy{}
+	for _, p := range policies {
+		if all || p.Restricts() {
+			shown = append(shown, p)
+		}
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Println()
+	if len(shown) == 0 {
+		fmt.Printf("✅ No constraints restrict %s (%d checked)\n", currentProject, len(policies))
+		return nil
+	}
+
+	for _, p := range shown {
+		source := "inherited"
+		if p.SetOnProject {
+			source = "set on project"
+		}
+		summary := p.Summary()
+		if p.Restricts() {
+			summary = internal.Colorize(internal.ColorYellow, summary)
+		}
+		fmt.Printf("🛡️  %s %s\n", p.Constraint, internal.Colorize(internal.ColorGray, "("+source+")"))
+		if p.DisplayName != "" {
+			fmt.Printf("   %s\n", p.DisplayName)
+		}
+		fmt.Printf("   %s\n", summary)
+		fmt.Println()
+	}
+
+	restricting := 0
+	for _, p := range policies {
+		if p.Restricts() {
+			restricting++
+		}
+	}
+	fmt.Printf("📋 %d of %d constraint(s) restrict %s\n", restricting, len(policies), currentProject)
+	fmt.Println("💡 Details: gcloud resource-manager org-policies describe <constraint> --effective --project " + currentProject)
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strings"
+)
+
+// skipChecks disables the auth and cluster preflight checks (--skip-checks)
+var skipChecks bool
+
+// preflightState is the result of this invocation's preflight, shared by every check
+var preflightState *internal.PreflightState
+
+// preflight returns the auth/project/cluster state, reusing a fresh cached result from a
+// previous invocation and otherwise running the checks in parallel. Only successful
+// results are cached.
+func preflight(withCluster bool) internal.PreflightState {
+	if preflightState != nil && (preflightState.ClusterChecked || !withCluster) {
+		return *preflightState
+	}
+	// A per-invocation project override must not use or replace the shared cache
+	overridden := os.Getenv("GCPEASY_PROJECT") != ""
+	if cached, ok := internal.LoadPreflightCache(); ok && !overridden && (cached.ClusterChecked || !withCluster) {
+		internal.EmitEvent(internal.EventStepFinished, map[string]any{"step": "preflight", "ok": true, "cached": true})
+		preflightState = cached
+		return *cached
+	}
+
+	var state internal.PreflightState
+	internal.EmitStep("preflight", func() error {
+		state = internal.CheckPreflight(withCluster)
+		return nil
+	})
+	if state.Account != "" && state.Project != "" && !overridden {
+		if err := internal.SavePreflightCache(state); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to cache preflight checks: %v\n", err)
+		}
+	}
+	preflightState = &state
+	return state
+}
+
+// machineOutput sends everything printed to stdout to stderr instead, including the status
+// lines and prompts of the preflight checks and cluster setup, so stdout only carries what
+// the command writes to the returned file, e.g. JSON for -o json. The returned function
+// restores stdout.
+func machineOutput() (*os.File, func()) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return stdout, func() { os.Stdout = stdout }
+}
+
+// requireProject runs the authentication and project checks shared by most commands.
+// It returns an empty string when the command should stop; the reason has already been printed.
+func requireProject() string {
+	if skipChecks {
+		// Prefer a cached project to avoid even the gcloud lookup
+		var projectID string
+		if cached, ok := internal.LoadPreflightCache(); ok && cached.Project != "" && os.Getenv("GCPEASY_PROJECT") == "" {
+			invocation.project = cached.Project
+			projectID = cached.Project
+		} else {
+			projectID = getCurrentProject()
+		}
+		applyPodScope(projectID)
+		return projectID
+	}
+
+	state := preflight(false)
+	suffix := ""
+	if state.Cached {
+		suffix = " (cached)"
+	}
+
+	if state.Account == "" {
+		fmt.Println("❌ Not authenticated with Google Cloud")
+		fmt.Println("Please run 'gcpeasy login' first to authenticate.")
+		return ""
+	}
+	fmt.Printf("✅ Authenticated%s\n", suffix)
+
+	// GCPEASY_PROJECT overrides the gcloud project for a single invocation (used by history rerun)
+	currentProject := os.Getenv("GCPEASY_PROJECT")
+	if currentProject == "" {
+		currentProject = state.Project
+	}
+	invocation.project = currentProject
+	if currentProject == "" {
+		fmt.Println("❌ No GCP project selected")
+		fmt.Println("Please run 'gcpeasy env select' to choose an environment.")
+		return ""
+	}
+	fmt.Printf("✅ Current project: %s%s\n", currentProject, suffix)
+	applyPodScope(currentProject)
+
+	return currentProject
+}
+
+// ensureCluster makes sure kubectl points at a reachable cluster in the project, trusting
+// a fresh cached check and only falling back to the full cluster setup when needed
+func ensureCluster(projectID string) error {
+	if skipChecks {
+		return nil
+	}
+
+	state := preflight(true)
+	if state.ClusterReachable && internal.ContextBelongsToProject(state.Context, projectID) {
+		fmt.Printf("✅ Using current cluster context: %s\n", state.Context)
+		internal.EmitEvent(internal.EventClusterSelected, map[string]any{"context": state.Context})
+		return nil
+	}
+	return internal.EmitStep("cluster.setup", func() error {
+		return internal.SetupClusterIfNeeded(projectID)
+	})
+}
+
+// setupCluster runs the shared preflight and cluster setup, returning false if the command should stop
+func setupCluster() bool {
+	// Check auth, project and cluster in a single parallel round
+	if !skipChecks {
+		preflight(true)
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return false
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+		} else {
+			fmt.Printf("❌ Failed to setup cluster: %v\n", err)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// confirm asks a yes/no question and returns true only for an explicit yes
+func confirm(prompt string) bool {
+	internal.EmitEvent(internal.EventPrompt, map[string]any{"prompt": "confirm", "message": prompt, "default": "n"})
+	fmt.Printf("%s (y/N): ", prompt)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// confirmProtected guards destructive actions on protected environments by requiring the
+// project ID to be typed. It returns true immediately for unprotected environments.
+func confirmProtected(projectID, action string) bool {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return false
+	}
+	if !cfg.Environment(projectID).Protected {
+		return true
+	}
+
+	fmt.Printf("🛡️  %s is a protected environment.\n", projectID)
+	fmt.Printf("Type the project ID to confirm you want to %s: ", action)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+	return strings.TrimSpace(scanner.Text()) == projectID
+}
+
+// confirmDefaultYes asks a yes/no question where an empty answer means yes
+func confirmDefaultYes(prompt string) bool {
+	internal.EmitEvent(internal.EventPrompt, map[string]any{"prompt": "confirm", "message": prompt, "default": "y"})
+	fmt.Printf("%s (Y/n): ", prompt)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "" || answer == "y" || answer == "yes"
+}
+
+// selectMany asks for a selection of numbered items: numbers and ranges separated by
+// commas (e.g. "1,3-5") or "all". It returns the chosen 0-based indexes in order.
+func selectMany(prompt string, count int) ([]int, error) {
+	internal.EmitEvent(internal.EventPrompt, map[string]any{"prompt": "select-many", "message": prompt, "count": count})
+	fmt.Printf("%s (e.g. 1,3-5, 'all', or 'q' to quit): ", prompt)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	switch input {
+	case "q", "":
+		return nil, fmt.Errorf("cancelled by user")
+	case "all":
+		indexes := make([]int, count)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+
+	chosen := make(map[int]bool)
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			to = from
+		}
+		start, err1 := strconv.Atoi(strings.TrimSpace(from))
+		end, err2 := strconv.Atoi(strings.TrimSpace(to))
+		if err1 != nil || err2 != nil || start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("invalid selection: %s", part)
+		}
+		for i := start; i <= end; i++ {
+			chosen[i-1] = true
+		}
+	}
+
+	var indexes []int
+	for i := 0; i < count; i++ {
+		if chosen[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes, nil
+}
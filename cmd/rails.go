@@ -2,9 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"gcpeasy/internal"
-	"os"
-	"os/exec"
+	"github.com/scttymn/gcpeasy/internal"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -20,9 +18,11 @@ var railsConsoleCmd = &cobra.Command{
 	Use:     "console",
 	Aliases: []string{"c"},
 	Short:   "Access Rails console",
-	Long:    "Connect to a Rails application console running in the current GCP environment. Automatically detects Rails pods and provides console access.",
+	Long:    "Connect to a Rails application console running in the current GCP environment. Automatically detects Rails pods and provides console access. The Rails entrypoint (bin/rails, bundle exec rails or rails) is detected once per deployment and remembered. Use --env KEY=VALUE (e.g. DISABLE_SPRING=1) to set variables for the session, in addition to the environment's session_env from the config file. Use --pod <name|regex> (and --first) to choose the pod without prompting.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := runRailsConsole(); err != nil {
+		var opts sessionOptions
+		opts.Env, _ = cmd.Flags().GetStringArray("env")
+		if err := runRailsConsole(opts); err != nil {
 			fmt.Printf("Error accessing Rails console: %v\n", err)
 		}
 	},
@@ -34,63 +34,34 @@ var railsLogsCmd = &cobra.Command{
 	Long:       "View logs from Rails application pods. Use -f to follow logs in real-time. Use -e/--error or -w/--warn to filter by log level.\n\nDEPRECATED: This command is deprecated. Use 'gcpeasy pod logs' instead.",
 	Deprecated: "Use 'gcpeasy pod logs' instead",
 	Run: func(cmd *cobra.Command, args []string) {
-		follow, _ := cmd.Flags().GetBool("follow")
-		errorOnly, _ := cmd.Flags().GetBool("error")
-		warnOnly, _ := cmd.Flags().GetBool("warn")
-		infoOnly, _ := cmd.Flags().GetBool("info")
-		debugOnly, _ := cmd.Flags().GetBool("debug")
-
-		var level string
-		if errorOnly {
-			level = "error"
-		} else if warnOnly {
-			level = "warn"
-		} else if infoOnly {
-			level = "info"
-		} else if debugOnly {
-			level = "debug"
-		}
-
-		if err := runPodLogs(follow, level, false); err != nil {
+		if err := runPodLogs(logOptionsFromFlags(cmd)); err != nil {
 			fmt.Printf("Error viewing logs: %v\n", err)
 		}
 	},
 }
 
 func init() {
-	railsLogsCmd.Flags().BoolP("follow", "f", false, "Follow logs in real-time")
-	railsLogsCmd.Flags().BoolP("error", "e", false, "Show only error logs")
-	railsLogsCmd.Flags().BoolP("warn", "w", false, "Show only warning logs")
-	railsLogsCmd.Flags().BoolP("info", "i", false, "Show only info logs")
-	railsLogsCmd.Flags().BoolP("debug", "d", false, "Show only debug logs")
+	addSessionEnvFlag(railsConsoleCmd)
+	addPodTargetFlags(railsConsoleCmd)
+	addLogFlags(railsLogsCmd)
+	addNamespaceFlag(railsCmd)
 	railsCmd.AddCommand(railsConsoleCmd)
 	railsCmd.AddCommand(railsLogsCmd)
 	rootCmd.AddCommand(railsCmd)
 }
 
-func runRailsConsole() error {
-	// Check if user is authenticated
-	fmt.Println("🔍 Checking authentication...")
-	if !isAuthenticated() {
-		fmt.Println("❌ Not authenticated with Google Cloud")
-		fmt.Println("Please run 'gcpeasy login' first to authenticate.")
-		return nil
-	}
-	fmt.Println("✅ Authenticated")
-
-	// Get current project
-	fmt.Println("🔍 Getting current project...")
-	currentProject := getCurrentProject()
+func runRailsConsole(opts sessionOptions) error {
+	currentProject := requireProject()
 	if currentProject == "" {
-		fmt.Println("❌ No GCP project selected")
-		fmt.Println("Please run 'gcpeasy env select' to choose an environment.")
 		return nil
 	}
-	fmt.Printf("✅ Current project: %s\n", currentProject)
+	if err := opts.applyEnvironment(currentProject); err != nil {
+		return err
+	}
 
 	fmt.Printf("🔍 Looking for Rails applications in project: %s\n", currentProject)
 
-	selectedPod, err := internal.SetupClusterAndSelectPod(currentProject)
+	selectedPod, err := selectTargetPod(currentProject)
 	if err != nil {
 		if strings.Contains(err.Error(), "cancelled by user") {
 			fmt.Println("Cancelled.")
@@ -100,54 +71,57 @@ func runRailsConsole() error {
 	}
 
 	fmt.Printf("🚀 Connecting to Rails console in pod: %s\n", selectedPod)
-	return connectToRailsConsole(selectedPod)
+	return connectToRailsConsole(currentProject, selectedPod, opts)
 }
 
-func connectToRailsConsole(podNameWithNamespace string) error {
-	parts := strings.Split(podNameWithNamespace, "/")
-	if len(parts) != 2 {
+func connectToRailsConsole(projectID, podNameWithNamespace string, opts sessionOptions) error {
+	if !strings.Contains(podNameWithNamespace, "/") {
 		return fmt.Errorf("invalid pod format: %s", podNameWithNamespace)
 	}
 
-	namespace := parts[0]
-	podName := parts[1]
-
-	fmt.Println("🎯 Connecting to Rails console...")
-	fmt.Println("(Type 'exit' or press Ctrl+D to disconnect)")
-	fmt.Println()
-
-	// Try common Rails console commands
-	consoleCommands := []string{
-		"bundle exec rails console",
-		"bundle exec rails c",
-		"rails console",
-		"rails c",
-		"bin/rails console",
-		"bin/rails c",
+	pod, err := internal.GetPod(podNameWithNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
 	}
+	key := internal.RailsConsoleKey(projectID, pod)
 
-	for _, consoleCmd := range consoleCommands {
-		fmt.Printf("Trying: %s\n", consoleCmd)
-
-		cmd := exec.Command("kubectl", "exec", "-it", podName, "-n", namespace, "--", "sh", "-c", consoleCmd)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
-
-		err := cmd.Run()
-		if err == nil {
-			return nil
+	console, cached := internal.CachedRailsConsole(key)
+	if cached {
+		if _, ok := pod.Container(console.Container); !ok {
+			cached = false
+		}
+	}
+	if !cached {
+		fmt.Println("🔍 Looking for the Rails entrypoint...")
+		var found bool
+		console, found, err = internal.ProbeRailsConsole(podNameWithNamespace, pod)
+		if !found {
+			if err != nil {
+				fmt.Printf("⚠️  %v\n", err)
+			}
+			// Without bin/rails, bundler or rails there is no console to start, fall back to a shell
+			fmt.Println("❌ No Rails entrypoint found (bin/rails, bundle exec rails or rails), opening shell instead...")
+			return connectToShell(projectID, podNameWithNamespace, shellOptions{sessionOptions: opts})
+		}
+		if err := internal.SaveRailsConsole(key, console); err != nil {
+			fmt.Printf("⚠️  Could not remember the console command: %v\n", err)
 		}
-
-		fmt.Printf("Command failed, trying next option...\n")
 	}
 
-	// If Rails console commands fail, try a shell
-	fmt.Println("Rails console commands failed, opening shell instead...")
-	cmd := exec.Command("kubectl", "exec", "-it", podName, "-n", namespace, "--", "/bin/bash")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	fmt.Printf("🎯 Connecting to Rails console (%s in container %s)...\n", console.Command, console.Container)
+	fmt.Println("(Type 'exit' or press Ctrl+D to disconnect)")
+	fmt.Println()
 
-	return cmd.Run()
+	opts.Container = console.Container
+	opts.notFoundExit = shellNotFoundExit
+	outcome, err := runInteractiveExec(podNameWithNamespace, opts, "sh", "-c", console.Command)
+	if err != nil {
+		return err
+	}
+	if outcome == execUnavailable {
+		// The image changed since the command was found; probe again next time
+		internal.ForgetRailsConsole(key)
+		return fmt.Errorf("%s is not available in container %s, run the command again to re-detect it", console.Command, console.Container)
+	}
+	return nil
 }
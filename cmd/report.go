@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reporting commands",
+	Long:  "Commands for exporting reports about GCP environments.",
+}
+
+var reportInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Export an environment inventory",
+	Long:  "Export clusters, node pools, deployments (with images and replica counts) and Cloud SQL instances for the current environment, or every accessible environment with --all-envs.",
+	Run: func(cmd *cobra.Command, args []string) {
+		allEnvs, _ := cmd.Flags().GetBool("all-envs")
+		format, _ := cmd.Flags().GetString("output")
+		file, _ := cmd.Flags().GetString("file")
+		if err := runReportInventory(allEnvs, format, file); err != nil {
+			fmt.Printf("Error exporting inventory: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	reportInventoryCmd.Flags().Bool("all-envs", false, "Include every accessible environment")
+	reportInventoryCmd.Flags().StringP("output", "o", "csv", "Output format: csv or json")
+	reportInventoryCmd.Flags().String("file", "", "Output file (default: inventory-<timestamp>.<format>)")
+	reportCmd.AddCommand(reportInventoryCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReportInventory(allEnvs bool, format, file string) error {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unsupported output format %q (use csv or json)", format)
+	}
+
+	var projects []string
+	if allEnvs {
+		if !isAuthenticated() {
+			fmt.Println("❌ Not authenticated with Google Cloud")
+			fmt.Println("Please run 'gcpeasy login' first to authenticate.")
+			return nil
+		}
+		fmt.Println("🔍 Discovering GCP projects...")
+		gcpProjects, err := getGCPProjects()
+		if err != nil {
+			return fmt.Errorf("failed to discover projects: %w", err)
+		}
+		for _, p := range gcpProjects {
+			projects = append(projects, p.ProjectID)
+		}
+	} else {
+		currentProject := requireProject()
+		if currentProject == "" {
+			return nil
+		}
+		projects = []string{currentProject}
+	}
+
+	var inventories []*internal.EnvironmentInventory
+	for _, project := range projects {
+		fmt.Printf("🔍 Collecting inventory for %s...\n", project)
+		inv, err := internal.CollectInventory(project)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping %s: %v\n", project, err)
+			continue
+		}
+		for _, e := range inv.Errors {
+			fmt.Printf("⚠️  %s: %s\n", project, e)
+		}
+		fmt.Printf("✅ %s: %s\n", project, internal.InventoryCounts(inv))
+		inventories = append(inventories, inv)
+	}
+
+	if file == "" {
+		file = fmt.Sprintf("inventory-%s.%s", time.Now().Format("20060102-150405"), format)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	defer f.Close()
+
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(inventories)
+	} else {
+		err = internal.WriteInventoryCSV(f, inventories)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write inventory: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("📄 Inventory for %d environment(s) written to %s\n", len(inventories), file)
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/scttymn/gcpeasy/internal"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -15,15 +16,56 @@ var rootCmd = &cobra.Command{
 	Long: `gcpeasy streamlines working with Google Cloud Platform and Kubernetes infrastructure 
 by providing simple commands for common development workflows. It eliminates the need 
 to remember complex kubectl and gcloud commands and automates environment switching.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if eventsJSON {
+			internal.EnableEvents(os.Stderr)
+		}
+		if projectFlag != "" {
+			applyProjectOverride(projectFlag)
+		}
+		started := map[string]any{"command": cmd.CommandPath(), "args": args}
+		if !canRecordArgs(cmd) {
+			started["args"] = []string{}
+		}
+		internal.EmitEvent(internal.EventCommandStarted, started)
+		beginInvocation(cmd)
+	},
 }
 
+// eventsJSON enables structured JSON progress events on stderr (--events-json)
+var eventsJSON bool
+
+// projectFlag overrides the gcloud project for a single invocation (--project)
+var projectFlag string
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if err != nil {
+		finishCommand(1)
 		os.Exit(1)
 	}
+	finishCommand(0)
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&eventsJSON, "events-json", false, "Emit structured JSON progress events on stderr")
+	rootCmd.PersistentFlags().BoolVar(&skipChecks, "skip-checks", false, "Skip authentication and cluster preflight checks")
+	rootCmd.PersistentFlags().StringVar(&projectFlag, "project", "", "GCP project to use for this invocation, without changing the gcloud config")
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
-}
\ No newline at end of file
+}
+
+// finishCommand records the invocation and emits the final event
+func finishCommand(code int) {
+	recordInvocation()
+	internal.EmitEvent(internal.EventCommandFinished, map[string]any{"exit_code": code, "project": invocation.project, "pod": invocation.pod})
+	if restoreKubeconfig != nil {
+		restoreKubeconfig()
+	}
+}
+
+// exitWithCode records the invocation and exits with the given status code
+func exitWithCode(code int) {
+	finishCommand(code)
+	os.Exit(code)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Traffic routing commands",
+	Long:  "Commands for reviewing service mesh and Gateway API traffic routing.",
+}
+
+var routesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Summarize hostname → service routing",
+	Long:  "Detect Istio VirtualServices or Gateway API HTTPRoutes and summarize which hostnames and paths route to which services, including traffic-split weights.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listRoutes(); err != nil {
+			fmt.Printf("Error listing routes: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	routesCmd.AddCommand(routesListCmd)
+	rootCmd.AddCommand(routesCmd)
+}
+
+func listRoutes() error {
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Detecting routing resources...")
+
+	var routes []internal.RouteInfo
+	found := false
+
+	if internal.HasAPIResource(internal.IstioVirtualServiceResource) {
+		found = true
+		vs, err := internal.GetVirtualServices()
+		if err != nil {
+			return fmt.Errorf("failed to get VirtualServices: %w", err)
+		}
+		fmt.Printf("✅ Istio detected (%d VirtualService(s))\n", len(vs))
+		routes = append(routes, vs...)
+	}
+
+	if internal.HasAPIResource(internal.HTTPRouteResource) {
+		found = true
+		hr, err := internal.GetHTTPRoutes()
+		if err != nil {
+			return fmt.Errorf("failed to get HTTPRoutes: %w", err)
+		}
+		fmt.Printf("✅ Gateway API detected (%d HTTPRoute(s))\n", len(hr))
+		routes = append(routes, hr...)
+	}
+
+	if !found {
+		fmt.Println("❌ No Istio VirtualServices or Gateway API HTTPRoutes found in this cluster")
+		return nil
+	}
+	if len(routes) == 0 {
+		fmt.Println("No routes found in application namespaces.")
+		return nil
+	}
+
+	splits := 0
+	for _, r := range routes {
+		fmt.Println()
+		fmt.Printf("📍 %s/%s (%s)\n", r.Namespace, r.Name, r.Type)
+		fmt.Printf("   Hosts: %s\n", orDash(strings.Join(r.Hosts, ", ")))
+		if len(r.Parents) > 0 {
+			fmt.Printf("   Via:   %s\n", strings.Join(r.Parents, ", "))
+		}
+
+		for _, rule := range r.Rules {
+			var backends []string
+			for _, b := range rule.Backends {
+				if rule.Split() {
+					backends = append(backends, fmt.Sprintf("%s %.0f%%", b, b.Percent))
+				} else {
+					backends = append(backends, b.String())
+				}
+			}
+			line := fmt.Sprintf("   %-30s → %s", truncate(rule.Match, 30), orDash(strings.Join(backends, ", ")))
+			if rule.Split() {
+				splits++
+				line = internal.Colorize(internal.ColorYellow, line)
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %d route(s)", len(routes))
+	if splits > 0 {
+		fmt.Printf(", %d rule(s) splitting traffic", splits)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [deployment] -- <command> [args...]",
+	Short: "Run a one-off command in a copy of a deployment's pods",
+	Long: `Clone a deployment's pod template into a Job (same image, env, secrets, volumes and
+service account), run a command in it, stream its logs and delete the Job once the command
+exits, e.g. for migrations that shouldn't run inside pods serving traffic:
+
+  gcpeasy run web -- bin/rails db:migrate
+
+The deployment can be given as namespace/name or a name, or is chosen from the list. The
+command replaces the container's command; the pod doesn't get the deployment's selector
+labels or any label a Service in the namespace selects on, so Services don't send it
+traffic. gcpeasy exits with the command's exit code. Use --timeout 0 to let the command
+run without a time limit.
+
+Ctrl+C stops following the command but leaves the Job running, so an interrupted migration
+isn't killed halfway; gcpeasy prints how to follow its logs or delete it.
+
+Use --yes to skip the confirmation; protected environments still require typing the
+project ID.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash == -1 || dash == len(args) {
+			return fmt.Errorf("missing command: use gcpeasy run [deployment] -- <command>")
+		}
+		if dash > 1 {
+			return fmt.Errorf("expected at most one deployment before --, got %d", dash)
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		dash := cmd.ArgsLenAtDash()
+		var opts runOptions
+		if dash == 1 {
+			opts.Target = args[0]
+		}
+		opts.Command = args[dash:]
+		opts.Container, _ = cmd.Flags().GetString("container")
+		opts.Keep, _ = cmd.Flags().GetBool("keep")
+		opts.Timeout, _ = cmd.Flags().GetDuration("timeout")
+		opts.Yes, _ = cmd.Flags().GetBool("yes")
+		err := runOneOff(opts)
+		var failed *jobFailedError
+		switch {
+		case err == nil:
+		case errors.As(err, &failed):
+			exitWithCode(failed.exitCode)
+		case strings.Contains(err.Error(), "cancelled by user"):
+			fmt.Println("Cancelled.")
+		default:
+			fmt.Printf("Error running command: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.Flags().StringP("container", "c", "", "Container to run the command in (default: the first container)")
+	runCmd.Flags().Bool("keep", false, "Don't delete the job when the command exits")
+	runCmd.Flags().Duration("timeout", time.Hour, "How long the command may run (0 for no limit)")
+	runCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	rootCmd.AddCommand(runCmd)
+}
+
+type runOptions struct {
+	Target    string
+	Command   []string
+	Container string
+	Keep      bool
+	Timeout   time.Duration
+	Yes       bool
+}
+
+func runOneOff(opts runOptions) error {
+	if opts.Timeout < 0 {
+		return fmt.Errorf("--timeout can't be negative")
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	d, err := selectDeployment(opts.Target)
+	if err != nil {
+		return err
+	}
+	var container *internal.Container
+	for i, c := range d.Spec.Template.Spec.Containers {
+		if (opts.Container == "" && i == 0) || c.Name == opts.Container {
+			container = &d.Spec.Template.Spec.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return fmt.Errorf("%s has no container named %s", d.Metadata.Name, opts.Container)
+	}
+	name := d.Metadata.Namespace + "/" + d.Metadata.Name
+	command := strings.Join(opts.Command, " ")
+
+	fmt.Printf("🎯 %s, container %s (%s)\n", name, container.Name, container.Image)
+	fmt.Printf("   Command: %s\n", command)
+	fmt.Println()
+	if !confirmProtected(currentProject, "run a command from "+d.Metadata.Name) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !opts.Yes && !confirm("Run it?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return runNotified("run", name+": "+command, func() error {
+		jobName, err := internal.CreateOneOffJob(*d, container.Name, opts.Command, opts.Timeout)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🚀 Created job %s/%s\n", d.Metadata.Namespace, jobName)
+		keep := opts.Keep
+		defer func() {
+			if keep {
+				return
+			}
+			if err := internal.DeleteJob(d.Metadata.Namespace, jobName); err != nil {
+				fmt.Printf("⚠️  Failed to delete job %s: %v\n", jobName, err)
+				return
+			}
+			fmt.Printf("🧹 Deleted job %s\n", jobName)
+		}()
+
+		// Ctrl+C stops following the command but leaves the job running, as killing a
+		// migration halfway is worse than letting it finish
+		interrupted := make(chan os.Signal, 1)
+		signal.Notify(interrupted, os.Interrupt)
+		defer signal.Stop(interrupted)
+		cancelled := func() bool {
+			select {
+			case <-interrupted:
+				keep = true
+				fmt.Println()
+				fmt.Printf("⏸️  Stopped following; job %s/%s keeps running\n", d.Metadata.Namespace, jobName)
+				fmt.Printf("💡 Follow it with 'gcpeasy job logs %s -n %s -f', or stop it with 'kubectl delete job %s -n %s'\n",
+					jobName, d.Metadata.Namespace, jobName, d.Metadata.Namespace)
+				return true
+			default:
+				return false
+			}
+		}
+
+		fmt.Println("⏳ Waiting for the pod to start...")
+		pod, err := internal.WaitForJobPod(d.Metadata.Namespace, jobName, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if cancelled() {
+			return fmt.Errorf("cancelled by user")
+		}
+		fmt.Println()
+		if err := internal.StreamContainerLogs(d.Metadata.Namespace, pod, container.Name); err != nil {
+			fmt.Printf("⚠️  Failed to follow logs: %v\n", err)
+		}
+
+		// Sidecars keep the pod running, so wait for the command's container only
+		deadline := time.Now().Add(opts.Timeout)
+		for {
+			if cancelled() {
+				return fmt.Errorf("cancelled by user")
+			}
+			code, done, err := internal.ContainerExitCode(d.Metadata.Namespace, pod, container.Name)
+			if err != nil {
+				return err
+			}
+			if done {
+				fmt.Println()
+				if code == 0 {
+					fmt.Println("✅ Command succeeded")
+					return nil
+				}
+				fmt.Printf("❌ Command failed with exit code %d\n", code)
+				return &jobFailedError{exitCode: code}
+			}
+			if opts.Timeout > 0 && time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s waiting for the command to exit", opts.Timeout)
+			}
+			time.Sleep(2 * time.Second)
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var scheduleEnv string
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Scheduled environment commands",
+	Long:  "Commands for scheduling changes to non-production environments, such as scaling them down overnight.",
+}
+
+var scheduleScaleCmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Scale deployments down overnight and back up in the morning",
+	Long: `Create CronJobs in the cluster that scale the deployments in application namespaces
+to zero at the --down time and restore their replicas at the --up time, e.g.:
+
+  gcpeasy schedule scale --env staging --down 19:00 --up 07:00 --weekdays
+
+The replicas of each deployment are recorded in the gcpeasy.io/scaled-down-replicas
+annotation when it is scaled down. Label a deployment gcpeasy.io/keep-running=true to
+leave it running. Running this again updates the schedule. Protected environments can't
+be scheduled. Use --emit-iac yaml or --emit-iac terraform to print the created objects
+as manifests or Terraform resources.
+
+The CronJobs, with a service account allowed to scale deployments, live in the
+gcpeasy-system namespace and use the cluster's time zone support (Kubernetes 1.27+).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts scaleScheduleOptions
+		opts.Down, _ = cmd.Flags().GetString("down")
+		opts.Up, _ = cmd.Flags().GetString("up")
+		opts.Weekdays, _ = cmd.Flags().GetBool("weekdays")
+		opts.TimeZone, _ = cmd.Flags().GetString("time-zone")
+		opts.Image, _ = cmd.Flags().GetString("image")
+		opts.EmitIaC, _ = cmd.Flags().GetString("emit-iac")
+		if err := scheduleScale(opts); err != nil {
+			fmt.Printf("Error scheduling scaling: %v\n", err)
+		}
+	},
+}
+
+var scheduleStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the scaling schedule",
+	Long:  "Show the scaling CronJobs, when they last ran, and the deployments that are currently scaled down.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showScaleSchedule(); err != nil {
+			fmt.Printf("Error getting schedule: %v\n", err)
+		}
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the scaling schedule",
+	Long:  "Delete the scaling CronJobs and their service account and RBAC objects. Deployments that are scaled down stay that way.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removeScaleSchedule(); err != nil {
+			fmt.Printf("Error removing schedule: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	scheduleCmd.PersistentFlags().StringVar(&scheduleEnv, "env", "", "Environment to use, by project ID or a unique part of one such as \"staging\" (default: current project)")
+	scheduleScaleCmd.Flags().String("down", "", "Time of day to scale down (HH:MM, e.g. 19:00)")
+	scheduleScaleCmd.Flags().String("up", "", "Time of day to scale back up (HH:MM, e.g. 07:00)")
+	scheduleScaleCmd.Flags().Bool("weekdays", false, "Only run on weekdays, keeping the environment down over the weekend")
+	scheduleScaleCmd.Flags().String("time-zone", "", "IANA time zone of the times (default: the local time zone)")
+	scheduleScaleCmd.Flags().String("image", internal.ScheduleImage, "Image with sh and kubectl to run the CronJobs")
+	addNamespaceFlag(scheduleScaleCmd)
+	addEmitIaCFlag(scheduleScaleCmd)
+	scheduleCmd.AddCommand(scheduleScaleCmd)
+	scheduleCmd.AddCommand(scheduleStatusCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
+
+// scaleScheduleOptions are the flags of `schedule scale`
+type scaleScheduleOptions struct {
+	Down     string
+	Up       string
+	Weekdays bool
+	TimeZone string
+	Image    string
+	EmitIaC  string
+}
+
+func scheduleScale(opts scaleScheduleOptions) error {
+	if opts.Down == "" || opts.Up == "" {
+		return fmt.Errorf("--down and --up are required (e.g. --down 19:00 --up 07:00)")
+	}
+	if err := checkIaCFormat(opts.EmitIaC); err != nil {
+		return err
+	}
+	schedule := internal.ScaleSchedule{TimeZone: opts.TimeZone, Image: opts.Image}
+	var err error
+	if schedule.Down, err = internal.CronSchedule(opts.Down, opts.Weekdays); err != nil {
+		return err
+	}
+	if schedule.Up, err = internal.CronSchedule(opts.Up, opts.Weekdays); err != nil {
+		return err
+	}
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = internal.LocalTimeZone()
+	}
+
+	projectID, err := useEnvironment(scheduleEnv)
+	if err != nil {
+		return err
+	}
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Environment(projectID).Protected {
+		return fmt.Errorf("%s is a protected environment; scheduled scale-downs are for non-production environments", projectID)
+	}
+	if !setupCluster() {
+		return nil
+	}
+	schedule.Namespace = internal.NamespaceScope()
+
+	scope := "all application namespaces"
+	if schedule.Namespace != "" {
+		scope = "namespace " + schedule.Namespace
+	}
+	days := "every day"
+	if opts.Weekdays {
+		days = "Monday to Friday"
+	}
+	fmt.Println()
+	if context, err := internal.GetCurrentCluster(); err == nil {
+		fmt.Printf("🎯 Cluster: %s\n", context)
+	}
+	fmt.Printf("📋 Deployments in %s will be:\n", scope)
+	fmt.Printf("   ⏬ scaled to zero at %s (%s)\n", opts.Down, schedule.Down)
+	fmt.Printf("   ⏫ restored at %s (%s)\n", opts.Up, schedule.Up)
+	fmt.Printf("   %s, %s time\n", days, schedule.TimeZone)
+	fmt.Printf("💡 Label deployments %s=true to keep them running\n", internal.KeepRunningLabel)
+	fmt.Println()
+
+	if !confirm("Create the schedule?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("schedule scale", fmt.Sprintf("down %s, up %s (%s, %s)", opts.Down, opts.Up, days, schedule.TimeZone), func() error {
+		return internal.ApplyScaleSchedule(schedule)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Println("✅ Schedule created")
+	fmt.Println("💡 Check it with: gcpeasy schedule status")
+	emitIaC(opts.EmitIaC, func(format string) (string, error) {
+		return internal.ScaleScheduleIaC(format, schedule)
+	})
+	return nil
+}
+
+func showScaleSchedule() error {
+	if _, err := useEnvironment(scheduleEnv); err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	jobs, err := internal.GetScaleSchedule()
+	if err != nil {
+		return fmt.Errorf("failed to get CronJobs: %w", err)
+	}
+	fmt.Println()
+	if len(jobs) == 0 {
+		fmt.Println("No scaling schedule in this cluster.")
+		fmt.Println("💡 Create one with: gcpeasy schedule scale --down 19:00 --up 07:00")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-16s %-20s %-22s %-22s\n", "CRONJOB", "SCHEDULE", "TIME ZONE", "LAST RUN", "LAST SUCCESS")
+	fmt.Println(strings.Repeat("-", 104))
+	for _, j := range jobs {
+		schedule := j.Schedule
+		if j.Suspended {
+			schedule += " (suspended)"
+		}
+		fmt.Printf("%-20s %-16s %-20s %-22s %-22s\n",
+			j.Name, schedule, orDash(j.TimeZone), orDash(j.LastSchedule), orDash(j.LastSuccessful))
+	}
+	fmt.Println()
+
+	scaled, err := internal.ScaledDownDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if len(scaled) == 0 {
+		fmt.Println("✅ No deployments are scaled down")
+		return nil
+	}
+	fmt.Printf("⏬ %d deployment(s) scaled down:\n", len(scaled))
+	for _, d := range scaled {
+		fmt.Printf("   %s/%s (restores to %s)\n", d.Metadata.Namespace, d.Metadata.Name, d.Metadata.Annotations[internal.ScaledDownAnnotation])
+	}
+	return nil
+}
+
+func removeScaleSchedule() error {
+	projectID, err := useEnvironment(scheduleEnv)
+	if err != nil {
+		return err
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	scaled, err := internal.ScaledDownDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if len(scaled) > 0 {
+		fmt.Printf("⚠️  %d deployment(s) are scaled down and will stay that way. Restore them first with:\n", len(scaled))
+		fmt.Printf("   kubectl create job -n %s --from=cronjob/%s %s-now\n", internal.ScheduleNamespace, internal.ScaleUpCronJob, internal.ScaleUpCronJob)
+	}
+
+	if !confirmProtected(projectID, "remove the scaling schedule") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !confirm("Remove the scaling schedule?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = runNotified("schedule remove", "scaling schedule", internal.DeleteScaleSchedule)
+	if err != nil {
+		return err
+	}
+	fmt.Println("✅ Schedule removed")
+	return nil
+}
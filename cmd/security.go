@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var securityCmd = &cobra.Command{
+	Use:   "security",
+	Short: "Security commands",
+	Long:  "Commands for reviewing the security posture of application workloads.",
+}
+
+var securityReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report workloads with risky security settings",
+	Long: `Check the Deployments, StatefulSets, DaemonSets and CronJobs in application namespaces
+and report, grouped by namespace:
+
+  high    privileged containers, containers running as UID 0
+  medium  containers that may run as root (no runAsNonRoot or runAsUser), containers
+          without a memory limit, images using :latest or no tag
+  low     pods mounting the default service account's token
+
+Use --severity to only show findings at or above a level.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		severity, _ := cmd.Flags().GetString("severity")
+		output, _ := cmd.Flags().GetString("output")
+		if err := securityReport(severity, output); err != nil {
+			fmt.Printf("Error creating security report: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	securityReportCmd.Flags().String("severity", internal.SeverityLow, "Minimum severity to show: high, medium or low")
+	securityReportCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	addNamespaceFlag(securityCmd)
+	securityCmd.AddCommand(securityReportCmd)
+	rootCmd.AddCommand(securityCmd)
+}
+
+func securityReport(minSeverity, output string) error {
+	minSeverity = strings.ToLower(minSeverity)
+	if !internal.ValidSeverity(minSeverity) {
+		return fmt.Errorf("unknown severity %q (use high, medium or low)", minSeverity)
+	}
+	output = strings.ToLower(output)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unsupported output format %q (use text or json)", output)
+	}
+
+	out := os.Stdout
+	if output == "json" {
+		var restore func()
+		out, restore = machineOutput()
+		defer restore()
+	}
+	if !setupCluster() {
+		return nil
+	}
+
+	fmt.Println("🔍 Checking workload security settings...")
+	all, err := internal.ScanWorkloadSecurity()
+	if err != nil {
+		return fmt.Errorf("failed to get workloads: %w", err)
+	}
+	findings := []internal.SecurityFinding{}
+	for _, f := range all {
+		if internal.SeverityAtLeast(f.Severity, minSeverity) {
+			findings = append(findings, f)
+		}
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Println()
+	if len(findings) == 0 {
+		fmt.Printf("✅ No findings at %s severity or above\n", minSeverity)
+		return nil
+	}
+
+	counts := make(map[string]int)
+	namespace, workload := "", ""
+	for _, f := range findings {
+		counts[f.Severity]++
+		if f.Namespace != namespace {
+			if namespace != "" {
+				fmt.Println()
+			}
+			namespace, workload = f.Namespace, ""
+			fmt.Printf("📁 %s\n", namespace)
+		}
+		if f.Workload != workload {
+			workload = f.Workload
+			fmt.Printf("  %s\n", workload)
+		}
+		subject := "pod"
+		if f.Container != "" {
+			subject = "container " + f.Container
+		}
+		fmt.Printf("    %s %s %s\n", severityLabel(f.Severity), subject, f.Message)
+	}
+
+	fmt.Println()
+	fmt.Printf("📋 %d finding(s): %d high, %d medium, %d low\n", len(findings),
+		counts[internal.SeverityHigh], counts[internal.SeverityMedium], counts[internal.SeverityLow])
+	return nil
+}
+
+// severityLabel returns the padded, colored label of a severity
+func severityLabel(severity string) string {
+	label := fmt.Sprintf("%-6s", strings.ToUpper(severity))
+	switch severity {
+	case internal.SeverityHigh:
+		return internal.Colorize(internal.ColorRed, label)
+	case internal.SeverityMedium:
+		return internal.Colorize(internal.ColorYellow, label)
+	default:
+		return internal.Colorize(internal.ColorGray, label)
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// execOutcome is how an interactive exec session ended
+type execOutcome int
+
+const (
+	// execCompleted means the session ran and the remote command exited successfully
+	execCompleted execOutcome = iota
+	// execFailed means the session ran but the remote command exited non-zero
+	execFailed
+	// execUnavailable means the command does not exist in the container
+	execUnavailable
+)
+
+const (
+	// maxReconnects bounds consecutive reconnects of sessions that drop again quickly
+	maxReconnects = 3
+	// stableSession is how long a session must last before its reconnect budget is reset
+	stableSession = time.Minute
+)
+
+// Substrings of kubectl errors that mean the connection, not the remote command, failed
+var droppedSessionErrors = []string{
+	"connection reset by peer",
+	"use of closed network connection",
+	"broken pipe",
+	"i/o timeout",
+	"unexpected EOF",
+	"error dialing backend",
+	"unable to upgrade connection",
+	"websocket: close",
+	"stream error",
+	"TLS handshake timeout",
+	"connection refused",
+	"http2: client connection lost",
+}
+
+// Substrings of kubectl errors that mean credentials must be refreshed
+var authSessionErrors = []string{
+	"Unauthorized",
+	"You must be logged in",
+	"token has expired",
+	"invalid_grant",
+	"Reauthentication",
+	"failed to get token",
+}
+
+// Substrings of the container runtime's exec error that mean the requested command is
+// missing from the container. Exit codes 126 and 127 aren't used, as a shell also ends
+// with them when the last command typed in it wasn't found.
+var unavailableCommandErrors = []string{
+	"executable file not found",
+	"no such file or directory",
+}
+
+// commandUnavailable reports whether kubectl's stderr holds the runtime's exec error for a
+// missing command, e.g. `OCI runtime exec failed: exec failed: ... exec: "bash":
+// executable file not found in $PATH`
+func commandUnavailable(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "exec failed") && containsAny(line, unavailableCommandErrors) {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionOptions customizes how an interactive exec session is started
+type sessionOptions struct {
+	// Container to exec into; empty uses the pod's default container
+	Container string
+	// User to switch to inside the container, via su or sudo
+	User string
+	// Workdir is the directory to start in
+	Workdir string
+	// Env holds KEY=VALUE variables set for the session; later entries win
+	Env []string
+	// limits time-box the session in protected environments
+	limits sessionLimits
+	// notFoundExit is an exit status of the remote command that means it doesn't exist,
+	// e.g. shellNotFoundExit for a command run through sh -c; zero for none
+	notFoundExit int
+}
+
+// shellNotFoundExit is the status sh exits with when the command it runs isn't found. The
+// "not found" message goes to the session's terminal, not kubectl's stderr, so the status
+// is all there is to go on.
+const shellNotFoundExit = 127
+
+// envVarName matches a valid environment variable name
+var envVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func addSessionEnvFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("env", nil, "Set an environment variable for the session as KEY=VALUE (repeatable)")
+}
+
+// applyEnvironment validates the --env variables and applies the environment's settings:
+// its configured session_env goes before the flags, so they win, and protected
+// environments get their session limits
+func (o *sessionOptions) applyEnvironment(projectID string) error {
+	for _, kv := range o.Env {
+		name, _, ok := strings.Cut(kv, "=")
+		if !ok || !envVarName.MatchString(name) {
+			return fmt.Errorf("invalid --env %q, expected KEY=VALUE", kv)
+		}
+	}
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		return nil
+	}
+	environment := cfg.Environment(projectID)
+	if environment.Protected {
+		if o.limits, err = parseSessionLimits(environment.SessionLimits); err != nil {
+			return err
+		}
+	}
+
+	configured := environment.SessionEnv
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		if !envVarName.MatchString(name) {
+			fmt.Printf("⚠️  Ignoring invalid session_env variable %q\n", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := make([]string, 0, len(names)+len(o.Env))
+	for _, name := range names {
+		env = append(env, name+"="+configured[name])
+	}
+	o.Env = append(env, o.Env...)
+	return nil
+}
+
+// wrap returns command adjusted for the session's user, working directory and
+// environment, run through sh when any of them is set
+func (o sessionOptions) wrap(command []string) []string {
+	if o.User == "" && o.Workdir == "" && len(o.Env) == 0 {
+		return command
+	}
+
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = shellQuote(arg)
+	}
+	// Variables are exported after switching user, as sudo resets the environment
+	run := "exec " + strings.Join(quoted, " ")
+	if len(o.Env) > 0 {
+		exports := make([]string, len(o.Env))
+		for i, kv := range o.Env {
+			name, value, _ := strings.Cut(kv, "=")
+			exports[i] = name + "=" + shellQuote(value)
+		}
+		run = "export " + strings.Join(exports, " ") + "; " + run
+	}
+
+	if o.User != "" {
+		user := shellQuote(o.User)
+		run = fmt.Sprintf(`if [ "$(id -un 2>/dev/null)" = %[1]s ]; then %[2]s; `+
+			`elif [ "$(id -u)" = 0 ]; then exec su %[1]s -s /bin/sh -c %[3]s; `+
+			`elif command -v sudo >/dev/null 2>&1; then exec sudo -u %[1]s sh -c %[3]s; `+
+			`else echo "gcpeasy: cannot switch to user %[4]s: not running as root and sudo is not available" >&2; exit 1; fi`,
+			user, run, shellQuote(run), strings.ReplaceAll(o.User, `"`, ""))
+	}
+	if o.Workdir != "" {
+		run = fmt.Sprintf("cd %s || exit 1; %s", shellQuote(o.Workdir), run)
+	}
+	return []string{"sh", "-c", run}
+}
+
+// stderrTail passes kubectl's stderr through to the terminal while keeping the last few
+// KB so the cause of a failed session can be classified afterwards
+type stderrTail struct {
+	buf []byte
+}
+
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > 4096 {
+		t.buf = t.buf[len(t.buf)-4096:]
+	}
+	return os.Stderr.Write(p)
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// runInteractiveExec runs command in a pod with a TTY attached. If the connection drops
+// (network blip, expired credentials) it re-authenticates when needed and offers to
+// reattach a new session to the same pod, or to a replacement from the same workload.
+func runInteractiveExec(pod string, opts sessionOptions, command ...string) (execOutcome, error) {
+	// The maximum duration covers reconnects, so dropping the connection doesn't reset it
+	var deadline time.Time
+	if opts.limits.MaxDuration > 0 {
+		deadline = time.Now().Add(opts.limits.MaxDuration)
+	}
+	if opts.limits.enabled() {
+		fmt.Printf("⏱️  Protected environment: %s\n", opts.limits)
+	}
+
+	reconnects := 0
+	for {
+		namespace, podName, ok := strings.Cut(pod, "/")
+		if !ok {
+			return execCompleted, fmt.Errorf("invalid pod format: %s", pod)
+		}
+
+		args := []string{"exec", "-it", podName, "-n", namespace}
+		if opts.Container != "" {
+			args = append(args, "-c", opts.Container)
+		}
+		args = append(append(args, "--"), opts.wrap(command)...)
+
+		tail := &stderrTail{}
+		cmd := exec.Command("kubectl", args...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = tail
+		cmd.Stdin = os.Stdin
+
+		started := time.Now()
+		internal.EmitEvent(internal.EventExecStarted, map[string]any{"pod": pod, "command": command})
+		err := cmd.Start()
+		if err == nil {
+			var watch *sessionWatch
+			if opts.limits.enabled() {
+				watch = watchSession(cmd, opts.limits, deadline)
+			}
+			err = cmd.Wait()
+			if watch != nil {
+				if reason := watch.stop(); reason != "" {
+					internal.EmitEvent(internal.EventExecFinished, map[string]any{"pod": pod, "command": command, "terminated": reason})
+					fmt.Println()
+					fmt.Printf("⏱️  Session ended: %s\n", reason)
+					return execCompleted, nil
+				}
+			}
+		}
+		exitCode := 0
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		}
+		internal.EmitEvent(internal.EventExecFinished, map[string]any{"pod": pod, "command": command, "exit_code": exitCode})
+		if err == nil {
+			return execCompleted, nil
+		}
+		if opts.notFoundExit != 0 && exitCode == opts.notFoundExit {
+			return execUnavailable, nil
+		}
+
+		output := string(tail.buf)
+		authFailed := containsAny(output, authSessionErrors)
+		switch {
+		case authFailed || containsAny(output, droppedSessionErrors):
+		case commandUnavailable(output):
+			return execUnavailable, nil
+		default:
+			if _, ok := err.(*exec.ExitError); ok {
+				// The remote command exited non-zero; the session itself was fine
+				return execFailed, nil
+			}
+			return execCompleted, err
+		}
+
+		if time.Since(started) > stableSession {
+			reconnects = 0
+		}
+		reconnects++
+		fmt.Println()
+		fmt.Printf("🔌 Connection to %s was lost\n", pod)
+		if reconnects > maxReconnects {
+			return execCompleted, fmt.Errorf("session dropped %d times in a row, giving up", maxReconnects)
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			fmt.Printf("⏱️  Not reconnecting: maximum session duration of %s reached\n", opts.limits.MaxDuration)
+			return execCompleted, nil
+		}
+
+		if authFailed {
+			if err := refreshCredentials(); err != nil {
+				return execCompleted, err
+			}
+		}
+
+		pod, err = reattachTarget(pod)
+		if err != nil {
+			return execCompleted, err
+		}
+		if pod == "" || !confirmDefaultYes(fmt.Sprintf("Reconnect to %s?", pod)) {
+			return execCompleted, nil
+		}
+		recordPodTarget(pod)
+		fmt.Printf("🔄 Reconnecting to %s...\n", pod)
+	}
+}
+
+// refreshCredentials re-runs gcloud login when the current credentials no longer work
+func refreshCredentials() error {
+	if _, err := internal.AccessToken(); err == nil {
+		return nil
+	}
+	fmt.Println("🔐 Credentials have expired, re-authenticating...")
+	return runLogin()
+}
+
+// reattachTarget returns the pod to reconnect to: the same pod while it is still running,
+// otherwise a running pod from the same workload. It returns "" if neither exists.
+func reattachTarget(pod string) (string, error) {
+	namespace, podName, _ := strings.Cut(pod, "/")
+	phase, err := exec.Command("kubectl", "get", "pod", podName, "-n", namespace, "-o", "jsonpath={.status.phase}").Output()
+	if err == nil && strings.TrimSpace(string(phase)) == "Running" {
+		return pod, nil
+	}
+
+	pods, err := internal.FindApplicationPods()
+	if err != nil {
+		return "", fmt.Errorf("failed to find application pods: %w", err)
+	}
+	replacement, ok := internal.ResolvePod(pods, pod)
+	if !ok {
+		fmt.Printf("❌ Pod %s is gone and no replacement was found\n", pod)
+		return "", nil
+	}
+	fmt.Printf("⚠️  Pod %s is gone, %s from the same workload is available\n", pod, replacement)
+	return replacement, nil
+}
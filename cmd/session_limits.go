@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultSessionWarning is how long before a limit ends a session the user is warned
+	defaultSessionWarning = time.Minute
+	// sessionStopGrace is how long kubectl gets to restore the terminal before it is killed
+	sessionStopGrace = 5 * time.Second
+)
+
+// sessionLimits time-box an interactive session
+type sessionLimits struct {
+	MaxDuration time.Duration
+	IdleTimeout time.Duration
+	WarnBefore  time.Duration
+}
+
+func (l sessionLimits) enabled() bool {
+	return l.MaxDuration > 0 || l.IdleTimeout > 0
+}
+
+func (l sessionLimits) String() string {
+	switch {
+	case l.MaxDuration > 0 && l.IdleTimeout > 0:
+		return fmt.Sprintf("sessions end after %s, or after %s idle", l.MaxDuration, l.IdleTimeout)
+	case l.MaxDuration > 0:
+		return fmt.Sprintf("sessions end after %s", l.MaxDuration)
+	default:
+		return fmt.Sprintf("sessions end after %s idle", l.IdleTimeout)
+	}
+}
+
+// parseSessionLimits reads an environment's session limits. Invalid values are errors
+// rather than ignored, so a typo can't silently lift an access policy.
+func parseSessionLimits(cfg internal.SessionLimitConfig) (sessionLimits, error) {
+	limits := sessionLimits{WarnBefore: defaultSessionWarning}
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"max_duration", cfg.MaxDuration, &limits.MaxDuration},
+		{"idle_timeout", cfg.IdleTimeout, &limits.IdleTimeout},
+		{"warn_before", cfg.WarnBefore, &limits.WarnBefore},
+	} {
+		if field.value == "" {
+			continue
+		}
+		d, err := internal.ParseDuration(field.value)
+		if err != nil {
+			return sessionLimits{}, fmt.Errorf("invalid session_limits.%s: %w", field.name, err)
+		}
+		*field.dest = d
+	}
+	return limits, nil
+}
+
+// warningFor returns how long before a limit to warn, leaving at least half of it usable
+func (l sessionLimits) warningFor(limit time.Duration) time.Duration {
+	if l.WarnBefore > limit/2 {
+		return limit / 2
+	}
+	return l.WarnBefore
+}
+
+// sessionWatch enforces session limits on a running kubectl exec, warning on the
+// terminal before stopping it. Idleness is measured from the terminal's modification
+// time, which is updated whenever the session writes to it, including typed input being
+// echoed back.
+type sessionWatch struct {
+	cmd      *exec.Cmd
+	limits   sessionLimits
+	deadline time.Time
+
+	lastActivity time.Time
+	noticeAt     time.Time
+	warnedEnd    bool
+	warnedIdle   bool
+
+	mu     sync.Mutex
+	reason string
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// watchSession starts enforcing limits on cmd, which must already be started. deadline is
+// when the maximum session duration runs out, zero for none.
+func watchSession(cmd *exec.Cmd, limits sessionLimits, deadline time.Time) *sessionWatch {
+	w := &sessionWatch{
+		cmd:          cmd,
+		limits:       limits,
+		deadline:     deadline,
+		lastActivity: time.Now(),
+		done:         make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+// stop ends the watch and returns why the session was terminated, or "" if it wasn't
+func (w *sessionWatch) stop() string {
+	close(w.done)
+	w.wg.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reason
+}
+
+func (w *sessionWatch) run() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	trackIdle := w.limits.IdleTimeout > 0 && internal.StdoutIsTerminal()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case now := <-ticker.C:
+			if !w.deadline.IsZero() {
+				remaining := w.deadline.Sub(now)
+				if remaining <= 0 {
+					w.terminate(fmt.Sprintf("maximum session duration of %s reached", w.limits.MaxDuration))
+					return
+				}
+				if !w.warnedEnd && remaining <= w.limits.warningFor(w.limits.MaxDuration) {
+					w.warnedEnd = true
+					w.notice(fmt.Sprintf("⚠️  This session ends in %s (maximum session duration %s)", remaining.Round(time.Second), w.limits.MaxDuration))
+				}
+			}
+
+			if trackIdle {
+				w.updateActivity()
+				idle := now.Sub(w.lastActivity)
+				warnAt := w.limits.IdleTimeout - w.limits.warningFor(w.limits.IdleTimeout)
+				switch {
+				case idle >= w.limits.IdleTimeout:
+					w.terminate(fmt.Sprintf("idle for %s", w.limits.IdleTimeout))
+					return
+				case idle >= warnAt && !w.warnedIdle:
+					w.warnedIdle = true
+					w.notice(fmt.Sprintf("⚠️  Session idle, disconnecting in %s without activity", (w.limits.IdleTimeout - idle).Round(time.Second)))
+				case idle < warnAt:
+					w.warnedIdle = false
+				}
+			}
+		}
+	}
+}
+
+// updateActivity picks up terminal writes that weren't our own notices
+func (w *sessionWatch) updateActivity() {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return
+	}
+	modified := info.ModTime()
+	if !modified.After(w.lastActivity) {
+		return
+	}
+	if !w.noticeAt.IsZero() && !modified.Before(w.noticeAt) && modified.Sub(w.noticeAt) < time.Second {
+		return
+	}
+	w.lastActivity = modified
+}
+
+// notice writes a message over the raw-mode terminal of the running session
+func (w *sessionWatch) notice(message string) {
+	w.noticeAt = time.Now()
+	fmt.Fprintf(os.Stderr, "\r\n%s\r\n", message)
+}
+
+// terminate stops kubectl with SIGTERM so it restores the terminal, killing it if it
+// doesn't exit in time
+func (w *sessionWatch) terminate(reason string) {
+	w.mu.Lock()
+	w.reason = reason
+	w.mu.Unlock()
+
+	w.notice(fmt.Sprintf("⏱️  Ending session: %s", reason))
+	if err := w.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		w.cmd.Process.Kill()
+		return
+	}
+	select {
+	case <-w.done:
+	case <-time.After(sessionStopGrace):
+		w.cmd.Process.Kill()
+	}
+}
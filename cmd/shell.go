@@ -11,12 +11,16 @@ var shellCmd = &cobra.Command{
 	Short: "Open shell on selected pod (shortcut for 'pod shell')",
 	Long:  "Connect to a shell on a selected application pod. This is a shortcut for 'gcpeasy pod shell'.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := runPodShell(); err != nil {
+		if err := runPodShell(shellOptionsFromFlags(cmd)); err != nil {
 			fmt.Printf("Error accessing shell: %v\n", err)
 		}
 	},
 }
 
 func init() {
+	addShellFlags(shellCmd)
+	addNamespaceFlag(shellCmd)
+	addPodTargetFlags(shellCmd)
+	addSelectorFlag(shellCmd)
 	rootCmd.AddCommand(shellCmd)
 }
\ No newline at end of file
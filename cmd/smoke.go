@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// errSmokeFailed is returned when smoke checks ran and at least one failed
+var errSmokeFailed = errors.New("smoke checks failed")
+
+var smokeCmd = &cobra.Command{
+	Use:   "smoke",
+	Short: "Synthetic HTTP check commands",
+	Long: `Run synthetic HTTP checks configured for an environment, e.g. after a deploy:
+
+  environments:
+    my-project-staging:
+      smoke:
+        urls: [https://staging.example.com]
+        timeout: 10s
+        checks:
+          - name: health
+            path: /healthz
+            body: ok
+          - name: login page
+            path: /login
+            status: 200
+          - name: api
+            method: POST
+            path: /api/search
+            headers: {Authorization: "Bearer ${SMOKE_TOKEN}"}
+            data: '{"q":"test"}'
+
+Each check runs against every URL. Without status, any 2xx passes.`,
+}
+
+var smokeRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the environment's smoke checks",
+	Long: `Send each configured check to each of the environment's URLs and report the results.
+Exits with status 1 when a check fails, so it can gate a deploy pipeline. Use --retries to
+give a rollout time to settle before failing.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts smokeOptions
+		opts.Env, _ = cmd.Flags().GetString("env")
+		opts.URLs, _ = cmd.Flags().GetStringArray("url")
+		opts.Retries, _ = cmd.Flags().GetInt("retries")
+		opts.RetryDelay, _ = cmd.Flags().GetDuration("retry-delay")
+		if err := runSmoke(opts); err != nil {
+			if !errors.Is(err, errSmokeFailed) {
+				fmt.Printf("Error running smoke checks: %v\n", err)
+			}
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	smokeRunCmd.Flags().String("env", "", "Environment to check, by project ID or a unique part of one such as \"staging\" (default: current project)")
+	addSmokeFlags(smokeRunCmd)
+	smokeCmd.AddCommand(smokeRunCmd)
+	rootCmd.AddCommand(smokeCmd)
+}
+
+// addSmokeFlags registers the flags that tune how smoke checks run
+func addSmokeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("url", nil, "URL to check instead of the configured ones (repeatable)")
+	cmd.Flags().Int("retries", 0, "Retry failed checks this many times")
+	cmd.Flags().Duration("retry-delay", 10*time.Second, "How long to wait between retries")
+}
+
+type smokeOptions struct {
+	Env        string
+	URLs       []string
+	Retries    int
+	RetryDelay time.Duration
+}
+
+func runSmoke(opts smokeOptions) error {
+	projectID, err := useEnvironment(opts.Env)
+	if err != nil {
+		return err
+	}
+	if projectID == "" {
+		return fmt.Errorf("no project selected, use --env or 'gcpeasy env select'")
+	}
+	return runSmokeChecks(projectID, opts)
+}
+
+// runSmokeChecks runs the environment's checks, retrying failed ones, and returns
+// errSmokeFailed when any still fail
+func runSmokeChecks(projectID string, opts smokeOptions) error {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+	smoke := cfg.Environment(projectID).Smoke
+	if len(smoke.Checks) == 0 {
+		path, _ := internal.ConfigPath()
+		return fmt.Errorf("no smoke checks configured for %s; set environments.%s.smoke.checks in %s", projectID, projectID, path)
+	}
+	urls := opts.URLs
+	if len(urls) == 0 {
+		urls = smoke.URLs
+	}
+	if len(urls) == 0 {
+		path, _ := internal.ConfigPath()
+		return fmt.Errorf("no URLs to check for %s; set environments.%s.smoke.urls in %s or use --url", projectID, projectID, path)
+	}
+	client, err := internal.SmokeClient(smoke)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Running %d smoke check(s) against %s\n", len(smoke.Checks)*len(urls), strings.Join(urls, ", "))
+	type pending struct {
+		url   string
+		check internal.SmokeCheck
+	}
+	var checks []pending
+	for _, url := range urls {
+		for _, c := range smoke.Checks {
+			checks = append(checks, pending{url, c})
+		}
+	}
+
+	passed := 0
+	for attempt := 0; ; attempt++ {
+		fmt.Println()
+		var failed []pending
+		for _, p := range checks {
+			result := internal.RunSmokeCheck(client, p.url, p.check)
+			printSmokeResult(result)
+			if result.Err != nil {
+				failed = append(failed, p)
+			} else {
+				passed++
+			}
+		}
+		if len(failed) == 0 || attempt == opts.Retries {
+			fmt.Println()
+			if len(failed) > 0 {
+				fmt.Printf("❌ %d of %d smoke check(s) failed\n", len(failed), passed+len(failed))
+				return errSmokeFailed
+			}
+			fmt.Printf("✅ All %d smoke check(s) passed\n", passed)
+			return nil
+		}
+		fmt.Println()
+		fmt.Printf("🔄 %d check(s) failed, retrying in %s (%d of %d)...\n", len(failed), opts.RetryDelay, attempt+1, opts.Retries)
+		time.Sleep(opts.RetryDelay)
+		checks = failed
+	}
+}
+
+func printSmokeResult(r internal.SmokeResult) {
+	status := "-"
+	if r.Status != 0 {
+		status = fmt.Sprintf("%d", r.Status)
+	}
+	duration := r.Duration.Round(time.Millisecond)
+	if r.Err != nil {
+		fmt.Printf("❌ %-30s %-4s %8s  %s: %v\n", truncate(r.Check.Label(), 30), status, duration, r.URL, r.Err)
+		return
+	}
+	fmt.Printf("✅ %-30s %-4s %8s  %s\n", truncate(r.Check.Label(), 30), status, duration, r.URL)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Environment snapshot commands",
+	Long:  "Commands for exporting and comparing snapshots of the live manifests in the current cluster.",
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Export live manifests to a snapshot",
+	Long: `Export Deployments, Services, ConfigMaps, Ingresses and Secrets (values redacted) from
+application namespaces into a timestamped snapshot directory. Use --to to choose the parent
+directory or a gs:// path to upload the snapshot to Cloud Storage.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		to, _ := cmd.Flags().GetString("to")
+		if err := runSnapshotCreate(to); err != nil {
+			fmt.Printf("Error creating snapshot: %v\n", err)
+		}
+	},
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <snapshot-a> <snapshot-b>",
+	Short: "Compare two snapshots",
+	Long:  "Compare two snapshots (local directories or gs:// paths) and print added, removed and changed objects with field-level differences.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runSnapshotDiff(args[0], args[1]); err != nil {
+			fmt.Printf("Error comparing snapshots: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	snapshotCreateCmd.Flags().String("to", "snapshots", "Parent directory or gs:// path for the snapshot")
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+func runSnapshotCreate(to string) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if err := ensureCluster(currentProject); err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return fmt.Errorf("failed to setup cluster: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s", currentProject, time.Now().Format("20060102-150405"))
+	upload := strings.HasPrefix(to, "gs://")
+
+	parent := to
+	if upload {
+		tmp, err := os.MkdirTemp("", "gcpeasy-snapshot-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		parent = tmp
+	}
+	dir := filepath.Join(parent, name)
+
+	fmt.Println("📸 Exporting live manifests...")
+	count, err := internal.CreateSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Exported %d object(s)\n", count)
+
+	if upload {
+		dest := strings.TrimSuffix(to, "/") + "/"
+		fmt.Printf("☁️  Uploading snapshot to %s...\n", dest)
+		cmd := exec.Command("gcloud", "storage", "cp", "-r", dir, dest)
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to upload snapshot: %w", err)
+		}
+		fmt.Printf("📄 Snapshot written to %s%s\n", dest, name)
+		return nil
+	}
+
+	fmt.Printf("📄 Snapshot written to %s\n", dir)
+	return nil
+}
+
+func runSnapshotDiff(a, b string) error {
+	dirA, cleanupA, err := fetchSnapshot(a)
+	if err != nil {
+		return err
+	}
+	defer cleanupA()
+
+	dirB, cleanupB, err := fetchSnapshot(b)
+	if err != nil {
+		return err
+	}
+	defer cleanupB()
+
+	snapA, err := internal.LoadSnapshot(dirA)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", a, err)
+	}
+	snapB, err := internal.LoadSnapshot(dirB)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", b, err)
+	}
+
+	fmt.Printf("📸 Comparing %s → %s\n", a, b)
+	fmt.Println()
+
+	diff := internal.DiffSnapshots(snapA, snapB)
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Modified) == 0 {
+		fmt.Println("✅ Snapshots are identical")
+		return nil
+	}
+
+	for _, key := range diff.Added {
+		fmt.Println(internal.Colorize(internal.ColorGreen, "+ "+key+" (added)"))
+	}
+	for _, key := range diff.Removed {
+		fmt.Println(internal.Colorize(internal.ColorRed, "- "+key+" (removed)"))
+	}
+	for _, key := range sortedKeys(diff.Modified) {
+		fmt.Println(internal.Colorize(internal.ColorYellow, "~ "+key))
+		internal.PrintFieldChanges(diff.Modified[key], "    ")
+	}
+
+	fmt.Println()
+	fmt.Printf("📋 %d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Modified))
+	return nil
+}
+
+// fetchSnapshot returns a local directory for the snapshot, downloading gs:// paths first
+func fetchSnapshot(location string) (string, func(), error) {
+	if !strings.HasPrefix(location, "gs://") {
+		if _, err := os.Stat(location); err != nil {
+			return "", nil, fmt.Errorf("snapshot not found: %s", location)
+		}
+		return location, func() {}, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "gcpeasy-snapshot-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(tmp) }
+
+	fmt.Printf("☁️  Downloading %s...\n", location)
+	cmd := exec.Command("gcloud", "storage", "cp", "-r", strings.TrimSuffix(location, "/"), tmp)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+
+	return filepath.Join(tmp, filepath.Base(strings.TrimSuffix(location, "/"))), cleanup, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
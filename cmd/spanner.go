@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var spannerCmd = &cobra.Command{
+	Use:   "spanner",
+	Short: "Cloud Spanner commands",
+	Long:  "Commands for querying Cloud Spanner databases in the current project.",
+}
+
+var spannerQueryCmd = &cobra.Command{
+	Use:   "query <sql>",
+	Short: "Run a SQL statement against a Spanner database",
+	Long: `Run a SQL statement against a Cloud Spanner database and print the result:
+
+  gcpeasy spanner query 'SELECT * FROM Singers LIMIT 10'
+  gcpeasy spanner query 'SELECT COUNT(*) FROM Orders' --instance main --database orders -o json
+
+The instance and database are chosen from the project's when not given, or used directly
+when there is only one. Statements that change data (anything that doesn't start with
+SELECT, WITH, SHOW, EXPLAIN or DESCRIBE) ask for confirmation first, and protected
+environments require typing the project ID.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, _ := cmd.Flags().GetString("instance")
+		database, _ := cmd.Flags().GetString("database")
+		output, _ := cmd.Flags().GetString("output")
+		yes, _ := cmd.Flags().GetBool("yes")
+		if output != "table" && output != "json" {
+			fmt.Printf("Error: unknown output format %q (use table or json)\n", output)
+			exitWithCode(2)
+		}
+		if err := spannerQuery(args[0], instance, database, output == "json", yes); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error running query: %v\n", err)
+			exitWithCode(1)
+		}
+	},
+}
+
+func init() {
+	spannerQueryCmd.Flags().String("instance", "", "Spanner instance (default: chosen from the project's instances)")
+	spannerQueryCmd.Flags().String("database", "", "Database (default: chosen from the instance's databases)")
+	spannerQueryCmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	spannerQueryCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	spannerCmd.AddCommand(spannerQueryCmd)
+	rootCmd.AddCommand(spannerCmd)
+}
+
+func spannerQuery(sql, instance, database string, asJSON, skipConfirm bool) error {
+	out := os.Stdout
+	if asJSON {
+		var restore func()
+		out, restore = machineOutput()
+		defer restore()
+	}
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if instance == "" {
+		instances, err := internal.GetSpannerInstances(currentProject)
+		if err != nil {
+			return fmt.Errorf("failed to list Spanner instances: %w", err)
+		}
+		if len(instances) == 1 {
+			instance = instances[0].ID()
+		} else {
+			selected, err := internal.SelectSpannerInstance(instances)
+			if err != nil {
+				return err
+			}
+			instance = selected.ID()
+		}
+	}
+	if database == "" {
+		databases, err := internal.GetSpannerDatabases(currentProject, instance)
+		if err != nil {
+			return fmt.Errorf("failed to list databases of %s: %w", instance, err)
+		}
+		if len(databases) == 1 {
+			database = databases[0].ID()
+		} else {
+			selected, err := internal.SelectSpannerDatabase(databases)
+			if err != nil {
+				return err
+			}
+			database = selected.ID()
+		}
+	}
+
+	if internal.IsReadOnlySQL(sql) {
+		fmt.Printf("🔍 Querying %s/%s\n", instance, database)
+		fmt.Println()
+		return internal.SpannerQuery(currentProject, instance, database, sql, asJSON, out)
+	}
+
+	fmt.Printf("⚠️  This statement may change data in %s/%s:\n", instance, database)
+	fmt.Printf("   %s\n", sql)
+	fmt.Println()
+	if !confirmProtected(currentProject, "modify "+database) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if !skipConfirm && !confirm("Run it?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	return runNotified("spanner query", instance+"/"+database, func() error {
+		return internal.SpannerQuery(currentProject, instance, database, sql, asJSON, out)
+	})
+}
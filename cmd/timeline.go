@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show a chronological timeline of what happened in the environment",
+	Long: `Merge what happened in the current environment into a single chronological timeline,
+e.g. for a postmortem:
+
+  deploy   deployment rollouts (from the ReplicaSets kept in revision history)
+  restart  the last container restart of each pod, with its reason and exit code
+  node     node events such as NodeNotReady, Rebooted or preemptions
+  audit    Admin Activity audit log entries by people and service accounts
+  alert    Cloud Monitoring alerts firing and resolving
+
+Use --source to choose the sources. -n limits deploys and restarts to a namespace; the
+other sources cover the whole cluster or project.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var opts timelineOptions
+		opts.Since, _ = cmd.Flags().GetString("since")
+		opts.Sources, _ = cmd.Flags().GetStringSlice("source")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		opts.Output, _ = cmd.Flags().GetString("output")
+		if err := showTimeline(opts); err != nil {
+			fmt.Printf("Error building timeline: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	timelineCmd.Flags().String("since", "6h", "How far back to look (e.g. 30m, 6h, 2d)")
+	timelineCmd.Flags().StringSlice("source", internal.TimelineSources, "Sources to include: "+strings.Join(internal.TimelineSources, ", "))
+	timelineCmd.Flags().Int("limit", 1000, "Maximum number of audit log entries to read")
+	timelineCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	addNamespaceFlag(timelineCmd)
+	rootCmd.AddCommand(timelineCmd)
+}
+
+type timelineOptions struct {
+	Since   string
+	Sources []string
+	Limit   int
+	Output  string
+}
+
+var timelineColors = map[string]string{
+	internal.TimelineDeploy:  internal.ColorGreen,
+	internal.TimelineRestart: internal.ColorRed,
+	internal.TimelineNode:    internal.ColorYellow,
+	internal.TimelineAudit:   internal.ColorBlue,
+	internal.TimelineAlert:   internal.ColorMagenta,
+}
+
+func showTimeline(opts timelineOptions) error {
+	since, err := internal.ParseDuration(opts.Since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	for _, source := range opts.Sources {
+		if !slices.Contains(internal.TimelineSources, source) {
+			return fmt.Errorf("unknown source %q (use %s)", source, strings.Join(internal.TimelineSources, ", "))
+		}
+	}
+	opts.Output = strings.ToLower(opts.Output)
+	if opts.Output != "text" && opts.Output != "json" {
+		return fmt.Errorf("unsupported output format %q (use text or json)", opts.Output)
+	}
+	out := os.Stdout
+	if opts.Output == "json" {
+		var restore func()
+		out, restore = machineOutput()
+		defer restore()
+	}
+	if !setupCluster() {
+		return nil
+	}
+	currentProject := getCurrentProject()
+
+	fmt.Printf("🔍 Gathering %s for the last %s...\n", strings.Join(opts.Sources, ", "), opts.Since)
+
+	collectors := map[string]func() ([]internal.TimelineEvent, error){
+		internal.TimelineDeploy:  func() ([]internal.TimelineEvent, error) { return internal.RolloutTimeline(since) },
+		internal.TimelineRestart: func() ([]internal.TimelineEvent, error) { return internal.RestartTimeline(since) },
+		internal.TimelineNode:    func() ([]internal.TimelineEvent, error) { return internal.NodeTimeline(since) },
+		internal.TimelineAudit: func() ([]internal.TimelineEvent, error) {
+			return internal.AuditTimeline(currentProject, since, opts.Limit)
+		},
+		internal.TimelineAlert: func() ([]internal.TimelineEvent, error) { return internal.AlertTimeline(currentProject, since) },
+	}
+
+	var events []internal.TimelineEvent
+	for _, source := range internal.TimelineSources {
+		if !slices.Contains(opts.Sources, source) {
+			continue
+		}
+		found, err := collectors[source]()
+		if err != nil {
+			fmt.Printf("⚠️  Warning: could not get %s events: %v\n", source, err)
+			continue
+		}
+		if source == internal.TimelineAudit && len(found) >= opts.Limit {
+			fmt.Printf("⚠️  Reached the limit of %d audit log entries; older ones were left out (use --limit)\n", opts.Limit)
+		}
+		events = append(events, found...)
+	}
+	internal.SortTimeline(events)
+
+	if opts.Output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if events == nil {
+			events = []internal.TimelineEvent{}
+		}
+		return encoder.Encode(events)
+	}
+
+	fmt.Println()
+	if len(events) == 0 {
+		fmt.Printf("✅ Nothing happened in the last %s\n", opts.Since)
+		return nil
+	}
+	fmt.Printf("🕒 Timeline of %s, last %s (%d event(s)):\n", currentProject, opts.Since, len(events))
+	fmt.Println()
+	day := ""
+	for _, e := range events {
+		local := e.Time.Local()
+		if d := local.Format("Mon 2006-01-02"); d != day {
+			day = d
+			fmt.Println(internal.Colorize(internal.ColorCyan, "── "+day))
+		}
+		source := internal.Colorize(timelineColors[e.Source], fmt.Sprintf("%-8s", e.Source))
+		fmt.Printf("%s  %s %s  %s\n", local.Format(time.TimeOnly), source, e.Subject, e.Detail)
+	}
+	return nil
+}
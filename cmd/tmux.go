@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// podShellScript starts the best available shell without printing errors for missing ones
+const podShellScript = "if command -v bash >/dev/null 2>&1; then exec bash; elif command -v zsh >/dev/null 2>&1; then exec zsh; else exec sh; fi"
+
+// openTmuxShells opens a tmux window with one pane per pod, each running an interactive shell
+// with the given KEY=VALUE variables set. Inside an existing tmux session a new window is
+// created; otherwise a new session is started and attached.
+func openTmuxShells(pods, env []string, syncPanes bool) error {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	paneCommands := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		parts := strings.Split(pod, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid pod format: %s", pod)
+		}
+		paneCommands = append(paneCommands, tmuxPaneCommand(parts[0], parts[1], env))
+	}
+
+	insideTmux := os.Getenv("TMUX") != ""
+	session := fmt.Sprintf("gcpeasy-%d", time.Now().Unix())
+
+	var window string
+	var err error
+	if insideTmux {
+		window, err = tmuxOutput("new-window", "-P", "-F", "#{window_id}", "-n", "gcpeasy-shells", paneCommands[0])
+	} else {
+		window, err = tmuxOutput("new-session", "-d", "-P", "-F", "#{window_id}", "-s", session, "-n", "gcpeasy-shells", paneCommands[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create tmux window: %w", err)
+	}
+
+	for _, paneCmd := range paneCommands[1:] {
+		if _, err := tmuxOutput("split-window", "-t", window, paneCmd); err != nil {
+			return fmt.Errorf("failed to create tmux pane: %w", err)
+		}
+		// Re-tile after every split so tmux doesn't run out of room for new panes
+		tmuxOutput("select-layout", "-t", window, "tiled")
+	}
+
+	if syncPanes {
+		if _, err := tmuxOutput("set-window-option", "-t", window, "synchronize-panes", "on"); err != nil {
+			return fmt.Errorf("failed to synchronize panes: %w", err)
+		}
+		fmt.Println("🔗 Input is synchronized across all panes (toggle with ':setw synchronize-panes')")
+	}
+
+	if insideTmux {
+		fmt.Printf("✅ Opened %d shell(s) in a new tmux window\n", len(pods))
+		return nil
+	}
+
+	attach := exec.Command("tmux", "attach-session", "-t", session)
+	attach.Stdin = os.Stdin
+	attach.Stdout = os.Stdout
+	attach.Stderr = os.Stderr
+	return attach.Run()
+}
+
+func tmuxPaneCommand(namespace, podName string, env []string) string {
+	args := []string{"kubectl", "exec", "-it", podName, "-n", namespace, "--"}
+	if len(env) > 0 {
+		args = append(append(args, "env"), env...)
+	}
+	args = append(args, "sh", "-c", podShellScript)
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuote(a)
+	}
+	// Keep the pane open after the shell exits so errors stay visible
+	return strings.Join(quoted, " ") + "; echo; echo '[session ended - press enter to close]'; read _"
+}
+
+func tmuxOutput(args ...string) (string, error) {
+	output, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// shellQuote quotes s for safe use in a POSIX shell command line
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
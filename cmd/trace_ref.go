@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var traceRefCmd = &cobra.Command{
+	Use:   "trace-ref <configmap|secret|sa|pvc> <name>",
+	Short: "List the workloads that reference an object",
+	Long: `List the Deployments, StatefulSets, DaemonSets, CronJobs, Jobs and standalone pods in
+application namespaces that reference a ConfigMap, Secret, ServiceAccount or
+PersistentVolumeClaim, to assess the blast radius before changing or deleting it.
+
+References are found in volumes (including projected volumes and StatefulSet volume claim
+templates), env and envFrom, imagePullSecrets and serviceAccountName. Pods without a
+service account use "default".
+
+The object can be given as namespace/name or a name. References to an object that
+doesn't exist are shown too, as they break new pods.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := traceReferences(args[0], args[1]); err != nil {
+			fmt.Printf("Error tracing references: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	addNamespaceFlag(traceRefCmd)
+	rootCmd.AddCommand(traceRefCmd)
+}
+
+func traceReferences(kindArg, target string) error {
+	kind, err := internal.ParseReferenceKind(kindArg)
+	if err != nil {
+		return err
+	}
+
+	if !setupCluster() {
+		return nil
+	}
+
+	namespace, name, qualified := strings.Cut(target, "/")
+	if !qualified {
+		namespace, name = "", target
+	}
+
+	fmt.Printf("🔍 Looking for workloads referencing %s %s...\n", kind, target)
+	namespaces, err := internal.FindConfigObject(kind, name)
+	if err != nil {
+		return fmt.Errorf("failed to find %s %s: %w", kind, name, err)
+	}
+	refs, err := internal.FindReferences(kind, namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get workloads: %w", err)
+	}
+	fmt.Println()
+
+	if qualified && !slices.Contains(namespaces, namespace) {
+		namespaces = nil
+	}
+	if len(namespaces) == 0 {
+		fmt.Printf("⚠️  %s %s not found\n", kind, target)
+	}
+
+	if len(refs) == 0 {
+		fmt.Printf("✅ No workloads reference %s %s\n", kind, target)
+		return nil
+	}
+
+	fmt.Printf("%-15s %-12s %-35s %s\n", "NAMESPACE", "KIND", "NAME", "USES")
+	fmt.Println(strings.Repeat("-", 100))
+	missing := 0
+	for _, r := range refs {
+		line := fmt.Sprintf("%-15s %-12s %-35s %s",
+			truncate(r.Namespace, 15),
+			r.Kind,
+			truncate(r.Name, 35),
+			strings.Join(r.Uses, ", "))
+		// References only resolve within the workload's own namespace
+		if !slices.Contains(namespaces, r.Namespace) {
+			missing++
+			fmt.Println(internal.Colorize(internal.ColorRed, line+"  ❌ not found in namespace"))
+			continue
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	fmt.Printf("📋 %d workload(s) reference %s %s\n", len(refs), kind, name)
+	if missing > 0 {
+		fmt.Printf("⚠️  %d of them reference it in a namespace where it doesn't exist; new pods will fail to start\n", missing)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var tunnelCmd = &cobra.Command{
+	Use:   "tunnel",
+	Short: "Tunnel commands",
+	Long:  "Commands for reaching private resources outside the cluster.",
+}
+
+var tunnelBastionCmd = &cobra.Command{
+	Use:   "bastion <host:port>",
+	Short: "Forward a local port to a private host through a bastion VM",
+	Long: `Forward a local port to a host that is only reachable from inside the VPC, such as a
+legacy database or an internal admin panel, through SSH to a bastion VM over an IAP tunnel,
+so the bastion needs no external IP:
+
+  gcpeasy tunnel bastion 10.20.0.5:5432
+  gcpeasy tunnel bastion admin.internal:443 --via jump-1
+
+The bastion is the VM given with --via, else the environment's bastion in the config file,
+else chosen from the project's VMs. The local port is the same as the remote one (8000 plus
+the port for privileged ports), or a free port when that is taken. Press Ctrl+C to stop.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		via, _ := cmd.Flags().GetString("via")
+		localPort, _ := cmd.Flags().GetInt("local-port")
+		if err := tunnelBastion(args[0], via, localPort); err != nil {
+			if strings.Contains(err.Error(), "cancelled by user") {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Printf("Error opening tunnel: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	tunnelBastionCmd.Flags().String("via", "", "Bastion VM to tunnel through (default: the environment's bastion from the config)")
+	tunnelBastionCmd.Flags().Int("local-port", 0, "Local port to listen on (default: the same port, or a free one)")
+	tunnelCmd.AddCommand(tunnelBastionCmd)
+	rootCmd.AddCommand(tunnelCmd)
+}
+
+func tunnelBastion(target, via string, localPort int) error {
+	host, portText, err := net.SplitHostPort(target)
+	if err != nil {
+		return fmt.Errorf("invalid target %q: use host:port", target)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %q", portText)
+	}
+
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	if via == "" {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			return err
+		}
+		via = cfg.Environment(currentProject).Bastion
+	}
+	var vmArgs []string
+	if via != "" {
+		vmArgs = []string{via}
+	}
+	vm, err := resolveVM(currentProject, vmArgs)
+	if err != nil {
+		return err
+	}
+	if vm.Status != "RUNNING" {
+		fmt.Printf("❌ Bastion %s is %s\n", vm.Name, vm.Status)
+		fmt.Println("Use 'gcpeasy vm start' to start it first.")
+		return nil
+	}
+
+	if localPort == 0 {
+		if localPort, err = internal.LocalPortFor(port, nil); err != nil {
+			return err
+		}
+	} else if !internal.LocalPortFree(localPort) {
+		return fmt.Errorf("local port %d is already in use", localPort)
+	}
+
+	fmt.Printf("🔌 Forwarding localhost:%d → %s via %s (%s) through IAP\n", localPort, net.JoinHostPort(host, portText), vm.Name, vm.Zone)
+	fmt.Println("(Press Ctrl+C to stop)")
+	fmt.Println()
+	return internal.TunnelThroughVM(currentProject, *vm, localPort, host, port)
+}
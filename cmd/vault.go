@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var vaultCmd = &cobra.Command{
+	Use:   "vault",
+	Short: "Keep secrets gcpeasy needs in the OS keychain",
+	Long: `Store values gcpeasy needs to remember, such as webhook URLs, API tokens and IAP client
+IDs, in the OS keychain instead of the config file: the macOS login keychain, or the
+Secret Service (GNOME Keyring, KWallet) through secret-tool on Linux.
+
+Refer to a stored value in the config file as vault:<name>, e.g.
+
+  notifications:
+    slack_webhook: vault:slack-webhook
+
+This works for the notification webhook URLs and header values and iap_client_id.`,
+}
+
+var vaultSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Store a value in the vault",
+	Long: `Store a value in the vault, replacing any value stored under the name. The value is read
+from stdin, without echo when typed in a terminal, so it stays out of your shell history,
+the gcpeasy history and --events-json events, e.g.
+
+  gcpeasy vault set slack-webhook
+  pbpaste | gcpeasy vault set slack-webhook`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := vaultSet(args[0]); err != nil {
+			fmt.Printf("Error storing value: %v\n", err)
+		}
+	},
+}
+
+var vaultGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "Print a value from the vault",
+	Long:  "Print a value from the vault to stdout, e.g. to pass it to another command.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := internal.VaultGet(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading value: %v\n", err)
+			exitWithCode(1)
+		}
+		fmt.Print(value)
+		if internal.StdoutIsTerminal() {
+			fmt.Println()
+		}
+	},
+}
+
+var vaultListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the names stored in the vault",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listVault(); err != nil {
+			fmt.Printf("Error listing vault: %v\n", err)
+		}
+	},
+}
+
+var vaultDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Remove a value from the vault",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes && !confirm(fmt.Sprintf("Remove %s from the vault?", args[0])) {
+			fmt.Println("Cancelled.")
+			return
+		}
+		if err := internal.VaultDelete(args[0]); err != nil {
+			fmt.Printf("Error removing value: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Removed %s from the vault\n", args[0])
+	},
+}
+
+func init() {
+	vaultDeleteCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	vaultCmd.AddCommand(vaultSetCmd)
+	vaultCmd.AddCommand(vaultGetCmd)
+	vaultCmd.AddCommand(vaultListCmd)
+	vaultCmd.AddCommand(vaultDeleteCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
+
+func vaultSet(name string) error {
+	if err := internal.ValidateVaultName(name); err != nil {
+		return err
+	}
+
+	value, err := readSecretValue(name)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return fmt.Errorf("empty value")
+	}
+
+	if err := internal.VaultSet(name, value); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Stored %s in the vault\n", name)
+	fmt.Printf("💡 Use it in the config file as %s%s\n", internal.VaultPrefix, name)
+	return nil
+}
+
+// readSecretValue reads a value from stdin, turning off echo while it's typed in a terminal
+func readSecretValue(name string) (string, error) {
+	if !internal.StdinIsTerminal() {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	fmt.Printf("Value for %s: ", name)
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = os.Stdin
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err == nil {
+		defer func() {
+			stty("echo")
+			fmt.Println()
+		}()
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("no value given")
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+func listVault() error {
+	names, err := internal.VaultNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Println("The vault is empty.")
+		fmt.Println("💡 Store a value with 'gcpeasy vault set <name>'")
+		return nil
+	}
+
+	fmt.Printf("🔐 %d value(s) in the vault:\n", len(names))
+	for _, name := range names {
+		fmt.Printf("   %s\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/scttymn/gcpeasy/internal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var vmCmd = &cobra.Command{
+	Use:   "vm",
+	Short: "Compute Engine VM commands",
+	Long:  "Commands for listing and managing Compute Engine VMs in the current GCP project.",
+}
+
+var vmListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List VMs",
+	Long:  "List Compute Engine VMs in the current project with zone, machine type, status and IP addresses.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listVMs(); err != nil {
+			fmt.Printf("Error listing VMs: %v\n", err)
+		}
+	},
+}
+
+var vmStartCmd = &cobra.Command{
+	Use:   "start [name]",
+	Short: "Start a VM",
+	Long:  "Start a stopped Compute Engine VM. If no name is provided, shows an interactive selection.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := changeVMState(args, "start", true); err != nil {
+			fmt.Printf("Error starting VM: %v\n", err)
+		}
+	},
+}
+
+var vmStopCmd = &cobra.Command{
+	Use:   "stop [name]",
+	Short: "Stop a VM",
+	Long:  "Stop a running Compute Engine VM after confirmation. Protected environments require typing the project ID. If no name is provided, shows an interactive selection.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		if err := changeVMState(args, "stop", yes); err != nil {
+			fmt.Printf("Error stopping VM: %v\n", err)
+		}
+	},
+}
+
+var vmSSHCmd = &cobra.Command{
+	Use:   "ssh [name] [-- command...]",
+	Short: "SSH into a VM through IAP",
+	Long:  "Open an SSH session to a VM through Identity-Aware Proxy, so no external IP is required. The zone is resolved automatically. If no name is provided, shows an interactive selection. Arguments after -- are run as a remote command.",
+	Run: func(cmd *cobra.Command, args []string) {
+		var remote []string
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			remote = args[dash:]
+			args = args[:dash]
+		}
+		if len(args) > 1 {
+			fmt.Println("Too many arguments; use -- before a remote command.")
+			return
+		}
+		if err := sshToVM(args, remote); err != nil {
+			fmt.Printf("Error connecting to VM: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	vmStopCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt (protected environments still require confirmation)")
+	vmCmd.AddCommand(vmListCmd)
+	vmCmd.AddCommand(vmStartCmd)
+	vmCmd.AddCommand(vmStopCmd)
+	vmCmd.AddCommand(vmSSHCmd)
+	rootCmd.AddCommand(vmCmd)
+}
+
+func listVMs() error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	fmt.Printf("🔍 Looking for VMs in project: %s\n", currentProject)
+	fmt.Println()
+
+	vms, err := internal.GetVMs(currentProject)
+	if err != nil {
+		return fmt.Errorf("failed to list VMs: %w", err)
+	}
+
+	if len(vms) == 0 {
+		fmt.Println("No VMs found.")
+		return nil
+	}
+
+	fmt.Printf("%-30s %-18s %-16s %-12s %-16s %-16s\n",
+		"NAME", "ZONE", "MACHINE TYPE", "STATUS", "INTERNAL IP", "EXTERNAL IP")
+	fmt.Println(strings.Repeat("-", 113))
+
+	for _, vm := range vms {
+		fmt.Printf("%-30s %-18s %-16s %-12s %-16s %-16s\n",
+			truncate(vm.Name, 30),
+			vm.Zone,
+			truncate(vm.MachineType, 16),
+			vm.Status,
+			vm.InternalIP,
+			orDash(vm.ExternalIP))
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Use 'gcpeasy vm start' or 'gcpeasy vm stop' to change a VM's state")
+	return nil
+}
+
+// resolveVM returns the VM named in args, or prompts for one when no name is given
+func resolveVM(projectID string, args []string) (*internal.VMInfo, error) {
+	vms, err := internal.GetVMs(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VMs: %w", err)
+	}
+
+	if len(vms) == 0 {
+		return nil, fmt.Errorf("no VMs found in project %s", projectID)
+	}
+
+	if len(args) == 0 {
+		return internal.SelectVM(vms)
+	}
+
+	vm, ok := internal.FindVM(vms, args[0])
+	if !ok {
+		return nil, fmt.Errorf("VM '%s' not found, use 'gcpeasy vm list' to see available VMs", args[0])
+	}
+	return vm, nil
+}
+
+func changeVMState(args []string, action string, skipConfirm bool) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	vm, err := resolveVM(currentProject, args)
+	if err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return err
+	}
+
+	warnManaged("VM "+vm.Name, vm.Labels, nil)
+	if action == "stop" {
+		if !confirmProtected(currentProject, "stop VM "+vm.Name) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if !skipConfirm && !confirm(fmt.Sprintf("Stop VM %s in %s?", vm.Name, vm.Zone)) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Printf("🔧 Running %s on VM %s (%s)...\n", action, vm.Name, vm.Zone)
+	err = runNotified("vm "+action, fmt.Sprintf("VM %s in %s", vm.Name, vm.Zone), func() error {
+		return internal.SetVMState(currentProject, *vm, action)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ VM %s: %s complete\n", vm.Name, action)
+	return nil
+}
+
+func sshToVM(args, remote []string) error {
+	currentProject := requireProject()
+	if currentProject == "" {
+		return nil
+	}
+
+	vm, err := resolveVM(currentProject, args)
+	if err != nil {
+		if strings.Contains(err.Error(), "cancelled by user") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return err
+	}
+
+	if vm.Status != "RUNNING" {
+		fmt.Printf("❌ VM %s is %s\n", vm.Name, vm.Status)
+		fmt.Println("Use 'gcpeasy vm start' to start it first.")
+		return nil
+	}
+
+	fmt.Printf("🚀 Connecting to %s (%s) through IAP...\n", vm.Name, vm.Zone)
+	fmt.Println("(Type 'exit' or press Ctrl+D to disconnect)")
+	fmt.Println()
+
+	return internal.SSHToVM(currentProject, *vm, remote)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// AccessProfile is what a role on the team is expected to be able to do
+type AccessProfile struct {
+	Name        string
+	Description string
+	// Exec and ExecProtected are whether the role may exec into pods in unprotected
+	// and protected environments
+	Exec          bool
+	ExecProtected bool
+}
+
+// AccessProfiles are the profiles `access verify` checks against, by name
+var AccessProfiles = map[string]AccessProfile{
+	"viewer":    {Name: "viewer", Description: "read-only access to every environment"},
+	"developer": {Name: "developer", Description: "read access everywhere, exec into unprotected environments only", Exec: true},
+	"sre":       {Name: "sre", Description: "read access and exec into every environment", Exec: true, ExecProtected: true},
+}
+
+// AccessProfileNames returns the profile names, sorted
+func AccessProfileNames() []string {
+	names := make([]string, 0, len(AccessProfiles))
+	for name := range AccessProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AccessCheck is one item of an access checklist: whether something was allowed, and
+// whether the profile expects it to be
+type AccessCheck struct {
+	Environment string
+	Name        string
+	Expected    bool
+	Allowed     bool
+	// Detail explains a denial, or why the check couldn't run
+	Detail string
+}
+
+// Passed reports whether the access matches what the profile expects
+func (c AccessCheck) Passed() bool {
+	return c.Allowed == c.Expected
+}
+
+// CheckListProjects checks the active account can list GCP projects
+func CheckListProjects() AccessCheck {
+	check := AccessCheck{Name: "List projects", Expected: true}
+	output, err := runOutput("gcloud", "projects", "list", "--limit", "1", "--format=value(projectId)")
+	switch {
+	case err != nil:
+		check.Detail = err.Error()
+	case strings.TrimSpace(string(output)) == "":
+		check.Detail = "no projects are visible"
+	default:
+		check.Allowed = true
+	}
+	return check
+}
+
+// VerifyEnvironmentAccess checks what the active account can do in an environment:
+// see the project, get cluster credentials, read pods and their logs, read Cloud
+// Logging, and exec into pods. Cluster permissions are checked with kubectl auth can-i in
+// the namespace set with SetNamespaceScope, or across all namespaces. Credentials are
+// written to a temporary kubeconfig, leaving the user's untouched.
+func VerifyEnvironmentAccess(projectID string, protected bool, profile AccessProfile) []AccessCheck {
+	newCheck := func(name string, expected bool) AccessCheck {
+		return AccessCheck{Environment: projectID, Name: name, Expected: expected}
+	}
+	expectExec := profile.Exec
+	if protected {
+		expectExec = profile.ExecProtected
+	}
+
+	project := newCheck("See the project", true)
+	if _, err := runOutput("gcloud", "projects", "describe", projectID, "--format=value(projectId)"); err != nil {
+		project.Detail = err.Error()
+	} else {
+		project.Allowed = true
+	}
+
+	logging := newCheck("Read Cloud Logging", true)
+	if _, err := runOutput("gcloud", "logging", "read", "--project", projectID, "--limit", "1", "--freshness", "1h", "--format=value(timestamp)"); err != nil {
+		logging.Detail = err.Error()
+	} else {
+		logging.Allowed = true
+	}
+
+	clusterChecks := []AccessCheck{
+		newCheck("Read pods", true),
+		newCheck("Read pod logs", true),
+		newCheck("Exec into pods", expectExec),
+	}
+	canI := [][]string{
+		{"list", "pods"},
+		{"get", "pods", "--subresource=log"},
+		{"create", "pods", "--subresource=exec"},
+	}
+
+	credentials := newCheck("Get cluster credentials", true)
+	kubeconfig, err := clusterCredentials(projectID)
+	if err != nil {
+		credentials.Detail = err.Error()
+		for i := range clusterChecks {
+			clusterChecks[i].Detail = "not checked: no cluster credentials"
+		}
+	} else {
+		defer os.Remove(kubeconfig)
+		credentials.Allowed = true
+		for i := range clusterChecks {
+			clusterChecks[i].Allowed, clusterChecks[i].Detail = kubectlCanI(kubeconfig, canI[i]...)
+		}
+	}
+
+	return append([]AccessCheck{project, credentials}, append(clusterChecks, logging)...)
+}
+
+// clusterCredentials gets credentials for the project's first GKE cluster into a new
+// temporary kubeconfig and returns its path
+func clusterCredentials(projectID string) (string, error) {
+	clusters, err := GetGKEClusters(projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list clusters: %w", err)
+	}
+	if len(clusters) == 0 {
+		return "", fmt.Errorf("no clusters are visible in the project")
+	}
+	cluster := clusters[0]
+
+	file, err := os.CreateTemp("", "gcpeasy-access-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	file.Close()
+
+	cmd := exec.Command("gcloud", "container", "clusters", "get-credentials", cluster.Name, "--location", cluster.Location, "--project", projectID)
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+file.Name())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(file.Name())
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", cluster.Name, msg)
+		}
+		return "", fmt.Errorf("%s: %w", cluster.Name, err)
+	}
+	return file.Name(), nil
+}
+
+// kubectlCanI asks the cluster whether an action is allowed; detail explains a denial or
+// an error
+func kubectlCanI(kubeconfig string, action ...string) (allowed bool, detail string) {
+	args := append([]string{"auth", "can-i"}, action...)
+	cmd := exec.Command("kubectl", append(args, namespaceArgs()...)...)
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+kubeconfig)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// can-i exits with 1 when the answer is no, so go by its output
+	output, _ := cmd.Output()
+	switch strings.TrimSpace(string(output)) {
+	case "yes":
+		return true, ""
+	case "no":
+		return false, "denied by RBAC"
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return false, msg
+	}
+	return false, "kubectl auth can-i gave no answer"
+}
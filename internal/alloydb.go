@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AlloyDBProxyBinary is the AlloyDB Auth Proxy executable `alloydb connect` runs
+const AlloyDBProxyBinary = "alloydb-auth-proxy"
+
+// AlloyDBInstance is an AlloyDB primary or read pool instance
+type AlloyDBInstance struct {
+	// Name is the full resource name:
+	// projects/<project>/locations/<region>/clusters/<cluster>/instances/<instance>
+	Name         string `json:"name"`
+	InstanceType string `json:"instanceType"`
+	State        string `json:"state"`
+	IPAddress    string `json:"ipAddress"`
+}
+
+// resourcePart returns the segment after key in the instance's resource name
+func (i AlloyDBInstance) resourcePart(key string) string {
+	parts := strings.Split(i.Name, "/")
+	for k := 0; k+1 < len(parts); k++ {
+		if parts[k] == key {
+			return parts[k+1]
+		}
+	}
+	return ""
+}
+
+// ID is the instance's name within its cluster
+func (i AlloyDBInstance) ID() string {
+	return i.resourcePart("instances")
+}
+
+// Cluster is the name of the instance's cluster
+func (i AlloyDBInstance) Cluster() string {
+	return i.resourcePart("clusters")
+}
+
+// Region is the instance's region
+func (i AlloyDBInstance) Region() string {
+	return i.resourcePart("locations")
+}
+
+// GetAlloyDBInstances returns the AlloyDB instances of all clusters in the project
+func GetAlloyDBInstances(projectID string) ([]AlloyDBInstance, error) {
+	var instances []AlloyDBInstance
+	if err := GcloudJSON(&instances, "alloydb", "instances", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// FindAlloyDBInstance finds an instance by its ID, cluster/ID or full resource name
+func FindAlloyDBInstance(instances []AlloyDBInstance, name string) (*AlloyDBInstance, error) {
+	var matches []AlloyDBInstance
+	for _, i := range instances {
+		if name == i.Name || name == i.Cluster()+"/"+i.ID() || name == i.ID() {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("AlloyDB instance %q not found", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%q matches instances in several clusters, use cluster/instance", name)
+	}
+}
+
+// SelectAlloyDBInstance prompts the user to pick one of the instances
+func SelectAlloyDBInstance(instances []AlloyDBInstance) (*AlloyDBInstance, error) {
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no AlloyDB instances available")
+	}
+
+	fmt.Printf("📋 Found %d AlloyDB instance(s):\n", len(instances))
+	fmt.Println()
+
+	for i, instance := range instances {
+		fmt.Printf("%d. %s/%s (%s, %s, %s)\n", i+1, instance.Cluster(), instance.ID(), instance.Region(), instance.InstanceType, instance.State)
+	}
+
+	fmt.Println()
+	fmt.Print("Select instance (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(instances) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &instances[num-1], nil
+}
+
+// AlloyDBProxy is a running AlloyDB Auth Proxy
+type AlloyDBProxy struct {
+	Port   int
+	cmd    *exec.Cmd
+	exited chan struct{}
+}
+
+// AlloyDBProxyOptions are how the proxy connects to the instance
+type AlloyDBProxyOptions struct {
+	Port int
+	// PublicIP connects to the instance's public IP instead of its private IP
+	PublicIP bool
+	// AutoIAMAuthn logs in to the database as the active gcloud account
+	AutoIAMAuthn bool
+}
+
+// StartAlloyDBProxy starts the AlloyDB Auth Proxy for an instance on a local port and
+// returns once it accepts connections. The proxy runs in its own process group, so
+// Ctrl+C in a client sharing the terminal (e.g. cancelling a query in psql) doesn't stop
+// it; it runs until Close.
+func StartAlloyDBProxy(instance AlloyDBInstance, opts AlloyDBProxyOptions) (*AlloyDBProxy, error) {
+	if _, err := exec.LookPath(AlloyDBProxyBinary); err != nil {
+		return nil, fmt.Errorf("%s not found; install it from https://cloud.google.com/alloydb/docs/auth-proxy/connect", AlloyDBProxyBinary)
+	}
+
+	args := []string{instance.Name, "--port", strconv.Itoa(opts.Port)}
+	if opts.PublicIP {
+		args = append(args, "--public-ip")
+	}
+	if opts.AutoIAMAuthn {
+		args = append(args, "--auto-iam-authn")
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(AlloyDBProxyBinary, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.SysProcAttr = ownProcessGroup()
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", AlloyDBProxyBinary, err)
+	}
+	proxy := &AlloyDBProxy{Port: opts.Port, cmd: cmd, exited: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		close(proxy.exited)
+	}()
+
+	address := fmt.Sprintf("127.0.0.1:%d", opts.Port)
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		select {
+		case <-proxy.exited:
+			return nil, fmt.Errorf("%s exited: %s", AlloyDBProxyBinary, strings.TrimSpace(output.String()))
+		default:
+		}
+		if conn, err := net.DialTimeout("tcp", address, time.Second); err == nil {
+			conn.Close()
+			return proxy, nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	proxy.Close()
+	return nil, fmt.Errorf("timed out waiting for %s to listen on %s", AlloyDBProxyBinary, address)
+}
+
+// Wait blocks until the proxy exits
+func (p *AlloyDBProxy) Wait() {
+	<-p.exited
+}
+
+// Close stops the proxy
+func (p *AlloyDBProxy) Close() {
+	if p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+	}
+	<-p.exited
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SlotLabel selects which of a blue/green Service's deployments receives its traffic
+const SlotLabel = "gcpeasy.io/slot"
+
+// Blue/green slots
+const (
+	SlotBlue  = "blue"
+	SlotGreen = "green"
+)
+
+// OtherSlot returns the slot that isn't slot
+func OtherSlot(slot string) string {
+	if slot == SlotBlue {
+		return SlotGreen
+	}
+	return SlotBlue
+}
+
+// ActiveSlot is the slot the Service sends traffic to, empty when its selector has no slot
+func (s Service) ActiveSlot() string {
+	return s.Spec.Selector[SlotLabel]
+}
+
+// slotSelector is the Service's selector switched to a slot
+func (s Service) slotSelector(slot string) LabelSelector {
+	labels := make(map[string]string, len(s.Spec.Selector))
+	for k, v := range s.Spec.Selector {
+		labels[k] = v
+	}
+	labels[SlotLabel] = slot
+	return LabelSelector{MatchLabels: labels}
+}
+
+// SlotDeployments returns the Service's deployments by slot: the ones whose pods the
+// Service selects when its selector is switched to that slot
+func SlotDeployments(s Service) (map[string]*Deployment, error) {
+	var list DeploymentList
+	if err := KubectlJSON(&list, "get", "deployments", "-n", s.Metadata.Namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	slots := make(map[string]*Deployment)
+	for _, slot := range []string{SlotBlue, SlotGreen} {
+		selector := s.slotSelector(slot)
+		for i, d := range list.Items {
+			if selector.Matches(d.Spec.Template.Metadata.Labels) {
+				if other, ok := slots[slot]; ok {
+					return nil, fmt.Errorf("both %s and %s serve the %s slot of %s", other.Metadata.Name, d.Metadata.Name, slot, s.Metadata.Name)
+				}
+				slots[slot] = &list.Items[i]
+			}
+		}
+	}
+	return slots, nil
+}
+
+// SlotDeploymentName names the deployment of a slot after the active one, replacing its
+// -blue or -green suffix
+func SlotDeploymentName(active Deployment, slot string) string {
+	name := active.Metadata.Name
+	for _, suffix := range []string{"-" + SlotBlue, "-" + SlotGreen} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	return name + "-" + slot
+}
+
+// DeploySlot creates or replaces the deployment of a slot as a copy of the active
+// deployment that runs image in one of its containers, with the same replicas
+func DeploySlot(active Deployment, slot, name, container, image, changeCause string) error {
+	if active.Spec.Selector.MatchLabels[SlotLabel] == "" {
+		return fmt.Errorf("the selector of %s doesn't include %s, so it would also select the %s pods", active.Metadata.Name, SlotLabel, slot)
+	}
+
+	var obj map[string]any
+	if err := KubectlJSON(&obj, "get", "deployment", active.Metadata.Name, "-n", active.Metadata.Namespace, "-o", "json"); err != nil {
+		return err
+	}
+	metadata, _ := obj["metadata"].(map[string]any)
+	spec, _ := obj["spec"].(map[string]any)
+	selector, _ := spec["selector"].(map[string]any)
+	template, _ := spec["template"].(map[string]any)
+	templateMeta, _ := template["metadata"].(map[string]any)
+	podSpec, _ := template["spec"].(map[string]any)
+	if metadata == nil || selector == nil || templateMeta == nil || podSpec == nil {
+		return fmt.Errorf("unexpected deployment spec of %s", active.Metadata.Name)
+	}
+
+	withSlot := func(labels any) map[string]any {
+		m, _ := labels.(map[string]any)
+		if m == nil {
+			m = make(map[string]any)
+		}
+		m[SlotLabel] = slot
+		return m
+	}
+	// Server-set metadata and the active deployment's annotations aren't copied
+	obj["metadata"] = map[string]any{
+		"name":        name,
+		"namespace":   active.Metadata.Namespace,
+		"labels":      withSlot(metadata["labels"]),
+		"annotations": map[string]any{ChangeCauseAnnotation: changeCause},
+	}
+	delete(obj, "status")
+	selector["matchLabels"] = withSlot(selector["matchLabels"])
+	templateMeta["labels"] = withSlot(templateMeta["labels"])
+
+	found := false
+	containers, _ := podSpec["containers"].([]any)
+	for _, c := range containers {
+		if c, ok := c.(map[string]any); ok && c["name"] == container {
+			c["image"] = image
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s has no container named %s", active.Metadata.Name, container)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("kubectl", "apply", "-f", "-")
+	cmd.Stdin = strings.NewReader(string(data))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to apply %s: %w", name, err)
+	}
+	return nil
+}
+
+// SlotPod returns a ready pod ("namespace/pod") of a slot, to check it before switching
+func SlotPod(s Service, slot string) (string, error) {
+	var selector []string
+	for k, v := range s.slotSelector(slot).MatchLabels {
+		selector = append(selector, k+"="+v)
+	}
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "-n", s.Metadata.Namespace, "-l", strings.Join(selector, ","), "-o", "json"); err != nil {
+		return "", err
+	}
+	for _, pod := range list.Items {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == "Ready" && c.Status == "True" {
+				return pod.Metadata.Namespace + "/" + pod.Metadata.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no ready pod in the %s slot of %s", slot, s.Metadata.Name)
+}
+
+// TargetContainerPort resolves the container port the Service's first port sends traffic
+// to, looking up named ports in the deployment's containers
+func TargetContainerPort(s Service, d Deployment) (int, error) {
+	if len(s.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("service %s has no ports", s.Metadata.Name)
+	}
+	port := s.Spec.Ports[0]
+	switch target := port.TargetPort.(type) {
+	case float64:
+		return int(target), nil
+	case string:
+		for _, c := range d.Spec.Template.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == target {
+					return p.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("no container of %s has a port named %s", d.Metadata.Name, target)
+	default:
+		return port.Port, nil
+	}
+}
+
+// SwitchSlot points the Service's selector at a slot, moving all traffic to its deployment
+func SwitchSlot(s Service, slot string) error {
+	patch := fmt.Sprintf(`{"spec":{"selector":{%q:%q}}}`, SlotLabel, slot)
+	if _, err := runOutput("kubectl", "patch", "service", s.Metadata.Name, "-n", s.Metadata.Namespace, "--type", "merge", "-p", patch); err != nil {
+		return fmt.Errorf("failed to switch %s to %s: %w", s.Metadata.Name, slot, err)
+	}
+	return nil
+}
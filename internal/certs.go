@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Certificate resources gcpeasy knows how to report on
+const (
+	CertManagerCertificateResource = "certificates.cert-manager.io"
+	CertManagerChallengeResource   = "challenges.acme.cert-manager.io"
+	ManagedCertificateResource     = "managedcertificates.networking.gke.io"
+)
+
+// CertificateInfo is the readiness and expiry of a cert-manager Certificate or GKE ManagedCertificate
+type CertificateInfo struct {
+	Type      string
+	Namespace string
+	Name      string
+	Domains   []string
+	Ready     bool
+	Status    string
+	Expiry    time.Time
+	Problems  []string
+}
+
+// ExpiresWithin reports whether the certificate has a known expiry within d
+func (c CertificateInfo) ExpiresWithin(d time.Duration) bool {
+	return !c.Expiry.IsZero() && time.Until(c.Expiry) < d
+}
+
+type certManagerCertificateList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			DNSNames   []string `json:"dnsNames"`
+			CommonName string   `json:"commonName"`
+		} `json:"spec"`
+		Status struct {
+			NotAfter   string      `json:"notAfter"`
+			Conditions []Condition `json:"conditions"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type challengeList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			DNSName string `json:"dnsName"`
+			Type    string `json:"type"`
+		} `json:"spec"`
+		Status struct {
+			State  string `json:"state"`
+			Reason string `json:"reason"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type managedCertificateList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Domains []string `json:"domains"`
+		} `json:"spec"`
+		Status struct {
+			CertificateStatus string `json:"certificateStatus"`
+			ExpireTime        string `json:"expireTime"`
+			DomainStatus      []struct {
+				Domain string `json:"domain"`
+				Status string `json:"status"`
+			} `json:"domainStatus"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// GetCertManagerCertificates returns every cert-manager Certificate in the cluster, with the
+// reasons of any pending ACME challenges for its domains
+func GetCertManagerCertificates() ([]CertificateInfo, error) {
+	var list certManagerCertificateList
+	if err := KubectlJSON(&list, "get", CertManagerCertificateResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	// Challenges only exist while an ACME order is in flight; index their problems by namespace/domain
+	challenges := make(map[string][]string)
+	if HasAPIResource(CertManagerChallengeResource) {
+		var cl challengeList
+		if err := KubectlJSON(&cl, "get", CertManagerChallengeResource, "--all-namespaces", "-o", "json"); err == nil {
+			for _, ch := range cl.Items {
+				if ch.Status.State == "valid" {
+					continue
+				}
+				key := ch.Metadata.Namespace + "/" + ch.Spec.DNSName
+				problem := ch.Spec.DNSName + ": " + ch.Spec.Type + " challenge " + orUnknown(ch.Status.State)
+				if ch.Status.Reason != "" {
+					problem += " - " + ch.Status.Reason
+				}
+				challenges[key] = append(challenges[key], problem)
+			}
+		}
+	}
+
+	var certs []CertificateInfo
+	for _, item := range list.Items {
+		c := CertificateInfo{
+			Type:      "cert-manager",
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Domains:   item.Spec.DNSNames,
+			Status:    "Unknown",
+		}
+		if len(c.Domains) == 0 && item.Spec.CommonName != "" {
+			c.Domains = []string{item.Spec.CommonName}
+		}
+		if t, err := time.Parse(time.RFC3339, item.Status.NotAfter); err == nil {
+			c.Expiry = t
+		}
+
+		for _, cond := range item.Status.Conditions {
+			switch cond.Type {
+			case "Ready":
+				c.Ready = cond.Status == "True"
+				if c.Ready {
+					c.Status = "Ready"
+				} else {
+					c.Status = orUnknown(cond.Reason)
+					if cond.Message != "" {
+						c.Problems = append(c.Problems, cond.Message)
+					}
+				}
+			case "Issuing":
+				if cond.Status == "True" && cond.Message != "" {
+					c.Problems = append(c.Problems, "issuing: "+cond.Message)
+				}
+			}
+		}
+
+		for _, d := range c.Domains {
+			c.Problems = append(c.Problems, challenges[c.Namespace+"/"+d]...)
+		}
+
+		certs = append(certs, c)
+	}
+
+	sortCertificates(certs)
+	return certs, nil
+}
+
+// GetManagedCertificates returns every GKE ManagedCertificate in the cluster
+func GetManagedCertificates() ([]CertificateInfo, error) {
+	var list managedCertificateList
+	if err := KubectlJSON(&list, "get", ManagedCertificateResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var certs []CertificateInfo
+	for _, item := range list.Items {
+		c := CertificateInfo{
+			Type:      "managed",
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Domains:   item.Spec.Domains,
+			Status:    orUnknown(item.Status.CertificateStatus),
+			Ready:     item.Status.CertificateStatus == "Active",
+		}
+		if t, err := time.Parse(time.RFC3339, item.Status.ExpireTime); err == nil {
+			c.Expiry = t
+		}
+		for _, ds := range item.Status.DomainStatus {
+			if ds.Status != "Active" {
+				c.Problems = append(c.Problems, ds.Domain+": "+ds.Status)
+			}
+		}
+
+		certs = append(certs, c)
+	}
+
+	sortCertificates(certs)
+	return certs, nil
+}
+
+func orUnknown(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "Unknown"
+	}
+	return s
+}
+
+func sortCertificates(certs []CertificateInfo) {
+	sort.Slice(certs, func(i, j int) bool {
+		if certs[i].Namespace != certs[j].Namespace {
+			return certs[i].Namespace < certs[j].Namespace
+		}
+		return certs[i].Name < certs[j].Name
+	})
+}
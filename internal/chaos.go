@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// GetApplicationPods returns the pods in application namespaces, or in the namespace set
+// with SetNamespaceScope, that match the pod selector
+func GetApplicationPods() ([]Pod, error) {
+	var list PodList
+	args := append([]string{"get", "pods"}, podListScope()...)
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var pods []Pod
+	for _, p := range list.Items {
+		if !isSystemNamespace(p.Metadata.Namespace) {
+			pods = append(pods, p)
+		}
+	}
+	return pods, nil
+}
+
+// Ready reports whether the pod is ready to serve and not being deleted
+func (p Pod) Ready() bool {
+	if p.Metadata.DeletionTimestamp != "" {
+		return false
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == "Ready" {
+			return c.Status == "True"
+		}
+	}
+	return false
+}
+
+// CountReady returns how many of the pods are ready
+func CountReady(pods []Pod) int {
+	ready := 0
+	for _, p := range pods {
+		if p.Ready() {
+			ready++
+		}
+	}
+	return ready
+}
+
+// PickRandomPods chooses count distinct pods at random
+func PickRandomPods(pods []Pod, count int) []Pod {
+	picked := make([]Pod, 0, count)
+	for _, i := range rand.Perm(len(pods))[:min(count, len(pods))] {
+		picked = append(picked, pods[i])
+	}
+	return picked
+}
+
+// DeletePod deletes a pod without waiting for it to terminate, as a crash or eviction would
+func DeletePod(p Pod) error {
+	if _, err := runOutput("kubectl", "delete", "pod", p.Metadata.Name, "-n", p.Metadata.Namespace, "--wait=false"); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", p.Metadata.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Garbage is a leftover Kubernetes object that can be deleted
+type Garbage struct {
+	// Kind is the kubectl resource type: "job", "pod" or "replicaset"
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+	// Age is how long ago the object finished, or was created when that's unknown
+	Age time.Duration
+}
+
+// FindClusterGarbage finds, in application namespaces, Jobs that finished more than
+// olderThan ago, Evicted and other Failed or Completed pods not managed by a Job, and
+// scaled-down ReplicaSets whose Deployment no longer owns them
+func FindClusterGarbage(olderThan time.Duration) ([]Garbage, error) {
+	now := time.Now()
+	age := func(timestamps ...string) time.Duration {
+		for _, ts := range timestamps {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				return now.Sub(t)
+			}
+		}
+		return 0
+	}
+
+	var garbage []Garbage
+
+	var jobs JobList
+	if err := KubectlJSON(&jobs, append(append([]string{"get", "jobs"}, namespaceArgs()...), "-o", "json")...); err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	for _, j := range jobs.Items {
+		if isSystemNamespace(j.Metadata.Namespace) {
+			continue
+		}
+		for _, c := range j.Status.Conditions {
+			if c.Status != "True" || (c.Type != "Complete" && c.Type != "Failed") {
+				continue
+			}
+			finished := age(j.Status.CompletionTime, c.LastTransitionTime, j.Metadata.CreationTimestamp)
+			if finished >= olderThan {
+				reason := "Succeeded"
+				if c.Type == "Failed" {
+					reason = "Failed"
+				}
+				garbage = append(garbage, Garbage{Kind: "job", Namespace: j.Metadata.Namespace, Name: j.Metadata.Name, Reason: reason, Age: finished})
+			}
+			break
+		}
+	}
+
+	var pods PodList
+	if err := KubectlJSON(&pods, append(append([]string{"get", "pods"}, namespaceArgs()...), "-o", "json")...); err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+	for _, p := range pods.Items {
+		if isSystemNamespace(p.Metadata.Namespace) {
+			continue
+		}
+		// Job pods go with their Job, and keep its logs until then
+		if strings.HasPrefix(podOwner(p), "job/") {
+			continue
+		}
+		var reason string
+		switch p.Status.Phase {
+		case "Succeeded":
+			reason = "Completed"
+		case "Failed":
+			reason = p.Status.Reason
+			if reason == "" {
+				reason = "Failed"
+			}
+		default:
+			continue
+		}
+		garbage = append(garbage, Garbage{Kind: "pod", Namespace: p.Metadata.Namespace, Name: p.Metadata.Name, Reason: reason, Age: age(p.Status.StartTime, p.Metadata.CreationTimestamp)})
+	}
+
+	var replicaSets ReplicaSetList
+	if err := KubectlJSON(&replicaSets, append(append([]string{"get", "replicasets"}, namespaceArgs()...), "-o", "json")...); err != nil {
+		return nil, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+	for _, rs := range replicaSets.Items {
+		if isSystemNamespace(rs.Metadata.Namespace) {
+			continue
+		}
+		owned := false
+		for _, ref := range rs.Metadata.OwnerReferences {
+			owned = owned || ref.Controller
+		}
+		if owned || rs.Status.Replicas > 0 || (rs.Spec.Replicas != nil && *rs.Spec.Replicas > 0) {
+			continue
+		}
+		garbage = append(garbage, Garbage{Kind: "replicaset", Namespace: rs.Metadata.Namespace, Name: rs.Metadata.Name, Reason: "Orphaned, 0 replicas", Age: age(rs.Metadata.CreationTimestamp)})
+	}
+
+	sort.SliceStable(garbage, func(i, j int) bool {
+		if garbage[i].Kind != garbage[j].Kind {
+			return garbage[i].Kind < garbage[j].Kind
+		}
+		if garbage[i].Namespace != garbage[j].Namespace {
+			return garbage[i].Namespace < garbage[j].Namespace
+		}
+		return garbage[i].Name < garbage[j].Name
+	})
+	return garbage, nil
+}
+
+// DeleteGarbage deletes the objects, one kubectl call per kind and namespace
+func DeleteGarbage(garbage []Garbage) error {
+	type batch struct{ kind, namespace string }
+	var order []batch
+	names := make(map[batch][]string)
+	for _, g := range garbage {
+		b := batch{g.Kind, g.Namespace}
+		if _, ok := names[b]; !ok {
+			order = append(order, b)
+		}
+		names[b] = append(names[b], g.Name)
+	}
+
+	for _, b := range order {
+		args := append([]string{"delete", b.kind, "-n", b.namespace, "--ignore-not-found", "--wait=false"}, names[b]...)
+		cmd := exec.Command("kubectl", args...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to delete %ss in %s: %w", b.kind, b.namespace, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContainerLogQuery selects container logs from Cloud Logging. Empty fields match anything.
+type ContainerLogQuery struct {
+	Cluster   string
+	Namespace string
+	Pod       string
+	Container string
+	// ExcludeNamespaces are left out, e.g. system namespaces
+	ExcludeNamespaces []string
+	Since             time.Duration
+	// MinSeverity keeps entries at or above a severity, e.g. ERROR
+	MinSeverity string
+	// Limit caps the number of entries returned; 0 uses a default
+	Limit int
+}
+
+// ContainerLogEntry is a container log line read from Cloud Logging
+type ContainerLogEntry struct {
+	Time      time.Time `json:"timestamp"`
+	Severity  string    `json:"severity"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Container string    `json:"container"`
+	// Text is the textPayload, or the jsonPayload encoded as JSON
+	Text string `json:"text"`
+}
+
+// defaultLogLimit bounds Cloud Logging reads so an unbounded query can't run forever
+const defaultLogLimit = 10000
+
+// Filter returns the Cloud Logging filter for the query
+func (q ContainerLogQuery) Filter(projectID string) string {
+	clauses := []string{
+		`resource.type="k8s_container"`,
+		fmt.Sprintf(`resource.labels.project_id=%q`, projectID),
+	}
+	if q.Cluster != "" {
+		clauses = append(clauses, fmt.Sprintf(`resource.labels.cluster_name=%q`, q.Cluster))
+	}
+	if q.Namespace != "" {
+		clauses = append(clauses, fmt.Sprintf(`resource.labels.namespace_name=%q`, q.Namespace))
+	}
+	if q.Pod != "" {
+		clauses = append(clauses, fmt.Sprintf(`resource.labels.pod_name=%q`, q.Pod))
+	}
+	for _, ns := range q.ExcludeNamespaces {
+		clauses = append(clauses, fmt.Sprintf(`resource.labels.namespace_name!=%q`, ns))
+	}
+	if q.Container != "" {
+		clauses = append(clauses, fmt.Sprintf(`resource.labels.container_name=%q`, q.Container))
+	}
+	if q.MinSeverity != "" {
+		clauses = append(clauses, "severity>="+q.MinSeverity)
+	}
+	if q.Since > 0 {
+		clauses = append(clauses, fmt.Sprintf(`timestamp>=%q`, time.Now().Add(-q.Since).UTC().Format(time.RFC3339)))
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// ReadContainerLogs reads container logs from Cloud Logging, oldest first. This works for
+// pods that no longer exist.
+func ReadContainerLogs(projectID string, q ContainerLogQuery) ([]ContainerLogEntry, error) {
+	limit := q.Limit
+	if limit == 0 {
+		limit = defaultLogLimit
+	}
+
+	var raw []struct {
+		Timestamp   string          `json:"timestamp"`
+		Severity    string          `json:"severity"`
+		TextPayload string          `json:"textPayload"`
+		JSONPayload json.RawMessage `json:"jsonPayload"`
+		Resource    struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"resource"`
+	}
+	// gcloud returns the newest entries first; --order=asc would return the oldest ones
+	// within the limit instead of the latest
+	if err := GcloudJSON(&raw, "logging", "read", q.Filter(projectID), "--project", projectID, "--limit", strconv.Itoa(limit)); err != nil {
+		return nil, err
+	}
+
+	entries := make([]ContainerLogEntry, 0, len(raw))
+	for i := len(raw) - 1; i >= 0; i-- {
+		r := raw[i]
+		entry := ContainerLogEntry{
+			Severity:  r.Severity,
+			Namespace: r.Resource.Labels["namespace_name"],
+			Pod:       r.Resource.Labels["pod_name"],
+			Container: r.Resource.Labels["container_name"],
+			Text:      strings.TrimRight(r.TextPayload, "\n"),
+		}
+		entry.Time, _ = time.Parse(time.RFC3339Nano, r.Timestamp)
+		if len(r.JSONPayload) > 0 {
+			entry.Text = string(r.JSONPayload)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Line returns the entry as a log line: JSON payloads with the timestamp and severity
+// added so they parse like the original, text payloads prefixed with the timestamp
+func (e ContainerLogEntry) Line() string {
+	timestamp := e.Time.UTC().Format(time.RFC3339Nano)
+
+	var fields map[string]any
+	if strings.HasPrefix(e.Text, "{") && json.Unmarshal([]byte(e.Text), &fields) == nil {
+		if _, ok := fields["time"]; !ok {
+			fields["time"] = timestamp
+		}
+		if _, ok := fields["severity"]; !ok && e.Severity != "" && e.Severity != "DEFAULT" {
+			fields["severity"] = e.Severity
+		}
+		if encoded, err := json.Marshal(fields); err == nil {
+			return string(encoded)
+		}
+	}
+	return timestamp + " " + e.Text
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"regexp"
+)
+
+// ANSI color codes used for terminal output
+const (
+	ColorReset   = "\033[0m"
+	ColorRed     = "\033[31m"
+	ColorGreen   = "\033[32m"
+	ColorYellow  = "\033[33m"
+	ColorBlue    = "\033[34m"
+	ColorMagenta = "\033[35m"
+	ColorCyan    = "\033[36m"
+	ColorGray    = "\033[90m"
+)
+
+// ColorEnabled reports whether stdout is a terminal and NO_COLOR is not set
+func ColorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return StdoutIsTerminal()
+}
+
+// StdoutIsTerminal reports whether stdout is a terminal rather than a file or pipe
+func StdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Colorize wraps s in the given color when color output is enabled
+func Colorize(color, s string) string {
+	if !ColorEnabled() {
+		return s
+	}
+	return color + s + ColorReset
+}
+
+// colorPattern matches the ANSI color sequences Colorize adds
+var colorPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plainWriter removes color sequences from what is written to it
+type plainWriter struct {
+	w io.Writer
+}
+
+// NewPlainWriter returns a writer that strips ANSI colors before writing to w, e.g. for
+// saving colored terminal output to a file. Color sequences must not be split across writes.
+func NewPlainWriter(w io.Writer) io.Writer {
+	return plainWriter{w: w}
+}
+
+func (p plainWriter) Write(data []byte) (int, error) {
+	if _, err := p.w.Write(colorPattern.ReplaceAll(data, nil)); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// StdinIsTerminal reports whether stdin is a terminal rather than a file or pipe
+func StdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
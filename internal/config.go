@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds user settings loaded from the gcpeasy config file
+type Config struct {
+	Notifications NotificationConfig           `yaml:"notifications"`
+	Environments  map[string]EnvironmentConfig `yaml:"environments"`
+	// ImageShells maps container image patterns (globs or substrings) to the shell to use
+	ImageShells map[string]string `yaml:"image_shells"`
+	// ManagedBy maps the tools whose resources gcpeasy warns about changing (e.g.
+	// terraform, argocd, flux, helm) to an optional hint on where to change them instead
+	ManagedBy map[string]string `yaml:"managed_by"`
+}
+
+// EnvironmentConfig holds settings for a single environment, keyed by GCP project ID
+type EnvironmentConfig struct {
+	Protected                 bool        `yaml:"protected"`
+	ImpersonateServiceAccount string      `yaml:"impersonate_service_account"`
+	Logs                      LogDefaults `yaml:"logs"`
+	// Shell is the preferred shell for `pod shell` in this environment
+	Shell string `yaml:"shell"`
+	// SessionEnv is set in shell, exec and console sessions, before any --env flags
+	SessionEnv map[string]string `yaml:"session_env"`
+	// SessionLimits time-box interactive sessions; only enforced when Protected is set
+	SessionLimits SessionLimitConfig `yaml:"session_limits"`
+	// IAPClientID is the OAuth client ID of the environment's IAP-protected apps, or a
+	// vault:<name> reference to it
+	IAPClientID string `yaml:"iap_client_id"`
+	// CostRates replace the built-in list prices in `deploy cost`, e.g. to apply discounts
+	CostRates *CostRates `yaml:"cost_rates"`
+	// ImageRepository is the Artifact Registry repository images are promoted from and to,
+	// e.g. us-docker.pkg.dev/my-project/app
+	ImageRepository string `yaml:"image_repository"`
+	// Preview configures `env preview` branch environments
+	Preview PreviewConfig `yaml:"preview"`
+	// Smoke is the HTTP checks `smoke run` sends to the environment after a deploy
+	Smoke SmokeConfig `yaml:"smoke"`
+	// Bastion is the VM `tunnel bastion` forwards through to reach private hosts
+	Bastion string `yaml:"bastion"`
+}
+
+// CostRates are hourly prices per requested vCPU and GiB of memory
+type CostRates struct {
+	CPUHour      float64 `yaml:"cpu_hour"`
+	MemoryGBHour float64 `yaml:"memory_gb_hour"`
+}
+
+// SessionLimitConfig bounds interactive shell and console sessions. Durations use the
+// ParseDuration format; empty values mean no limit.
+type SessionLimitConfig struct {
+	MaxDuration string `yaml:"max_duration"`
+	IdleTimeout string `yaml:"idle_timeout"`
+	// WarnBefore is how long before termination to warn (default 1m)
+	WarnBefore string `yaml:"warn_before"`
+}
+
+// LogDefaults are the `pod logs` settings used for an environment when the corresponding
+// flags aren't given
+type LogDefaults struct {
+	Follow *bool  `yaml:"follow"`
+	Since  string `yaml:"since"`
+	JSON   *bool  `yaml:"json"`
+	Level  string `yaml:"level"`
+	// Tail is the number of recent lines to show, -1 for all
+	Tail       int   `yaml:"tail"`
+	Timestamps *bool `yaml:"timestamps"`
+}
+
+// Environment returns the settings for a project, or zero values if none are configured
+func (c *Config) Environment(projectID string) EnvironmentConfig {
+	return c.Environments[projectID]
+}
+
+// ResolveEnvironment finds the project ID of a configured environment named by its project
+// ID or a unique dash-separated part of it (e.g. "prod" for "my-project-prod"). Names
+// that match no configured environment are returned as they are.
+func (c *Config) ResolveEnvironment(name string) (string, error) {
+	if _, ok := c.Environments[name]; ok {
+		return name, nil
+	}
+
+	var matches []string
+	for projectID := range c.Environments {
+		if slices.Contains(strings.Split(projectID, "-"), name) {
+			matches = append(matches, projectID)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return name, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches several environments (%s)", name, strings.Join(matches, ", "))
+	}
+}
+
+// PreferredShell returns the configured shell for a container image in an environment,
+// or "" to detect one. Image patterns take precedence over the environment's shell.
+func (c *Config) PreferredShell(projectID, image string) string {
+	patterns := make([]string, 0, len(c.ImageShells))
+	for pattern := range c.ImageShells {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, image); matched || strings.Contains(image, pattern) {
+			return c.ImageShells[pattern]
+		}
+	}
+	return c.Environment(projectID).Shell
+}
+
+// NotificationConfig describes where operation notifications are posted. The webhook URLs
+// and header values may be vault:<name> references to values kept in the OS keychain.
+type NotificationConfig struct {
+	SlackWebhook string            `yaml:"slack_webhook"`
+	WebhookURL   string            `yaml:"webhook_url"`
+	Headers      map[string]string `yaml:"headers"`
+}
+
+// ConfigDir returns the directory gcpeasy stores its configuration and state in
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("GCPEASY_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(base, "gcpeasy"), nil
+}
+
+// ConfigPath returns the location of the gcpeasy config file
+func ConfigPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// LoadConfig reads the config file, returning an empty config if it does not exist
+func LoadConfig() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
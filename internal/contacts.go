@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EssentialContact is a contact Google notifies about a project, set on the project or
+// inherited from its folders and organization
+type EssentialContact struct {
+	Email string `json:"email"`
+	// Categories are the notification categories, e.g. SECURITY or TECHNICAL
+	Categories []string `json:"notificationCategorySubscriptions"`
+}
+
+// essentialContactCategories are the notification categories to look up contacts for
+var essentialContactCategories = []string{"all", "suspension", "security", "technical", "billing", "legal", "product-updates", "technical-incidents"}
+
+// GetEssentialContacts returns the project's Essential Contacts, including inherited ones
+func GetEssentialContacts(projectID string) ([]EssentialContact, error) {
+	var contacts []EssentialContact
+	if err := GcloudJSON(&contacts, "essential-contacts", "compute", "--project", projectID,
+		"--notification-categories", strings.Join(essentialContactCategories, ",")); err != nil {
+		return nil, err
+	}
+	sort.Slice(contacts, func(i, k int) bool { return contacts[i].Email < contacts[k].Email })
+	return contacts, nil
+}
+
+// GetProjectLabels returns the labels of a project
+func GetProjectLabels(projectID string) (map[string]string, error) {
+	var project struct {
+		Labels map[string]string `json:"labels"`
+	}
+	if err := GcloudJSON(&project, "projects", "describe", projectID); err != nil {
+		return nil, err
+	}
+	return project.Labels, nil
+}
+
+// ownershipLabelKeys are project label keys that commonly name the owning team or person
+var ownershipLabelKeys = []string{"owner", "owners", "team", "squad", "contact", "maintainer", "department", "cost-center", "costcenter"}
+
+// IsOwnershipLabel reports whether a label key commonly names the owning team or person
+func IsOwnershipLabel(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range ownershipLabelKeys {
+		if key == k || strings.HasSuffix(key, "-"+k) || strings.HasSuffix(key, "_"+k) {
+			return true
+		}
+	}
+	return false
+}
+
+// adminRoles are the roles that can change a project's IAM policy
+var adminRoles = []string{"roles/owner", "roles/resourcemanager.projectIamAdmin", "roles/iam.securityAdmin"}
+
+// ProjectAdmin is a principal that can change a project's IAM policy
+type ProjectAdmin struct {
+	// Member is the policy member, e.g. user:alice@example.com or group:ops@example.com
+	Member string   `json:"member"`
+	Roles  []string `json:"roles"`
+}
+
+// GetProjectAdmins returns the members granted an admin role directly on the project
+func GetProjectAdmins(projectID string) ([]ProjectAdmin, error) {
+	var policy struct {
+		Bindings []struct {
+			Role    string   `json:"role"`
+			Members []string `json:"members"`
+		} `json:"bindings"`
+	}
+	if err := GcloudJSON(&policy, "projects", "get-iam-policy", projectID); err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string][]string)
+	for _, b := range policy.Bindings {
+		for _, role := range adminRoles {
+			if b.Role != role {
+				continue
+			}
+			for _, m := range b.Members {
+				roles[m] = append(roles[m], role)
+			}
+		}
+	}
+	var admins []ProjectAdmin
+	for member, r := range roles {
+		admins = append(admins, ProjectAdmin{Member: member, Roles: r})
+	}
+	sort.Slice(admins, func(i, k int) bool { return admins[i].Member < admins[k].Member })
+	return admins, nil
+}
+
+// IAMChange is a change to the project's IAM policy from the audit log
+type IAMChange struct {
+	Time      time.Time `json:"time"`
+	Principal string    `json:"principal"`
+}
+
+// RecentIAMChanges returns who changed the project's IAM policy in the last since, newest
+// first
+func RecentIAMChanges(projectID string, since time.Duration) ([]IAMChange, error) {
+	filter := strings.Join([]string{
+		fmt.Sprintf(`logName="projects/%s/logs/cloudaudit.googleapis.com%%2Factivity"`, projectID),
+		fmt.Sprintf(`timestamp>=%q`, time.Now().Add(-since).UTC().Format(time.RFC3339)),
+		`protoPayload.methodName="SetIamPolicy"`,
+		`resource.type="project"`,
+	}, " AND ")
+
+	var raw []struct {
+		Timestamp    string `json:"timestamp"`
+		ProtoPayload struct {
+			AuthenticationInfo struct {
+				PrincipalEmail string `json:"principalEmail"`
+			} `json:"authenticationInfo"`
+		} `json:"protoPayload"`
+	}
+	if err := GcloudJSON(&raw, "logging", "read", filter, "--project", projectID, "--limit", "100"); err != nil {
+		return nil, err
+	}
+
+	var changes []IAMChange
+	for _, r := range raw {
+		t, err := time.Parse(time.RFC3339Nano, r.Timestamp)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, IAMChange{Time: t, Principal: orUnknown(r.ProtoPayload.AuthenticationInfo.PrincipalEmail)})
+	}
+	sort.Slice(changes, func(i, k int) bool { return changes[i].Time.After(changes[k].Time) })
+	return changes, nil
+}
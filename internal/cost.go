@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// HoursPerMonth is the average number of hours in a month, as used by GCP pricing
+const HoursPerMonth = 730
+
+// spotDiscount approximates Spot and preemptible VM prices relative to on-demand ones
+const spotDiscount = 0.35
+
+// machineFamilyRates are approximate us-central1 on-demand list prices per vCPU and GiB hour
+var machineFamilyRates = map[string]CostRates{
+	"e2":  {CPUHour: 0.021811, MemoryGBHour: 0.002923},
+	"n1":  {CPUHour: 0.031611, MemoryGBHour: 0.004237},
+	"n2":  {CPUHour: 0.031611, MemoryGBHour: 0.004237},
+	"n2d": {CPUHour: 0.027502, MemoryGBHour: 0.003686},
+	"n4":  {CPUHour: 0.030200, MemoryGBHour: 0.004044},
+	"t2d": {CPUHour: 0.027502, MemoryGBHour: 0.003686},
+	"t2a": {CPUHour: 0.024200, MemoryGBHour: 0.003025},
+	"c2":  {CPUHour: 0.033980, MemoryGBHour: 0.004550},
+	"c2d": {CPUHour: 0.029563, MemoryGBHour: 0.003959},
+	"c3":  {CPUHour: 0.033980, MemoryGBHour: 0.004560},
+	"c3d": {CPUHour: 0.029563, MemoryGBHour: 0.003959},
+	"m1":  {CPUHour: 0.034806, MemoryGBHour: 0.005101},
+}
+
+// autopilotRates are approximate us-central1 Autopilot general-purpose pod prices, which
+// are billed by resource requests
+var autopilotRates = CostRates{CPUHour: 0.0445, MemoryGBHour: 0.0049225}
+
+// NodePricing is what a node's capacity costs per requested vCPU and GiB
+type NodePricing struct {
+	MachineType string
+	Capacity    string
+	Rates       CostRates
+	// Source explains where the rates come from
+	Source string
+}
+
+// PriceNode looks up the rates for a node from its machine type and capacity type.
+// Configured rates replace the built-in prices; unknown machine families fall back to e2.
+func PriceNode(n Node, configured *CostRates) NodePricing {
+	p := NodePricing{
+		MachineType: n.Metadata.Labels["node.kubernetes.io/instance-type"],
+		Capacity:    n.CapacityType(),
+	}
+
+	family, _, _ := strings.Cut(p.MachineType, "-")
+	switch {
+	case configured != nil:
+		p.Rates, p.Source = *configured, "configured cost_rates"
+	case strings.HasPrefix(n.Metadata.Name, "gk3-"):
+		// Autopilot nodes are named gk3-<cluster>-...; pods are billed at Autopilot rates
+		p.Rates, p.Source = autopilotRates, "Autopilot list price"
+	default:
+		rates, ok := machineFamilyRates[family]
+		p.Rates, p.Source = rates, family+" list price"
+		if !ok {
+			p.Rates, p.Source = machineFamilyRates["e2"], "e2 list price (unknown machine family)"
+		}
+	}
+
+	if configured == nil && p.Capacity != CapacityStandard {
+		p.Rates.CPUHour *= spotDiscount
+		p.Rates.MemoryGBHour *= spotDiscount
+		p.Source += ", " + p.Capacity + " discount"
+	}
+	return p
+}
+
+// MonthlyCost is the monthly cost of a vCPU and memory (bytes) request at these rates
+func (p NodePricing) MonthlyCost(cpu, memory float64) float64 {
+	return (cpu*p.Rates.CPUHour + memory/(1<<30)*p.Rates.MemoryGBHour) * HoursPerMonth
+}
+
+// WorkloadCost estimates what a deployment's requests cost per month
+type WorkloadCost struct {
+	Replicas int
+	// CPU and Memory are the requests of one pod, in cores and bytes
+	CPU    float64
+	Memory float64
+	// PodMonthly is the average monthly cost of one pod
+	PodMonthly float64
+	// Pricing lists the node pricing the running pods were costed at, most common first
+	Pricing []NodePricing
+	// PodsByPricing counts the running pods per Pricing entry
+	PodsByPricing []int
+	// Assumed is set when no pods are running and Pricing describes the cluster's nodes
+	Assumed bool
+}
+
+// Monthly is the monthly cost of all desired replicas
+func (c WorkloadCost) Monthly() float64 {
+	return c.PodMonthly * float64(c.Replicas)
+}
+
+// podRequests sums the CPU and memory requests of a pod spec's containers
+func podRequests(spec PodSpec) (cpu, memory float64) {
+	for _, c := range spec.Containers {
+		v, _ := ParseQuantity(c.Resources.Requests["cpu"])
+		cpu += v
+		v, _ = ParseQuantity(c.Resources.Requests["memory"])
+		memory += v
+	}
+	return cpu, memory
+}
+
+// EstimateDeploymentCost prices a deployment's requests at the rates of the nodes its pods
+// run on. Without running pods, the most common node type in the cluster is used.
+func EstimateDeploymentCost(d Deployment, configured *CostRates) (WorkloadCost, error) {
+	cost := WorkloadCost{Replicas: d.DesiredReplicas()}
+	cost.CPU, cost.Memory = podRequests(d.Spec.Template.Spec)
+
+	var nodes NodeList
+	if err := KubectlJSON(&nodes, "get", "nodes", "-o", "json"); err != nil {
+		return cost, err
+	}
+	byName := make(map[string]Node)
+	for _, n := range nodes.Items {
+		byName[n.Metadata.Name] = n
+	}
+
+	var pods PodList
+	if err := KubectlJSON(&pods, "get", "pods", "-n", d.Metadata.Namespace, "-o", "json"); err != nil {
+		return cost, err
+	}
+
+	counts := make(map[string]int)
+	pricing := make(map[string]NodePricing)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != "Running" || !d.Spec.Selector.Matches(pod.Metadata.Labels) {
+			continue
+		}
+		n, ok := byName[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		p := PriceNode(n, configured)
+		key := p.MachineType + "/" + p.Capacity + "/" + p.Source
+		pricing[key] = p
+		counts[key]++
+	}
+
+	// Without running pods, assume the cluster's most common node type
+	if len(counts) == 0 {
+		cost.Assumed = true
+		for _, n := range nodes.Items {
+			p := PriceNode(n, configured)
+			key := p.MachineType + "/" + p.Capacity + "/" + p.Source
+			pricing[key] = p
+			counts[key]++
+		}
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if cost.Assumed && len(keys) > 0 {
+		keys = keys[:1]
+	}
+
+	total, counted := 0.0, 0
+	for _, key := range keys {
+		cost.Pricing = append(cost.Pricing, pricing[key])
+		cost.PodsByPricing = append(cost.PodsByPricing, counts[key])
+		total += pricing[key].MonthlyCost(cost.CPU, cost.Memory) * float64(counts[key])
+		counted += counts[key]
+	}
+	if counted > 0 {
+		cost.PodMonthly = total / float64(counted)
+	}
+	return cost, nil
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CustomResourceDefinition is a Kubernetes apiextensions/v1 CRD
+type CustomResourceDefinition struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Group string `json:"group"`
+		Scope string `json:"scope"`
+		Names struct {
+			Kind       string   `json:"kind"`
+			Plural     string   `json:"plural"`
+			Singular   string   `json:"singular"`
+			ShortNames []string `json:"shortNames"`
+		} `json:"names"`
+		Versions []struct {
+			Name    string `json:"name"`
+			Served  bool   `json:"served"`
+			Storage bool   `json:"storage"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// CustomResourceDefinitionList is the result of `kubectl get crds -o json`
+type CustomResourceDefinitionList struct {
+	Items []CustomResourceDefinition `json:"items"`
+}
+
+// Resource returns the fully qualified resource name (e.g. "certificates.cert-manager.io")
+func (c CustomResourceDefinition) Resource() string {
+	return c.Spec.Names.Plural + "." + c.Spec.Group
+}
+
+// StorageVersion returns the version objects are persisted in
+func (c CustomResourceDefinition) StorageVersion() string {
+	for _, v := range c.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return "-"
+}
+
+// Namespaced reports whether instances of the CRD live in namespaces
+func (c CustomResourceDefinition) Namespaced() bool {
+	return c.Spec.Scope == "Namespaced"
+}
+
+// matches reports whether name refers to this CRD by kind, plural, singular, short name
+// or fully qualified resource name
+func (c CustomResourceDefinition) matches(name string) bool {
+	n := c.Spec.Names
+	candidates := append([]string{n.Kind, n.Plural, n.Singular, c.Resource(), n.Kind + "." + c.Spec.Group}, n.ShortNames...)
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCRDs returns the custom resource definitions installed in the cluster, sorted by group and kind
+func GetCRDs() ([]CustomResourceDefinition, error) {
+	var list CustomResourceDefinitionList
+	if err := KubectlJSON(&list, "get", "customresourcedefinitions", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	crds := list.Items
+	sort.Slice(crds, func(i, j int) bool {
+		if crds[i].Spec.Group != crds[j].Spec.Group {
+			return crds[i].Spec.Group < crds[j].Spec.Group
+		}
+		return crds[i].Spec.Names.Kind < crds[j].Spec.Names.Kind
+	})
+	return crds, nil
+}
+
+// FindCRD resolves a kind, plural, short name or "kind.group" to a single CRD
+func FindCRD(name string) (*CustomResourceDefinition, error) {
+	crds, err := GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []CustomResourceDefinition
+	for _, c := range crds {
+		if c.matches(name) {
+			matched = append(matched, c)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no custom resource definition matches %q (see 'gcpeasy crd list')", name)
+	case 1:
+		return &matched[0], nil
+	default:
+		var names []string
+		for _, c := range matched {
+			names = append(names, c.Resource())
+		}
+		return nil, fmt.Errorf("%q is ambiguous, use one of: %s", name, strings.Join(names, ", "))
+	}
+}
+
+// CustomResource is a generic custom resource instance with standard status conditions
+type CustomResource struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Status   struct {
+		Conditions []Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// CustomResourceList is the result of `kubectl get <resource> -o json`
+type CustomResourceList struct {
+	Items []CustomResource `json:"items"`
+}
+
+// Condition returns the named status condition, if present
+func (r CustomResource) Condition(conditionType string) (Condition, bool) {
+	for _, c := range r.Status.Conditions {
+		if c.Type == conditionType {
+			return c, true
+		}
+	}
+	return Condition{}, false
+}
+
+// Age returns how long ago the resource was created
+func (r CustomResource) Age() string {
+	created, err := time.Parse(time.RFC3339, r.Metadata.CreationTimestamp)
+	if err != nil {
+		return "-"
+	}
+	return FormatDuration(time.Since(created))
+}
+
+// GetCustomResources lists instances of a CRD, in one namespace or across all of them when namespace is empty
+func GetCustomResources(crd CustomResourceDefinition, namespace string) ([]CustomResource, error) {
+	args := []string{"get", crd.Resource(), "-o", "json"}
+	if crd.Namespaced() {
+		if namespace == "" {
+			args = append(args, "--all-namespaces")
+		} else {
+			args = append(args, "-n", namespace)
+		}
+	}
+
+	var list CustomResourceList
+	if err := KubectlJSON(&list, args...); err != nil {
+		return nil, err
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Metadata.Namespace != items[j].Metadata.Namespace {
+			return items[i].Metadata.Namespace < items[j].Metadata.Namespace
+		}
+		return items[i].Metadata.Name < items[j].Metadata.Name
+	})
+	return items, nil
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DebugPodImage has dig, nslookup and getent; it is the image the Kubernetes DNS
+// debugging guide uses
+const DebugPodImage = "registry.k8s.io/e2e-test-images/jessie-dnsutils:1.7"
+
+// DebugPodLabel marks the throwaway pods gcpeasy creates, so leftovers can be found
+const DebugPodLabel = "app.kubernetes.io/managed-by=gcpeasy"
+
+// RunDebugPod runs script with args in a throwaway pod in namespace and returns its
+// output. The pod is deleted when the script exits.
+func RunDebugPod(namespace, image, script string, args ...string) (string, error) {
+	name := fmt.Sprintf("gcpeasy-debug-%x", time.Now().UnixNano()&0xffffff)
+	kubectlArgs := []string{
+		"run", name, "-n", namespace, "--image", image, "--labels", DebugPodLabel,
+		"--restart=Never", "--rm", "-i", "--quiet", "--pod-running-timeout=2m",
+		"--command", "--", "sh", "-c", script, "sh",
+	}
+	kubectlArgs = append(kubectlArgs, args...)
+
+	cmd := exec.Command("kubectl", kubectlArgs...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("kubectl run: %s", msg)
+		}
+		return stdout.String(), fmt.Errorf("kubectl run: %w", err)
+	}
+	return stdout.String(), nil
+}
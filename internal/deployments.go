@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetDeployments returns the deployments in application namespaces, or in the namespace
+// set with SetNamespaceScope
+func GetDeployments() ([]Deployment, error) {
+	var list DeploymentList
+	args := append([]string{"get", "deployments"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var deployments []Deployment
+	for _, d := range list.Items {
+		if !isSystemNamespace(d.Metadata.Namespace) {
+			deployments = append(deployments, d)
+		}
+	}
+	return deployments, nil
+}
+
+// FindDeployment looks up a deployment given as "namespace/name" or a bare name, which
+// must be unique across namespaces
+func FindDeployment(deployments []Deployment, target string) (*Deployment, error) {
+	namespace, name, qualified := strings.Cut(target, "/")
+	if !qualified {
+		namespace, name = "", target
+	}
+
+	var matches []*Deployment
+	for i, d := range deployments {
+		if d.Metadata.Name == name && (namespace == "" || d.Metadata.Namespace == namespace) {
+			matches = append(matches, &deployments[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("deployment %s not found", target)
+	case 1:
+		return matches[0], nil
+	default:
+		var names []string
+		for _, d := range matches {
+			names = append(names, d.Metadata.Namespace+"/"+d.Metadata.Name)
+		}
+		return nil, fmt.Errorf("%s matches several deployments (%s), use namespace/name", target, strings.Join(names, ", "))
+	}
+}
+
+// SelectDeployment prompts the user to select a deployment from the list
+func SelectDeployment(deployments []Deployment) (*Deployment, error) {
+	if len(deployments) == 0 {
+		return nil, fmt.Errorf("no deployments available")
+	}
+
+	fmt.Printf("📋 Found %d deployment(s):\n", len(deployments))
+	fmt.Println()
+
+	for i, d := range deployments {
+		fmt.Printf("%d. %s/%s (%d/%d ready)\n", i+1, d.Metadata.Namespace, d.Metadata.Name, d.Status.ReadyReplicas, d.DesiredReplicas())
+	}
+
+	fmt.Println()
+	fmt.Print("Select deployment (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(deployments) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &deployments[num-1], nil
+}
+
+// WatchRollout streams `kubectl rollout status` for a deployment until the rollout
+// completes, fails its progress deadline or timeout passes
+func WatchRollout(d Deployment, timeout time.Duration) error {
+	cmd := exec.Command("kubectl", "rollout", "status", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace,
+		"--timeout", timeout.String())
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("rollout of %s did not complete: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// RestartDeployment triggers a rolling restart of a deployment's pods, as with
+// `kubectl rollout restart`
+func RestartDeployment(d Deployment) error {
+	if _, err := runOutput("kubectl", "rollout", "restart", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// GetDeployment fetches a deployment's current state
+func GetDeployment(namespace, name string) (*Deployment, error) {
+	var d Deployment
+	if err := KubectlJSON(&d, "get", "deployment", name, "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ScaleDeployment sets a deployment's replicas, as with `kubectl scale`
+func ScaleDeployment(d Deployment, replicas int) error {
+	if _, err := runOutput("kubectl", "scale", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace, "--replicas="+strconv.Itoa(replicas)); err != nil {
+		return fmt.Errorf("failed to scale %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// Autoscaler is the HorizontalPodAutoscaler of a deployment
+type Autoscaler struct {
+	Name        string
+	MinReplicas int
+	MaxReplicas int
+}
+
+// DeploymentAutoscaler returns the HorizontalPodAutoscaler that scales a deployment, if any
+func DeploymentAutoscaler(d Deployment) (*Autoscaler, error) {
+	var list struct {
+		Items []struct {
+			Metadata ObjectMeta `json:"metadata"`
+			Spec     struct {
+				ScaleTargetRef struct {
+					Kind string `json:"kind"`
+					Name string `json:"name"`
+				} `json:"scaleTargetRef"`
+				MinReplicas *int `json:"minReplicas"`
+				MaxReplicas int  `json:"maxReplicas"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "horizontalpodautoscalers", "-n", d.Metadata.Namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	for _, hpa := range list.Items {
+		target := hpa.Spec.ScaleTargetRef
+		if target.Kind != "Deployment" || target.Name != d.Metadata.Name {
+			continue
+		}
+		// minReplicas defaults to 1
+		min := 1
+		if hpa.Spec.MinReplicas != nil {
+			min = *hpa.Spec.MinReplicas
+		}
+		return &Autoscaler{Name: hpa.Metadata.Name, MinReplicas: min, MaxReplicas: hpa.Spec.MaxReplicas}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetPodEvents returns the events recorded for a pod, oldest first. The API server keeps
+// events for about an hour.
+func GetPodEvents(namespace, name string) ([]KubeEvent, error) {
+	var list KubeEventList
+	selector := "involvedObject.kind=Pod,involvedObject.name=" + name
+	if err := KubectlJSON(&list, "get", "events", "-n", namespace, "--field-selector", selector, "-o", "json"); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list.Items, func(i, k int) bool { return list.Items[i].Time().Before(list.Items[k].Time()) })
+	return list.Items, nil
+}
+
+// ProbeFailure sums up the failures of one probe of a container
+type ProbeFailure struct {
+	Container string
+	// Probe is Liveness, Readiness or Startup
+	Probe string
+	Count int
+	Last  time.Time
+	// Message is the latest failure
+	Message string
+}
+
+// ProbeFailures collects the probe failures reported by a pod's Unhealthy events,
+// most recent first
+func ProbeFailures(events []KubeEvent) []ProbeFailure {
+	byProbe := make(map[string]*ProbeFailure)
+	var failures []*ProbeFailure
+	for _, e := range events {
+		if e.Reason != "Unhealthy" {
+			continue
+		}
+		// e.g. "Readiness probe failed: HTTP probe failed with statuscode: 503"
+		probe, message, ok := strings.Cut(e.Message, " probe failed: ")
+		if !ok {
+			probe, message, ok = strings.Cut(e.Message, " probe errored: ")
+		}
+		if !ok {
+			continue
+		}
+		container := containerFromFieldPath(e.InvolvedObject.FieldPath)
+
+		key := container + "/" + probe
+		f, seen := byProbe[key]
+		if !seen {
+			f = &ProbeFailure{Container: container, Probe: probe}
+			byProbe[key] = f
+			failures = append(failures, f)
+		}
+		f.Count += max(e.Count, 1)
+		if t := e.Time(); !t.Before(f.Last) {
+			f.Last = t
+			f.Message = strings.TrimSpace(message)
+		}
+	}
+
+	result := make([]ProbeFailure, len(failures))
+	for i, f := range failures {
+		result[i] = *f
+	}
+	sort.SliceStable(result, func(i, k int) bool { return result[i].Last.After(result[k].Last) })
+	return result
+}
+
+// containerFromFieldPath extracts the container name from a field path such as
+// spec.containers{app}
+func containerFromFieldPath(fieldPath string) string {
+	_, rest, ok := strings.Cut(fieldPath, "{")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, "}")
+	return name
+}
+
+// DescribeProbe renders a probe the way kubectl describe does, e.g.
+// "http-get :8080/healthz delay=10s timeout=1s period=10s #failure=3"
+func DescribeProbe(p *Probe) string {
+	var handler string
+	switch {
+	case p.HTTPGet != nil:
+		scheme := ""
+		if p.HTTPGet.Scheme != "" && p.HTTPGet.Scheme != "HTTP" {
+			scheme = strings.ToLower(p.HTTPGet.Scheme) + "://"
+		}
+		handler = fmt.Sprintf("http-get %s:%v%s", scheme, p.HTTPGet.Port, p.HTTPGet.Path)
+	case p.TCPSocket != nil:
+		handler = fmt.Sprintf("tcp-socket :%v", p.TCPSocket.Port)
+	case p.GRPC != nil:
+		handler = fmt.Sprintf("grpc :%d", p.GRPC.Port)
+	case p.Exec != nil:
+		handler = "exec [" + strings.Join(p.Exec.Command, " ") + "]"
+	default:
+		handler = "unknown"
+	}
+	// Unset fields fall back to the API server's defaults
+	timeout, period, failures := p.TimeoutSeconds, p.PeriodSeconds, p.FailureThreshold
+	if timeout == 0 {
+		timeout = 1
+	}
+	if period == 0 {
+		period = 10
+	}
+	if failures == 0 {
+		failures = 3
+	}
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #failure=%d", handler, p.InitialDelaySeconds, timeout, period, failures)
+}
+
+// DescribeContainerState renders a container state, e.g. "Running for 2h3m",
+// "Waiting: CrashLoopBackOff" or "Terminated: Error (exit code 1)"
+func DescribeContainerState(s ContainerState) string {
+	switch {
+	case s.Running != nil:
+		if started, err := time.Parse(time.RFC3339, s.Running.StartedAt); err == nil {
+			return "Running for " + FormatDuration(time.Since(started))
+		}
+		return "Running"
+	case s.Waiting != nil:
+		if s.Waiting.Reason == "" {
+			return "Waiting"
+		}
+		return "Waiting: " + s.Waiting.Reason
+	case s.Terminated != nil:
+		state := fmt.Sprintf("Terminated: %s (exit code %d)", orUnknown(s.Terminated.Reason), s.Terminated.ExitCode)
+		if finished, err := time.Parse(time.RFC3339, s.Terminated.FinishedAt); err == nil {
+			state += " " + FormatDuration(time.Since(finished)) + " ago"
+		}
+		return state
+	}
+	return "Unknown"
+}
@@ -0,0 +1,354 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Field change kinds reported by DiffFields
+const (
+	FieldAdded    = "added"
+	FieldRemoved  = "removed"
+	FieldModified = "modified"
+)
+
+// FieldChange is a single difference between two decoded objects
+type FieldChange struct {
+	Path string
+	Kind string
+	Old  interface{}
+	New  interface{}
+	// Hidden is set for secret values, which are never printed
+	Hidden bool
+}
+
+// DiffFields compares two decoded JSON/YAML values and returns field-level changes
+// sorted by path. Maps are compared key by key and lists index by index.
+func DiffFields(old, new interface{}) []FieldChange {
+	var changes []FieldChange
+	diffValue("", normalizeValue(old), normalizeValue(new), &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diffValue(path string, old, new interface{}, changes *[]FieldChange) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		n, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for k, ov := range o {
+			nv, exists := n[k]
+			if !exists {
+				*changes = append(*changes, FieldChange{Path: joinPath(path, k), Kind: FieldRemoved, Old: ov})
+				continue
+			}
+			diffValue(joinPath(path, k), ov, nv, changes)
+		}
+		for k, nv := range n {
+			if _, exists := o[k]; !exists {
+				*changes = append(*changes, FieldChange{Path: joinPath(path, k), Kind: FieldAdded, New: nv})
+			}
+		}
+		return
+	case []interface{}:
+		n, ok := new.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(o) || i < len(n); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(n):
+				*changes = append(*changes, FieldChange{Path: p, Kind: FieldRemoved, Old: o[i]})
+			case i >= len(o):
+				*changes = append(*changes, FieldChange{Path: p, Kind: FieldAdded, New: n[i]})
+			default:
+				diffValue(p, o[i], n[i], changes)
+			}
+		}
+		return
+	}
+
+	if !valuesEqual(old, new) {
+		*changes = append(*changes, FieldChange{Path: path, Kind: FieldModified, Old: old, New: new})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func valuesEqual(a, b interface{}) bool {
+	return FormatValue(a) == FormatValue(b)
+}
+
+// normalizeValue round-trips a value through JSON so that YAML-decoded and
+// JSON-decoded objects share the same map/slice/number types
+func normalizeValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// FormatValue renders a value compactly for diff output
+func FormatValue(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// PrintFieldChanges prints changes indented beneath an object header, colored by kind
+func PrintFieldChanges(changes []FieldChange, indent string) {
+	for _, c := range changes {
+		if c.Hidden {
+			printHiddenChange(c, indent)
+			continue
+		}
+		switch c.Kind {
+		case FieldAdded:
+			fmt.Println(Colorize(ColorGreen, fmt.Sprintf("%s+ %s: %s", indent, c.Path, FormatValue(c.New))))
+		case FieldRemoved:
+			fmt.Println(Colorize(ColorRed, fmt.Sprintf("%s- %s: %s", indent, c.Path, FormatValue(c.Old))))
+		default:
+			fmt.Printf("%s~ %s: %s → %s\n", indent, c.Path,
+				Colorize(ColorRed, FormatValue(c.Old)),
+				Colorize(ColorGreen, FormatValue(c.New)))
+		}
+	}
+}
+
+func printHiddenChange(c FieldChange, indent string) {
+	switch c.Kind {
+	case FieldAdded:
+		fmt.Println(Colorize(ColorGreen, fmt.Sprintf("%s+ %s: (added)", indent, c.Path)))
+	case FieldRemoved:
+		fmt.Println(Colorize(ColorRed, fmt.Sprintf("%s- %s: (removed)", indent, c.Path)))
+	default:
+		fmt.Printf("%s~ %s: %s\n", indent, c.Path, Colorize(ColorYellow, "(changed)"))
+	}
+}
+
+// DiffDesired compares a desired manifest against a live object, only considering
+// fields present in the desired manifest so server-defaulted fields are not reported.
+// Old values come from the live object and New values from the desired manifest.
+func DiffDesired(desired, live interface{}) []FieldChange {
+	var changes []FieldChange
+	diffSubset("", normalizeValue(desired), normalizeValue(live), &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diffSubset(path string, desired, live interface{}, changes *[]FieldChange) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for k, dv := range d {
+			lv, exists := l[k]
+			if !exists {
+				*changes = append(*changes, FieldChange{Path: joinPath(path, k), Kind: FieldAdded, New: dv})
+				continue
+			}
+			diffSubset(joinPath(path, k), dv, lv, changes)
+		}
+		return
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(d) || i < len(l); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(l):
+				*changes = append(*changes, FieldChange{Path: p, Kind: FieldAdded, New: d[i]})
+			case i >= len(d):
+				*changes = append(*changes, FieldChange{Path: p, Kind: FieldRemoved, Old: l[i]})
+			default:
+				diffSubset(p, d[i], l[i], changes)
+			}
+		}
+		return
+	}
+
+	if !valuesEqual(desired, live) {
+		*changes = append(*changes, FieldChange{Path: path, Kind: FieldModified, Old: live, New: desired})
+	}
+}
+
+// DiffSecret compares a desired Secret against the live one like DiffDesired, without
+// ever reporting values: stringData is encoded into data as the API server does, and each
+// data key is only reported as added or changed
+func DiffSecret(desired, live map[string]interface{}) []FieldChange {
+	d, _ := normalizeValue(desired).(map[string]interface{})
+	l, _ := normalizeValue(live).(map[string]interface{})
+	desiredData, liveData := secretData(d), secretData(l)
+
+	changes := DiffDesired(d, l)
+	for k, dv := range desiredData {
+		path := joinPath("data", k)
+		lv, exists := liveData[k]
+		switch {
+		case !exists:
+			changes = append(changes, FieldChange{Path: path, Kind: FieldAdded, Hidden: true})
+		case lv != dv:
+			changes = append(changes, FieldChange{Path: path, Kind: FieldModified, Hidden: true})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// secretData removes data and stringData from a Secret and returns its base64-encoded
+// values by key, with stringData taking precedence as it does on the API server
+func secretData(obj map[string]interface{}) map[string]string {
+	values := make(map[string]string)
+	if data, ok := obj["data"].(map[string]interface{}); ok {
+		for k, v := range data {
+			values[k] = fmt.Sprint(v)
+		}
+	}
+	if data, ok := obj["stringData"].(map[string]interface{}); ok {
+		for k, v := range data {
+			values[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(v)))
+		}
+	}
+	delete(obj, "data")
+	delete(obj, "stringData")
+	return values
+}
+
+// DiffLine is one line of a line-based diff; Op is ' ', '-' or '+'
+type DiffLine struct {
+	Op   byte
+	Text string
+}
+
+// maxLCSCells caps the size of the LCS table LineDiff builds, about 8MB
+const maxLCSCells = 1 << 20
+
+// LineDiff computes a line diff between a and b using the longest common subsequence.
+// Common leading and trailing lines are matched first; when what remains is too large for
+// the LCS table, it is reported as removed and re-added in full.
+func LineDiff(a, b []string) []DiffLine {
+	var lines []DiffLine
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		lines = append(lines, DiffLine{' ', a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines = append(lines, lcsDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, DiffLine{' ', line})
+	}
+	return lines
+}
+
+func lcsDiff(a, b []string) []DiffLine {
+	var lines []DiffLine
+	if (len(a)+1)*(len(b)+1) > maxLCSCells {
+		for _, line := range a {
+			lines = append(lines, DiffLine{'-', line})
+		}
+		for _, line := range b {
+			lines = append(lines, DiffLine{'+', line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, DiffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// PrintLineDiff prints changed lines with the given number of context lines around them
+func PrintLineDiff(lines []DiffLine, context int) {
+	show := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == ' ' {
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(lines)-1, i+context); k++ {
+			show[k] = true
+		}
+	}
+
+	skipped := false
+	for i, l := range lines {
+		if !show[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			fmt.Println(Colorize(ColorCyan, "@@ ... @@"))
+			skipped = false
+		}
+		switch l.Op {
+		case '-':
+			fmt.Println(Colorize(ColorRed, "-"+l.Text))
+		case '+':
+			fmt.Println(Colorize(ColorGreen, "+"+l.Text))
+		default:
+			fmt.Println(" " + l.Text)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DNSLookup is the answer for a hostname from one vantage point
+type DNSLookup struct {
+	Addresses []string
+	// Status is NOERROR, NXDOMAIN, SERVFAIL, TIMEOUT or another resolver status
+	Status  string
+	Elapsed time.Duration
+	// Nameservers, Search and Ndots describe the resolver configuration, when known
+	Nameservers []string
+	Search      []string
+	Ndots       string
+	Err         error
+}
+
+// Resolved reports whether the lookup returned any addresses
+func (l DNSLookup) Resolved() bool {
+	return len(l.Addresses) > 0
+}
+
+// clusterDNSScript resolves $1 the way applications do (getent, through libc with the
+// search domains) and asks dig for the resolver status
+const clusterDNSScript = `echo "@@resolv"; cat /etc/resolv.conf
+echo "@@getent"; start=$(date +%s%N); getent ahosts "$1" | awk '{print $1}' | sort -u; end=$(date +%s%N)
+echo "@@elapsed $(( (end - start) / 1000000 ))"
+echo "@@dig"; dig +search +time=2 +tries=2 "$1" | grep -E "status:|connection timed out"`
+
+// LookupHostLocal resolves host with the local machine's resolver
+func LookupHostLocal(host string) DNSLookup {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	lookup := DNSLookup{Elapsed: time.Since(start), Status: "NOERROR"}
+	if err != nil {
+		lookup.Err = err
+		var dnsErr *net.DNSError
+		switch {
+		case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+			lookup.Status = "NXDOMAIN"
+		case errors.As(err, &dnsErr) && dnsErr.IsTimeout:
+			lookup.Status = "TIMEOUT"
+		default:
+			lookup.Status = "SERVFAIL"
+		}
+		return lookup
+	}
+	sort.Strings(addrs)
+	lookup.Addresses = addrs
+	return lookup
+}
+
+// LookupHostInCluster resolves host from a throwaway pod in namespace, so the answer
+// reflects the cluster DNS, its search domains and the VPC's private zones
+func LookupHostInCluster(namespace, host string) DNSLookup {
+	output, err := RunDebugPod(namespace, DebugPodImage, clusterDNSScript, host)
+	if err != nil && output == "" {
+		return DNSLookup{Err: err}
+	}
+	return parseClusterDNSOutput(output)
+}
+
+func parseClusterDNSOutput(output string) DNSLookup {
+	var lookup DNSLookup
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if marker, ok := strings.CutPrefix(line, "@@"); ok {
+			if ms, ok := strings.CutPrefix(marker, "elapsed "); ok {
+				if d, err := time.ParseDuration(ms + "ms"); err == nil {
+					lookup.Elapsed = d
+				}
+				continue
+			}
+			section = marker
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch section {
+		case "resolv":
+			switch fields[0] {
+			case "nameserver":
+				lookup.Nameservers = append(lookup.Nameservers, fields[1:]...)
+			case "search":
+				lookup.Search = fields[1:]
+			case "options":
+				for _, opt := range fields[1:] {
+					if ndots, ok := strings.CutPrefix(opt, "ndots:"); ok {
+						lookup.Ndots = ndots
+					}
+				}
+			}
+		case "getent":
+			lookup.Addresses = append(lookup.Addresses, fields[0])
+		case "dig":
+			if strings.Contains(line, "connection timed out") {
+				lookup.Status = "TIMEOUT"
+			} else if _, status, ok := strings.Cut(line, "status: "); ok {
+				lookup.Status = strings.TrimSuffix(strings.Fields(status)[0], ",")
+			}
+		}
+	}
+	if lookup.Status == "" && lookup.Resolved() {
+		lookup.Status = "NOERROR"
+	}
+	sort.Strings(lookup.Addresses)
+	return lookup
+}
+
+// ClusterDNSPod is a kube-dns/CoreDNS or NodeLocal DNSCache pod
+type ClusterDNSPod struct {
+	Name     string
+	Node     string
+	Ready    bool
+	Restarts int
+	// LastReason is why the pod's containers last terminated, e.g. OOMKilled
+	LastReason string
+}
+
+// GetClusterDNSPods returns the cluster DNS pods (kube-dns on GKE, or CoreDNS) and the
+// NodeLocal DNSCache pods, if the cache is enabled
+func GetClusterDNSPods() (dns []ClusterDNSPod, nodeLocal []ClusterDNSPod, err error) {
+	if dns, err = getDNSPods("k8s-app=kube-dns"); err != nil {
+		return nil, nil, err
+	}
+	if nodeLocal, err = getDNSPods("k8s-app=node-local-dns"); err != nil {
+		return nil, nil, err
+	}
+	return dns, nodeLocal, nil
+}
+
+func getDNSPods(selector string) ([]ClusterDNSPod, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "-n", "kube-system", "-l", selector, "-o", "json"); err != nil {
+		return nil, err
+	}
+	pods := make([]ClusterDNSPod, 0, len(list.Items))
+	for _, p := range list.Items {
+		pod := ClusterDNSPod{Name: p.Metadata.Name, Node: p.Spec.NodeName, Ready: p.Status.Phase == "Running"}
+		for _, cs := range p.Status.ContainerStatuses {
+			pod.Ready = pod.Ready && cs.Ready
+			pod.Restarts += cs.RestartCount
+			if cs.LastState.Terminated != nil {
+				pod.LastReason = cs.LastState.Terminated.Reason
+			}
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EgressProbeImage has nc and an HTTPS-capable wget
+const EgressProbeImage = "busybox:1.36"
+
+// egressConnectTimeout bounds the TCP connection attempt, in seconds
+const egressConnectTimeout = 10
+
+// egressProbeScript connects to $1:$2, then asks an echo service for the address the
+// connection appears to come from
+var egressProbeScript = fmt.Sprintf(`now() { t=$(date +%%s%%N 2>/dev/null); case "$t" in *[!0-9]*|"") echo 0;; *) echo "$t";; esac; }
+start=$(now)
+err=$(nc -z -w %[1]d "$1" "$2" 2>&1); code=$?
+end=$(now)
+if [ "$start" -gt 0 ] && [ "$end" -gt 0 ]; then elapsed=$(( (end - start) / 1000000 )); else elapsed=-1; fi
+echo "@@connect $code $elapsed"
+echo "@@error $err" | tr '\n' ' '; echo
+echo "@@source $(wget -qO- -T %[1]d https://api.ipify.org 2>/dev/null)"`, egressConnectTimeout)
+
+// EgressProbe is the result of an outbound connection test from inside the cluster
+type EgressProbe struct {
+	Connected bool
+	// Elapsed is how long the connection attempt took, 0 if unknown
+	Elapsed time.Duration
+	// Error is the connection error reported by nc
+	Error string
+	// SourceIP is the public address outbound connections appear to come from
+	SourceIP string
+}
+
+// TimedOut reports whether the connection attempt ran into the timeout
+func (p EgressProbe) TimedOut() bool {
+	return !p.Connected && (strings.Contains(p.Error, "timed out") || p.Elapsed >= egressConnectTimeout*time.Second)
+}
+
+// ProbeEgress tests a TCP connection to host:port from a throwaway pod in namespace
+func ProbeEgress(namespace, host string, port int) (EgressProbe, error) {
+	output, err := RunDebugPod(namespace, EgressProbeImage, egressProbeScript, host, strconv.Itoa(port))
+	if err != nil && !strings.Contains(output, "@@connect") {
+		return EgressProbe{}, err
+	}
+	return parseEgressOutput(output), nil
+}
+
+func parseEgressOutput(output string) EgressProbe {
+	var probe EgressProbe
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "@@connect "):
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				probe.Connected = fields[1] == "0"
+				if ms, err := strconv.Atoi(fields[2]); err == nil && ms >= 0 {
+					probe.Elapsed = time.Duration(ms) * time.Millisecond
+				}
+			}
+		case strings.HasPrefix(line, "@@error"):
+			probe.Error = strings.TrimSpace(strings.TrimPrefix(line, "@@error"))
+		case strings.HasPrefix(line, "@@source"):
+			probe.SourceIP = strings.TrimSpace(strings.TrimPrefix(line, "@@source"))
+		}
+	}
+	return probe
+}
+
+// GetNodeExternalIPs maps the cluster nodes' external IPs to node names
+func GetNodeExternalIPs() (map[string]string, error) {
+	var list NodeList
+	if err := KubectlJSON(&list, "get", "nodes", "-o", "json"); err != nil {
+		return nil, err
+	}
+	ips := make(map[string]string)
+	for _, n := range list.Items {
+		for _, addr := range n.Status.Addresses {
+			if addr.Type == "ExternalIP" {
+				ips[addr.Address] = n.Metadata.Name
+			}
+		}
+	}
+	return ips, nil
+}
+
+// NATUsage summarises a Cloud NAT gateway's port usage and drops over a window
+type NATUsage struct {
+	Gateway string
+	Region  string
+	// DroppedOutOfResources counts packets dropped because the VM ran out of NAT ports
+	DroppedOutOfResources float64
+	// DroppedEndpointConflict counts packets dropped by endpoint-independent mapping conflicts
+	DroppedEndpointConflict float64
+	// PeakPortUsage is the highest number of ports a VM used to a single destination
+	PeakPortUsage float64
+	// MinAllocatedPorts is the smallest number of ports allocated to a VM
+	MinAllocatedPorts float64
+	// AllocationFailed reports whether the gateway failed to allocate NAT IPs
+	AllocationFailed bool
+}
+
+// PortUtilization is peak port usage relative to the ports allocated per VM
+func (u NATUsage) PortUtilization() float64 {
+	if u.MinAllocatedPorts == 0 {
+		return 0
+	}
+	return u.PeakPortUsage / u.MinAllocatedPorts
+}
+
+// GetNATUsage reads the Cloud NAT port and drop metrics of every gateway in the project
+func GetNATUsage(projectID string, since time.Duration) ([]NATUsage, error) {
+	usage := make(map[string]*NATUsage)
+	var order []string
+	get := func(s TimeSeries) *NATUsage {
+		key := s.ResourceLabels["region"] + "/" + s.ResourceLabels["gateway_name"]
+		if u, ok := usage[key]; ok {
+			return u
+		}
+		u := &NATUsage{Gateway: s.ResourceLabels["gateway_name"], Region: s.ResourceLabels["region"]}
+		usage[key] = u
+		order = append(order, key)
+		return u
+	}
+	groupBy := []string{"resource.labels.region", "resource.labels.gateway_name"}
+
+	dropped, err := QueryTimeSeries(projectID, MonitoringQuery{
+		Filter:  `metric.type="router.googleapis.com/nat/dropped_sent_packets_count" AND resource.type="nat_gateway"`,
+		Window:  since,
+		Aligner: "ALIGN_SUM",
+		Reducer: "REDUCE_SUM",
+		GroupBy: append(groupBy, "metric.labels.reason"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range dropped {
+		total := 0.0
+		for _, p := range s.Points {
+			total += p
+		}
+		u := get(s)
+		switch s.MetricLabels["reason"] {
+		case "OUT_OF_RESOURCES":
+			u.DroppedOutOfResources += total
+		case "ENDPOINT_INDEPENDENCE_CONFLICT":
+			u.DroppedEndpointConflict += total
+		}
+	}
+
+	for _, q := range []struct {
+		metric  string
+		aligner string
+		reducer string
+		apply   func(u *NATUsage, s TimeSeries)
+	}{
+		{"port_usage", "ALIGN_MAX", "REDUCE_MAX", func(u *NATUsage, s TimeSeries) { u.PeakPortUsage = s.Max() }},
+		{"allocated_ports", "ALIGN_MIN", "REDUCE_MIN", func(u *NATUsage, s TimeSeries) {
+			if len(s.Points) > 0 {
+				u.MinAllocatedPorts = s.Points[0]
+				for _, p := range s.Points {
+					u.MinAllocatedPorts = min(u.MinAllocatedPorts, p)
+				}
+			}
+		}},
+		{"nat_allocation_failed", "ALIGN_COUNT_TRUE", "REDUCE_MAX", func(u *NATUsage, s TimeSeries) { u.AllocationFailed = s.Max() > 0 }},
+	} {
+		series, err := QueryTimeSeries(projectID, MonitoringQuery{
+			Filter:  fmt.Sprintf(`metric.type="router.googleapis.com/nat/%s" AND resource.type="nat_gateway"`, q.metric),
+			Window:  since,
+			Aligner: q.aligner,
+			Reducer: q.reducer,
+			GroupBy: groupBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range series {
+			q.apply(get(s), s)
+		}
+	}
+
+	result := make([]NATUsage, 0, len(order))
+	for _, key := range order {
+		result = append(result, *usage[key])
+	}
+	return result, nil
+}
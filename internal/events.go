@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event names emitted with --events-json
+const (
+	EventCommandStarted  = "command.started"
+	EventCommandFinished = "command.finished"
+	EventStepStarted     = "step.started"
+	EventStepFinished    = "step.finished"
+	EventClusterSelected = "cluster.selected"
+	EventPodSelected     = "pod.selected"
+	EventPrompt          = "prompt"
+	EventExecStarted     = "exec.started"
+	EventExecFinished    = "exec.finished"
+)
+
+var events struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// EnableEvents sends structured events as JSON lines to w
+func EnableEvents(w io.Writer) {
+	events.Lock()
+	events.w = w
+	events.Unlock()
+}
+
+// EventsEnabled reports whether structured events are being emitted
+func EventsEnabled() bool {
+	events.Lock()
+	defer events.Unlock()
+	return events.w != nil
+}
+
+// EmitEvent writes a single structured event when events are enabled. Fields are merged
+// into the event object alongside "time" and "event".
+func EmitEvent(name string, fields map[string]any) {
+	events.Lock()
+	defer events.Unlock()
+	if events.w == nil {
+		return
+	}
+
+	event := map[string]any{
+		"time":  time.Now().UTC().Format(time.RFC3339Nano),
+		"event": name,
+	}
+	for k, v := range fields {
+		event[k] = v
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	events.w.Write(append(data, '\n'))
+}
+
+// EmitStep emits step.started, runs fn and emits step.finished with its outcome
+func EmitStep(step string, fn func() error) error {
+	EmitEvent(EventStepStarted, map[string]any{"step": step})
+	started := time.Now()
+	err := fn()
+	fields := map[string]any{"step": step, "ok": err == nil, "duration_ms": time.Since(started).Milliseconds()}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	EmitEvent(EventStepFinished, fields)
+	return err
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// KubectlJSON runs kubectl with the given arguments and decodes its JSON output into out
+func KubectlJSON(out interface{}, args ...string) error {
+	return runJSON(out, "kubectl", args...)
+}
+
+// GcloudJSON runs gcloud with the given arguments and decodes its JSON output into out
+func GcloudJSON(out interface{}, args ...string) error {
+	return runJSON(out, "gcloud", append(args, "--format=json")...)
+}
+
+func runJSON(out interface{}, name string, args ...string) error {
+	output, err := runOutput(name, args...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return fmt.Errorf("failed to parse %s output: %w", name, err)
+	}
+	return nil
+}
+
+// runOutput runs a command and returns stdout, folding stderr into the error on failure
+func runOutput(name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s %s: %s", name, args[0], msg)
+		}
+		return nil, fmt.Errorf("%s %s: %w", name, args[0], err)
+	}
+	return output, nil
+}
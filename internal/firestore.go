@@ -0,0 +1,322 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FirestoreDefaultDatabase is the ID of a project's default Firestore database
+const FirestoreDefaultDatabase = "(default)"
+
+// FirestoreDocument is a document with its fields decoded to plain values: strings,
+// booleans, json.Number, nil, []any and map[string]any
+type FirestoreDocument struct {
+	// Path is the document's path, e.g. users/alice
+	Path       string         `json:"path"`
+	Fields     map[string]any `json:"fields"`
+	CreateTime string         `json:"createTime"`
+	UpdateTime string         `json:"updateTime"`
+}
+
+// FirestoreFilter is a field filter of a Firestore query
+type FirestoreFilter struct {
+	Field string
+	// Op is a Firestore operator, e.g. EQUAL or GREATER_THAN
+	Op    string
+	Value any
+}
+
+// firestoreOperators maps the filter operators accepted by ParseFirestoreFilter to the
+// API's, longest first so ">=" isn't read as ">"
+var firestoreOperators = []struct{ symbol, op string }{
+	{"==", "EQUAL"},
+	{"!=", "NOT_EQUAL"},
+	{">=", "GREATER_THAN_OR_EQUAL"},
+	{"<=", "LESS_THAN_OR_EQUAL"},
+	{">", "GREATER_THAN"},
+	{"<", "LESS_THAN"},
+	{"=", "EQUAL"},
+}
+
+// ParseFirestoreFilter parses a filter like status==active, age>=21 or deleted=null.
+// Values are numbers, true, false or null when they look like one, otherwise strings;
+// quote a value ("42") to force a string.
+func ParseFirestoreFilter(s string) (FirestoreFilter, error) {
+	for i := range s {
+		for _, o := range firestoreOperators {
+			if !strings.HasPrefix(s[i:], o.symbol) {
+				continue
+			}
+			field := strings.TrimSpace(s[:i])
+			if field == "" {
+				return FirestoreFilter{}, fmt.Errorf("invalid filter %q: missing field", s)
+			}
+			value := parseFirestoreValue(strings.TrimSpace(s[i+len(o.symbol):]))
+			if value == nil && o.op != "EQUAL" && o.op != "NOT_EQUAL" {
+				return FirestoreFilter{}, fmt.Errorf("invalid filter %q: null can only be compared with == or !=", s)
+			}
+			return FirestoreFilter{Field: field, Op: o.op, Value: value}, nil
+		}
+	}
+	return FirestoreFilter{}, fmt.Errorf("invalid filter %q: use <field><op><value> with ==, !=, <, <=, > or >=", s)
+}
+
+func parseFirestoreValue(s string) any {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	switch s {
+	case "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// firestoreCondition converts a filter to the API's. Comparisons with null are unary
+// IS_NULL and IS_NOT_NULL filters, as the client libraries send them; a field filter
+// with a null value is rejected.
+func firestoreCondition(f FirestoreFilter) map[string]any {
+	field := map[string]any{"fieldPath": f.Field}
+	if f.Value == nil {
+		op := "IS_NULL"
+		if f.Op == "NOT_EQUAL" {
+			op = "IS_NOT_NULL"
+		}
+		return map[string]any{"unaryFilter": map[string]any{"field": field, "op": op}}
+	}
+	return map[string]any{"fieldFilter": map[string]any{
+		"field": field,
+		"op":    f.Op,
+		"value": encodeFirestoreValue(f.Value),
+	}}
+}
+
+// encodeFirestoreValue converts a filter value to the API's typed value
+func encodeFirestoreValue(v any) map[string]any {
+	switch v := v.(type) {
+	case bool:
+		return map[string]any{"booleanValue": v}
+	case int64:
+		return map[string]any{"integerValue": strconv.FormatInt(v, 10)}
+	case float64:
+		return map[string]any{"doubleValue": v}
+	default:
+		return map[string]any{"stringValue": fmt.Sprint(v)}
+	}
+}
+
+// firestoreValue is the API's typed value; exactly one field is set
+type firestoreValue struct {
+	NullValue      *string  `json:"nullValue"`
+	BooleanValue   *bool    `json:"booleanValue"`
+	IntegerValue   *string  `json:"integerValue"`
+	DoubleValue    *float64 `json:"doubleValue"`
+	TimestampValue *string  `json:"timestampValue"`
+	StringValue    *string  `json:"stringValue"`
+	BytesValue     *string  `json:"bytesValue"`
+	ReferenceValue *string  `json:"referenceValue"`
+	GeoPointValue  *struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"geoPointValue"`
+	ArrayValue *struct {
+		Values []firestoreValue `json:"values"`
+	} `json:"arrayValue"`
+	MapValue *struct {
+		Fields map[string]firestoreValue `json:"fields"`
+	} `json:"mapValue"`
+}
+
+// decode converts the value to a plain one; timestamps, bytes and references become
+// strings and geo points maps
+func (v firestoreValue) decode() any {
+	switch {
+	case v.BooleanValue != nil:
+		return *v.BooleanValue
+	case v.IntegerValue != nil:
+		return json.Number(*v.IntegerValue)
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.TimestampValue != nil:
+		return *v.TimestampValue
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	case v.ReferenceValue != nil:
+		return *v.ReferenceValue
+	case v.GeoPointValue != nil:
+		return map[string]any{"latitude": v.GeoPointValue.Latitude, "longitude": v.GeoPointValue.Longitude}
+	case v.ArrayValue != nil:
+		values := make([]any, 0, len(v.ArrayValue.Values))
+		for _, item := range v.ArrayValue.Values {
+			values = append(values, item.decode())
+		}
+		return values
+	case v.MapValue != nil:
+		return decodeFirestoreFields(v.MapValue.Fields)
+	}
+	return nil
+}
+
+func decodeFirestoreFields(fields map[string]firestoreValue) map[string]any {
+	decoded := make(map[string]any, len(fields))
+	for name, value := range fields {
+		decoded[name] = value.decode()
+	}
+	return decoded
+}
+
+// firestoreDocument is a document as returned by the API
+type firestoreDocument struct {
+	Name       string                    `json:"name"`
+	Fields     map[string]firestoreValue `json:"fields"`
+	CreateTime string                    `json:"createTime"`
+	UpdateTime string                    `json:"updateTime"`
+}
+
+func (d firestoreDocument) decode() FirestoreDocument {
+	path := d.Name
+	if i := strings.Index(path, "/documents/"); i >= 0 {
+		path = path[i+len("/documents/"):]
+	}
+	return FirestoreDocument{Path: path, Fields: decodeFirestoreFields(d.Fields), CreateTime: d.CreateTime, UpdateTime: d.UpdateTime}
+}
+
+var firestoreClient = &http.Client{Timeout: 60 * time.Second}
+
+// firestoreRequest calls the Firestore API for the project's database and decodes the
+// response into out
+func firestoreRequest(projectID, database, method, path string, body any, out any) error {
+	token, err := AccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	endpoint := fmt.Sprintf("https://firestore.googleapis.com/v1/projects/%s/databases/%s/documents%s", projectID, database, path)
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := firestoreClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("firestore API returned %s: %s", resp.Status, apiErr.Error.Message)
+		}
+		return fmt.Errorf("firestore API returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse firestore response: %w", err)
+	}
+	return nil
+}
+
+// firestoreSegments splits a document or collection path into its segments
+func firestoreSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// GetFirestoreDocument gets a document by its path, e.g. users/alice
+func GetFirestoreDocument(projectID, database, path string) (*FirestoreDocument, error) {
+	segments := firestoreSegments(path)
+	if len(segments)%2 != 0 {
+		return nil, fmt.Errorf("%q is a collection, not a document: use <collection>/<document>", path)
+	}
+
+	var doc firestoreDocument
+	if err := firestoreRequest(projectID, database, http.MethodGet, "/"+strings.Join(segments, "/"), nil, &doc); err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, fmt.Errorf("document %s not found", path)
+		}
+		return nil, err
+	}
+	decoded := doc.decode()
+	return &decoded, nil
+}
+
+// QueryFirestore returns up to limit documents of a collection, e.g. users or
+// users/alice/orders, that match all filters
+func QueryFirestore(projectID, database, collection string, filters []FirestoreFilter, limit int) ([]FirestoreDocument, error) {
+	segments := firestoreSegments(collection)
+	if len(segments)%2 != 1 {
+		return nil, fmt.Errorf("%q is a document, not a collection", collection)
+	}
+	parent := ""
+	if len(segments) > 1 {
+		parent = "/" + strings.Join(segments[:len(segments)-1], "/")
+	}
+
+	query := map[string]any{
+		"from": []map[string]any{{"collectionId": segments[len(segments)-1]}},
+	}
+	if limit > 0 {
+		query["limit"] = limit
+	}
+	var conditions []map[string]any
+	for _, f := range filters {
+		conditions = append(conditions, firestoreCondition(f))
+	}
+	switch len(conditions) {
+	case 0:
+	case 1:
+		query["where"] = conditions[0]
+	default:
+		query["where"] = map[string]any{"compositeFilter": map[string]any{"op": "AND", "filters": conditions}}
+	}
+
+	var results []struct {
+		Document *firestoreDocument `json:"document"`
+	}
+	body := map[string]any{"structuredQuery": query}
+	if err := firestoreRequest(projectID, database, http.MethodPost, parent+":runQuery", body, &results); err != nil {
+		return nil, err
+	}
+
+	var docs []FirestoreDocument
+	for _, r := range results {
+		if r.Document != nil {
+			docs = append(docs, r.Document.decode())
+		}
+	}
+	return docs, nil
+}
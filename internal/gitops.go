@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// GitOps resources gcpeasy knows how to report on
+const (
+	ArgoApplicationResource   = "applications.argoproj.io"
+	FluxKustomizationResource = "kustomizations.kustomize.toolkit.fluxcd.io"
+)
+
+// GitOpsStatus is the sync/health state of a single ArgoCD Application or Flux Kustomization
+type GitOpsStatus struct {
+	Tool          string
+	Namespace     string
+	Name          string
+	Sync          string
+	Health        string
+	Revision      string
+	LastReconcile string
+	Message       string
+}
+
+// Healthy reports whether the resource is in sync and healthy
+func (s GitOpsStatus) Healthy() bool {
+	return (s.Sync == "Synced" || s.Sync == "Applied") && (s.Health == "Healthy" || s.Health == "Ready")
+}
+
+type argoApplicationList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Status   struct {
+			Sync struct {
+				Status   string `json:"status"`
+				Revision string `json:"revision"`
+			} `json:"sync"`
+			Health struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"health"`
+			ReconciledAt   string      `json:"reconciledAt"`
+			Conditions     []Condition `json:"conditions"`
+			OperationState struct {
+				Phase   string `json:"phase"`
+				Message string `json:"message"`
+			} `json:"operationState"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type fluxKustomizationList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Suspend bool `json:"suspend"`
+		} `json:"spec"`
+		Status struct {
+			LastAppliedRevision   string      `json:"lastAppliedRevision"`
+			LastAttemptedRevision string      `json:"lastAttemptedRevision"`
+			Conditions            []Condition `json:"conditions"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// GetArgoApplications returns the status of every ArgoCD Application in the cluster
+func GetArgoApplications() ([]GitOpsStatus, error) {
+	var list argoApplicationList
+	if err := KubectlJSON(&list, "get", ArgoApplicationResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var statuses []GitOpsStatus
+	for _, app := range list.Items {
+		s := GitOpsStatus{
+			Tool:          "argocd",
+			Namespace:     app.Metadata.Namespace,
+			Name:          app.Metadata.Name,
+			Sync:          app.Status.Sync.Status,
+			Health:        app.Status.Health.Status,
+			Revision:      shortRevision(app.Status.Sync.Revision),
+			LastReconcile: app.Status.ReconciledAt,
+		}
+
+		// Surface error conditions first, then failed sync operations
+		for _, c := range app.Status.Conditions {
+			if strings.Contains(c.Type, "Error") {
+				s.Message = c.Message
+				break
+			}
+		}
+		if s.Message == "" && (app.Status.OperationState.Phase == "Failed" || app.Status.OperationState.Phase == "Error") {
+			s.Message = app.Status.OperationState.Message
+		}
+		if s.Message == "" && s.Health != "Healthy" {
+			s.Message = app.Status.Health.Message
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	sortGitOps(statuses)
+	return statuses, nil
+}
+
+// GetFluxKustomizations returns the status of every Flux Kustomization in the cluster
+func GetFluxKustomizations() ([]GitOpsStatus, error) {
+	var list fluxKustomizationList
+	if err := KubectlJSON(&list, "get", FluxKustomizationResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var statuses []GitOpsStatus
+	for _, k := range list.Items {
+		s := GitOpsStatus{
+			Tool:      "flux",
+			Namespace: k.Metadata.Namespace,
+			Name:      k.Metadata.Name,
+			Revision:  shortRevision(k.Status.LastAppliedRevision),
+			Health:    "Unknown",
+		}
+
+		for _, c := range k.Status.Conditions {
+			if c.Type != "Ready" {
+				continue
+			}
+			s.LastReconcile = c.LastTransitionTime
+			if c.Status == "True" {
+				s.Health = "Ready"
+			} else {
+				s.Health = "NotReady"
+				s.Message = c.Reason + ": " + c.Message
+			}
+		}
+
+		switch {
+		case k.Spec.Suspend:
+			s.Sync = "Suspended"
+		case k.Status.LastAttemptedRevision != "" && k.Status.LastAttemptedRevision != k.Status.LastAppliedRevision:
+			s.Sync = "OutOfSync"
+		default:
+			s.Sync = "Applied"
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	sortGitOps(statuses)
+	return statuses, nil
+}
+
+// shortRevision trims git SHAs (optionally prefixed with a branch, e.g. "main@sha1:abc...")
+func shortRevision(rev string) string {
+	if i := strings.LastIndex(rev, ":"); i >= 0 {
+		rev = rev[i+1:]
+	}
+	if i := strings.LastIndex(rev, "/"); i >= 0 {
+		rev = rev[i+1:]
+	}
+	if len(rev) > 12 {
+		return rev[:12]
+	}
+	return rev
+}
+
+func sortGitOps(statuses []GitOpsStatus) {
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+}
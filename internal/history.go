@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// historyMaxEntries is how many entries are kept when the history file is compacted
+const historyMaxEntries = 500
+
+// HistoryEntry records a single gcpeasy invocation and the targets it resolved
+type HistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Args    []string  `json:"args"`
+	Project string    `json:"project,omitempty"`
+	Context string    `json:"context,omitempty"`
+	Pod     string    `json:"pod,omitempty"`
+	// Redacted is set when only the command and its flag names were recorded, without
+	// positional arguments or flag values, because they may hold secrets
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Command returns the invocation as it would be typed on the command line
+func (e HistoryEntry) Command() string {
+	if e.Redacted {
+		return "gcpeasy " + strings.Join(e.Args, " ") + " (values not recorded)"
+	}
+	return "gcpeasy " + strings.Join(e.Args, " ")
+}
+
+func historyPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// AppendHistory adds an entry to the audit trail, compacting it when it grows large
+func AppendHistory(entry HistoryEntry) error {
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > 1<<20 {
+		return compactHistory(path)
+	}
+	return nil
+}
+
+func compactHistory(path string) error {
+	entries, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+	if len(entries) > historyMaxEntries {
+		entries = entries[len(entries)-historyMaxEntries:]
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		data, _ := json.Marshal(e)
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	return os.WriteFile(path, []byte(b.String()), 0600)
+}
+
+// LoadHistory returns all recorded invocations, oldest first
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the infrastructure-as-code snippets printed by --emit-iac
+const (
+	IaCYAML      = "yaml"
+	IaCTerraform = "terraform"
+)
+
+// ValidIaCFormat reports whether f is one of the snippet formats
+func ValidIaCFormat(f string) bool {
+	return f == IaCYAML || f == IaCTerraform
+}
+
+// marshalYAML renders v as YAML indented by two spaces, as manifests usually are
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var terraformNameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// terraformName turns a Kubernetes name into a Terraform resource name, e.g. my-app to
+// my_app
+func terraformName(name string) string {
+	return terraformNameInvalid.ReplaceAllString(name, "_")
+}
+
+// deploymentIaC renders the spec fields of a deployment as a partial manifest or as the
+// matching blocks of a kubernetes_deployment_v1 resource. tfSpec is the body of the
+// resource's spec block.
+func deploymentIaC(format string, d Deployment, spec map[string]any, tfSpec string) (string, error) {
+	if format == IaCTerraform {
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Only the changed attributes; merge them into the existing resource\n")
+		fmt.Fprintf(&b, "resource \"kubernetes_deployment_v1\" %q {\n", terraformName(d.Metadata.Name))
+		fmt.Fprintf(&b, "  metadata {\n    name      = %q\n    namespace = %q\n  }\n", d.Metadata.Name, d.Metadata.Namespace)
+		fmt.Fprintf(&b, "  spec {\n%s  }\n}\n", tfSpec)
+		return b.String(), nil
+	}
+
+	data, err := marshalYAML(map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": d.Metadata.Name, "namespace": d.Metadata.Namespace},
+		"spec":       spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	return "# Only the changed fields; merge them into the existing manifest\n" + string(data), nil
+}
+
+// DeploymentReplicasIaC returns a snippet setting a deployment's replicas
+func DeploymentReplicasIaC(format string, d Deployment, replicas int) (string, error) {
+	return deploymentIaC(format, d, map[string]any{"replicas": replicas},
+		fmt.Sprintf("    replicas = %d\n", replicas))
+}
+
+// DeploymentImageIaC returns a snippet setting the image of a deployment's container
+func DeploymentImageIaC(format string, d Deployment, container, image string) (string, error) {
+	spec := map[string]any{"template": map[string]any{"spec": map[string]any{
+		"containers": []any{map[string]any{"name": container, "image": image}},
+	}}}
+	tfSpec := fmt.Sprintf("    template {\n      spec {\n        container {\n          name  = %q\n          image = %q\n        }\n      }\n    }\n", container, image)
+	return deploymentIaC(format, d, spec, tfSpec)
+}
+
+// ScaleScheduleIaC returns the objects of a scale schedule as YAML documents, or as
+// kubernetes_manifest resources
+func ScaleScheduleIaC(format string, s ScaleSchedule) (string, error) {
+	docs, err := ScaleScheduleManifests(s)
+	if err != nil {
+		return "", err
+	}
+	if format != IaCTerraform {
+		return strings.Join(docs, "---\n"), nil
+	}
+
+	var resources []string
+	for i, object := range scheduleManifests(s) {
+		metadata, _ := object["metadata"].(map[string]any)
+		name := fmt.Sprintf("%s_%s", strings.ToLower(fmt.Sprint(object["kind"])), terraformName(fmt.Sprint(metadata["name"])))
+		var b strings.Builder
+		fmt.Fprintf(&b, "resource \"kubernetes_manifest\" %q {\n", name)
+		b.WriteString("  manifest = yamldecode(<<-EOT\n")
+		for _, line := range strings.Split(strings.TrimRight(docs[i], "\n"), "\n") {
+			b.WriteString("    " + line + "\n")
+		}
+		b.WriteString("  EOT\n  )\n}\n")
+		resources = append(resources, b.String())
+	}
+	return strings.Join(resources, "\n"), nil
+}
@@ -0,0 +1,248 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServiceAccountEmail returns the email of a service account given as an email or as the
+// account ID of a service account in the project
+func ServiceAccountEmail(projectID, account string) string {
+	if strings.Contains(account, "@") {
+		return account
+	}
+	return fmt.Sprintf("%s@%s.iam.gserviceaccount.com", account, projectID)
+}
+
+// ServiceAccountKey is a user-managed key of a service account
+type ServiceAccountKey struct {
+	// Name is the full resource name, ending in the key ID
+	Name        string `json:"name"`
+	ValidAfter  string `json:"validAfterTime"`
+	ValidBefore string `json:"validBeforeTime"`
+	Disabled    bool   `json:"disabled"`
+}
+
+// ID is the key's ID, as shown in the console and in key files
+func (k ServiceAccountKey) ID() string {
+	return path.Base(k.Name)
+}
+
+// Created is when the key was created
+func (k ServiceAccountKey) Created() time.Time {
+	t, _ := time.Parse(time.RFC3339, k.ValidAfter)
+	return t
+}
+
+// GetServiceAccountKeys returns the user-managed keys of a service account
+func GetServiceAccountKeys(projectID, account string) ([]ServiceAccountKey, error) {
+	var keys []ServiceAccountKey
+	if err := GcloudJSON(&keys, "iam", "service-accounts", "keys", "list", "--iam-account", account,
+		"--managed-by", "user", "--project", projectID); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// SelectServiceAccountKey prompts the user to select a key
+func SelectServiceAccountKey(keys []ServiceAccountKey) (*ServiceAccountKey, error) {
+	fmt.Printf("📋 Found %d key(s):\n", len(keys))
+	fmt.Println()
+
+	for i, k := range keys {
+		fmt.Printf("%d. %s (created %s ago)\n", i+1, k.ID(), FormatDuration(time.Since(k.Created())))
+	}
+
+	fmt.Println()
+	fmt.Print("Select key (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(keys) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &keys[num-1], nil
+}
+
+// CreateServiceAccountKey creates a JSON key for a service account, writes it to file
+// and returns its key ID
+func CreateServiceAccountKey(projectID, account, file string) (string, error) {
+	if _, err := runOutput("gcloud", "iam", "service-accounts", "keys", "create", file,
+		"--iam-account", account, "--project", projectID); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	var key struct {
+		PrivateKeyID string `json:"private_key_id"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return "", fmt.Errorf("failed to parse the new key: %w", err)
+	}
+	return key.PrivateKeyID, nil
+}
+
+// VerifyServiceAccountKey checks that a key file authenticates by getting an access token
+// with it, in a throwaway gcloud configuration so the active account is untouched. New
+// keys can take a minute to be usable, so it retries until timeout passes.
+func VerifyServiceAccountKey(file string, timeout time.Duration) error {
+	config, err := os.MkdirTemp("", "gcpeasy-key-check-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(config)
+
+	run := func(args ...string) error {
+		cmd := exec.Command("gcloud", args...)
+		cmd.Env = append(os.Environ(), "CLOUDSDK_CONFIG="+config)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	if err := run("auth", "activate-service-account", "--key-file", file); err != nil {
+		return fmt.Errorf("failed to activate the key: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := run("auth", "print-access-token")
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the key does not authenticate: %w", err)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// DisableServiceAccountKey disables a key, so it no longer authenticates but can still be
+// re-enabled
+func DisableServiceAccountKey(projectID, account, keyID string) error {
+	_, err := runOutput("gcloud", "iam", "service-accounts", "keys", "disable", keyID,
+		"--iam-account", account, "--project", projectID)
+	return err
+}
+
+// DeleteServiceAccountKey deletes a key permanently
+func DeleteServiceAccountKey(projectID, account, keyID string) error {
+	_, err := runOutput("gcloud", "iam", "service-accounts", "keys", "delete", keyID,
+		"--iam-account", account, "--project", projectID, "--quiet")
+	return err
+}
+
+// UpdateSecretKey sets one key of a Kubernetes Secret, leaving its other keys as they are
+func UpdateSecretKey(namespace, name, key string, value []byte) error {
+	patch, err := json.Marshal(map[string]any{"data": map[string]string{key: base64.StdEncoding.EncodeToString(value)}})
+	if err != nil {
+		return err
+	}
+	// The patch goes through a file so the key doesn't show up in the process list
+	file, err := os.CreateTemp("", "gcpeasy-secret-patch-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(patch); err != nil {
+		file.Close()
+		return err
+	}
+	file.Close()
+
+	_, err = runOutput("kubectl", "patch", "secret", name, "-n", namespace, "--type", "merge", "--patch-file", file.Name())
+	return err
+}
+
+// AddSecretVersion adds a new version to a Secret Manager secret from a file
+func AddSecretVersion(projectID, secret, file string) error {
+	_, err := runOutput("gcloud", "secrets", "versions", "add", secret, "--data-file", file, "--project", projectID)
+	return err
+}
+
+// KeyDeletion is a disabled service account key scheduled for deletion once it has stayed
+// disabled for a grace period, so it can be re-enabled if something still used it
+type KeyDeletion struct {
+	ProjectID   string    `json:"project_id"`
+	Account     string    `json:"account"`
+	KeyID       string    `json:"key_id"`
+	DisabledAt  time.Time `json:"disabled_at"`
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
+func keyDeletionsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "key-deletions.json"), nil
+}
+
+// LoadKeyDeletions returns the scheduled key deletions
+func LoadKeyDeletions() ([]KeyDeletion, error) {
+	path, err := keyDeletionsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deletions []KeyDeletion
+	if err := json.Unmarshal(data, &deletions); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return deletions, nil
+}
+
+// SaveKeyDeletions replaces the scheduled key deletions
+func SaveKeyDeletions(deletions []KeyDeletion) error {
+	path, err := keyDeletionsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(deletions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// ScheduleKeyDeletion records a disabled key to delete after grace
+func ScheduleKeyDeletion(projectID, account, keyID string, grace time.Duration) (KeyDeletion, error) {
+	now := time.Now()
+	d := KeyDeletion{ProjectID: projectID, Account: account, KeyID: keyID, DisabledAt: now, DeleteAfter: now.Add(grace)}
+	deletions, err := LoadKeyDeletions()
+	if err != nil {
+		return d, err
+	}
+	return d, SaveKeyDeletions(append(deletions, d))
+}
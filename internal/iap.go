@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// iapClient doesn't follow redirects: IAP answers a rejected token with a redirect to the
+// Google sign-in page, which should be reported rather than fetched
+var iapClient = &http.Client{
+	Timeout: 60 * time.Second,
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// IdentityToken returns an OIDC identity token for audience, e.g. an IAP OAuth client ID.
+// gcloud can only mint tokens with a custom audience for service accounts, so user
+// accounts need impersonateServiceAccount.
+func IdentityToken(audience, impersonateServiceAccount string) (string, error) {
+	args := []string{"auth", "print-identity-token", "--audiences=" + audience}
+	if impersonateServiceAccount != "" {
+		args = append(args, "--impersonate-service-account="+impersonateServiceAccount, "--include-email")
+	}
+	output, err := runOutput("gcloud", args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IAPRequest sends an HTTP request to an IAP-protected URL with an identity token.
+// headers are "Name: value" strings, as accepted by curl.
+func IAPRequest(method, url, token string, headers []string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", header)
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	// IAP consumes Proxy-Authorization, leaving Authorization for the application
+	if req.Header.Get("Authorization") != "" {
+		req.Header.Set("Proxy-Authorization", "Bearer "+token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return iapClient.Do(req)
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ImageRef is a container image reference, e.g. us-docker.pkg.dev/proj/repo/app:1.2.3
+type ImageRef struct {
+	// Repository is everything before the image name, e.g. us-docker.pkg.dev/proj/repo
+	Repository string
+	Name       string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageRef splits an image reference into its repository, name, tag and digest
+func ParseImageRef(ref string) ImageRef {
+	var r ImageRef
+	ref, r.Digest, _ = strings.Cut(ref, "@")
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		r.Repository, ref = ref[:i], ref[i+1:]
+	}
+	r.Name, r.Tag, _ = strings.Cut(ref, ":")
+	return r
+}
+
+// String formats the reference, preferring the digest over the tag when both are set
+func (r ImageRef) String() string {
+	s := r.Name
+	if r.Repository != "" {
+		s = r.Repository + "/" + s
+	}
+	switch {
+	case r.Digest != "":
+		return s + "@" + r.Digest
+	case r.Tag != "":
+		return s + ":" + r.Tag
+	}
+	return s
+}
+
+// ImageDigest returns the digest an Artifact Registry image reference points at
+func ImageDigest(ref string) (string, error) {
+	var image struct {
+		ImageSummary struct {
+			Digest string `json:"digest"`
+		} `json:"image_summary"`
+	}
+	if err := GcloudJSON(&image, "artifacts", "docker", "images", "describe", ref); err != nil {
+		return "", err
+	}
+	if image.ImageSummary.Digest == "" {
+		return "", fmt.Errorf("no digest found for %s", ref)
+	}
+	return image.ImageSummary.Digest, nil
+}
+
+// PromoteImage makes dst point at the same image as src. Within a repository this only
+// adds a tag; across repositories the image is copied.
+func PromoteImage(src, dst ImageRef) error {
+	args := []string{"artifacts", "docker", "tags", "add", src.String(), dst.String()}
+	if src.Repository != dst.Repository {
+		args = []string{"container", "images", "add-tag", src.String(), dst.String(), "--quiet"}
+	}
+
+	cmd := exec.Command("gcloud", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to promote %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
+
+// ChangeCauseAnnotation is shown as the CHANGE-CAUSE of a revision by `kubectl rollout history`
+const ChangeCauseAnnotation = "kubernetes.io/change-cause"
+
+// SetDeploymentImage updates the image of one of a deployment's containers, which rolls
+// out new pods. changeCause, when set, is recorded in the change-cause annotation.
+func SetDeploymentImage(d Deployment, container, image, changeCause string) error {
+	// One patch, so the new ReplicaSet carries the cause into `kubectl rollout history` and
+	// a rejected image doesn't leave the current revision labelled with it
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []map[string]string{{"name": container, "image": image}},
+				},
+			},
+		},
+	}
+	if changeCause != "" {
+		patch["metadata"] = map[string]any{
+			"annotations": map[string]string{ChangeCauseAnnotation: changeCause},
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("kubectl", "patch", "deployment", d.Metadata.Name, "-n", d.Metadata.Namespace, "--type", "strategic", "-p", string(data))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set image of %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// ContainerForImage finds the container of a deployment running an image with the given
+// name, from any repository
+func ContainerForImage(d Deployment, name string) (Container, bool) {
+	for _, c := range d.Spec.Template.Spec.Containers {
+		if ParseImageRef(c.Image).Name == name {
+			return c, true
+		}
+	}
+	return Container{}, false
+}
@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Time is when the event last happened
+func (e KubeEvent) Time() time.Time {
+	for _, ts := range []string{e.LastTimestamp, e.EventTime, e.Metadata.CreationTimestamp} {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// GetWarningEvents returns the Warning events of the last since in application
+// namespaces, or in the namespace set with SetNamespaceScope, oldest first
+func GetWarningEvents(since time.Duration) ([]KubeEvent, error) {
+	var list KubeEventList
+	args := append([]string{"get", "events"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "--field-selector", "type=Warning", "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	var events []KubeEvent
+	for _, e := range list.Items {
+		if !isSystemNamespace(e.Metadata.Namespace) && e.Time().After(cutoff) {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, k int) bool { return events[i].Time().Before(events[k].Time()) })
+	return events, nil
+}
+
+// PodProblem explains why a pod is failing: a container waiting on an error such as
+// CrashLoopBackOff, a pod stuck pending or failed, or running but not ready. ok is false
+// for healthy and completed pods.
+func PodProblem(p Pod) (problem string, ok bool) {
+	if p.Metadata.DeletionTimestamp != "" || p.Status.Phase == "Succeeded" {
+		return "", false
+	}
+	for _, c := range p.Status.ContainerStatuses {
+		if w := c.State.Waiting; w != nil && w.Reason != "" && w.Reason != "ContainerCreating" && w.Reason != "PodInitializing" {
+			if t := c.LastState.Terminated; t != nil && t.Reason != "" {
+				return fmt.Sprintf("%s (%s: last exit %s)", w.Reason, c.Name, t.Reason), true
+			}
+			return fmt.Sprintf("%s (%s)", w.Reason, c.Name), true
+		}
+	}
+	switch p.Status.Phase {
+	case "Pending", "Failed", "Unknown":
+		if p.Status.Reason != "" {
+			return p.Status.Phase + ": " + p.Status.Reason, true
+		}
+		return p.Status.Phase, true
+	}
+	if !p.Ready() {
+		return "Not ready", true
+	}
+	return "", false
+}
+
+// PodRestarts sums the restarts of a pod's containers
+func PodRestarts(p Pod) int {
+	restarts := 0
+	for _, c := range p.Status.ContainerStatuses {
+		restarts += c.RestartCount
+	}
+	return restarts
+}
+
+// DeployRecord is a rollout of a deployment: when its ReplicaSet was created, and the
+// change-cause recorded for it
+type DeployRecord struct {
+	Namespace   string
+	Deployment  string
+	Revision    string
+	Time        time.Time
+	ChangeCause string
+}
+
+// GetLastDeploys returns the latest rollout of each deployment in application
+// namespaces, or in the namespace set with SetNamespaceScope, newest first
+func GetLastDeploys() ([]DeployRecord, error) {
+	rollouts, err := GetRollouts(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DeployRecord
+	seen := make(map[string]bool)
+	for _, r := range rollouts {
+		key := r.Namespace + "/" + r.Deployment
+		if !seen[key] {
+			seen[key] = true
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// GetRollouts returns the rollouts of the last since (all when 0) of deployments in
+// application namespaces, or in the namespace set with SetNamespaceScope, newest first.
+// Each is the creation of a ReplicaSet, so only rollouts whose ReplicaSet is still kept
+// in the deployment's revision history are found.
+func GetRollouts(since time.Duration) ([]DeployRecord, error) {
+	var list ReplicaSetList
+	args := append([]string{"get", "replicasets"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var records []DeployRecord
+	for _, rs := range list.Items {
+		if isSystemNamespace(rs.Metadata.Namespace) {
+			continue
+		}
+		owner := ""
+		for _, ref := range rs.Metadata.OwnerReferences {
+			if ref.Kind == "Deployment" {
+				owner = ref.Name
+			}
+		}
+		created, err := time.Parse(time.RFC3339, rs.Metadata.CreationTimestamp)
+		if owner == "" || err != nil || (since > 0 && time.Since(created) > since) {
+			continue
+		}
+		records = append(records, DeployRecord{
+			Namespace:   rs.Metadata.Namespace,
+			Deployment:  owner,
+			Revision:    rs.Metadata.Annotations[RevisionAnnotation],
+			Time:        created,
+			ChangeCause: rs.Metadata.Annotations[ChangeCauseAnnotation],
+		})
+	}
+	sort.Slice(records, func(i, k int) bool { return records[i].Time.After(records[k].Time) })
+	return records, nil
+}
+
+// MonitoringAlert is a Cloud Monitoring alert: an incident of an alerting policy
+type MonitoringAlert struct {
+	Name     string    `json:"name"`
+	State    string    `json:"state"`
+	OpenTime time.Time `json:"openTime"`
+	// CloseTime is zero while the alert is open
+	CloseTime time.Time `json:"closeTime"`
+	Policy    struct {
+		DisplayName string `json:"displayName"`
+		Severity    string `json:"severity"`
+	} `json:"policy"`
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+}
+
+// GetOpenAlerts returns the project's open Cloud Monitoring alerts, oldest first
+func GetOpenAlerts(projectID string) ([]MonitoringAlert, error) {
+	return getAlerts(projectID, `state="OPEN"`)
+}
+
+// GetAlertsSince returns the project's Cloud Monitoring alerts opened in the last since,
+// oldest first
+func GetAlertsSince(projectID string, since time.Duration) ([]MonitoringAlert, error) {
+	return getAlerts(projectID, fmt.Sprintf(`open_time>=%q`, time.Now().Add(-since).UTC().Format(time.RFC3339)))
+}
+
+func getAlerts(projectID, filter string) ([]MonitoringAlert, error) {
+	token, err := AccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("filter", filter)
+	var alerts []MonitoringAlert
+	for {
+		endpoint := fmt.Sprintf("https://monitoring.googleapis.com/v3/projects/%s/alerts?%s", projectID, params.Encode())
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := monitoringClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("monitoring API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+
+		var page struct {
+			Alerts        []MonitoringAlert `json:"alerts"`
+			NextPageToken string            `json:"nextPageToken"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse monitoring response: %w", err)
+		}
+		alerts = append(alerts, page.Alerts...)
+
+		if page.NextPageToken == "" {
+			break
+		}
+		params.Set("pageToken", page.NextPageToken)
+	}
+
+	sort.Slice(alerts, func(i, k int) bool { return alerts[i].OpenTime.Before(alerts[k].OpenTime) })
+	return alerts, nil
+}
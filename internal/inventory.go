@@ -0,0 +1,264 @@
+package internal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// EnvironmentInventory lists the infrastructure found in a single GCP project
+type EnvironmentInventory struct {
+	Project      string                 `json:"project"`
+	Clusters     []ClusterInventory     `json:"clusters"`
+	SQLInstances []SQLInstanceInventory `json:"sqlInstances"`
+	Errors       []string               `json:"errors,omitempty"`
+}
+
+// ClusterInventory describes a GKE cluster and the workloads running in it
+type ClusterInventory struct {
+	Name        string                `json:"name"`
+	Location    string                `json:"location"`
+	Version     string                `json:"version"`
+	NodePools   []NodePoolInventory   `json:"nodePools"`
+	Deployments []DeploymentInventory `json:"deployments"`
+}
+
+// NodePoolInventory describes a GKE node pool. NodeCount is its current size, across all
+// of its zones.
+type NodePoolInventory struct {
+	Name        string `json:"name"`
+	MachineType string `json:"machineType"`
+	NodeCount   int    `json:"nodeCount"`
+	Autoscaling bool   `json:"autoscaling"`
+	MinNodes    int    `json:"minNodes,omitempty"`
+	MaxNodes    int    `json:"maxNodes,omitempty"`
+}
+
+// DeploymentInventory describes an application deployment
+type DeploymentInventory struct {
+	Namespace     string   `json:"namespace"`
+	Name          string   `json:"name"`
+	Replicas      int      `json:"replicas"`
+	ReadyReplicas int      `json:"readyReplicas"`
+	Images        []string `json:"images"`
+}
+
+// SQLInstanceInventory describes a Cloud SQL instance
+type SQLInstanceInventory struct {
+	Name            string `json:"name"`
+	DatabaseVersion string `json:"databaseVersion"`
+	Region          string `json:"region"`
+	Tier            string `json:"tier"`
+	State           string `json:"state"`
+}
+
+type gkeCluster struct {
+	Name                 string `json:"name"`
+	Location             string `json:"location"`
+	CurrentMasterVersion string `json:"currentMasterVersion"`
+	NodePools            []struct {
+		Name             string   `json:"name"`
+		InitialNodeCount int      `json:"initialNodeCount"`
+		InstanceGroups   []string `json:"instanceGroupUrls"`
+		Config           struct {
+			MachineType string `json:"machineType"`
+		} `json:"config"`
+		Autoscaling struct {
+			Enabled      bool `json:"enabled"`
+			MinNodeCount int  `json:"minNodeCount"`
+			MaxNodeCount int  `json:"maxNodeCount"`
+		} `json:"autoscaling"`
+	} `json:"nodePools"`
+}
+
+type sqlInstance struct {
+	Name            string `json:"name"`
+	DatabaseVersion string `json:"databaseVersion"`
+	Region          string `json:"region"`
+	State           string `json:"state"`
+	Settings        struct {
+		Tier string `json:"tier"`
+	} `json:"settings"`
+}
+
+// CollectInventory gathers clusters, node pools, deployments and Cloud SQL instances
+// for a project. Partial failures are recorded in Errors rather than aborting.
+func CollectInventory(projectID string) (*EnvironmentInventory, error) {
+	inv := &EnvironmentInventory{Project: projectID}
+
+	var clusters []gkeCluster
+	if err := GcloudJSON(&clusters, "container", "clusters", "list", "--project", projectID); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var groupSizes map[string]int
+	if len(clusters) > 0 {
+		var err error
+		if groupSizes, err = instanceGroupSizes(projectID); err != nil {
+			inv.Errors = append(inv.Errors, fmt.Sprintf("node counts are the pools' initial sizes: %v", err))
+		}
+	}
+
+	for _, c := range clusters {
+		ci := ClusterInventory{Name: c.Name, Location: c.Location, Version: c.CurrentMasterVersion}
+
+		for _, np := range c.NodePools {
+			// initialNodeCount is the size the pool was created with, per zone; the
+			// instance groups hold the current size
+			nodes := np.InitialNodeCount * len(np.InstanceGroups)
+			if groupSizes != nil {
+				nodes = 0
+				for _, url := range np.InstanceGroups {
+					nodes += groupSizes[instanceGroupKey(url)]
+				}
+			}
+			ci.NodePools = append(ci.NodePools, NodePoolInventory{
+				Name:        np.Name,
+				MachineType: np.Config.MachineType,
+				NodeCount:   nodes,
+				Autoscaling: np.Autoscaling.Enabled,
+				MinNodes:    np.Autoscaling.MinNodeCount,
+				MaxNodes:    np.Autoscaling.MaxNodeCount,
+			})
+		}
+
+		deployments, err := collectDeployments(projectID, ClusterInfo{Name: c.Name, Location: c.Location})
+		if err != nil {
+			inv.Errors = append(inv.Errors, fmt.Sprintf("cluster %s: %v", c.Name, err))
+		}
+		ci.Deployments = deployments
+
+		inv.Clusters = append(inv.Clusters, ci)
+	}
+
+	var instances []sqlInstance
+	if err := GcloudJSON(&instances, "sql", "instances", "list", "--project", projectID); err != nil {
+		inv.Errors = append(inv.Errors, fmt.Sprintf("cloud sql: %v", err))
+	}
+	for _, i := range instances {
+		inv.SQLInstances = append(inv.SQLInstances, SQLInstanceInventory{
+			Name:            i.Name,
+			DatabaseVersion: i.DatabaseVersion,
+			Region:          i.Region,
+			Tier:            i.Settings.Tier,
+			State:           i.State,
+		})
+	}
+
+	return inv, nil
+}
+
+// instanceGroupSizes returns the target size of each managed instance group in the
+// project, keyed by "zone/name"
+func instanceGroupSizes(projectID string) (map[string]int, error) {
+	var groups []struct {
+		Name       string `json:"name"`
+		Zone       string `json:"zone"`
+		TargetSize int    `json:"targetSize"`
+	}
+	if err := GcloudJSON(&groups, "compute", "instance-groups", "managed", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int, len(groups))
+	for _, g := range groups {
+		sizes[path.Base(g.Zone)+"/"+g.Name] = g.TargetSize
+	}
+	return sizes, nil
+}
+
+// instanceGroupKey turns a node pool's instance group URL, ending in
+// zones/<zone>/instanceGroupManagers/<name>, into an instanceGroupSizes key
+func instanceGroupKey(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) < 3 {
+		return url
+	}
+	return parts[len(parts)-3] + "/" + parts[len(parts)-1]
+}
+
+func collectDeployments(projectID string, cluster ClusterInfo) ([]DeploymentInventory, error) {
+	contextName, err := EnsureClusterContext(projectID, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var list DeploymentList
+	if err := KubectlJSON(&list, "--context", contextName, "get", "deployments", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var deployments []DeploymentInventory
+	for _, d := range list.Items {
+		if isSystemNamespace(d.Metadata.Namespace) {
+			continue
+		}
+		var images []string
+		for _, c := range d.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		deployments = append(deployments, DeploymentInventory{
+			Namespace:     d.Metadata.Namespace,
+			Name:          d.Metadata.Name,
+			Replicas:      d.DesiredReplicas(),
+			ReadyReplicas: d.Status.ReadyReplicas,
+			Images:        images,
+		})
+	}
+
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].Namespace != deployments[j].Namespace {
+			return deployments[i].Namespace < deployments[j].Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+	return deployments, nil
+}
+
+// WriteInventoryCSV flattens the inventories into one row per resource
+func WriteInventoryCSV(w io.Writer, inventories []*EnvironmentInventory) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"environment", "type", "cluster", "location", "namespace", "name", "details", "replicas", "images"})
+
+	for _, inv := range inventories {
+		for _, c := range inv.Clusters {
+			cw.Write([]string{inv.Project, "cluster", c.Name, c.Location, "", c.Name, "version " + c.Version, "", ""})
+
+			for _, np := range c.NodePools {
+				details := fmt.Sprintf("%s x%d", np.MachineType, np.NodeCount)
+				if np.Autoscaling {
+					details += fmt.Sprintf(" (autoscaling %d-%d)", np.MinNodes, np.MaxNodes)
+				}
+				cw.Write([]string{inv.Project, "nodepool", c.Name, c.Location, "", np.Name, details, "", ""})
+			}
+
+			for _, d := range c.Deployments {
+				cw.Write([]string{
+					inv.Project, "deployment", c.Name, c.Location, d.Namespace, d.Name, "",
+					fmt.Sprintf("%d/%d", d.ReadyReplicas, d.Replicas),
+					strings.Join(d.Images, " "),
+				})
+			}
+		}
+
+		for _, s := range inv.SQLInstances {
+			details := fmt.Sprintf("%s %s %s", s.DatabaseVersion, s.Tier, s.State)
+			cw.Write([]string{inv.Project, "sql", "", s.Region, "", s.Name, details, "", ""})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// InventoryCounts summarises an inventory for progress output
+func InventoryCounts(inv *EnvironmentInventory) string {
+	deployments := 0
+	for _, c := range inv.Clusters {
+		deployments += len(c.Deployments)
+	}
+	return fmt.Sprintf("%d cluster(s), %d deployment(s), %d SQL instance(s)",
+		len(inv.Clusters), deployments, len(inv.SQLInstances))
+}
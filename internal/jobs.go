@@ -0,0 +1,369 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobInfo summarises a Kubernetes Job
+type JobInfo struct {
+	Namespace   string
+	Name        string
+	Status      string
+	Completions string
+	Failed      int
+	Duration    string
+	Age         string
+	Message     string
+	StartTime   time.Time
+}
+
+// GetApplicationJobs returns Jobs in application namespaces, newest first
+func GetApplicationJobs() ([]JobInfo, error) {
+	var list JobList
+	if err := KubectlJSON(&list, "get", "jobs", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var jobs []JobInfo
+	for _, j := range list.Items {
+		if isSystemNamespace(j.Metadata.Namespace) {
+			continue
+		}
+		jobs = append(jobs, summarizeJob(j))
+	}
+
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].StartTime.After(jobs[k].StartTime) })
+	return jobs, nil
+}
+
+// GetJob returns the summary of a single job
+func GetJob(namespace, name string) (JobInfo, error) {
+	var j Job
+	if err := KubectlJSON(&j, "get", "job", name, "-n", namespace, "-o", "json"); err != nil {
+		return JobInfo{}, err
+	}
+	return summarizeJob(j), nil
+}
+
+func summarizeJob(j Job) JobInfo {
+	completions := 1
+	if j.Spec.Completions != nil {
+		completions = *j.Spec.Completions
+	}
+
+	info := JobInfo{
+		Namespace:   j.Metadata.Namespace,
+		Name:        j.Metadata.Name,
+		Completions: fmt.Sprintf("%d/%d", j.Status.Succeeded, completions),
+		Failed:      j.Status.Failed,
+		Status:      "Running",
+	}
+
+	for _, c := range j.Status.Conditions {
+		if c.Status != "True" {
+			continue
+		}
+		switch c.Type {
+		case "Complete":
+			info.Status = "Complete"
+		case "Failed":
+			info.Status = "Failed"
+			info.Message = strings.TrimSpace(c.Reason + ": " + c.Message)
+		case "Suspended":
+			info.Status = "Suspended"
+		}
+	}
+	if info.Status == "Running" && j.Status.Active == 0 && j.Status.StartTime == "" {
+		info.Status = "Pending"
+	}
+
+	start, err := time.Parse(time.RFC3339, j.Status.StartTime)
+	if err == nil {
+		info.StartTime = start
+		end := time.Now()
+		if t, err := time.Parse(time.RFC3339, j.Status.CompletionTime); err == nil {
+			end = t
+		}
+		info.Duration = FormatDuration(end.Sub(start))
+	}
+	if created, err := time.Parse(time.RFC3339, j.Metadata.CreationTimestamp); err == nil {
+		info.Age = FormatDuration(time.Since(created))
+		if info.StartTime.IsZero() {
+			info.StartTime = created
+		}
+	}
+
+	return info
+}
+
+// FormatDuration renders a duration the way kubectl prints ages (e.g. 45s, 12m, 3h4m, 2d)
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// ParseDuration parses a Go duration, additionally accepting a whole-day suffix (e.g. "7d")
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// FindJobNamespace returns the application namespace containing a job with the given name
+func FindJobNamespace(name string) (string, error) {
+	jobs, err := GetApplicationJobs()
+	if err != nil {
+		return "", err
+	}
+
+	var namespaces []string
+	for _, j := range jobs {
+		if j.Name == name {
+			namespaces = append(namespaces, j.Namespace)
+		}
+	}
+
+	switch len(namespaces) {
+	case 0:
+		return "", fmt.Errorf("job %s not found", name)
+	case 1:
+		return namespaces[0], nil
+	default:
+		return "", fmt.Errorf("job %s exists in several namespaces (%s), use --namespace", name, strings.Join(namespaces, ", "))
+	}
+}
+
+// CronJobInfo summarises a Kubernetes CronJob
+type CronJobInfo struct {
+	Namespace string
+	Name      string
+	Schedule  string
+	Suspended bool
+	Active    int
+	// LastSchedule and LastSuccess are ages, e.g. 3h4m, or "" if the CronJob never ran
+	// or never succeeded
+	LastSchedule string
+	LastSuccess  string
+}
+
+// GetApplicationCronJobs returns CronJobs in application namespaces, sorted by namespace
+// and name
+func GetApplicationCronJobs() ([]CronJobInfo, error) {
+	var list CronJobList
+	if err := KubectlJSON(&list, "get", "cronjobs", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	age := func(timestamp string) string {
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return ""
+		}
+		return FormatDuration(time.Since(t))
+	}
+	var cronJobs []CronJobInfo
+	for _, c := range list.Items {
+		if isSystemNamespace(c.Metadata.Namespace) {
+			continue
+		}
+		cronJobs = append(cronJobs, CronJobInfo{
+			Namespace:    c.Metadata.Namespace,
+			Name:         c.Metadata.Name,
+			Schedule:     c.Spec.Schedule,
+			Suspended:    c.Spec.Suspend != nil && *c.Spec.Suspend,
+			Active:       len(c.Status.Active),
+			LastSchedule: age(c.Status.LastScheduleTime),
+			LastSuccess:  age(c.Status.LastSuccessfulTime),
+		})
+	}
+
+	sort.Slice(cronJobs, func(i, k int) bool {
+		if cronJobs[i].Namespace != cronJobs[k].Namespace {
+			return cronJobs[i].Namespace < cronJobs[k].Namespace
+		}
+		return cronJobs[i].Name < cronJobs[k].Name
+	})
+	return cronJobs, nil
+}
+
+// FindCronJobNamespace returns the application namespace containing a CronJob with the
+// given name
+func FindCronJobNamespace(name string) (string, error) {
+	cronJobs, err := GetApplicationCronJobs()
+	if err != nil {
+		return "", err
+	}
+
+	var namespaces []string
+	for _, c := range cronJobs {
+		if c.Name == name {
+			namespaces = append(namespaces, c.Namespace)
+		}
+	}
+
+	switch len(namespaces) {
+	case 0:
+		return "", fmt.Errorf("cronjob %s not found", name)
+	case 1:
+		return namespaces[0], nil
+	default:
+		return "", fmt.Errorf("cronjob %s exists in several namespaces (%s), use --namespace", name, strings.Join(namespaces, ", "))
+	}
+}
+
+// TriggerCronJob creates a Job from a CronJob's template, as with `kubectl create job
+// --from=cronjob/<name>`, and returns the Job's name
+func TriggerCronJob(namespace, cronJob string) (string, error) {
+	name := uniqueJobName(cronJob, "manual")
+	if _, err := runOutput("kubectl", "create", "job", name, "--from=cronjob/"+cronJob, "-n", namespace); err != nil {
+		return "", fmt.Errorf("failed to create job from cronjob %s: %w", cronJob, err)
+	}
+	return name, nil
+}
+
+// uniqueJobName names a job <base>-<kind>-<unix time>, shortening base so the name fits
+// the job-name label, which is limited to 63 characters
+func uniqueJobName(base, kind string) string {
+	suffix := fmt.Sprintf("-%s-%d", kind, time.Now().Unix())
+	if len(base)+len(suffix) > 63 {
+		base = strings.TrimRight(base[:63-len(suffix)], "-.")
+	}
+	return base + suffix
+}
+
+// DeleteJob deletes a job and its pods without waiting for them to terminate
+func DeleteJob(namespace, name string) error {
+	_, err := runOutput("kubectl", "delete", "job", name, "-n", namespace, "--cascade=background", "--wait=false")
+	return err
+}
+
+// WaitForJobPod waits until a job has a pod whose containers have started, so its logs can
+// be read, and returns the pod's name
+func WaitForJobPod(namespace, jobName string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var list PodList
+		if err := KubectlJSON(&list, "get", "pods", "-n", namespace, "-l", "job-name="+jobName, "-o", "json"); err != nil {
+			return "", err
+		}
+		for _, pod := range list.Items {
+			if pod.Status.Phase != "Pending" {
+				return pod.Metadata.Name, nil
+			}
+			for _, s := range pod.Status.ContainerStatuses {
+				if s.State.Waiting != nil && isImageFailure(s.State.Waiting.Reason) {
+					return "", fmt.Errorf("pod %s can't start: %s", pod.Metadata.Name, s.State.Waiting.Reason)
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for a pod of job %s to start", jobName)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// isImageFailure reports whether a waiting reason means the image can't be pulled
+func isImageFailure(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
+
+// WaitForJob waits until a job is complete or has failed and returns its summary
+func WaitForJob(namespace, name string, timeout time.Duration) (JobInfo, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := GetJob(namespace, name)
+		if err != nil {
+			return JobInfo{}, err
+		}
+		if job.Status == "Complete" || job.Status == "Failed" {
+			return job, nil
+		}
+		if time.Now().After(deadline) {
+			return job, fmt.Errorf("timed out waiting for job %s to finish", name)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// JobExitCode returns the exit code of a job's last pod: the first non-zero exit code of
+// its containers, or 0 if they all succeeded. ok is false if no container has terminated.
+func JobExitCode(namespace, jobName string) (code int, ok bool, err error) {
+	pods, err := GetJobPods(namespace, jobName)
+	if err != nil || len(pods) == 0 {
+		return 0, false, err
+	}
+	var pod Pod
+	if err := KubectlJSON(&pod, "get", "pod", pods[len(pods)-1], "-n", namespace, "-o", "json"); err != nil {
+		return 0, false, err
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if t := s.State.Terminated; t != nil {
+			ok = true
+			if t.ExitCode != 0 {
+				return t.ExitCode, true, nil
+			}
+		}
+	}
+	return 0, ok, nil
+}
+
+// GetJobPods returns the names of all pods created by a job (including completed ones), oldest first
+func GetJobPods(namespace, jobName string) ([]string, error) {
+	output, err := runOutput("kubectl", "get", "pods", "-n", namespace, "-l", "job-name="+jobName,
+		"--sort-by=.metadata.creationTimestamp", "-o", "custom-columns=NAME:.metadata.name", "--no-headers")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// StreamJobLogs prints the logs of every pod a job created, in creation order
+func StreamJobLogs(namespace, jobName string, follow bool) error {
+	pods, err := GetJobPods(namespace, jobName)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no pods found for job %s (they may have been garbage collected)", jobName)
+	}
+
+	for _, pod := range pods {
+		fmt.Println(Colorize(ColorCyan, fmt.Sprintf("==> %s/%s <==", namespace, pod)))
+		args := []string{"logs", pod, "-n", namespace, "--all-containers"}
+		if follow {
+			args = append(args, "-f")
+		}
+		cmd := exec.Command("kubectl", args...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Printf("⚠️  Failed to get logs for %s: %v\n", pod, err)
+		}
+		fmt.Println()
+	}
+	return nil
+}
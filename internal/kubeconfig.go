@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// IsolateKubeconfig makes context switches by this process private to it: KUBECONFIG gets
+// a temporary file in front of the existing kubeconfig files, which kubectl and gcloud then
+// write the current context and any new credentials to. The returned function removes it.
+func IsolateKubeconfig() (func(), error) {
+	existing := os.Getenv("KUBECONFIG")
+	if existing == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		existing = filepath.Join(home, ".kube", "config")
+	}
+
+	current, _ := exec.Command("kubectl", "config", "current-context").Output()
+
+	file, err := os.CreateTemp("", "gcpeasy-kubeconfig-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	content := "apiVersion: v1\nkind: Config\nclusters: []\ncontexts: []\nusers: []\n"
+	if context := strings.TrimSpace(string(current)); context != "" {
+		content += fmt.Sprintf("current-context: %q\n", context)
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	file.Close()
+
+	os.Setenv("KUBECONFIG", file.Name()+string(os.PathListSeparator)+existing)
+	return func() { os.Remove(file.Name()) }, nil
+}
@@ -63,6 +63,11 @@ func SelectCluster(clusters []ClusterInfo) (*ClusterInfo, error) {
 	}
 	
 	fmt.Println()
+	names := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		names[i] = cluster.Name
+	}
+	EmitEvent(EventPrompt, map[string]any{"prompt": "cluster", "choices": names})
 	fmt.Print("Select cluster (number, or 'q' to quit): ")
 	
 	scanner := bufio.NewScanner(os.Stdin)
@@ -88,7 +93,6 @@ func SelectCluster(clusters []ClusterInfo) (*ClusterInfo, error) {
 
 // ConfigureKubectl configures kubectl for the specified cluster
 func ConfigureKubectl(projectID string, cluster ClusterInfo) error {
-	fmt.Printf("🔧 Getting credentials for cluster %s in %s...\n", cluster.Name, cluster.Location)
 	cmd := exec.Command("gcloud", "container", "clusters", "get-credentials", cluster.Name, "--location", cluster.Location, "--project", projectID)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to get cluster credentials: %w", err)
@@ -114,16 +118,21 @@ func GetCurrentCluster() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// ContextBelongsToProject reports whether a kubectl context points at a cluster in the project.
+// GKE contexts are formatted as: gke_PROJECT_LOCATION_CLUSTER
+func ContextBelongsToProject(context, projectID string) bool {
+	return strings.Contains(context, "_"+projectID+"_") || strings.HasPrefix(context, "gke_"+projectID+"_")
+}
+
 // SetupClusterIfNeeded handles cluster setup only if kubectl is not configured
 func SetupClusterIfNeeded(projectID string) error {
 	// If kubectl is already configured and working, check if it matches the current project
 	if IsKubectlConfigured() {
 		context, err := GetCurrentCluster()
 		if err == nil && context != "" {
-			// GKE contexts are formatted as: gke_PROJECT_LOCATION_CLUSTER
-			// Check if the context belongs to the current project
-			if strings.Contains(context, "_"+projectID+"_") || strings.HasPrefix(context, "gke_"+projectID+"_") {
+			if ContextBelongsToProject(context, projectID) {
 				fmt.Printf("✅ Using current cluster context: %s\n", context)
+				EmitEvent(EventClusterSelected, map[string]any{"context": context})
 				return nil
 			}
 			// Context is for a different project, need to set up cluster for current project
@@ -152,10 +161,12 @@ func SetupClusterIfNeeded(projectID string) error {
 
 	// Configure kubectl for the cluster
 	fmt.Println("🔧 Configuring kubectl...")
+	fmt.Printf("🔧 Getting credentials for cluster %s in %s...\n", selectedCluster.Name, selectedCluster.Location)
 	if err := ConfigureKubectl(projectID, *selectedCluster); err != nil {
 		return fmt.Errorf("failed to configure kubectl: %w", err)
 	}
 	fmt.Println("✅ kubectl configured")
+	EmitEvent(EventClusterSelected, map[string]any{"context": ClusterContextName(projectID, *selectedCluster)})
 	
 	return nil
 }
@@ -186,4 +197,123 @@ func SetupClusterAndSelectPod(projectID string) (string, error) {
 	}
 
 	return selectedPod, nil
-}
\ No newline at end of file
+}
+
+// ClusterContextName returns the kubectl context name gcloud creates for a GKE cluster
+func ClusterContextName(projectID string, cluster ClusterInfo) string {
+	return fmt.Sprintf("gke_%s_%s_%s", projectID, cluster.Location, cluster.Name)
+}
+
+// ParseClusterContext splits a GKE context name (gke_PROJECT_LOCATION_CLUSTER) into its
+// location and cluster name; ok is false for non-GKE contexts
+func ParseClusterContext(contextName string) (location, cluster string, ok bool) {
+	parts := strings.SplitN(contextName, "_", 4)
+	if len(parts) != 4 || parts[0] != "gke" {
+		return "", "", false
+	}
+	return parts[2], parts[3], true
+}
+
+// EnsureClusterContext makes sure kubeconfig has credentials for the cluster without
+// changing the current kubectl context, and returns the context name to pass to --context
+func EnsureClusterContext(projectID string, cluster ClusterInfo) (string, error) {
+	contextName := ClusterContextName(projectID, cluster)
+
+	output, err := exec.Command("kubectl", "config", "get-contexts", "-o", "name").Output()
+	if err == nil {
+		for _, name := range strings.Split(string(output), "\n") {
+			if strings.TrimSpace(name) == contextName {
+				return contextName, nil
+			}
+		}
+	}
+
+	// get-credentials switches the current context, so restore it afterwards
+	previous, _ := GetCurrentCluster()
+	cmd := exec.Command("gcloud", "container", "clusters", "get-credentials", cluster.Name, "--location", cluster.Location, "--project", projectID)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get credentials for cluster %s: %w", cluster.Name, err)
+	}
+	if previous != "" && previous != contextName {
+		exec.Command("kubectl", "config", "use-context", previous).Run()
+	}
+
+	return contextName, nil
+}
+
+var apiResources map[string]bool
+
+// HasAPIResource reports whether the cluster serves the given fully qualified
+// resource (e.g. "applications.argoproj.io"), as listed by `kubectl api-resources`
+func HasAPIResource(resource string) bool {
+	if apiResources == nil {
+		// api-resources exits non-zero when a single API group is unavailable (often
+		// metrics.k8s.io) but still lists the others, so its output is used regardless.
+		// Nothing is cached when nothing was listed, so a later call tries again.
+		output, _ := exec.Command("kubectl", "api-resources", "-o", "name").Output()
+		resources := make(map[string]bool)
+		for _, name := range strings.Split(string(output), "\n") {
+			if name = strings.TrimSpace(name); name != "" {
+				resources[name] = true
+			}
+		}
+		if len(resources) == 0 {
+			return false
+		}
+		apiResources = resources
+	}
+	return apiResources[resource]
+}
+
+// GetClusterLabels returns the resource labels of a GKE cluster
+func GetClusterLabels(projectID string, cluster ClusterInfo) (map[string]string, error) {
+	var described struct {
+		ResourceLabels map[string]string `json:"resourceLabels"`
+	}
+	if err := GcloudJSON(&described, "container", "clusters", "describe", cluster.Name, "--location", cluster.Location, "--project", projectID); err != nil {
+		return nil, err
+	}
+	return described.ResourceLabels, nil
+}
+
+// DeleteCluster deletes a GKE cluster, streaming gcloud's progress to the terminal
+func DeleteCluster(projectID string, cluster ClusterInfo) error {
+	cmd := exec.Command("gcloud", "container", "clusters", "delete", cluster.Name, "--location", cluster.Location, "--project", projectID, "--quiet")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete cluster %s: %w", cluster.Name, err)
+	}
+	return nil
+}
+
+// RemoveClusterContexts removes the kubeconfig context, cluster and user entries gcloud
+// created for a cluster, unsetting the current context if it pointed at it. It returns the
+// removed context names.
+func RemoveClusterContexts(projectID string, cluster ClusterInfo) ([]string, error) {
+	output, err := exec.Command("kubectl", "config", "get-contexts", "-o", "name").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubectl contexts: %w", err)
+	}
+
+	contextName := ClusterContextName(projectID, cluster)
+	current, _ := GetCurrentCluster()
+	var removed []string
+	for _, line := range strings.Split(string(output), "\n") {
+		name := strings.TrimSpace(line)
+		if name != contextName {
+			continue
+		}
+		if name == current {
+			exec.Command("kubectl", "config", "unset", "current-context").Run()
+		}
+		if _, err := runOutput("kubectl", "config", "delete-context", name); err != nil {
+			return removed, err
+		}
+		// gcloud names the cluster and user entries after the context
+		exec.Command("kubectl", "config", "delete-cluster", name).Run()
+		exec.Command("kubectl", "config", "unset", "users."+name).Run()
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
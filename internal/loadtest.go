@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadTestImage is the load generator the loadtest Job runs
+const LoadTestImage = "fortio/fortio:latest_release"
+
+// LoadTestLabel marks the Jobs gcpeasy creates for load tests
+const LoadTestLabel = "gcpeasy.io/loadtest"
+
+// LoadTestPercentiles are the latency percentiles a load test reports
+var LoadTestPercentiles = []float64{50, 90, 95, 99}
+
+// LoadTest is a load generator run against a Service from inside the cluster
+type LoadTest struct {
+	Name        string
+	Namespace   string
+	URL         string
+	RPS         int
+	Connections int
+	Duration    time.Duration
+}
+
+// NewLoadTest targets path on one of the Service's ports, given by number or name (the
+// first port when empty). A full http(s) URL is used as is.
+func NewLoadTest(svc Service, path, port string, rps, connections int, duration time.Duration) (LoadTest, error) {
+	t := LoadTest{
+		Name:        fmt.Sprintf("gcpeasy-loadtest-%x", time.Now().UnixNano()&0xffffff),
+		Namespace:   svc.Metadata.Namespace,
+		RPS:         rps,
+		Connections: connections,
+		Duration:    duration,
+	}
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		t.URL = path
+		return t, nil
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return t, fmt.Errorf("service %s has no ports", svc.Metadata.Name)
+	}
+	servicePort := svc.Spec.Ports[0].Port
+	if port != "" {
+		servicePort = 0
+		for _, p := range svc.Spec.Ports {
+			if p.Name == port || strconv.Itoa(p.Port) == port {
+				servicePort = p.Port
+			}
+		}
+		if servicePort == 0 {
+			return t, fmt.Errorf("service %s has no port %s", svc.Metadata.Name, port)
+		}
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	t.URL = fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", svc.Metadata.Name, svc.Metadata.Namespace, servicePort, path)
+	return t, nil
+}
+
+// loadTestManifest builds the Job that runs the load generator once. It gives up a few
+// minutes after the test should have finished and is garbage collected after an hour,
+// in case gcpeasy isn't there to delete it.
+func loadTestManifest(t LoadTest) map[string]any {
+	var percentiles []string
+	for _, p := range LoadTestPercentiles {
+		percentiles = append(percentiles, strconv.FormatFloat(p, 'f', -1, 64))
+	}
+	labels := map[string]any{"app.kubernetes.io/managed-by": "gcpeasy", LoadTestLabel: t.Name}
+
+	return map[string]any{
+		"apiVersion": "batch/v1", "kind": "Job",
+		"metadata": map[string]any{"name": t.Name, "namespace": t.Namespace, "labels": labels},
+		"spec": map[string]any{
+			"backoffLimit":            0,
+			"activeDeadlineSeconds":   int(t.Duration.Seconds()) + 300,
+			"ttlSecondsAfterFinished": 3600,
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec": map[string]any{
+					"restartPolicy": "Never",
+					"containers": []any{map[string]any{
+						"name":  "load",
+						"image": LoadTestImage,
+						"args": []string{
+							"load", "-quiet", "-json", "-",
+							"-qps", strconv.Itoa(t.RPS),
+							"-c", strconv.Itoa(t.Connections),
+							"-t", t.Duration.String(),
+							"-p", strings.Join(percentiles, ","),
+							t.URL,
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// StartLoadTest creates the load test's Job
+func StartLoadTest(t LoadTest) error {
+	data, err := json.Marshal(loadTestManifest(t))
+	if err != nil {
+		return err
+	}
+	if err := kubectlApply(string(data)); err != nil {
+		return fmt.Errorf("failed to create job %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// LoadTestLogs returns the output of the load test's pod
+func LoadTestLogs(t LoadTest) (string, error) {
+	output, err := runOutput("kubectl", "logs", "job/"+t.Name, "-n", t.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// DeleteLoadTest deletes the load test's Job and its pod
+func DeleteLoadTest(t LoadTest) error {
+	if _, err := runOutput("kubectl", "delete", "job", t.Name, "-n", t.Namespace, "--ignore-not-found", "--wait=false"); err != nil {
+		return fmt.Errorf("failed to delete job %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// LoadTestResult is the part of fortio's JSON report gcpeasy uses. Latencies are in seconds.
+type LoadTestResult struct {
+	ActualQPS         float64
+	ActualDuration    time.Duration
+	DurationHistogram struct {
+		Count       int
+		Min         float64
+		Max         float64
+		Avg         float64
+		Percentiles []struct {
+			Percentile float64
+			Value      float64
+		}
+	}
+	RetCodes map[string]int
+}
+
+// ParseLoadTestResult finds fortio's JSON report in the load test's logs, which also
+// contain its log lines
+func ParseLoadTestResult(logs string) (*LoadTestResult, error) {
+	start := strings.Index(logs, "\n{\n")
+	if strings.HasPrefix(logs, "{\n") {
+		start = 0
+	} else if start >= 0 {
+		start++
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no report found in the load generator's output")
+	}
+
+	var result LoadTestResult
+	if err := json.NewDecoder(bytes.NewReader([]byte(logs[start:]))).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse the load generator's report: %w", err)
+	}
+	return &result, nil
+}
+
+// Latency returns a percentile of the request latency, if fortio reported it
+func (r LoadTestResult) Latency(percentile float64) (time.Duration, bool) {
+	for _, p := range r.DurationHistogram.Percentiles {
+		if p.Percentile == percentile {
+			return secondsToDuration(p.Value), true
+		}
+	}
+	return 0, false
+}
+
+// Errors counts requests that failed to connect or got a non-2xx/3xx status
+func (r LoadTestResult) Errors() int {
+	errors := 0
+	for code, n := range r.RetCodes {
+		if status, err := strconv.Atoi(code); err != nil || status < 200 || status >= 400 {
+			errors += n
+		}
+	}
+	return errors
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// ResourceUsage is the CPU (cores) and memory (bytes) a pod is using
+type ResourceUsage struct {
+	CPU    float64
+	Memory float64
+}
+
+// GetPodUsage returns the current usage of the pods matching selector in namespace, from
+// `kubectl top`, by pod name
+func GetPodUsage(namespace, selector string) (map[string]ResourceUsage, error) {
+	output, err := runOutput("kubectl", "top", "pods", "-n", namespace, "-l", selector, "--no-headers")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]ResourceUsage)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		cpu, err := ParseQuantity(fields[1])
+		if err != nil {
+			continue
+		}
+		memory, err := ParseQuantity(fields[2])
+		if err != nil {
+			continue
+		}
+		usage[fields[0]] = ResourceUsage{CPU: cpu, Memory: memory}
+	}
+	return usage, nil
+}
+
+// UsageStats aggregates a pod's usage samples over a load test
+type UsageStats struct {
+	Samples   int
+	CPUSum    float64
+	CPUMax    float64
+	MemoryMax float64
+}
+
+// Add records a usage sample
+func (s *UsageStats) Add(u ResourceUsage) {
+	s.Samples++
+	s.CPUSum += u.CPU
+	s.CPUMax = max(s.CPUMax, u.CPU)
+	s.MemoryMax = max(s.MemoryMax, u.Memory)
+}
+
+// CPUAvg is the average CPU usage over the samples
+func (s UsageStats) CPUAvg() float64 {
+	if s.Samples == 0 {
+		return 0
+	}
+	return s.CPUSum / float64(s.Samples)
+}
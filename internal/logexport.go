@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ApplicationLogQuery selects the logs of all application namespaces in a cluster
+func ApplicationLogQuery(cluster string, since time.Duration, limit int) ContainerLogQuery {
+	return ContainerLogQuery{
+		Cluster:           cluster,
+		ExcludeNamespaces: systemNamespaces,
+		Since:             since,
+		Limit:             limit,
+	}
+}
+
+// WriteLogEntriesJSON writes entries as newline-delimited JSON, one object per entry
+func WriteLogEntriesJSON(w io.Writer, entries []ContainerLogEntry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logExportSchema is the BigQuery schema of the rows written by WriteLogEntriesJSON
+const logExportSchema = "timestamp:TIMESTAMP,severity:STRING,namespace:STRING,pod:STRING,container:STRING,text:STRING"
+
+// UploadToGCS copies a local file to a gs:// URL
+func UploadToGCS(projectID, localPath, destination string) error {
+	if !strings.HasPrefix(destination, "gs://") {
+		return fmt.Errorf("destination must be a gs:// URL: %s", destination)
+	}
+	cmd := exec.Command("gcloud", "storage", "cp", localPath, destination, "--project", projectID)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", destination, err)
+	}
+	return nil
+}
+
+// LoadIntoBigQuery appends newline-delimited JSON log entries to a BigQuery table given
+// as "dataset.table", creating the table if needed
+func LoadIntoBigQuery(projectID, localPath, table string) error {
+	if strings.Count(table, ".") != 1 {
+		return fmt.Errorf("table must be given as dataset.table: %s", table)
+	}
+	cmd := exec.Command("bq", "--project_id", projectID, "load", "--source_format=NEWLINE_DELIMITED_JSON", table, localPath, logExportSchema)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to load into %s: %w", table, err)
+	}
+	return nil
+}
+
+// LogArchiveManifest describes what a log archive covers. It is stored in the archive as
+// manifest.json.
+type LogArchiveManifest struct {
+	Project   string           `json:"project"`
+	Cluster   string           `json:"cluster"`
+	Source    string           `json:"source"`
+	Since     string           `json:"since"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Files     []LogArchiveFile `json:"files"`
+}
+
+// LogArchiveFile is one container's log in an archive, with the time range its lines cover
+type LogArchiveFile struct {
+	Path      string `json:"path"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Lines     int    `json:"lines"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+// LogArchive writes container logs and their manifest to a gzip-compressed tar archive
+type LogArchive struct {
+	Manifest LogArchiveManifest
+	gz       *gzip.Writer
+	tw       *tar.Writer
+}
+
+// NewLogArchive starts an archive written to w; Close must be called to complete it
+func NewLogArchive(w io.Writer, manifest LogArchiveManifest) *LogArchive {
+	gz := gzip.NewWriter(w)
+	return &LogArchive{Manifest: manifest, gz: gz, tw: tar.NewWriter(gz)}
+}
+
+// Add stores a container's log as logs/<namespace>/<pod>/<container>.log. Lines are
+// expected to start with a timestamp, as written by kubectl logs --timestamps.
+func (a *LogArchive) Add(namespace, pod, container string, log []byte) error {
+	file := LogArchiveFile{
+		Path:      path.Join("logs", namespace, pod, container+".log"),
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(log), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		file.Lines++
+		if timestamp := strings.TrimSpace(timestampPrefix.FindString(line)); timestamp != "" {
+			if file.From == "" {
+				file.From = timestamp
+			}
+			file.To = timestamp
+		}
+	}
+	if err := a.writeFile(file.Path, log); err != nil {
+		return err
+	}
+	a.Manifest.Files = append(a.Manifest.Files, file)
+	return nil
+}
+
+// AddEntries stores Cloud Logging entries, one file per container
+func (a *LogArchive) AddEntries(entries []ContainerLogEntry) error {
+	logs := make(map[[3]string]*strings.Builder)
+	var keys [][3]string
+	for _, entry := range entries {
+		key := [3]string{entry.Namespace, entry.Pod, entry.Container}
+		if logs[key] == nil {
+			logs[key] = &strings.Builder{}
+			keys = append(keys, key)
+		}
+		fmt.Fprintf(logs[key], "%s %s\n", entry.Time.UTC().Format(time.RFC3339Nano), entry.Text)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(keys[i][:], "/") < strings.Join(keys[j][:], "/")
+	})
+	for _, key := range keys {
+		if err := a.Add(key[0], key[1], key[2], []byte(logs[key].String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *LogArchive) writeFile(name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: a.Manifest.CreatedAt}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := a.tw.Write(data)
+	return err
+}
+
+// Close writes manifest.json and completes the archive
+func (a *LogArchive) Close() error {
+	data, err := json.MarshalIndent(a.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := a.writeFile("manifest.json", append(data, '\n')); err != nil {
+		return err
+	}
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+// ContainerLogs returns a container's logs for the last since, each line prefixed with
+// its timestamp
+func ContainerLogs(namespace, pod, container string, since time.Duration) ([]byte, error) {
+	return runOutput("kubectl", "logs", pod, "-n", namespace, "-c", container, "--timestamps",
+		fmt.Sprintf("--since=%ds", int(since.Seconds())))
+}
@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLine is a single log line, with its fields extracted when it is structured
+type LogLine struct {
+	Raw string
+	// Structured is set for JSON lines
+	Structured bool
+	Time       string
+	// Severity is the level as written by the application, for JSON and klog lines
+	Severity string
+	Message  string
+	// Fields holds the remaining fields of a structured line
+	Fields map[string]any
+}
+
+// Field names used by common JSON loggers (Cloud Logging, zap, logrus, bunyan/pino, python
+// json-logger, ECS and Serilog's compact format). Dotted names also match nested objects,
+// e.g. "log.level" matches {"log":{"level":"error"}}.
+var (
+	severityKeys = []string{"severity", "level", "lvl", "levelname", "log.level", "@l", "level.name"}
+	timeKeys     = []string{"time", "timestamp", "ts", "@timestamp", "t", "@t", "asctime"}
+	messageKeys  = []string{"message", "msg", "@message", "@m", "@mt"}
+)
+
+// timestampPrefix matches the RFC3339 timestamp kubectl logs --timestamps puts before each line
+var timestampPrefix = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}) `)
+
+// klogPattern matches the klog header, e.g. "E0102 15:04:05.123456   12345 file.go:123] msg"
+var klogPattern = regexp.MustCompile(`^([IWEF])(\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+\d+ [^\]]+\] ?(.*)$`)
+
+var klogSeverities = map[string]string{"I": "info", "W": "warning", "E": "error", "F": "fatal"}
+
+// ParseLogLine parses a log line, extracting the severity, timestamp and message of JSON
+// lines and the severity of klog lines. A timestamp added by kubectl logs --timestamps is
+// skipped, and used as the time of lines that have none of their own.
+func ParseLogLine(raw string) LogLine {
+	trimmed := strings.TrimSpace(raw)
+	prefix := timestampPrefix.FindString(trimmed)
+	line := parseLogContent(raw, trimmed[len(prefix):])
+	if line.Time == "" {
+		line.Time = strings.TrimSpace(prefix)
+	}
+	return line
+}
+
+// parseLogContent parses the content of a line, after any kubectl timestamp
+func parseLogContent(raw, trimmed string) LogLine {
+	line := LogLine{Raw: raw}
+
+	if m := klogPattern.FindStringSubmatch(trimmed); m != nil {
+		line.Severity = klogSeverities[m[1]]
+		line.Time = m[2]
+		line.Message = m[3]
+		return line
+	}
+	if !strings.HasPrefix(trimmed, "{") {
+		return line
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return line
+	}
+
+	line.Structured = true
+	line.Severity = takeField(fields, severityKeys)
+	line.Time = takeField(fields, timeKeys)
+	line.Message = takeField(fields, messageKeys)
+	line.Fields = fields
+	return line
+}
+
+// takeField removes and returns the first of keys present in fields, formatted as a string
+func takeField(fields map[string]any, keys []string) string {
+	for _, key := range keys {
+		value, ok := removeField(fields, key)
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			return v
+		case float64:
+			// Epoch timestamps (e.g. zap's "ts") are seconds with a fraction
+			if key == "ts" || key == "t" {
+				sec := int64(v)
+				return time.Unix(sec, int64((v-float64(sec))*1e9)).UTC().Format(time.RFC3339Nano)
+			}
+			return fmt.Sprint(v)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// removeField removes and returns a field by name, or by its dotted path through nested
+// objects. Objects left empty are removed too.
+func removeField(fields map[string]any, key string) (any, bool) {
+	if value, ok := fields[key]; ok {
+		// Objects such as {"level":{"name":"error"}} are looked into by the dotted keys
+		if _, nested := value.(map[string]any); !nested {
+			delete(fields, key)
+			return value, true
+		}
+	}
+
+	parent, child, found := strings.Cut(key, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := fields[parent].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	value, ok := removeField(nested, child)
+	if ok && len(nested) == 0 {
+		delete(fields, parent)
+	}
+	return value, ok
+}
+
+// NormalizeSeverity maps the many spellings of log levels, including numeric bunyan/pino
+// levels, to "debug", "info", "warn" or "error". It returns "" for unrecognized values.
+func NormalizeSeverity(severity string) string {
+	if n, err := strconv.Atoi(severity); err == nil {
+		switch {
+		case n >= 50:
+			return "error"
+		case n >= 40:
+			return "warn"
+		case n >= 30:
+			return "info"
+		default:
+			return "debug"
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "trace", "debug", "verbose", "fine", "finer", "finest":
+		return "debug"
+	case "info", "information", "notice", "default":
+		return "info"
+	case "warn", "warning":
+		return "warn"
+	case "error", "err", "severe", "critical", "crit", "fatal", "alert", "emergency", "emerg", "panic", "dpanic":
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// Render formats a structured line as "time LEVEL message key=value ...", with the level
+// colored; unstructured lines are returned unchanged
+func (l LogLine) Render() string {
+	if !l.Structured {
+		return l.Raw
+	}
+
+	var parts []string
+	if l.Time != "" {
+		parts = append(parts, l.Time)
+	}
+	if l.Severity != "" {
+		parts = append(parts, colorSeverity(l.Severity))
+	}
+	if l.Message != "" {
+		parts = append(parts, l.Message)
+	}
+
+	keys := make([]string, 0, len(l.Fields))
+	for key := range l.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := l.Fields[key]
+		if s, ok := value.(string); ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", key, s))
+			continue
+		}
+		encoded, _ := json.Marshal(value)
+		parts = append(parts, fmt.Sprintf("%s=%s", key, encoded))
+	}
+	return strings.Join(parts, " ")
+}
+
+func colorSeverity(severity string) string {
+	label := strings.ToUpper(severity)
+	normalized := NormalizeSeverity(severity)
+	if _, err := strconv.Atoi(severity); err == nil && normalized != "" {
+		label = strings.ToUpper(normalized)
+	}
+
+	switch normalized {
+	case "error":
+		return Colorize(ColorRed, label)
+	case "warn":
+		return Colorize(ColorYellow, label)
+	default:
+		return label
+	}
+}
+
+// severityRank orders the normalized severities
+var severityRank = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+// LevelFilter is the set of normalized severities to show
+type LevelFilter map[string]bool
+
+// ParseLevelFilter parses a --level value. A single level is a minimum ("warn" shows warn
+// and error); a comma-separated list selects exactly those levels ("warn,error").
+func ParseLevelFilter(spec string) (LevelFilter, error) {
+	filter := make(LevelFilter)
+	parts := strings.Split(spec, ",")
+	for _, part := range parts {
+		level := NormalizeSeverity(part)
+		if level == "" {
+			return nil, fmt.Errorf("unknown log level %q (use debug, info, warn or error)", strings.TrimSpace(part))
+		}
+		filter[level] = true
+	}
+
+	if len(parts) == 1 {
+		for level := range filter {
+			for other, rank := range severityRank {
+				if rank >= severityRank[level] {
+					filter[other] = true
+				}
+			}
+		}
+	}
+	return filter, nil
+}
+
+// Allows reports whether a normalized severity is selected
+func (f LevelFilter) Allows(severity string) bool {
+	return f[severity]
+}
+
+// All reports whether every severity is selected, as --level debug does
+func (f LevelFilter) All() bool {
+	return len(f) == len(severityRank)
+}
+
+// Levels returns the selected severities from most to least severe
+func (f LevelFilter) Levels() []string {
+	levels := make([]string, 0, len(f))
+	for level := range f {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return severityRank[levels[i]] > severityRank[levels[j]] })
+	return levels
+}
+
+// HighlightMatches colors every match of pattern in s with the color chosen for the
+// matched text, leaving s unchanged when color output is off
+func HighlightMatches(s string, pattern *regexp.Regexp, color func(match string) string) string {
+	if pattern == nil || !ColorEnabled() {
+		return s
+	}
+	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+		return color(match) + match + ColorReset
+	})
+}
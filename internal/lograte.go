@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// rateWindow is the number of seconds the current rates are averaged over
+	rateWindow = 10
+	// rateHistory is the number of seconds kept, the rest serving as the error baseline
+	rateHistory = 60
+	// spikeFactor is how many times the baseline error rate counts as a spike
+	spikeFactor = 3
+	// spikeMinimum is the lowest error rate, per second, that counts as a spike
+	spikeMinimum = 1.0
+)
+
+// LogRateMonitor tracks line and error rates of a log stream in one-second buckets
+type LogRateMonitor struct {
+	mu      sync.Mutex
+	start   time.Time
+	current int64
+	lines   [rateHistory]int
+	errors  [rateHistory]int
+}
+
+// LogRates are the rates reported by a LogRateMonitor, per second
+type LogRates struct {
+	Lines  float64
+	Errors float64
+	// Baseline is the error rate before the current window
+	Baseline float64
+	// Spike is set when the error rate is well above the baseline
+	Spike bool
+}
+
+// NewLogRateMonitor starts tracking rates from now
+func NewLogRateMonitor() *LogRateMonitor {
+	now := time.Now()
+	return &LogRateMonitor{start: now, current: now.Unix()}
+}
+
+// Observe records a log line
+func (m *LogRateMonitor) Observe(isError bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.advance(time.Now().Unix())
+	i := m.current % rateHistory
+	m.lines[i]++
+	if isError {
+		m.errors[i]++
+	}
+}
+
+// advance clears the buckets of the seconds that passed without lines
+func (m *LogRateMonitor) advance(now int64) {
+	if now-m.current >= rateHistory {
+		m.current = now
+		m.lines, m.errors = [rateHistory]int{}, [rateHistory]int{}
+		return
+	}
+	for m.current < now {
+		m.current++
+		i := m.current % rateHistory
+		m.lines[i], m.errors[i] = 0, 0
+	}
+}
+
+// Rates returns the current line and error rates, comparing the error rate to the baseline
+func (m *LogRateMonitor) Rates() LogRates {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.advance(now.Unix())
+
+	elapsed := int(now.Sub(m.start).Seconds())
+	window := min(rateWindow, max(elapsed, 1))
+	var lines, errors int
+	for s := 0; s < window; s++ {
+		i := (m.current - int64(s)) % rateHistory
+		lines += m.lines[i]
+		errors += m.errors[i]
+	}
+
+	rates := LogRates{
+		Lines:  float64(lines) / float64(window),
+		Errors: float64(errors) / float64(window),
+	}
+
+	// Only compare against a baseline once there is one
+	baselineSeconds := min(elapsed, rateHistory) - rateWindow
+	if baselineSeconds <= 0 {
+		return rates
+	}
+	var baselineErrors int
+	for s := rateWindow; s < rateWindow+baselineSeconds; s++ {
+		baselineErrors += m.errors[(m.current-int64(s))%rateHistory]
+	}
+	rates.Baseline = float64(baselineErrors) / float64(baselineSeconds)
+	rates.Spike = rates.Errors >= spikeMinimum && rates.Errors >= spikeFactor*rates.Baseline
+	return rates
+}
@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogStreamOptions controls which logs `kubectl logs` returns
+type LogStreamOptions struct {
+	Follow bool
+	// Since limits the logs to a relative duration such as "1h"
+	Since string
+	// Tail limits the logs to the most recent lines, 0 for all
+	Tail int
+	// Timestamps prefixes each line with its RFC3339 timestamp
+	Timestamps bool
+	// Previous returns the logs of the previous, crashed instance of the container
+	Previous bool
+}
+
+// StreamPodLogs copies a pod's logs ("namespace/pod") to stdout, following new output
+// when opts.Follow is set, until the logs end or ctx is cancelled
+func StreamPodLogs(ctx context.Context, pod string, opts LogStreamOptions, stdout, stderr io.Writer) error {
+	namespace, podName, ok := strings.Cut(pod, "/")
+	if !ok {
+		return fmt.Errorf("invalid pod format: %s", pod)
+	}
+
+	args := []string{"logs", podName, "-n", namespace}
+	if opts.Follow {
+		args = append(args, "-f")
+	}
+	if opts.Since != "" {
+		args = append(args, "--since", opts.Since)
+	}
+	if opts.Tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(opts.Tail))
+	}
+	if opts.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	if opts.Previous {
+		args = append(args, "--previous")
+	}
+
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// FilterLines copies r to w a line at a time through fn, which returns the line to write
+// and whether to keep it
+func FilterLines(r io.Reader, w io.Writer, fn func(line string) (string, bool)) error {
+	scanner := bufio.NewScanner(r)
+	// Structured log lines with stack traces can be long
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line, keep := fn(scanner.Text())
+		if !keep {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// PrefixWriter writes complete lines to w with a prefix, holding back partial lines until
+// they end. Writers for several streams share mu so their lines don't interleave.
+type PrefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+// NewPrefixWriter returns a writer that prefixes each line written to w
+func NewPrefixWriter(w io.Writer, mu *sync.Mutex, prefix string) *PrefixWriter {
+	return &PrefixWriter{w: w, mu: mu, prefix: prefix}
+}
+
+func (p *PrefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			return len(data), nil
+		}
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+}
+
+// Flush writes a final line that didn't end with a newline
+func (p *PrefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+func (p *PrefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := p.w.Write(append([]byte(p.prefix), line...))
+	return err
+}
+
+// TimestampedLine is a log line from `kubectl logs --timestamps`, split from its timestamp
+type TimestampedLine struct {
+	// Source is the pod the line came from
+	Source    string
+	Time      time.Time
+	Timestamp string
+	Line      string
+}
+
+// MergeLogs merges the output of `kubectl logs --timestamps` from several sources into
+// one chronologically ordered list. Lines without a timestamp, such as the continuation
+// lines of a stack trace, stay after the line before them.
+func MergeLogs(sources []string, logs []string) []TimestampedLine {
+	var merged []TimestampedLine
+	for i, source := range sources {
+		var last TimestampedLine
+		for _, line := range strings.Split(strings.TrimRight(logs[i], "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			entry := TimestampedLine{Source: source, Time: last.Time, Timestamp: last.Timestamp, Line: line}
+			if prefix := timestampPrefix.FindString(line); prefix != "" {
+				entry.Timestamp = strings.TrimSpace(prefix)
+				entry.Time, _ = time.Parse(time.RFC3339Nano, entry.Timestamp)
+				entry.Line = line[len(prefix):]
+			}
+			merged = append(merged, entry)
+			last = entry
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged
+}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ClusterMaintenance describes a cluster's maintenance policy and node pool upgrade settings
+type ClusterMaintenance struct {
+	ReleaseChannel string
+	MasterVersion  string
+	// Window is a human readable description of the maintenance window, empty if none is set
+	Window     string
+	Exclusions []MaintenanceExclusion
+	NodePools  []NodePoolMaintenance
+}
+
+// MaintenanceExclusion is a period in which GKE won't perform automatic maintenance
+type MaintenanceExclusion struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+	Scope string
+}
+
+// Active reports whether the exclusion is currently in effect
+func (e MaintenanceExclusion) Active(now time.Time) bool {
+	return !now.Before(e.Start) && now.Before(e.End)
+}
+
+// NodePoolMaintenance is the version and auto-upgrade/repair state of a node pool
+type NodePoolMaintenance struct {
+	Name        string
+	Version     string
+	Status      string
+	AutoUpgrade bool
+	AutoRepair  bool
+}
+
+// ClusterOperation is a GKE operation such as an upgrade or node repair
+type ClusterOperation struct {
+	Name   string
+	Type   string
+	Status string
+	Target string
+	Detail string
+	Start  time.Time
+	End    time.Time
+}
+
+// InProgress reports whether the operation hasn't finished yet
+func (o ClusterOperation) InProgress() bool {
+	return o.Status != "DONE"
+}
+
+type clusterDescription struct {
+	CurrentMasterVersion string `json:"currentMasterVersion"`
+	ReleaseChannel       struct {
+		Channel string `json:"channel"`
+	} `json:"releaseChannel"`
+	MaintenancePolicy struct {
+		Window struct {
+			DailyMaintenanceWindow *struct {
+				StartTime string `json:"startTime"`
+				Duration  string `json:"duration"`
+			} `json:"dailyMaintenanceWindow"`
+			RecurringWindow *struct {
+				Window struct {
+					StartTime string `json:"startTime"`
+					EndTime   string `json:"endTime"`
+				} `json:"window"`
+				Recurrence string `json:"recurrence"`
+			} `json:"recurringWindow"`
+			MaintenanceExclusions map[string]struct {
+				StartTime                   string `json:"startTime"`
+				EndTime                     string `json:"endTime"`
+				MaintenanceExclusionOptions struct {
+					Scope string `json:"scope"`
+				} `json:"maintenanceExclusionOptions"`
+			} `json:"maintenanceExclusions"`
+		} `json:"window"`
+	} `json:"maintenancePolicy"`
+	NodePools []struct {
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+		Status     string `json:"status"`
+		Management struct {
+			AutoUpgrade bool `json:"autoUpgrade"`
+			AutoRepair  bool `json:"autoRepair"`
+		} `json:"management"`
+	} `json:"nodePools"`
+}
+
+// GetClusterMaintenance describes the maintenance policy of a cluster
+func GetClusterMaintenance(projectID string, cluster ClusterInfo) (*ClusterMaintenance, error) {
+	var desc clusterDescription
+	if err := GcloudJSON(&desc, "container", "clusters", "describe", cluster.Name, "--location", cluster.Location, "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	m := &ClusterMaintenance{
+		ReleaseChannel: desc.ReleaseChannel.Channel,
+		MasterVersion:  desc.CurrentMasterVersion,
+	}
+
+	window := desc.MaintenancePolicy.Window
+	switch {
+	case window.RecurringWindow != nil:
+		start, err1 := time.Parse(time.RFC3339, window.RecurringWindow.Window.StartTime)
+		end, err2 := time.Parse(time.RFC3339, window.RecurringWindow.Window.EndTime)
+		if err1 == nil && err2 == nil {
+			m.Window = fmt.Sprintf("%s–%s UTC (%s), %s", start.UTC().Format("15:04"), end.UTC().Format("15:04"), end.Sub(start), window.RecurringWindow.Recurrence)
+		} else {
+			m.Window = window.RecurringWindow.Recurrence
+		}
+	case window.DailyMaintenanceWindow != nil:
+		m.Window = fmt.Sprintf("Daily from %s UTC (%s)", window.DailyMaintenanceWindow.StartTime, strings.TrimPrefix(window.DailyMaintenanceWindow.Duration, "PT"))
+	}
+
+	for name, exclusion := range window.MaintenanceExclusions {
+		start, _ := time.Parse(time.RFC3339, exclusion.StartTime)
+		end, _ := time.Parse(time.RFC3339, exclusion.EndTime)
+		scope := exclusion.MaintenanceExclusionOptions.Scope
+		if scope == "" {
+			scope = "NO_UPGRADES"
+		}
+		m.Exclusions = append(m.Exclusions, MaintenanceExclusion{Name: name, Start: start, End: end, Scope: scope})
+	}
+	sort.Slice(m.Exclusions, func(i, j int) bool { return m.Exclusions[i].Start.Before(m.Exclusions[j].Start) })
+
+	for _, pool := range desc.NodePools {
+		m.NodePools = append(m.NodePools, NodePoolMaintenance{
+			Name:        pool.Name,
+			Version:     pool.Version,
+			Status:      pool.Status,
+			AutoUpgrade: pool.Management.AutoUpgrade,
+			AutoRepair:  pool.Management.AutoRepair,
+		})
+	}
+	return m, nil
+}
+
+// GetClusterOperations returns the cluster's unfinished operations and those that started within since, newest first
+func GetClusterOperations(projectID string, cluster ClusterInfo, since time.Duration) ([]ClusterOperation, error) {
+	var ops []struct {
+		Name          string `json:"name"`
+		OperationType string `json:"operationType"`
+		Status        string `json:"status"`
+		TargetLink    string `json:"targetLink"`
+		Detail        string `json:"detail"`
+		StatusMessage string `json:"statusMessage"`
+		StartTime     string `json:"startTime"`
+		EndTime       string `json:"endTime"`
+	}
+	if err := GcloudJSON(&ops, "container", "operations", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	marker := "/clusters/" + cluster.Name
+	var result []ClusterOperation
+	for _, op := range ops {
+		// targetLink ends in /clusters/<name> or /clusters/<name>/nodePools/<pool>
+		i := strings.Index(op.TargetLink, marker)
+		if i < 0 {
+			continue
+		}
+		rest := op.TargetLink[i+len(marker):]
+		if rest != "" && !strings.HasPrefix(rest, "/") {
+			continue
+		}
+
+		o := ClusterOperation{
+			Name:   op.Name,
+			Type:   op.OperationType,
+			Status: op.Status,
+			Target: cluster.Name + rest,
+			Detail: op.Detail,
+		}
+		if o.Detail == "" {
+			o.Detail = op.StatusMessage
+		}
+		o.Start, _ = time.Parse(time.RFC3339Nano, op.StartTime)
+		o.End, _ = time.Parse(time.RFC3339Nano, op.EndTime)
+
+		if !o.InProgress() && o.Start.Before(cutoff) {
+			continue
+		}
+		result = append(result, o)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.After(result[j].Start) })
+	return result, nil
+}
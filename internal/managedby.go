@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// managerLabels are the labels and annotations whose value names the tool managing an
+// object; GCP labels can't contain slashes, hence the plain variants
+var managerLabels = []string{"app.kubernetes.io/managed-by", "managed-by", "managed_by", "managedby"}
+
+// managerMarkers are labels and annotations whose presence means an object is managed by
+// a tool
+var managerMarkers = map[string]string{
+	"argocd.argoproj.io/instance":      "argocd",
+	"argocd.argoproj.io/tracking-id":   "argocd",
+	"kustomize.toolkit.fluxcd.io/name": "flux",
+	"helm.toolkit.fluxcd.io/name":      "flux",
+	"meta.helm.sh/release-name":        "helm",
+	"goog-terraform-provisioned":       "terraform",
+}
+
+// Managers detects the tools that manage an object from its labels and annotations, e.g.
+// terraform, argocd, flux or helm, in lower case and sorted. gcpeasy itself isn't listed.
+func Managers(labels, annotations map[string]string) []string {
+	found := make(map[string]bool)
+	for _, m := range []map[string]string{labels, annotations} {
+		for _, key := range managerLabels {
+			if value := strings.ToLower(m[key]); value != "" && value != "gcpeasy" {
+				found[value] = true
+			}
+		}
+		for key, manager := range managerMarkers {
+			if _, ok := m[key]; ok {
+				found[manager] = true
+			}
+		}
+	}
+
+	managers := make([]string, 0, len(found))
+	for manager := range found {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+	return managers
+}
+
+// RespectedManagers returns the managers of an object that are listed in managed_by. It
+// returns nothing when managed_by isn't configured.
+func (c *Config) RespectedManagers(labels, annotations map[string]string) []string {
+	if len(c.ManagedBy) == 0 {
+		return nil
+	}
+	var respected []string
+	for _, manager := range Managers(labels, annotations) {
+		for name := range c.ManagedBy {
+			if strings.EqualFold(name, manager) {
+				respected = append(respected, manager)
+			}
+		}
+	}
+	return respected
+}
+
+// ManagerHint returns the configured hint for a manager, or ""
+func (c *Config) ManagerHint(manager string) string {
+	for name, hint := range c.ManagedBy {
+		if strings.EqualFold(name, manager) {
+			return hint
+		}
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is a decoded Kubernetes object read from a local file
+type Manifest struct {
+	Source string
+	Object map[string]interface{}
+}
+
+// Kind returns the object's kind
+func (m Manifest) Kind() string {
+	kind, _ := m.Object["kind"].(string)
+	return kind
+}
+
+// Name returns metadata.name
+func (m Manifest) Name() string {
+	meta, _ := m.Object["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	return name
+}
+
+// Namespace returns metadata.namespace, or an empty string if unset
+func (m Manifest) Namespace() string {
+	meta, _ := m.Object["metadata"].(map[string]interface{})
+	namespace, _ := meta["namespace"].(string)
+	return namespace
+}
+
+var kustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// LoadManifests reads Kubernetes objects from a file or directory. Directories containing
+// a kustomization file are rendered with `kubectl kustomize`; otherwise every .yaml/.yml
+// file is read recursively. List objects are expanded into their items.
+func LoadManifests(path string) ([]Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return decodeManifests(path, data)
+	}
+
+	for _, name := range kustomizationFiles {
+		if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+			output, err := runOutput("kubectl", "kustomize", path)
+			if err != nil {
+				return nil, fmt.Errorf("kustomize build failed: %w", err)
+			}
+			return decodeManifests(path+" (kustomize)", output)
+		}
+	}
+
+	var manifests []Manifest
+	err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || !(strings.HasSuffix(p, ".yaml") || strings.HasSuffix(p, ".yml")) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		decoded, err := decodeManifests(p, data)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, decoded...)
+		return nil
+	})
+	return manifests, err
+}
+
+func decodeManifests(source string, data []byte) ([]Manifest, error) {
+	var manifests []Manifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var obj map[string]interface{}
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		if obj == nil || obj["kind"] == nil {
+			continue
+		}
+
+		if items, ok := obj["items"].([]interface{}); ok && strings.HasSuffix(fmt.Sprint(obj["kind"]), "List") {
+			for _, item := range items {
+				if m, ok := item.(map[string]interface{}); ok {
+					manifests = append(manifests, Manifest{Source: source, Object: m})
+				}
+			}
+			continue
+		}
+		manifests = append(manifests, Manifest{Source: source, Object: obj})
+	}
+	return manifests, nil
+}
+
+// GetLiveObject fetches the live version of a manifest. It returns nil without error
+// when the object does not exist in the cluster.
+func GetLiveObject(m Manifest, namespace string) (map[string]interface{}, error) {
+	args := []string{"get", m.Kind(), m.Name(), "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	cmd := exec.Command("kubectl", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "NotFound") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
+	}
+
+	var live map[string]interface{}
+	if err := yaml.Unmarshal(output, &live); err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// GetContextNamespace returns the namespace configured for the current kubectl context
+func GetContextNamespace() string {
+	output, err := exec.Command("kubectl", "config", "view", "--minify", "-o", "jsonpath={..namespace}").Output()
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return "default"
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MonitoringQuery describes an aggregated Cloud Monitoring time series query
+type MonitoringQuery struct {
+	Filter  string
+	Window  time.Duration
+	Period  time.Duration
+	Aligner string
+	Reducer string
+	GroupBy []string
+}
+
+// TimeSeries is a single aggregated Cloud Monitoring series
+type TimeSeries struct {
+	ResourceLabels map[string]string
+	MetricLabels   map[string]string
+	Points         []float64
+}
+
+// Max returns the largest point in the series
+func (t TimeSeries) Max() float64 {
+	m := 0.0
+	for _, p := range t.Points {
+		m = max(m, p)
+	}
+	return m
+}
+
+type timeSeriesResponse struct {
+	TimeSeries []struct {
+		Metric struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metric"`
+		Resource struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"resource"`
+		Points []struct {
+			Value struct {
+				DoubleValue *float64 `json:"doubleValue"`
+				Int64Value  *string  `json:"int64Value"`
+			} `json:"value"`
+		} `json:"points"`
+	} `json:"timeSeries"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+var monitoringClient = &http.Client{Timeout: 60 * time.Second}
+
+// AccessToken returns an OAuth access token for the active gcloud account
+func AccessToken() (string, error) {
+	output, err := runOutput("gcloud", "auth", "print-access-token")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// QueryTimeSeries runs an aggregated query against the Cloud Monitoring API
+func QueryTimeSeries(projectID string, q MonitoringQuery) ([]TimeSeries, error) {
+	token, err := AccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	end := time.Now().UTC()
+	period := q.Period
+	if period == 0 {
+		period = q.Window
+	}
+
+	params := url.Values{}
+	params.Set("filter", q.Filter)
+	params.Set("interval.startTime", end.Add(-q.Window).Format(time.RFC3339))
+	params.Set("interval.endTime", end.Format(time.RFC3339))
+	params.Set("aggregation.alignmentPeriod", fmt.Sprintf("%ds", int(period.Seconds())))
+	if q.Aligner != "" {
+		params.Set("aggregation.perSeriesAligner", q.Aligner)
+	}
+	if q.Reducer != "" {
+		params.Set("aggregation.crossSeriesReducer", q.Reducer)
+	}
+	for _, g := range q.GroupBy {
+		params.Add("aggregation.groupByFields", g)
+	}
+
+	var series []TimeSeries
+	for {
+		endpoint := fmt.Sprintf("https://monitoring.googleapis.com/v3/projects/%s/timeSeries?%s", projectID, params.Encode())
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := monitoringClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("monitoring API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+
+		var page timeSeriesResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse monitoring response: %w", err)
+		}
+
+		for _, ts := range page.TimeSeries {
+			s := TimeSeries{ResourceLabels: ts.Resource.Labels, MetricLabels: ts.Metric.Labels}
+			for _, p := range ts.Points {
+				switch {
+				case p.Value.DoubleValue != nil:
+					s.Points = append(s.Points, *p.Value.DoubleValue)
+				case p.Value.Int64Value != nil:
+					var v float64
+					fmt.Sscan(*p.Value.Int64Value, &v)
+					s.Points = append(s.Points, v)
+				}
+			}
+			series = append(series, s)
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		params.Set("pageToken", page.NextPageToken)
+	}
+
+	return series, nil
+}
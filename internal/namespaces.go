@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// namespaceScope limits pod discovery to one namespace; empty means all application
+// namespaces
+var namespaceScope string
+
+// SetNamespaceScope limits FindApplicationPods and GetDetailedPodInfo to a namespace, or
+// lifts the limit when namespace is empty
+func SetNamespaceScope(namespace string) {
+	namespaceScope = namespace
+}
+
+// NamespaceScope returns the namespace pod discovery is limited to, if any
+func NamespaceScope() string {
+	return namespaceScope
+}
+
+// podSelector is a label selector pod discovery is limited to; empty means all pods
+var podSelector string
+
+// SetPodSelector limits FindApplicationPods and GetDetailedPodInfo to pods matching a
+// kubectl label selector (e.g. "app=web"), or lifts the limit when selector is empty
+func SetPodSelector(selector string) {
+	podSelector = selector
+}
+
+// namespaceArgs returns the kubectl arguments selecting the namespaces to look in
+func namespaceArgs() []string {
+	if namespaceScope != "" {
+		return []string{"-n", namespaceScope}
+	}
+	return []string{"--all-namespaces"}
+}
+
+// podListScope returns the kubectl arguments selecting the pods to discover
+func podListScope() []string {
+	args := namespaceArgs()
+	if podSelector != "" {
+		args = append(args, "-l", podSelector)
+	}
+	return args
+}
+
+// namespaceFile maps project IDs to their selected namespace
+type namespaceFile map[string]string
+
+func namespacesPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "namespaces.json"), nil
+}
+
+func loadNamespaceFile() (namespaceFile, error) {
+	path, err := namespacesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return namespaceFile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	namespaces := namespaceFile{}
+	if err := json.Unmarshal(data, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return namespaces, nil
+}
+
+// LoadSelectedNamespace returns the namespace selected for a project, or "" for all
+func LoadSelectedNamespace(projectID string) (string, error) {
+	namespaces, err := loadNamespaceFile()
+	if err != nil {
+		return "", err
+	}
+	return namespaces[projectID], nil
+}
+
+// SaveSelectedNamespace persists the namespace selected for a project; "" clears it
+func SaveSelectedNamespace(projectID, namespace string) error {
+	namespaces, err := loadNamespaceFile()
+	if err != nil {
+		return err
+	}
+	if namespace == "" {
+		delete(namespaces, projectID)
+	} else {
+		namespaces[projectID] = namespace
+	}
+
+	path, err := namespacesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(namespaces, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// GetApplicationNamespaces returns the cluster's non-system namespaces
+func GetApplicationNamespaces() ([]string, error) {
+	var list struct {
+		Items []struct {
+			Metadata ObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, ns := range list.Items {
+		if !isSystemNamespace(ns.Metadata.Name) {
+			namespaces = append(namespaces, ns.Metadata.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// SelectNamespace prompts the user to select a namespace, or all namespaces ("")
+func SelectNamespace(namespaces []string, current string) (string, error) {
+	if len(namespaces) == 0 {
+		return "", fmt.Errorf("no application namespaces available")
+	}
+
+	fmt.Printf("📋 Found %d namespace(s):\n", len(namespaces))
+	fmt.Println()
+
+	checkbox := func(selected bool) string {
+		if selected {
+			return "- [x]"
+		}
+		return "- [ ]"
+	}
+	fmt.Printf("%s 0. (all application namespaces)\n", checkbox(current == ""))
+	for i, ns := range namespaces {
+		fmt.Printf("%s %d. %s\n", checkbox(ns == current), i+1, ns)
+	}
+
+	fmt.Println()
+	fmt.Print("Select namespace (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return "", fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return "", fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 0 || num > len(namespaces) {
+		return "", fmt.Errorf("invalid selection: %s", input)
+	}
+	if num == 0 {
+		return "", nil
+	}
+	return namespaces[num-1], nil
+}
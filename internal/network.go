@@ -0,0 +1,436 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FirewallRule is a VPC firewall rule
+type FirewallRule struct {
+	Name                  string          `json:"name"`
+	Network               string          `json:"network"`
+	Direction             string          `json:"direction"`
+	Priority              int             `json:"priority"`
+	Disabled              bool            `json:"disabled"`
+	SourceRanges          []string        `json:"sourceRanges"`
+	DestinationRanges     []string        `json:"destinationRanges"`
+	SourceTags            []string        `json:"sourceTags"`
+	SourceServiceAccounts []string        `json:"sourceServiceAccounts"`
+	TargetTags            []string        `json:"targetTags"`
+	TargetServiceAccounts []string        `json:"targetServiceAccounts"`
+	Allowed               []FirewallPorts `json:"allowed"`
+	Denied                []FirewallPorts `json:"denied"`
+}
+
+// FirewallPorts is a protocol and optional list of ports or port ranges
+type FirewallPorts struct {
+	IPProtocol string   `json:"IPProtocol"`
+	Ports      []string `json:"ports"`
+}
+
+// GetFirewallRules returns the project's firewall rules sorted by network and priority
+func GetFirewallRules(projectID string) ([]FirewallRule, error) {
+	var rules []FirewallRule
+	if err := GcloudJSON(&rules, "compute", "firewall-rules", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		rules[i].Network = path.Base(rules[i].Network)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Network != rules[j].Network {
+			return rules[i].Network < rules[j].Network
+		}
+		return rules[i].Priority < rules[j].Priority
+	})
+	return rules, nil
+}
+
+// Action returns ALLOW or DENY
+func (r FirewallRule) Action() string {
+	if len(r.Denied) > 0 {
+		return "DENY"
+	}
+	return "ALLOW"
+}
+
+// AppliesToAll reports whether the rule targets every instance in the network
+func (r FirewallRule) AppliesToAll() bool {
+	return len(r.TargetTags) == 0 && len(r.TargetServiceAccounts) == 0
+}
+
+// Targets reports whether the rule applies to instances with the given tags or service accounts
+func (r FirewallRule) Targets(tags, serviceAccounts []string) bool {
+	if r.AppliesToAll() {
+		return true
+	}
+	for _, t := range r.TargetTags {
+		for _, tag := range tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+	for _, s := range r.TargetServiceAccounts {
+		for _, sa := range serviceAccounts {
+			if s == sa {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchesPort reports whether the rule's allowed/denied entries cover the given port
+func (r FirewallRule) MatchesPort(port int) bool {
+	for _, p := range append(append([]FirewallPorts{}, r.Allowed...), r.Denied...) {
+		if p.IPProtocol == "all" || len(p.Ports) == 0 && (p.IPProtocol == "tcp" || p.IPProtocol == "udp") {
+			return true
+		}
+		for _, spec := range p.Ports {
+			lo, hi, ok := parsePortRange(spec)
+			if ok && port >= lo && port <= hi {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parsePortRange(spec string) (int, int, bool) {
+	loStr, hiStr, isRange := strings.Cut(spec, "-")
+	lo, err := strconv.Atoi(loStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !isRange {
+		return lo, lo, true
+	}
+	hi, err := strconv.Atoi(hiStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lo, hi, true
+}
+
+// PortsSummary renders the protocols and ports, e.g. "tcp:80,443 udp:53"
+func (r FirewallRule) PortsSummary() string {
+	entries := r.Allowed
+	if len(r.Denied) > 0 {
+		entries = r.Denied
+	}
+	var parts []string
+	for _, p := range entries {
+		if len(p.Ports) == 0 {
+			parts = append(parts, p.IPProtocol)
+			continue
+		}
+		parts = append(parts, p.IPProtocol+":"+strings.Join(p.Ports, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// SourcesSummary renders where traffic comes from (ingress) or goes to (egress)
+func (r FirewallRule) SourcesSummary() string {
+	var parts []string
+	if r.Direction == "EGRESS" {
+		parts = append(parts, r.DestinationRanges...)
+	} else {
+		parts = append(parts, r.SourceRanges...)
+		for _, t := range r.SourceTags {
+			parts = append(parts, "tag:"+t)
+		}
+		for _, sa := range r.SourceServiceAccounts {
+			parts = append(parts, "sa:"+sa)
+		}
+	}
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, ",")
+}
+
+// TargetsSummary renders which instances the rule applies to
+func (r FirewallRule) TargetsSummary() string {
+	if r.AppliesToAll() {
+		return "all instances"
+	}
+	var parts []string
+	for _, t := range r.TargetTags {
+		parts = append(parts, "tag:"+t)
+	}
+	for _, sa := range r.TargetServiceAccounts {
+		parts = append(parts, "sa:"+sa)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Summary is a one-line human readable description of the rule
+func (r FirewallRule) Summary() string {
+	direction := "from"
+	if r.Direction == "EGRESS" {
+		direction = "to"
+	}
+	return fmt.Sprintf("%s %s %s %s → %s", r.Action(), r.PortsSummary(), direction, r.SourcesSummary(), r.TargetsSummary())
+}
+
+// NATGateway describes a Cloud NAT configuration and the IPs it currently uses
+type NATGateway struct {
+	Router     string
+	Region     string
+	Network    string
+	Name       string
+	Manual     bool
+	IPs        []string
+	AllSubnets bool
+}
+
+type cloudRouter struct {
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Network string `json:"network"`
+	Nats    []struct {
+		Name                          string   `json:"name"`
+		NatIPAllocateOption           string   `json:"natIpAllocateOption"`
+		SourceSubnetworkIPRangesToNat string   `json:"sourceSubnetworkIpRangesToNat"`
+		NatIPs                        []string `json:"natIps"`
+	} `json:"nats"`
+}
+
+type routerStatus struct {
+	Result struct {
+		NatStatus []struct {
+			Name                string   `json:"name"`
+			AutoAllocatedNatIPs []string `json:"autoAllocatedNatIps"`
+			UserAllocatedNatIPs []string `json:"userAllocatedNatIps"`
+		} `json:"natStatus"`
+	} `json:"result"`
+}
+
+// GetNATGateways returns all Cloud NAT gateways in the project with their active IPs
+func GetNATGateways(projectID string) ([]NATGateway, error) {
+	var routers []cloudRouter
+	if err := GcloudJSON(&routers, "compute", "routers", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	var gateways []NATGateway
+	for _, r := range routers {
+		if len(r.Nats) == 0 {
+			continue
+		}
+		region := path.Base(r.Region)
+
+		var status routerStatus
+		statusErr := GcloudJSON(&status, "compute", "routers", "get-status", r.Name, "--region", region, "--project", projectID)
+
+		for _, nat := range r.Nats {
+			gw := NATGateway{
+				Router:     r.Name,
+				Region:     region,
+				Network:    path.Base(r.Network),
+				Name:       nat.Name,
+				Manual:     nat.NatIPAllocateOption == "MANUAL_ONLY",
+				AllSubnets: strings.HasPrefix(nat.SourceSubnetworkIPRangesToNat, "ALL_SUBNETWORKS"),
+			}
+			if statusErr == nil {
+				for _, s := range status.Result.NatStatus {
+					if s.Name == nat.Name {
+						gw.IPs = append(append(gw.IPs, s.UserAllocatedNatIPs...), s.AutoAllocatedNatIPs...)
+					}
+				}
+			}
+			if len(gw.IPs) == 0 {
+				for _, ip := range nat.NatIPs {
+					gw.IPs = append(gw.IPs, path.Base(ip))
+				}
+			}
+			gateways = append(gateways, gw)
+		}
+	}
+	return gateways, nil
+}
+
+// StaticAddress is a reserved external IP address
+type StaticAddress struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Region  string   `json:"region"`
+	Status  string   `json:"status"`
+	Type    string   `json:"addressType"`
+	Users   []string `json:"users"`
+}
+
+// GetStaticAddresses returns reserved external addresses in the project
+func GetStaticAddresses(projectID string) ([]StaticAddress, error) {
+	var addresses []StaticAddress
+	if err := GcloudJSON(&addresses, "compute", "addresses", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	var external []StaticAddress
+	for _, a := range addresses {
+		if a.Type != "" && a.Type != "EXTERNAL" {
+			continue
+		}
+		a.Region = path.Base(a.Region)
+		external = append(external, a)
+	}
+	return external, nil
+}
+
+// ClusterNetworkInfo describes the networking of a GKE cluster
+type ClusterNetworkInfo struct {
+	Name                  string
+	Location              string
+	Network               string
+	Subnetwork            string
+	PrivateNodes          bool
+	PodRangeName          string
+	ServiceRangeName      string
+	PodCIDR               string
+	ServiceCIDR           string
+	DefaultMaxPodsPerNode int
+}
+
+type gkeClusterNetwork struct {
+	Name                 string `json:"name"`
+	Location             string `json:"location"`
+	Network              string `json:"network"`
+	Subnetwork           string `json:"subnetwork"`
+	ClusterIPv4CIDR      string `json:"clusterIpv4Cidr"`
+	ServicesIPv4CIDR     string `json:"servicesIpv4Cidr"`
+	PrivateClusterConfig struct {
+		EnablePrivateNodes bool `json:"enablePrivateNodes"`
+	} `json:"privateClusterConfig"`
+	NetworkConfig struct {
+		DefaultEnablePrivateNodes bool `json:"defaultEnablePrivateNodes"`
+	} `json:"networkConfig"`
+	IPAllocationPolicy struct {
+		ClusterSecondaryRangeName  string `json:"clusterSecondaryRangeName"`
+		ServicesSecondaryRangeName string `json:"servicesSecondaryRangeName"`
+	} `json:"ipAllocationPolicy"`
+	DefaultMaxPodsConstraint struct {
+		MaxPodsPerNode string `json:"maxPodsPerNode"`
+	} `json:"defaultMaxPodsConstraint"`
+}
+
+// GetClusterNetwork describes the networking configuration of a GKE cluster
+func GetClusterNetwork(projectID string, cluster ClusterInfo) (*ClusterNetworkInfo, error) {
+	var c gkeClusterNetwork
+	if err := GcloudJSON(&c, "container", "clusters", "describe", cluster.Name, "--location", cluster.Location, "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	maxPods, _ := strconv.Atoi(c.DefaultMaxPodsConstraint.MaxPodsPerNode)
+	return &ClusterNetworkInfo{
+		Name:                  c.Name,
+		Location:              c.Location,
+		Network:               path.Base(c.Network),
+		Subnetwork:            path.Base(c.Subnetwork),
+		PrivateNodes:          c.PrivateClusterConfig.EnablePrivateNodes || c.NetworkConfig.DefaultEnablePrivateNodes,
+		PodRangeName:          c.IPAllocationPolicy.ClusterSecondaryRangeName,
+		ServiceRangeName:      c.IPAllocationPolicy.ServicesSecondaryRangeName,
+		PodCIDR:               c.ClusterIPv4CIDR,
+		ServiceCIDR:           c.ServicesIPv4CIDR,
+		DefaultMaxPodsPerNode: maxPods,
+	}, nil
+}
+
+// LocationRegion returns the region of a zone or region location (us-central1-a → us-central1)
+func LocationRegion(location string) string {
+	if parts := strings.Split(location, "-"); len(parts) == 3 {
+		return parts[0] + "-" + parts[1]
+	}
+	return location
+}
+
+// Subnet describes a VPC subnetwork
+type Subnet struct {
+	Name                  string `json:"name"`
+	IPCIDRRange           string `json:"ipCidrRange"`
+	PrivateIPGoogleAccess bool   `json:"privateIpGoogleAccess"`
+	SecondaryIPRanges     []struct {
+		RangeName   string `json:"rangeName"`
+		IPCIDRRange string `json:"ipCidrRange"`
+	} `json:"secondaryIpRanges"`
+}
+
+// SecondaryRange returns the CIDR of a named secondary range
+func (s Subnet) SecondaryRange(name string) string {
+	for _, r := range s.SecondaryIPRanges {
+		if r.RangeName == name {
+			return r.IPCIDRRange
+		}
+	}
+	return ""
+}
+
+// GetSubnet describes a subnetwork in the given region
+func GetSubnet(projectID, region, name string) (*Subnet, error) {
+	var subnet Subnet
+	if err := GcloudJSON(&subnet, "compute", "networks", "subnets", "describe", name, "--region", region, "--project", projectID); err != nil {
+		return nil, err
+	}
+	return &subnet, nil
+}
+
+// CIDRSize returns the number of addresses in a CIDR block, or 0 if it cannot be parsed
+func CIDRSize(cidr string) int {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0
+	}
+	ones, bits := ipNet.Mask.Size()
+	return 1 << (bits - ones)
+}
+
+// ClusterIPUsage counts the IP space consumed in a cluster's node, pod and service ranges
+type ClusterIPUsage struct {
+	Nodes             int
+	PodAddressesUsed  int
+	ServiceClusterIPs int
+}
+
+type nodeCIDRList struct {
+	Items []struct {
+		Spec struct {
+			PodCIDR string `json:"podCIDR"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+type serviceIPList struct {
+	Items []struct {
+		Spec struct {
+			ClusterIP string `json:"clusterIP"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// GetClusterIPUsage counts nodes, pod CIDR blocks assigned to nodes, and allocated service IPs
+func GetClusterIPUsage(contextName string) (*ClusterIPUsage, error) {
+	var nodes nodeCIDRList
+	if err := KubectlJSON(&nodes, "--context", contextName, "get", "nodes", "-o", "json"); err != nil {
+		return nil, err
+	}
+	var services serviceIPList
+	if err := KubectlJSON(&services, "--context", contextName, "get", "services", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	usage := &ClusterIPUsage{Nodes: len(nodes.Items)}
+	for _, n := range nodes.Items {
+		usage.PodAddressesUsed += CIDRSize(n.Spec.PodCIDR)
+	}
+	for _, s := range services.Items {
+		if s.Spec.ClusterIP != "" && s.Spec.ClusterIP != "None" {
+			usage.ServiceClusterIPs++
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,217 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node capacity types, from the GKE node labels
+const (
+	CapacitySpot        = "spot"
+	CapacityPreemptible = "preemptible"
+	CapacityStandard    = "standard"
+)
+
+// Node is a Kubernetes Node
+type Node struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Unschedulable bool `json:"unschedulable"`
+	} `json:"spec"`
+	Status struct {
+		Addresses  []NodeAddress `json:"addresses"`
+		Conditions []Condition   `json:"conditions"`
+		NodeInfo   struct {
+			KubeletVersion string `json:"kubeletVersion"`
+		} `json:"nodeInfo"`
+	} `json:"status"`
+}
+
+// NodeAddress is one of a node's addresses, e.g. its InternalIP or ExternalIP
+type NodeAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// NodeList is the result of `kubectl get nodes -o json`
+type NodeList struct {
+	Items []Node `json:"items"`
+}
+
+// CapacityType returns whether the node is a Spot, preemptible or standard VM
+func (n Node) CapacityType() string {
+	switch {
+	case n.Metadata.Labels["cloud.google.com/gke-spot"] == "true":
+		return CapacitySpot
+	case n.Metadata.Labels["cloud.google.com/gke-preemptible"] == "true":
+		return CapacityPreemptible
+	default:
+		return CapacityStandard
+	}
+}
+
+// Problems returns the node's problems: NotReady, the pressure conditions that are
+// True (e.g. DiskPressure) and SchedulingDisabled when it is cordoned
+func (n Node) Problems() []string {
+	var problems []string
+	for _, c := range n.Status.Conditions {
+		switch {
+		case c.Type == "Ready" && c.Status != "True":
+			problems = append(problems, "NotReady")
+		case c.Type != "Ready" && c.Status == "True":
+			problems = append(problems, c.Type)
+		}
+	}
+	if n.Spec.Unschedulable {
+		problems = append(problems, "SchedulingDisabled")
+	}
+	return problems
+}
+
+// GetNodes returns the cluster's nodes sorted by name
+func GetNodes() ([]Node, error) {
+	var list NodeList
+	if err := KubectlJSON(&list, "get", "nodes", "-o", "json"); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Metadata.Name < list.Items[j].Metadata.Name })
+	return list.Items, nil
+}
+
+// SelectNode prompts the user to select a node, showing its problems and capacity type
+func SelectNode(nodes []Node) (*Node, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes available")
+	}
+
+	fmt.Printf("📋 Found %d node(s):\n", len(nodes))
+	fmt.Println()
+
+	for i, node := range nodes {
+		status := "Ready"
+		if problems := node.Problems(); len(problems) > 0 {
+			status = Colorize(ColorYellow, strings.Join(problems, ","))
+		}
+		fmt.Printf("%d. %s (%s, %s)\n", i+1, node.Metadata.Name, status, node.CapacityType())
+	}
+
+	fmt.Println()
+	fmt.Print("Select node (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(nodes) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &nodes[num-1], nil
+}
+
+// GetNodeCapacityTypes maps each node name to its capacity type
+func GetNodeCapacityTypes() (map[string]string, error) {
+	var list NodeList
+	if err := KubectlJSON(&list, "get", "nodes", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]string, len(list.Items))
+	for _, node := range list.Items {
+		types[node.Metadata.Name] = node.CapacityType()
+	}
+	return types, nil
+}
+
+// Preemption is a Compute Engine preemption of a node VM
+type Preemption struct {
+	Node string
+	Zone string
+	Time time.Time
+}
+
+// GetPreemptions returns the VM preemptions in the project since the given time, newest first.
+// GKE node names match their VM instance names.
+func GetPreemptions(projectID string, since time.Time) ([]Preemption, error) {
+	var ops []struct {
+		TargetLink string `json:"targetLink"`
+		Zone       string `json:"zone"`
+		InsertTime string `json:"insertTime"`
+	}
+	filter := "operationType=compute.instances.preempted AND insertTime>=" + since.UTC().Format(time.RFC3339)
+	if err := GcloudJSON(&ops, "compute", "operations", "list", "--project", projectID, "--filter", filter); err != nil {
+		return nil, err
+	}
+
+	preemptions := make([]Preemption, 0, len(ops))
+	for _, op := range ops {
+		t, _ := time.Parse(time.RFC3339Nano, op.InsertTime)
+		preemptions = append(preemptions, Preemption{Node: path.Base(op.TargetLink), Zone: path.Base(op.Zone), Time: t})
+	}
+	sort.Slice(preemptions, func(i, j int) bool { return preemptions[i].Time.After(preemptions[j].Time) })
+	return preemptions, nil
+}
+
+// ShutdownPod is an application pod terminated because its node shut down
+type ShutdownPod struct {
+	Pod      string
+	Workload string
+	Node     string
+	Time     time.Time
+}
+
+// FindShutdownPods returns application pods that were terminated by a node shutdown since
+// the given time. The kubelet marks these when a Spot or preemptible VM is reclaimed.
+func FindShutdownPods(since time.Time) ([]ShutdownPod, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var pods []ShutdownPod
+	for _, pod := range list.Items {
+		if isSystemNamespace(pod.Metadata.Namespace) || pod.Status.Phase != "Failed" {
+			continue
+		}
+		reason := pod.Status.Reason
+		if reason != "Terminated" && reason != "NodeShutdown" && reason != "Shutdown" && !strings.Contains(pod.Status.Message, "node shutdown") {
+			continue
+		}
+
+		// The latest container termination is the best estimate of when the node went away
+		var at time.Time
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated == nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, status.State.Terminated.FinishedAt); err == nil && t.After(at) {
+				at = t
+			}
+		}
+		if at.IsZero() || at.Before(since) {
+			continue
+		}
+
+		pods = append(pods, ShutdownPod{
+			Pod:      pod.Metadata.Namespace + "/" + pod.Metadata.Name,
+			Workload: podOwner(pod),
+			Node:     pod.Spec.NodeName,
+			Time:     at,
+		})
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Time.After(pods[j].Time) })
+	return pods, nil
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// NodeShellImage is the default image of `nodes shell` debug pods
+const NodeShellImage = "busybox"
+
+// nodeDebuggerPrefix is the name prefix kubectl debug gives the pods it creates for a node
+func nodeDebuggerPrefix(node string) string {
+	return "node-debugger-" + node + "-"
+}
+
+// NodeDebuggerPods returns the names of the node debugging pods kubectl debug created for
+// a node in namespace
+func NodeDebuggerPods(namespace, node string) ([]string, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range list.Items {
+		if strings.HasPrefix(p.Metadata.Name, nodeDebuggerPrefix(node)) {
+			names = append(names, p.Metadata.Name)
+		}
+	}
+	return names, nil
+}
+
+// NodeShellCommand returns the kubectl debug command opening an interactive shell in a
+// privileged debugging pod on a node, attached to the terminal. The node's root
+// filesystem is mounted at /host; with chroot the shell runs in it, using the node's own
+// tools.
+func NodeShellCommand(namespace, node, image string, chroot bool) *exec.Cmd {
+	args := []string{"debug", "node/" + node, "-n", namespace, "-it", "--image", image, "--profile", "sysadmin"}
+	if chroot {
+		args = append(args, "--", "chroot", "/host", "/bin/sh", "-l")
+	} else {
+		args = append(args, "--", "sh")
+	}
+
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// DeleteNodeDebuggerPod deletes a node debugging pod without waiting for it to terminate
+func DeleteNodeDebuggerPod(namespace, name string) error {
+	_, err := runOutput("kubectl", "delete", "pod", name, "-n", namespace, "--wait=false")
+	return err
+}
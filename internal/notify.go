@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Notification statuses posted around an operation
+const (
+	NotifyStarted   = "started"
+	NotifySucceeded = "succeeded"
+	NotifyFailed    = "failed"
+)
+
+// Notification describes a single operation event sent to the configured endpoints
+type Notification struct {
+	Operation string    `json:"operation"`
+	Status    string    `json:"status"`
+	Project   string    `json:"project"`
+	User      string    `json:"user"`
+	Detail    string    `json:"detail,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// Enabled reports whether any notification endpoint is configured
+func (c NotificationConfig) Enabled() bool {
+	return c.SlackWebhook != "" || c.WebhookURL != ""
+}
+
+// Notify posts the notification to the Slack webhook and/or generic HTTP endpoint
+func Notify(cfg NotificationConfig, n Notification) error {
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now()
+	}
+
+	var firstErr error
+	if cfg.SlackWebhook != "" {
+		payload := map[string]string{"text": slackText(n)}
+		webhook, err := ResolveSecret(cfg.SlackWebhook)
+		if err == nil {
+			err = postJSON(webhook, payload, nil)
+		}
+		if err != nil {
+			firstErr = fmt.Errorf("slack webhook: %w", err)
+		}
+	}
+	if cfg.WebhookURL != "" {
+		if err := postWebhook(cfg, n); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("webhook: %w", err)
+		}
+	}
+	return firstErr
+}
+
+// postWebhook posts to the generic endpoint, reading the URL and header values from the
+// vault when they are "vault:<name>" references
+func postWebhook(cfg NotificationConfig, n Notification) error {
+	webhook, err := ResolveSecret(cfg.WebhookURL)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string, len(cfg.Headers))
+	for k, v := range cfg.Headers {
+		if headers[k], err = ResolveSecret(v); err != nil {
+			return fmt.Errorf("header %s: %w", k, err)
+		}
+	}
+	return postJSON(webhook, n, headers)
+}
+
+func slackText(n Notification) string {
+	icon := "🚀"
+	switch n.Status {
+	case NotifySucceeded:
+		icon = "✅"
+	case NotifyFailed:
+		icon = "❌"
+	}
+
+	text := fmt.Sprintf("%s *%s* %s in `%s` by %s", icon, n.Operation, n.Status, n.Project, n.User)
+	if n.Detail != "" {
+		text += fmt.Sprintf("\n%s", n.Detail)
+	}
+	if n.Error != "" {
+		text += fmt.Sprintf("\nError: %s", n.Error)
+	}
+	return text
+}
+
+func postJSON(url string, payload interface{}, headers map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := notifyClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
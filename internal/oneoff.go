@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// RunFromLabel marks the one-off Jobs created by CreateOneOffJob with the name of the
+// deployment they were cloned from
+const RunFromLabel = "gcpeasy.io/run-from"
+
+// CreateOneOffJob creates a Job from a deployment's pod template (same images, env,
+// secrets, volumes and service account) that runs command in one of its containers
+// instead of the container's own command, and returns the Job's name.
+//
+// The container's probes are dropped, the Job doesn't retry and is deleted by
+// Kubernetes an hour after it finishes. The pod doesn't carry the labels of the
+// deployment's selector, nor any label a Service in the namespace would select it by, so
+// Services don't send it traffic. A zero deadline means the Job may run indefinitely.
+func CreateOneOffJob(d Deployment, container string, command []string, deadline time.Duration) (string, error) {
+	var obj map[string]any
+	if err := KubectlJSON(&obj, "get", "deployment", d.Metadata.Name, "-n", d.Metadata.Namespace, "-o", "json"); err != nil {
+		return "", err
+	}
+	spec, _ := obj["spec"].(map[string]any)
+	template, _ := spec["template"].(map[string]any)
+	templateMeta, _ := template["metadata"].(map[string]any)
+	podSpec, _ := template["spec"].(map[string]any)
+	if templateMeta == nil || podSpec == nil {
+		return "", fmt.Errorf("unexpected deployment spec of %s", d.Metadata.Name)
+	}
+
+	labels, _ := templateMeta["labels"].(map[string]any)
+	if labels == nil {
+		labels = make(map[string]any)
+	}
+	for key := range d.Spec.Selector.MatchLabels {
+		delete(labels, key)
+	}
+	// Services may select on other labels than the deployment does, e.g. a subset of them
+	var services ServiceList
+	if err := KubectlJSON(&services, "get", "services", "-n", d.Metadata.Namespace, "-o", "json"); err != nil {
+		return "", err
+	}
+	for _, svc := range services.Items {
+		if selectsLabels(svc.Spec.Selector, labels) {
+			for key := range svc.Spec.Selector {
+				delete(labels, key)
+			}
+		}
+	}
+	labels[RunFromLabel] = d.Metadata.Name
+	templateMeta["labels"] = labels
+	podSpec["restartPolicy"] = "Never"
+
+	found := false
+	containers, _ := podSpec["containers"].([]any)
+	for _, c := range containers {
+		c, ok := c.(map[string]any)
+		if !ok || c["name"] != container {
+			continue
+		}
+		c["command"] = command
+		delete(c, "args")
+		for _, probe := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+			delete(c, probe)
+		}
+		found = true
+	}
+	if !found {
+		return "", fmt.Errorf("%s has no container named %s", d.Metadata.Name, container)
+	}
+
+	name := uniqueJobName(d.Metadata.Name, "run")
+	jobSpec := map[string]any{
+		"backoffLimit":            0,
+		"ttlSecondsAfterFinished": 3600,
+		"template":                template,
+	}
+	if deadline > 0 {
+		jobSpec["activeDeadlineSeconds"] = int(math.Ceil(deadline.Seconds()))
+	}
+	job := map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": d.Metadata.Namespace,
+			"labels":    map[string]any{RunFromLabel: d.Metadata.Name},
+		},
+		"spec": jobSpec,
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("kubectl", "create", "-f", "-")
+	cmd.Stdin = strings.NewReader(string(data))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to create job %s: %s", name, strings.TrimSpace(string(output)))
+	}
+	return name, nil
+}
+
+// selectsLabels reports whether a Service selector matches a pod with labels. An empty
+// selector selects nothing, as Kubernetes doesn't manage endpoints for it.
+func selectsLabels(selector map[string]string, labels map[string]any) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// StreamContainerLogs follows the logs of one container of a pod until it exits
+func StreamContainerLogs(namespace, pod, container string) error {
+	cmd := exec.Command("kubectl", "logs", pod, "-n", namespace, "-c", container, "-f")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// ContainerExitCode returns the exit code of a container of a pod. done is false while
+// the container hasn't terminated; an error is returned if the pod failed before it did.
+func ContainerExitCode(namespace, pod, container string) (code int, done bool, err error) {
+	var p Pod
+	if err := KubectlJSON(&p, "get", "pod", pod, "-n", namespace, "-o", "json"); err != nil {
+		return 0, false, err
+	}
+	for _, s := range p.Status.ContainerStatuses {
+		if s.Name == container && s.State.Terminated != nil {
+			return s.State.Terminated.ExitCode, true, nil
+		}
+	}
+	if p.Status.Phase == "Failed" {
+		reason := p.Status.Reason
+		if p.Status.Message != "" {
+			reason += ": " + p.Status.Message
+		}
+		return 0, false, fmt.Errorf("pod %s failed: %s", pod, strings.TrimSpace(reason))
+	}
+	return 0, false, nil
+}
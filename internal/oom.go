@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// OOMOffender is a container that was OOMKilled or restarted repeatedly within the scan window
+type OOMOffender struct {
+	Namespace      string
+	Pod            string
+	Container      string
+	OOMKilled      bool
+	LastReason     string
+	LastTerminated time.Time
+	Restarts       int
+	MemoryRequest  float64
+	MemoryLimit    float64
+	PeakUsage      float64
+	SuggestedLimit float64
+}
+
+// Score ranks offenders: a recent OOMKill outweighs any number of plain restarts
+func (o OOMOffender) Score() int {
+	score := o.Restarts
+	if o.OOMKilled {
+		score += 1000
+	}
+	return score
+}
+
+const restartSpikeThreshold = 3
+
+// FindOOMOffenders scans application pods for containers whose last termination within
+// the window was an OOMKill, or that restarted at least restartSpikeThreshold times
+func FindOOMOffenders(since time.Duration) ([]OOMOffender, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	var offenders []OOMOffender
+	for _, pod := range list.Items {
+		if isSystemNamespace(pod.Metadata.Namespace) {
+			continue
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			term := cs.LastState.Terminated
+			if term == nil {
+				continue
+			}
+			finished, err := time.Parse(time.RFC3339, term.FinishedAt)
+			if err != nil || finished.Before(cutoff) {
+				continue
+			}
+
+			oomKilled := term.Reason == "OOMKilled"
+			if !oomKilled && cs.RestartCount < restartSpikeThreshold {
+				continue
+			}
+
+			o := OOMOffender{
+				Namespace:      pod.Metadata.Namespace,
+				Pod:            pod.Metadata.Name,
+				Container:      cs.Name,
+				OOMKilled:      oomKilled,
+				LastReason:     term.Reason,
+				LastTerminated: finished,
+				Restarts:       cs.RestartCount,
+			}
+			if c, ok := pod.Container(cs.Name); ok {
+				o.MemoryRequest, _ = ParseQuantity(c.Resources.Requests["memory"])
+				o.MemoryLimit, _ = ParseQuantity(c.Resources.Limits["memory"])
+			}
+			offenders = append(offenders, o)
+		}
+	}
+
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].Score() > offenders[j].Score()
+	})
+	return offenders, nil
+}
+
+// GetPeakMemoryUsage returns the peak non-evictable memory per container over the window
+// from Cloud Monitoring, keyed by "namespace/pod/container"
+func GetPeakMemoryUsage(projectID, clusterName string, since time.Duration) (map[string]float64, error) {
+	filter := `metric.type="kubernetes.io/container/memory/used_bytes" AND resource.type="k8s_container" AND metric.labels.memory_type="non-evictable"`
+	if clusterName != "" {
+		filter += fmt.Sprintf(` AND resource.labels.cluster_name="%s"`, clusterName)
+	}
+
+	series, err := QueryTimeSeries(projectID, MonitoringQuery{
+		Filter:  filter,
+		Window:  since,
+		Aligner: "ALIGN_MAX",
+		Reducer: "REDUCE_MAX",
+		GroupBy: []string{"resource.labels.namespace_name", "resource.labels.pod_name", "resource.labels.container_name"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	peaks := make(map[string]float64)
+	for _, s := range series {
+		key := fmt.Sprintf("%s/%s/%s", s.ResourceLabels["namespace_name"], s.ResourceLabels["pod_name"], s.ResourceLabels["container_name"])
+		peaks[key] = s.Max()
+	}
+	return peaks, nil
+}
+
+const memoryLimitStep = 64 << 20
+
+// SuggestMemoryLimit proposes a new memory limit: 25% headroom over observed peak usage,
+// and for OOMKilled containers at least 50% above the limit that was hit, rounded up to 64Mi.
+// It returns 0 when the current limit looks adequate.
+func SuggestMemoryLimit(o OOMOffender) float64 {
+	suggested := o.PeakUsage * 1.25
+	if o.OOMKilled && o.MemoryLimit > 0 {
+		suggested = max(suggested, o.MemoryLimit*1.5)
+	}
+	if suggested == 0 {
+		return 0
+	}
+	suggested = math.Ceil(suggested/memoryLimitStep) * memoryLimitStep
+	if o.MemoryLimit > 0 && suggested <= o.MemoryLimit {
+		return 0
+	}
+	return suggested
+}
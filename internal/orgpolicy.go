@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OrgPolicy is the effective organization policy of a constraint on a project
+type OrgPolicy struct {
+	// Constraint is the constraint's name without the constraints/ prefix, e.g.
+	// compute.vmExternalIpAccess
+	Constraint  string `json:"constraint"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	// Boolean is set for boolean constraints, which are either enforced or not
+	Boolean  bool `json:"boolean"`
+	Enforced bool `json:"enforced"`
+	// AllValues is ALLOW or DENY when a list constraint allows or denies every value
+	AllValues     string   `json:"all_values,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	DeniedValues  []string `json:"denied_values,omitempty"`
+	// SetOnProject is set when the policy is set on the project itself rather than
+	// inherited from its folders or organization
+	SetOnProject bool `json:"set_on_project"`
+}
+
+// Restricts reports whether the policy restricts anything
+func (p OrgPolicy) Restricts() bool {
+	if p.Boolean {
+		return p.Enforced
+	}
+	return p.AllValues == "DENY" || len(p.AllowedValues) > 0 || len(p.DeniedValues) > 0
+}
+
+// Summary describes what the policy allows, e.g. "enforced" or "allows: in:eu-locations"
+func (p OrgPolicy) Summary() string {
+	if p.Boolean {
+		if p.Enforced {
+			return "enforced"
+		}
+		return "not enforced"
+	}
+	var parts []string
+	switch p.AllValues {
+	case "DENY":
+		parts = append(parts, "denies all values")
+	case "ALLOW":
+		parts = append(parts, "allows all values")
+	}
+	if len(p.AllowedValues) > 0 {
+		parts = append(parts, "allows only: "+strings.Join(p.AllowedValues, ", "))
+	}
+	if len(p.DeniedValues) > 0 {
+		parts = append(parts, "denies: "+strings.Join(p.DeniedValues, ", "))
+	}
+	if len(parts) == 0 {
+		return "allows all values"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// orgConstraint is a constraint as returned by listAvailableOrgPolicyConstraints
+type orgConstraint struct {
+	Name              string    `json:"name"`
+	DisplayName       string    `json:"displayName"`
+	Description       string    `json:"description"`
+	ConstraintDefault string    `json:"constraintDefault"`
+	BooleanConstraint *struct{} `json:"booleanConstraint"`
+}
+
+// orgPolicy is a policy as returned by the Resource Manager API
+type orgPolicy struct {
+	Constraint string `json:"constraint"`
+	ListPolicy *struct {
+		AllowedValues []string `json:"allowedValues"`
+		DeniedValues  []string `json:"deniedValues"`
+		AllValues     string   `json:"allValues"`
+	} `json:"listPolicy"`
+	BooleanPolicy *struct {
+		Enforced bool `json:"enforced"`
+	} `json:"booleanPolicy"`
+}
+
+var orgPolicyClient = &http.Client{Timeout: 60 * time.Second}
+
+// orgPolicyRequest calls a Resource Manager v1 method on the project, e.g.
+// getEffectiveOrgPolicy, and decodes the response into out
+func orgPolicyRequest(token, projectID, method string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v1/projects/%s:%s", projectID, method)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := orgPolicyClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("resource manager API returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse resource manager response: %w", err)
+	}
+	return nil
+}
+
+// GetOrgPolicies returns the effective policy of every constraint available on the project,
+// inherited from its folders and organization or set on the project, sorted by constraint
+func GetOrgPolicies(projectID string) ([]OrgPolicy, error) {
+	token, err := AccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	var constraints []orgConstraint
+	pageToken := ""
+	for {
+		var page struct {
+			Constraints   []orgConstraint `json:"constraints"`
+			NextPageToken string          `json:"nextPageToken"`
+		}
+		if err := orgPolicyRequest(token, projectID, "listAvailableOrgPolicyConstraints", map[string]any{"pageToken": pageToken}, &page); err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, page.Constraints...)
+		if pageToken = page.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	setOnProject := make(map[string]bool)
+	pageToken = ""
+	for {
+		var page struct {
+			Policies      []orgPolicy `json:"policies"`
+			NextPageToken string      `json:"nextPageToken"`
+		}
+		if err := orgPolicyRequest(token, projectID, "listOrgPolicies", map[string]any{"pageToken": pageToken}, &page); err != nil {
+			return nil, err
+		}
+		for _, p := range page.Policies {
+			setOnProject[p.Constraint] = true
+		}
+		if pageToken = page.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	// There are a few hundred constraints, each needing its own request
+	policies := make([]OrgPolicy, len(constraints))
+	errs := make([]error, len(constraints))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				policies[i], errs[i] = effectiveOrgPolicy(token, projectID, constraints[i], setOnProject[constraints[i].Name])
+			}
+		}()
+	}
+	for i := range constraints {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(policies, func(i, k int) bool { return policies[i].Constraint < policies[k].Constraint })
+	return policies, nil
+}
+
+func effectiveOrgPolicy(token, projectID string, c orgConstraint, setOnProject bool) (OrgPolicy, error) {
+	p := OrgPolicy{
+		Constraint:   strings.TrimPrefix(c.Name, "constraints/"),
+		DisplayName:  c.DisplayName,
+		Description:  c.Description,
+		Boolean:      c.BooleanConstraint != nil,
+		SetOnProject: setOnProject,
+	}
+
+	var policy orgPolicy
+	if err := orgPolicyRequest(token, projectID, "getEffectiveOrgPolicy", map[string]string{"constraint": c.Name}, &policy); err != nil {
+		return p, fmt.Errorf("failed to get the policy of %s: %w", p.Constraint, err)
+	}
+	switch {
+	case policy.BooleanPolicy != nil:
+		p.Enforced = policy.BooleanPolicy.Enforced
+	case policy.ListPolicy != nil:
+		p.AllValues = policy.ListPolicy.AllValues
+		p.AllowedValues = policy.ListPolicy.AllowedValues
+		p.DeniedValues = policy.ListPolicy.DeniedValues
+	case !p.Boolean && c.ConstraintDefault == "DENY":
+		// Without a policy, list constraints fall back to their default
+		p.AllValues = "DENY"
+	}
+	return p, nil
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ClusterFootprint records the cloud resources a cluster created outside itself, so they
+// can be found again once the cluster is gone
+type ClusterFootprint struct {
+	// ForwardingRules are the names GKE gives the forwarding rules of LoadBalancer Services
+	ForwardingRules map[string]string // rule name -> "namespace/service"
+	// Disks are the persistent disks backing PersistentVolumes
+	Disks map[string]string // disk name -> PersistentVolume name
+	// RetainedDisks back PersistentVolumes with the Retain reclaim policy, which keeps the
+	// data beyond the cluster, so they are never offered for cleanup
+	RetainedDisks map[string]string // disk name -> PersistentVolume name
+}
+
+// OrphanedResource is a load balancer or disk left behind by a deleted cluster
+type OrphanedResource struct {
+	Kind     string // "forwarding-rule" or "disk"
+	Name     string
+	Location string // region or zone
+	Regional bool
+	Owner    string // the Service or PersistentVolume it belonged to
+}
+
+// AppNamespaceUsage returns the number of pods in each non-system namespace of a cluster context
+func AppNamespaceUsage(context string) (map[string]int, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "--context", context, "get", "pods", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]int)
+	for _, pod := range list.Items {
+		ns := pod.Metadata.Namespace
+		if isSystemNamespace(ns) || strings.HasPrefix(ns, "gke-") || strings.HasPrefix(ns, "gmp-") {
+			continue
+		}
+		usage[ns]++
+	}
+	return usage, nil
+}
+
+// CaptureClusterFootprint lists the LoadBalancer Services and disk-backed PersistentVolumes of a cluster context
+func CaptureClusterFootprint(context string) (*ClusterFootprint, error) {
+	footprint := &ClusterFootprint{
+		ForwardingRules: make(map[string]string),
+		Disks:           make(map[string]string),
+		RetainedDisks:   make(map[string]string),
+	}
+
+	var services ServiceList
+	if err := KubectlJSON(&services, "--context", context, "get", "services", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type != "LoadBalancer" || svc.Metadata.UID == "" {
+			continue
+		}
+		footprint.ForwardingRules[forwardingRuleName(svc.Metadata.UID)] = svc.Metadata.Namespace + "/" + svc.Metadata.Name
+	}
+
+	var volumes PersistentVolumeList
+	if err := KubectlJSON(&volumes, "--context", context, "get", "pv", "-o", "json"); err != nil {
+		return nil, err
+	}
+	for _, pv := range volumes.Items {
+		var disk string
+		switch {
+		case pv.Spec.CSI != nil && pv.Spec.CSI.Driver == "pd.csi.storage.gke.io":
+			// The handle is projects/<project>/zones/<zone>/disks/<name>
+			disk = path.Base(pv.Spec.CSI.VolumeHandle)
+		case pv.Spec.GCEPersistentDisk != nil:
+			disk = pv.Spec.GCEPersistentDisk.PDName
+		default:
+			continue
+		}
+		if pv.Spec.PersistentVolumeReclaimPolicy == "Retain" {
+			footprint.RetainedDisks[disk] = pv.Metadata.Name
+		} else {
+			footprint.Disks[disk] = pv.Metadata.Name
+		}
+	}
+	return footprint, nil
+}
+
+// forwardingRuleName mirrors the GKE service controller's naming: "a" followed by the
+// Service UID without dashes, truncated to 32 characters
+func forwardingRuleName(uid string) string {
+	name := "a" + strings.ReplaceAll(uid, "-", "")
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	return name
+}
+
+// FindOrphanedResources returns the footprint's forwarding rules that still exist and its
+// disks that still exist and are no longer attached to any instance. Disks of Retain
+// PersistentVolumes are left out.
+func FindOrphanedResources(projectID string, footprint *ClusterFootprint) ([]OrphanedResource, error) {
+	var orphans []OrphanedResource
+
+	if len(footprint.ForwardingRules) > 0 {
+		var rules []struct {
+			Name   string `json:"name"`
+			Region string `json:"region"`
+		}
+		if err := GcloudJSON(&rules, "compute", "forwarding-rules", "list", "--project", projectID); err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if owner, ok := footprint.ForwardingRules[rule.Name]; ok && rule.Region != "" {
+				orphans = append(orphans, OrphanedResource{Kind: "forwarding-rule", Name: rule.Name, Location: path.Base(rule.Region), Regional: true, Owner: owner})
+			}
+		}
+	}
+
+	if len(footprint.Disks) > 0 {
+		var disks []struct {
+			Name   string   `json:"name"`
+			Zone   string   `json:"zone"`
+			Region string   `json:"region"`
+			Users  []string `json:"users"`
+		}
+		if err := GcloudJSON(&disks, "compute", "disks", "list", "--project", projectID); err != nil {
+			return nil, err
+		}
+		for _, disk := range disks {
+			owner, ok := footprint.Disks[disk.Name]
+			if !ok || len(disk.Users) > 0 {
+				continue
+			}
+			orphan := OrphanedResource{Kind: "disk", Name: disk.Name, Location: path.Base(disk.Zone), Owner: owner}
+			if disk.Zone == "" {
+				orphan.Location, orphan.Regional = path.Base(disk.Region), true
+			}
+			orphans = append(orphans, orphan)
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Kind != orphans[j].Kind {
+			return orphans[i].Kind > orphans[j].Kind
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// DeleteOrphanedResource deletes a forwarding rule or disk found by FindOrphanedResources
+func DeleteOrphanedResource(projectID string, orphan OrphanedResource) error {
+	locationFlag := "--zone"
+	if orphan.Regional {
+		locationFlag = "--region"
+	}
+
+	var kind string
+	switch orphan.Kind {
+	case "forwarding-rule":
+		kind = "forwarding-rules"
+	case "disk":
+		kind = "disks"
+	default:
+		return fmt.Errorf("unknown resource kind: %s", orphan.Kind)
+	}
+
+	if _, err := runOutput("gcloud", "compute", kind, "delete", orphan.Name, locationFlag, orphan.Location, "--project", projectID, "--quiet"); err != nil {
+		return err
+	}
+	if orphan.Kind == "forwarding-rule" {
+		// The service controller names the target pool after the rule; it may already be gone
+		exec.Command("gcloud", "compute", "target-pools", "delete", orphan.Name, "--region", orphan.Location, "--project", projectID, "--quiet").Run()
+	}
+	return nil
+}
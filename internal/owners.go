@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// PodGroup is a set of pods managed by the same workload
+type PodGroup struct {
+	// Workload is "kind/name" (e.g. "deployment/web"), "@name" for pinned pods,
+	// or empty for unowned pods
+	Workload  string
+	Namespace string
+	Pods      []string
+}
+
+// Name returns the workload's name without its kind
+func (g PodGroup) Name() string {
+	_, name, _ := strings.Cut(g.Workload, "/")
+	return name
+}
+
+// GetPodOwners maps "namespace/pod" to the workload that manages it, as "kind/name".
+// Pods owned by a ReplicaSet are attributed to its Deployment.
+func GetPodOwners() (map[string]string, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string)
+	for _, pod := range list.Items {
+		if owner := podOwner(pod); owner != "" {
+			owners[pod.Metadata.Namespace+"/"+pod.Metadata.Name] = owner
+		}
+	}
+	return owners, nil
+}
+
+// podOwner returns the workload that manages a pod as "kind/name", or "" if it has none
+func podOwner(pod Pod) string {
+	for _, ref := range pod.Metadata.OwnerReferences {
+		if !ref.Controller {
+			continue
+		}
+		kind, name := strings.ToLower(ref.Kind), ref.Name
+		// ReplicaSets created by a Deployment are named <deployment>-<pod-template-hash>
+		if hash := pod.Metadata.Labels["pod-template-hash"]; kind == "replicaset" && strings.HasSuffix(name, "-"+hash) {
+			kind, name = "deployment", strings.TrimSuffix(name, "-"+hash)
+		}
+		return kind + "/" + name
+	}
+	return ""
+}
+
+// GroupPodsByOwner groups pods by their owning workload. Workload groups are sorted by
+// namespace and name; pods without an owner are collected in a final group.
+func GroupPodsByOwner(pods []string, owners map[string]string) []PodGroup {
+	index := make(map[string]int)
+	var groups []PodGroup
+	var unowned []string
+
+	for _, pod := range pods {
+		workload, ok := owners[pod]
+		if !ok {
+			unowned = append(unowned, pod)
+			continue
+		}
+		namespace, _, _ := strings.Cut(pod, "/")
+		key := namespace + "/" + workload
+		if i, ok := index[key]; ok {
+			groups[i].Pods = append(groups[i].Pods, pod)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, PodGroup{Workload: workload, Namespace: namespace, Pods: []string{pod}})
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Namespace != groups[j].Namespace {
+			return groups[i].Namespace < groups[j].Namespace
+		}
+		return groups[i].Workload < groups[j].Workload
+	})
+	if len(unowned) > 0 {
+		groups = append(groups, PodGroup{Pods: unowned})
+	}
+	return groups
+}
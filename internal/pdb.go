@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GetPodDisruptionBudgets returns PDBs in application namespaces
+func GetPodDisruptionBudgets() ([]PodDisruptionBudget, error) {
+	var list PodDisruptionBudgetList
+	if err := KubectlJSON(&list, "get", "poddisruptionbudgets", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var pdbs []PodDisruptionBudget
+	for _, p := range list.Items {
+		if !isSystemNamespace(p.Metadata.Namespace) {
+			pdbs = append(pdbs, p)
+		}
+	}
+
+	sort.Slice(pdbs, func(i, j int) bool {
+		if pdbs[i].Metadata.Namespace != pdbs[j].Metadata.Namespace {
+			return pdbs[i].Metadata.Namespace < pdbs[j].Metadata.Namespace
+		}
+		return pdbs[i].Metadata.Name < pdbs[j].Metadata.Name
+	})
+	return pdbs, nil
+}
+
+// Budget renders the PDB's configured budget, e.g. "minAvailable=2" or "maxUnavailable=25%"
+func (p PodDisruptionBudget) Budget() string {
+	if p.Spec.MinAvailable != nil {
+		return fmt.Sprintf("minAvailable=%v", p.Spec.MinAvailable)
+	}
+	if p.Spec.MaxUnavailable != nil {
+		return fmt.Sprintf("maxUnavailable=%v", p.Spec.MaxUnavailable)
+	}
+	return "-"
+}
+
+// MatchingPDBs returns the PDBs in a namespace whose selector matches the given pod labels
+func MatchingPDBs(namespace string, podLabels map[string]string) ([]PodDisruptionBudget, error) {
+	var list PodDisruptionBudgetList
+	if err := KubectlJSON(&list, "get", "poddisruptionbudgets", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var matched []PodDisruptionBudget
+	for _, p := range list.Items {
+		if p.Spec.Selector.Matches(podLabels) {
+			matched = append(matched, p)
+		}
+	}
+	return matched, nil
+}
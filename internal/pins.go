@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Pin is a favorite pod target, referenced on the command line as @Name
+type Pin struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// Matches reports whether a "namespace/pod" matches the pin's pattern. Patterns containing
+// glob characters are matched against the pod name and namespace/name; others as substrings.
+func (p Pin) Matches(pod string) bool {
+	_, name, _ := strings.Cut(pod, "/")
+	if strings.ContainsAny(p.Pattern, "*?[") {
+		if ok, _ := filepath.Match(p.Pattern, name); ok {
+			return true
+		}
+		ok, _ := filepath.Match(p.Pattern, pod)
+		return ok
+	}
+	if strings.Contains(p.Pattern, "/") {
+		return strings.Contains(pod, p.Pattern)
+	}
+	return strings.Contains(name, p.Pattern)
+}
+
+// pinFile maps project IDs to their pins
+type pinFile map[string][]Pin
+
+func pinsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pins.json"), nil
+}
+
+func loadPinFile() (pinFile, error) {
+	path, err := pinsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return pinFile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pins := pinFile{}
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return pins, nil
+}
+
+func savePinFile(pins pinFile) error {
+	path, err := pinsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(pins, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// LoadPins returns the pins saved for a project
+func LoadPins(projectID string) ([]Pin, error) {
+	pins, err := loadPinFile()
+	if err != nil {
+		return nil, err
+	}
+	return pins[projectID], nil
+}
+
+// FindPin returns the project's pin with the given name
+func FindPin(projectID, name string) (Pin, bool, error) {
+	pins, err := LoadPins(projectID)
+	if err != nil {
+		return Pin{}, false, err
+	}
+	for _, p := range pins {
+		if p.Name == name {
+			return p, true, nil
+		}
+	}
+	return Pin{}, false, nil
+}
+
+// SavePin adds a pin to a project, replacing any existing pin with the same name
+func SavePin(projectID string, pin Pin) error {
+	pins, err := loadPinFile()
+	if err != nil {
+		return err
+	}
+	updated := []Pin{}
+	for _, p := range pins[projectID] {
+		if p.Name != pin.Name {
+			updated = append(updated, p)
+		}
+	}
+	pins[projectID] = append(updated, pin)
+	return savePinFile(pins)
+}
+
+// RemovePin deletes a project's pin by name, reporting whether it existed
+func RemovePin(projectID, name string) (bool, error) {
+	pins, err := loadPinFile()
+	if err != nil {
+		return false, err
+	}
+	var kept []Pin
+	for _, p := range pins[projectID] {
+		if p.Name != name {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(pins[projectID]) {
+		return false, nil
+	}
+	if len(kept) == 0 {
+		delete(pins, projectID)
+	} else {
+		pins[projectID] = kept
+	}
+	return true, savePinFile(pins)
+}
+
+// PinnedPods returns the pods matching a pin
+func PinnedPods(pods []string, pin Pin) []string {
+	var matched []string
+	for _, pod := range pods {
+		if pin.Matches(pod) {
+			matched = append(matched, pod)
+		}
+	}
+	return matched
+}
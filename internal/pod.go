@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -20,9 +21,12 @@ type PodInfo struct {
 	Node      string
 }
 
-// FindApplicationPods returns all running pods from non-system namespaces
+// FindApplicationPods returns all running pods from non-system namespaces, or from the
+// namespace set with SetNamespaceScope
 func FindApplicationPods() ([]string, error) {
-	cmd := exec.Command("kubectl", "get", "pods", "--all-namespaces", "-o", "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,STATUS:.status.phase", "--no-headers")
+	args := append([]string{"get", "pods"}, podListScope()...)
+	args = append(args, "-o", "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,STATUS:.status.phase", "--no-headers")
+	cmd := exec.Command("kubectl", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -59,7 +63,8 @@ func FindApplicationPods() ([]string, error) {
 // GetDetailedPodInfo returns detailed information about application pods
 func GetDetailedPodInfo() ([]PodInfo, error) {
 	// Use standard kubectl get pods which handles multi-container formatting better
-	cmd := exec.Command("kubectl", "get", "pods", "--all-namespaces", "--no-headers")
+	args := append([]string{"get", "pods"}, podListScope()...)
+	cmd := exec.Command("kubectl", append(args, "--no-headers")...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -75,6 +80,10 @@ func GetDetailedPodInfo() ([]PodInfo, error) {
 		
 		// Parse standard kubectl output: NAMESPACE NAME READY STATUS RESTARTS AGE
 		fields := strings.Fields(line)
+		if namespaceScope != "" {
+			// kubectl omits the NAMESPACE column for a single namespace
+			fields = append([]string{namespaceScope}, fields...)
+		}
 		if len(fields) < 6 {
 			continue
 		}
@@ -122,47 +131,279 @@ func getNodeForPod(namespace, podName string) string {
 }
 
 // SelectPod prompts user to select a pod from the list
-func SelectPod(pods []string) (string, error) {
+func SelectPod(pods []string, pins ...Pin) (string, error) {
 	if len(pods) == 0 {
 		return "", fmt.Errorf("no pods available")
 	}
 
+	// Group by owning workload when ownership can be determined, otherwise list pods flat
+	owners, err := GetPodOwners()
+	if err != nil {
+		owners = nil
+	}
+
+	// Pinned pods are listed first, and only once
+	pinned := make(map[string]bool)
+	var pinGroups []PodGroup
+	for _, pin := range pins {
+		var matched []string
+		for _, pod := range PinnedPods(pods, pin) {
+			if !pinned[pod] {
+				pinned[pod] = true
+				matched = append(matched, pod)
+			}
+		}
+		if len(matched) > 0 {
+			pinGroups = append(pinGroups, PodGroup{Workload: "@" + pin.Name, Pods: matched})
+		}
+	}
+	var rest []string
+	for _, pod := range pods {
+		if !pinned[pod] {
+			rest = append(rest, pod)
+		}
+	}
+	groups := GroupPodsByOwner(rest, owners)
+
 	fmt.Printf("📋 Found %d pod(s):\n", len(pods))
 	fmt.Println()
-	
-	for i, pod := range pods {
-		fmt.Printf("%d. %s\n", i+1, pod)
+
+	// Pods are numbered in display order, which differs from the input order when grouped
+	var ordered []string
+	for _, g := range pinGroups {
+		fmt.Printf("⭐ %s\n", g.Workload)
+		for _, pod := range g.Pods {
+			ordered = append(ordered, pod)
+			fmt.Printf("   %d. %s\n", len(ordered), pod)
+		}
 	}
-	
+
+	var workloads []PodGroup
+	for _, g := range groups {
+		indent := ""
+		if g.Workload != "" {
+			workloads = append(workloads, g)
+			fmt.Printf("📦 %s/%s\n", g.Namespace, g.Workload)
+			fmt.Printf("   w%d. any pod of %s (%d running)\n", len(workloads), g.Workload, len(g.Pods))
+			indent = "   "
+		} else if len(workloads) > 0 || len(pinGroups) > 0 {
+			fmt.Println("📦 Unmanaged pods")
+			indent = "   "
+		}
+		for _, pod := range g.Pods {
+			ordered = append(ordered, pod)
+			fmt.Printf("%s%d. %s\n", indent, len(ordered), pod)
+		}
+	}
+
 	fmt.Println()
-	fmt.Print("Select pod (number, or 'q' to quit): ")
-	
+	workloadNames := make([]string, len(workloads))
+	for i, g := range workloads {
+		workloadNames[i] = g.Namespace + "/" + g.Workload
+	}
+	EmitEvent(EventPrompt, map[string]any{"prompt": "pod", "choices": ordered, "workloads": workloadNames})
+	if len(workloads) > 0 || len(pinGroups) > 0 {
+		fmt.Print("Select pod (number, w<number>, workload name or @pin for any pod of it, or 'q' to quit): ")
+	} else {
+		fmt.Print("Select pod (number, or 'q' to quit): ")
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
 	if !scanner.Scan() {
 		return "", fmt.Errorf("failed to read input")
 	}
-	
+
 	input := strings.TrimSpace(scanner.Text())
-	
+
 	// Check for quit command
 	if input == "q" {
 		return "", fmt.Errorf("cancelled by user")
 	}
-	
-	num, err := strconv.Atoi(input)
-	if err != nil || num < 1 || num > len(pods) {
+
+	if num, err := strconv.Atoi(input); err == nil {
+		if num < 1 || num > len(ordered) {
+			return "", fmt.Errorf("invalid selection: %s", input)
+		}
+		return ordered[num-1], nil
+	}
+
+	for _, g := range pinGroups {
+		if input == g.Workload {
+			return anyPodOf(g), nil
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(input, "w"); ok {
+		if num, err := strconv.Atoi(rest); err == nil {
+			if num < 1 || num > len(workloads) {
+				return "", fmt.Errorf("invalid selection: %s", input)
+			}
+			return anyPodOf(workloads[num-1]), nil
+		}
+	}
+
+	var matched []PodGroup
+	for _, g := range workloads {
+		if input == g.Name() || input == g.Workload || input == g.Namespace+"/"+g.Workload {
+			matched = append(matched, g)
+		}
+	}
+	switch len(matched) {
+	case 0:
 		return "", fmt.Errorf("invalid selection: %s", input)
+	case 1:
+		return anyPodOf(matched[0]), nil
+	default:
+		return "", fmt.Errorf("workload %s exists in several namespaces, use namespace/kind/name", input)
 	}
-	
-	return pods[num-1], nil
 }
 
+// anyPodOf picks a pod from a workload group
+func anyPodOf(g PodGroup) string {
+	pod := g.Pods[0]
+	fmt.Printf("🎯 Using %s from %s\n", pod, g.Workload)
+	return pod
+}
+
+// systemNamespaces are the Kubernetes and GKE namespaces that don't hold application pods
+var systemNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", "gke-system"}
+
 func isSystemNamespace(namespace string) bool {
-	systemNamespaces := []string{"kube-system", "kube-public", "kube-node-lease", "gke-system"}
 	for _, sysNs := range systemNamespaces {
 		if namespace == sysNs {
 			return true
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// podSuffixChars are the characters Kubernetes uses for generated name suffixes
+const podSuffixChars = "bcdfghjklmnpqrstvwxz2456789"
+
+var (
+	replicaSetPodName  = regexp.MustCompile(`^(.+)-([` + podSuffixChars + `]{6,10})-[` + podSuffixChars + `]{5}$`)
+	statefulSetPodName = regexp.MustCompile(`^(.+)-[0-9]+$`)
+	generatedPodName   = regexp.MustCompile(`^(.+)-[` + podSuffixChars + `]{5}$`)
+)
+
+// nameOwner identifies the workload that created a pod, derived from the pod's name
+type nameOwner struct {
+	// Kind is ReplicaSet, StatefulSet or Generated (DaemonSets, Jobs)
+	Kind     string
+	Workload string
+	// ReplicaSet is <deployment>-<pod-template-hash> for Deployment pods
+	ReplicaSet string
+}
+
+// podNameOwner derives a pod's owner from its generated name: Deployment pods are named
+// <deployment>-<pod-template-hash>-<suffix>, StatefulSet pods <statefulset>-<ordinal> and
+// DaemonSet and Job pods <name>-<suffix>. The namespace, if given, is part of Workload.
+func podNameOwner(pod string) (nameOwner, bool) {
+	if m := replicaSetPodName.FindStringSubmatch(pod); m != nil {
+		return nameOwner{Kind: "ReplicaSet", Workload: m[1], ReplicaSet: m[1] + "-" + m[2]}, true
+	}
+	if m := statefulSetPodName.FindStringSubmatch(pod); m != nil {
+		return nameOwner{Kind: "StatefulSet", Workload: m[1]}, true
+	}
+	if m := generatedPodName.FindStringSubmatch(pod); m != nil {
+		return nameOwner{Kind: "Generated", Workload: m[1]}, true
+	}
+	return nameOwner{}, false
+}
+
+// ResolvePod finds the pod to use for a previously recorded target. If the exact pod
+// no longer exists, a running pod from the same workload is returned, preferring one from
+// the same ReplicaSet. The workload is taken from the pod name without its generated
+// suffix, so web-7d9f8c6b5-x2v4k is replaced by another web pod but never by a
+// web-worker one. A target without a namespace matches pods in any namespace.
+func ResolvePod(pods []string, target string) (string, bool) {
+	for _, pod := range pods {
+		if pod == target {
+			return pod, true
+		}
+	}
+
+	owner, ok := podNameOwner(target)
+	if !ok {
+		return "", false
+	}
+	withNamespace := strings.Contains(target, "/")
+	sameWorkload := ""
+	for _, pod := range pods {
+		name := pod
+		if !withNamespace {
+			_, name, _ = strings.Cut(pod, "/")
+		}
+		o, ok := podNameOwner(name)
+		if !ok || o.Kind != owner.Kind || o.Workload != owner.Workload {
+			continue
+		}
+		if owner.ReplicaSet != "" && o.ReplicaSet == owner.ReplicaSet {
+			return pod, true
+		}
+		if sameWorkload == "" {
+			sameWorkload = pod
+		}
+	}
+	return sameWorkload, sameWorkload != ""
+}
+
+// GetPod fetches a single pod given as "namespace/pod"
+func GetPod(pod string) (*Pod, error) {
+	namespace, name, ok := strings.Cut(pod, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid pod format: %s", pod)
+	}
+	var p Pod
+	if err := KubectlJSON(&p, "get", "pod", name, "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ControllerOf returns the owner reference of the controller managing a pod, such as its
+// ReplicaSet or StatefulSet
+func ControllerOf(p Pod) (OwnerReference, bool) {
+	for _, ref := range p.Metadata.OwnerReferences {
+		if ref.Controller {
+			return ref, true
+		}
+	}
+	return OwnerReference{}, false
+}
+
+// PodsOfController returns the pods in a namespace managed by a controller
+func PodsOfController(namespace string, owner OwnerReference) ([]Pod, error) {
+	var list PodList
+	if err := KubectlJSON(&list, "get", "pods", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	var pods []Pod
+	for _, p := range list.Items {
+		if ref, ok := ControllerOf(p); ok && ref.Kind == owner.Kind && ref.Name == owner.Name {
+			pods = append(pods, p)
+		}
+	}
+	return pods, nil
+}
+
+// ReplacementPod finds the pod the controller of a deleted pod created in its place: a
+// pod of the same controller whose UID isn't in known (the UIDs of its pods before the
+// deletion) and that isn't being deleted. StatefulSet replacements reuse the name, so
+// pods are told apart by UID. It returns nil while no replacement exists yet.
+func ReplacementPod(old Pod, known map[string]bool) (*Pod, error) {
+	owner, ok := ControllerOf(old)
+	if !ok {
+		return nil, fmt.Errorf("pod %s has no controller", old.Metadata.Name)
+	}
+	pods, err := PodsOfController(old.Metadata.Namespace, owner)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range pods {
+		if !known[p.Metadata.UID] && p.Metadata.DeletionTimestamp == "" {
+			return &pods[i], nil
+		}
+	}
+	return nil, nil
+}
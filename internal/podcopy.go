@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoTar is returned by CopyFromPod and CopyToPod when the container has no tar
+// binary, which kubectl cp relies on
+var ErrNoTar = errors.New("tar is not installed in the container")
+
+// CopyFromPod copies a file or directory out of a pod with kubectl cp
+func CopyFromPod(namespace, pod, container, remote, local string) error {
+	return runCopy(exec.Command("kubectl", copyArgs(namespace+"/"+pod+":"+remote, local, container)...))
+}
+
+// CopyToPod copies a local file or directory into a pod with kubectl cp
+func CopyToPod(namespace, pod, container, local, remote string) error {
+	return runCopy(exec.Command("kubectl", copyArgs(local, namespace+"/"+pod+":"+remote, container)...))
+}
+
+// StreamFromPod writes a single file from a pod to w with cat, for containers without tar
+func StreamFromPod(namespace, pod, container, remote string, w io.Writer) error {
+	cmd := exec.Command("kubectl", podExecArgs(namespace, pod, container, false, "cat", remote)...)
+	cmd.Stdout = w
+	return runCopy(cmd)
+}
+
+// StreamToPod writes r to a single file in a pod with cat, for containers without tar
+func StreamToPod(namespace, pod, container, remote string, r io.Reader) error {
+	cmd := exec.Command("kubectl", podExecArgs(namespace, pod, container, true, "sh", "-c", `cat > "$1"`, "sh", remote)...)
+	cmd.Stdin = r
+	return runCopy(cmd)
+}
+
+func copyArgs(src, dst, container string) []string {
+	args := []string{"cp", src, dst}
+	if container != "" {
+		args = append(args, "-c", container)
+	}
+	return args
+}
+
+func podExecArgs(namespace, pod, container string, stdin bool, command ...string) []string {
+	args := []string{"exec", pod, "-n", namespace}
+	if container != "" {
+		args = append(args, "-c", container)
+	}
+	if stdin {
+		args = append(args, "-i")
+	}
+	return append(append(args, "--"), command...)
+}
+
+// runCopy runs a copy command, turning its stderr into the error. kubectl cp warns on
+// stderr even when it succeeds (e.g. "Removing leading '/'"), so it is only shown on
+// failure.
+func runCopy(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if cmd.Args[1] == "cp" && strings.Contains(msg, "executable file not found") {
+			return ErrNoTar
+		}
+		if msg == "" {
+			return err
+		}
+		return errors.New(msg)
+	}
+	return nil
+}
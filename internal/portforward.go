@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PortForward is a running `kubectl port-forward` to a pod
+type PortForward struct {
+	LocalPort int
+	cmd       *exec.Cmd
+}
+
+var forwardingPattern = regexp.MustCompile(`Forwarding from 127\.0\.0\.1:(\d+)`)
+
+// StartPortForward forwards a local port to remotePort on a pod ("namespace/pod"). When
+// localPort is 0 a free port is chosen. It returns once the tunnel is ready.
+func StartPortForward(pod string, localPort, remotePort int) (*PortForward, error) {
+	namespace, podName, ok := strings.Cut(pod, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid pod format: %s", pod)
+	}
+
+	mapping := fmt.Sprintf(":%d", remotePort)
+	if localPort != 0 {
+		mapping = fmt.Sprintf("%d:%d", localPort, remotePort)
+	}
+
+	cmd := exec.Command("kubectl", "port-forward", "-n", namespace, "pod/"+podName, mapping)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start port-forward: %w", err)
+	}
+
+	ready := make(chan int, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if m := forwardingPattern.FindStringSubmatch(scanner.Text()); m != nil {
+				port, _ := strconv.Atoi(m[1])
+				ready <- port
+				break
+			}
+		}
+		close(ready)
+		// Keep draining so kubectl never blocks on a full pipe
+		for scanner.Scan() {
+		}
+	}()
+
+	select {
+	case port, ok := <-ready:
+		if !ok {
+			cmd.Wait()
+			return nil, fmt.Errorf("port-forward failed: %s", strings.TrimSpace(stderr.String()))
+		}
+		return &PortForward{LocalPort: port, cmd: cmd}, nil
+	case <-time.After(30 * time.Second):
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, fmt.Errorf("timed out waiting for port-forward to %s:%d", pod, remotePort)
+	}
+}
+
+// Close stops the port-forward
+func (p *PortForward) Close() {
+	if p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+		p.cmd.Wait()
+	}
+}
+
+// Wait blocks until the port-forward exits
+func (p *PortForward) Wait() error {
+	return p.cmd.Wait()
+}
+
+// ForwardPort is a TCP port of a forward target
+type ForwardPort struct {
+	Name string
+	Port int
+}
+
+// ForwardTarget is a Service or pod that can be port-forwarded to
+type ForwardTarget struct {
+	Kind      string // "svc" or "pod"
+	Namespace string
+	Name      string
+	Ports     []ForwardPort
+}
+
+// Resource is the target as kubectl names it, e.g. "svc/web"
+func (t ForwardTarget) Resource() string {
+	return t.Kind + "/" + t.Name
+}
+
+// GetForwardTargets returns the Services and running pods in application namespaces, or
+// in the namespace set with SetNamespaceScope, with their TCP ports. Pods are limited to
+// the pod selector.
+func GetForwardTargets() ([]ForwardTarget, error) {
+	services, err := GetApplicationServices()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := GetApplicationPods()
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []ForwardTarget
+	for _, svc := range services {
+		t := ForwardTarget{Kind: "svc", Namespace: svc.Metadata.Namespace, Name: svc.Metadata.Name}
+		for _, p := range svc.Spec.Ports {
+			if p.Protocol == "" || p.Protocol == "TCP" {
+				t.Ports = append(t.Ports, ForwardPort{Name: p.Name, Port: p.Port})
+			}
+		}
+		targets = append(targets, t)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != "Running" {
+			continue
+		}
+		t := ForwardTarget{Kind: "pod", Namespace: pod.Metadata.Namespace, Name: pod.Metadata.Name}
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Protocol == "" || p.Protocol == "TCP" {
+					t.Ports = append(t.Ports, ForwardPort{Name: p.Name, Port: p.ContainerPort})
+				}
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// SelectForwardTarget prompts the user to pick one of the targets
+func SelectForwardTarget(targets []ForwardTarget) (*ForwardTarget, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no services or pods available")
+	}
+
+	fmt.Printf("📋 Found %d service(s) and pod(s):\n", len(targets))
+	fmt.Println()
+
+	for i, t := range targets {
+		var ports []string
+		for _, p := range t.Ports {
+			ports = append(ports, strconv.Itoa(p.Port))
+		}
+		portList := "no ports"
+		if len(ports) > 0 {
+			portList = "port " + strings.Join(ports, ", ")
+		}
+		fmt.Printf("%d. %-4s %s/%s (%s)\n", i+1, t.Kind, t.Namespace, t.Name, portList)
+	}
+
+	fmt.Println()
+	fmt.Print("Select target (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(targets) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &targets[num-1], nil
+}
+
+// LocalPortFor picks the local port to forward a remote port to: the same port, or 8000
+// plus a privileged port (80 → 8080, 443 → 8443), when it is free, else any free port.
+// The result is never one of taken.
+func LocalPortFor(remotePort int, taken map[int]bool) (int, error) {
+	preferred := remotePort
+	if preferred < 1024 {
+		preferred += 8000
+	}
+	if !taken[preferred] && LocalPortFree(preferred) {
+		return preferred, nil
+	}
+	for {
+		port, err := freeLocalPort()
+		if err != nil {
+			return 0, err
+		}
+		if !taken[port] {
+			return port, nil
+		}
+	}
+}
+
+// LocalPortFree reports whether a local port can be listened on
+func LocalPortFree(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// RunPortForward runs `kubectl port-forward` to a target in the foreground until it is
+// interrupted. mappings are "local:remote" port pairs.
+func RunPortForward(t ForwardTarget, mappings []string) error {
+	args := append([]string{"port-forward", "-n", t.Namespace, t.Resource()}, mappings...)
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PreflightTTL is how long successful auth/project/cluster checks are reused
+const PreflightTTL = 5 * time.Minute
+
+// PreflightState is the outcome of the checks most commands run before doing any work
+type PreflightState struct {
+	Account string `json:"account"`
+	Project string `json:"project"`
+	// Context and ClusterReachable are only set when the cluster was checked
+	Context          string    `json:"context,omitempty"`
+	ClusterReachable bool      `json:"cluster_reachable,omitempty"`
+	ClusterChecked   bool      `json:"cluster_checked,omitempty"`
+	CheckedAt        time.Time `json:"checked_at"`
+	Fingerprint      string    `json:"fingerprint"`
+	Cached           bool      `json:"-"`
+}
+
+func preflightCachePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "preflight.json"), nil
+}
+
+// preflightFingerprint summarises the modification times of the gcloud configuration and
+// kubeconfig, so a cached state is discarded as soon as either is changed
+func preflightFingerprint() string {
+	gcloudDir := os.Getenv("CLOUDSDK_CONFIG")
+	if gcloudDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gcloudDir = filepath.Join(home, ".config", "gcloud")
+		}
+	}
+
+	paths := []string{filepath.Join(gcloudDir, "active_config")}
+	if configs, err := filepath.Glob(filepath.Join(gcloudDir, "configurations", "*")); err == nil {
+		paths = append(paths, configs...)
+	}
+	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
+		paths = append(paths, filepath.SplitList(kubeconfig)...)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".kube", "config"))
+	}
+
+	var b strings.Builder
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			fmt.Fprintf(&b, "%s:%d;", p, info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+}
+
+// LoadPreflightCache returns the cached state if it is still fresh
+func LoadPreflightCache() (*PreflightState, bool) {
+	path, err := preflightCachePath()
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var state PreflightState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, false
+	}
+	if time.Since(state.CheckedAt) > PreflightTTL || state.Fingerprint != preflightFingerprint() {
+		return nil, false
+	}
+	state.Cached = true
+	return &state, true
+}
+
+// SavePreflightCache stores a successful state for reuse by later invocations
+func SavePreflightCache(state PreflightState) error {
+	path, err := preflightCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// InvalidatePreflightCache forgets the cached state, e.g. after switching project or cluster
+func InvalidatePreflightCache() {
+	if path, err := preflightCachePath(); err == nil {
+		os.Remove(path)
+	}
+}
+
+// CheckPreflight runs the auth, project and (optionally) cluster checks in parallel
+func CheckPreflight(withCluster bool) PreflightState {
+	state := PreflightState{CheckedAt: time.Now(), Fingerprint: preflightFingerprint(), ClusterChecked: withCluster}
+
+	var wg sync.WaitGroup
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	run(func() {
+		output, _ := exec.Command("gcloud", "auth", "list", "--filter=status:ACTIVE", "--format=value(account)").Output()
+		state.Account = strings.TrimSpace(string(output))
+	})
+	run(func() {
+		output, _ := exec.Command("gcloud", "config", "get-value", "project").Output()
+		state.Project = strings.TrimSpace(string(output))
+	})
+	if withCluster {
+		run(func() {
+			state.Context, _ = GetCurrentCluster()
+		})
+		run(func() {
+			state.ClusterReachable = IsKubectlConfigured()
+		})
+	}
+
+	wg.Wait()
+	return state
+}
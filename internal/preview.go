@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PreviewLabel marks namespaces created by `env preview create`, with the branch's slug as value
+const PreviewLabel = "gcpeasy.io/preview"
+
+// DefaultPreviewPrefix is prepended to the branch's slug to name preview namespaces
+const DefaultPreviewPrefix = "preview-"
+
+// PreviewConfig describes how an environment creates per-branch preview environments
+type PreviewConfig struct {
+	// Template is a manifest file applied to each preview namespace, with {{NAMESPACE}},
+	// {{BRANCH}} and {{IMAGE_TAG}} substituted. Relative paths are resolved against the
+	// config directory.
+	Template string `yaml:"template"`
+	// NamespacePrefix names preview namespaces <prefix><branch> (default "preview-")
+	NamespacePrefix string `yaml:"namespace_prefix"`
+	// URL is printed for each preview with the same placeholders, e.g.
+	// https://{{BRANCH}}.preview.example.com
+	URL string `yaml:"url"`
+}
+
+// Preview is a branch's preview environment
+type Preview struct {
+	Branch    string
+	Namespace string
+	// Slug is the branch name as it is used in namespaces, URLs and image tags
+	Slug     string
+	ImageTag string
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NewPreview names the preview environment of a branch. The image tag defaults to the
+// branch's slug, as CI commonly tags branch builds.
+func NewPreview(cfg PreviewConfig, branch, imageTag string) (Preview, error) {
+	prefix := cfg.NamespacePrefix
+	if prefix == "" {
+		prefix = DefaultPreviewPrefix
+	}
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(branch), "-"), "-")
+	// Namespaces are DNS labels of at most 63 characters
+	if max := 63 - len(prefix); len(slug) > max {
+		slug = strings.TrimRight(slug[:max], "-")
+	}
+	if slug == "" {
+		return Preview{}, fmt.Errorf("invalid branch name %q", branch)
+	}
+	if imageTag == "" {
+		imageTag = slug
+	}
+	return Preview{Branch: branch, Namespace: prefix + slug, Slug: slug, ImageTag: imageTag}, nil
+}
+
+// Expand substitutes the preview's placeholders in a template
+func (p Preview) Expand(template string) string {
+	return strings.NewReplacer(
+		"{{NAMESPACE}}", p.Namespace,
+		"{{BRANCH}}", p.Slug,
+		"{{IMAGE_TAG}}", p.ImageTag,
+	).Replace(template)
+}
+
+// LoadPreviewTemplate reads the manifest template of an environment's previews
+func LoadPreviewTemplate(cfg PreviewConfig) (string, error) {
+	if cfg.Template == "" {
+		return "", fmt.Errorf("no preview template configured")
+	}
+	path := cfg.Template
+	if !filepath.IsAbs(path) {
+		dir, err := ConfigDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read preview template: %w", err)
+	}
+	return string(data), nil
+}
+
+// GetPreviewNamespaces returns the namespaces created for previews, by branch slug
+func GetPreviewNamespaces() (map[string]ObjectMeta, error) {
+	var list struct {
+		Items []struct {
+			Metadata ObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "namespaces", "-l", PreviewLabel, "-o", "json"); err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]ObjectMeta, len(list.Items))
+	for _, item := range list.Items {
+		namespaces[item.Metadata.Labels[PreviewLabel]] = item.Metadata
+	}
+	return namespaces, nil
+}
+
+// CreatePreview creates the preview's namespace, labeled as a preview, and applies the
+// expanded manifest template to it
+func CreatePreview(p Preview, template string) error {
+	namespace := fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: %s
+  labels:
+    %s: %s
+    app.kubernetes.io/managed-by: gcpeasy
+`, p.Namespace, PreviewLabel, p.Slug)
+	if err := kubectlApply(namespace); err != nil {
+		return fmt.Errorf("failed to create namespace %s: %w", p.Namespace, err)
+	}
+	if err := kubectlApply(p.Expand(template), "-n", p.Namespace); err != nil {
+		return fmt.Errorf("failed to apply the preview template: %w", err)
+	}
+	return nil
+}
+
+func kubectlApply(manifests string, args ...string) error {
+	cmd := exec.Command("kubectl", append([]string{"apply", "-f", "-"}, args...)...)
+	cmd.Stdin = strings.NewReader(manifests)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// WaitForPreview waits for every deployment in the preview's namespace to become available
+func WaitForPreview(p Preview, timeout string) error {
+	cmd := exec.Command("kubectl", "wait", "deployments", "--all", "-n", p.Namespace,
+		"--for=condition=Available", "--timeout="+timeout)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// PreviewURLs returns the URLs a preview namespace serves: its Ingress hosts and the
+// external addresses of its LoadBalancer services
+func PreviewURLs(namespace string) ([]string, error) {
+	var ingresses struct {
+		Items []struct {
+			Spec struct {
+				TLS []struct {
+					Hosts []string `json:"hosts"`
+				} `json:"tls"`
+				Rules []struct {
+					Host string `json:"host"`
+				} `json:"rules"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&ingresses, "get", "ingresses", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var urls []string
+	for _, ing := range ingresses.Items {
+		tls := make(map[string]bool)
+		for _, t := range ing.Spec.TLS {
+			for _, host := range t.Hosts {
+				tls[host] = true
+			}
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host == "" || seen[rule.Host] {
+				continue
+			}
+			seen[rule.Host] = true
+			scheme := "http://"
+			if tls[rule.Host] {
+				scheme = "https://"
+			}
+			urls = append(urls, scheme+rule.Host)
+		}
+	}
+
+	var services struct {
+		Items []struct {
+			Spec struct {
+				Type  string `json:"type"`
+				Ports []struct {
+					Port int `json:"port"`
+				} `json:"ports"`
+			} `json:"spec"`
+			Status struct {
+				LoadBalancer struct {
+					Ingress []struct {
+						IP       string `json:"ip"`
+						Hostname string `json:"hostname"`
+					} `json:"ingress"`
+				} `json:"loadBalancer"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&services, "get", "services", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type != "LoadBalancer" {
+			continue
+		}
+		for _, lb := range svc.Status.LoadBalancer.Ingress {
+			address := lb.IP
+			if address == "" {
+				address = lb.Hostname
+			}
+			for _, port := range svc.Spec.Ports {
+				urls = append(urls, fmt.Sprintf("http://%s:%d", address, port.Port))
+			}
+		}
+	}
+	sort.Strings(urls)
+	return urls, nil
+}
+
+// DeletePreview deletes a preview namespace and everything in it
+func DeletePreview(p Preview) error {
+	cmd := exec.Command("kubectl", "delete", "namespace", p.Namespace, "--wait=false")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
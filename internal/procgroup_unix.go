@@ -0,0 +1,11 @@
+//go:build !windows
+
+package internal
+
+import "syscall"
+
+// ownProcessGroup starts a process in a process group of its own, so Ctrl+C in the
+// terminal, which signals the whole foreground group, doesn't reach it
+func ownProcessGroup() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
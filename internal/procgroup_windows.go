@@ -0,0 +1,9 @@
+package internal
+
+import "syscall"
+
+// ownProcessGroup starts a process in a process group of its own, so Ctrl+C in the
+// console, which is sent to the whole group, doesn't reach it
+func ownProcessGroup() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
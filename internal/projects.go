@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Project is a GCP project as listed by gcloud
+type Project struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+// GetProjects returns the GCP projects the active account can access
+func GetProjects() ([]Project, error) {
+	var projects []Project
+	if err := GcloudJSON(&projects, "projects", "list"); err != nil {
+		return nil, fmt.Errorf("failed to list GCP projects: %w", err)
+	}
+	return projects, nil
+}
+
+// GetCurrentProject returns the project gcloud is configured to use, or "" if none is set
+func GetCurrentProject() string {
+	output, _ := exec.Command("gcloud", "config", "get-value", "project").Output()
+	return strings.TrimSpace(string(output))
+}
+
+// SetCurrentProject points gcloud at a project
+func SetCurrentProject(projectID string) error {
+	if err := exec.Command("gcloud", "config", "set", "project", projectID).Run(); err != nil {
+		return fmt.Errorf("failed to switch project: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MetricFamily is one metric in the Prometheus text exposition format
+type MetricFamily struct {
+	Name    string
+	Type    string
+	Help    string
+	Samples []MetricSample
+}
+
+// MetricSample is a single sample line of a metric family
+type MetricSample struct {
+	// Name is the sample name, which differs from the family for _bucket, _sum, _count etc.
+	Name   string
+	Labels string
+	Value  string
+}
+
+// ParseMetrics parses the Prometheus text exposition format into metric families, in the
+// order they appear
+func ParseMetrics(text string) []MetricFamily {
+	var families []MetricFamily
+	index := make(map[string]int)
+
+	family := func(name string) *MetricFamily {
+		if i, ok := index[name]; ok {
+			return &families[i]
+		}
+		index[name] = len(families)
+		families = append(families, MetricFamily{Name: name})
+		return &families[len(families)-1]
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "# HELP "); ok {
+			name, help, _ := strings.Cut(rest, " ")
+			family(name).Help = help
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "# TYPE "); ok {
+			name, typ, _ := strings.Cut(rest, " ")
+			family(name).Type = typ
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		sample, ok := parseSample(line)
+		if !ok {
+			continue
+		}
+		f := family(sampleFamily(sample.Name, index))
+		f.Samples = append(f.Samples, sample)
+	}
+	return families
+}
+
+// sampleFamily maps a sample name to the family it belongs to, so histogram and summary
+// series (_bucket, _sum, _count) are grouped under their declared family
+func sampleFamily(name string, index map[string]int) string {
+	if _, ok := index[name]; ok {
+		return name
+	}
+	for _, suffix := range []string{"_bucket", "_sum", "_count", "_total", "_created"} {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			if _, ok := index[base]; ok {
+				return base
+			}
+		}
+	}
+	return name
+}
+
+func parseSample(line string) (MetricSample, bool) {
+	var s MetricSample
+	rest := line
+	if i := strings.IndexByte(line, '{'); i >= 0 {
+		end := strings.LastIndexByte(line, '}')
+		if end < i {
+			return s, false
+		}
+		s.Name = line[:i]
+		s.Labels = line[i+1 : end]
+		rest = strings.TrimSpace(line[end+1:])
+	} else {
+		name, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return s, false
+		}
+		s.Name = name
+		rest = strings.TrimSpace(value)
+	}
+
+	// Drop the optional timestamp after the value
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return s, false
+	}
+	s.Value = fields[0]
+	return s, true
+}
+
+// String renders the sample as name{labels}
+func (s MetricSample) String() string {
+	if s.Labels == "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%s{%s}", s.Name, s.Labels)
+}
+
+// metricsPortNames are container port names conventionally used for Prometheus endpoints
+var metricsPortNames = []string{"metrics", "http-metrics", "prometheus", "prom", "monitoring"}
+
+// MetricsEndpoint finds the Prometheus port and path of a pod from its prometheus.io/*
+// annotations, falling back to a container port named like a metrics port
+func MetricsEndpoint(pod *Pod) (int, string, bool) {
+	path := "/metrics"
+	if annotated := pod.Metadata.Annotations["prometheus.io/path"]; annotated != "" {
+		path = annotated
+	}
+
+	if port, err := strconv.Atoi(pod.Metadata.Annotations["prometheus.io/port"]); err == nil && port > 0 {
+		return port, path, true
+	}
+
+	for _, name := range metricsPortNames {
+		for _, container := range pod.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.Name == name {
+					return port.ContainerPort, path, true
+				}
+			}
+		}
+	}
+	return 0, path, false
+}
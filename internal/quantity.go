@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// ParseQuantity converts a Kubernetes resource quantity ("250m", "1.5Gi", "2") to a float
+// in base units (cores for CPU, bytes for memory)
+func ParseQuantity(q string) (float64, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+
+	for _, s := range quantitySuffixes {
+		if strings.HasSuffix(q, s.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(q, s.suffix), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid quantity %q", q)
+			}
+			return v * s.multiplier, nil
+		}
+	}
+
+	v, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", q)
+	}
+	return v, nil
+}
+
+// FormatCPU renders cores as a Kubernetes CPU quantity (e.g. 0.25 → "250m")
+func FormatCPU(cores float64) string {
+	if cores >= 1 && cores == float64(int(cores)) {
+		return strconv.Itoa(int(cores))
+	}
+	return fmt.Sprintf("%dm", int(cores*1000+0.5))
+}
+
+// FormatMemory renders bytes as a Kubernetes memory quantity using the largest whole binary unit
+func FormatMemory(bytes float64) string {
+	switch {
+	case bytes >= 1<<30 && int64(bytes)%(1<<30) == 0:
+		return fmt.Sprintf("%dGi", int64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%dMi", int64(bytes+(1<<20)-1)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%dKi", int64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d", int64(bytes))
+	}
+}
+
+// FormatBytes renders a size for people (e.g. 1536 → "1.5 KiB")
+func FormatBytes(bytes int64) string {
+	if bytes < 1<<10 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	unit := 0
+	for value >= 1<<10 && unit < 4 {
+		value /= 1 << 10
+		unit++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTP"[unit-1])
+}
@@ -0,0 +1,36 @@
+package internal
+
+// GetResourceQuotas returns the ResourceQuotas in a namespace
+func GetResourceQuotas(namespace string) ([]ResourceQuota, error) {
+	var list struct {
+		Items []ResourceQuota `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "resourcequotas", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetLimitRanges returns the LimitRanges in a namespace
+func GetLimitRanges(namespace string) ([]LimitRange, error) {
+	var list struct {
+		Items []LimitRange `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "limitranges", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// QuotaUsage returns used/hard as a percentage, or -1 if either cannot be parsed
+func QuotaUsage(used, hard string) float64 {
+	u, err := ParseQuantity(used)
+	if err != nil {
+		return -1
+	}
+	h, err := ParseQuantity(hard)
+	if err != nil || h == 0 {
+		return -1
+	}
+	return u * 100 / h
+}
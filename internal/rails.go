@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RailsConsole is the command that starts a Rails console in a workload's pods
+type RailsConsole struct {
+	Container string `json:"container"`
+	Command   string `json:"command"`
+}
+
+// railsConsoleProbe prints the console command for the app in the working directory:
+// binstubs set up bundler themselves, otherwise bundler is used when there is a Gemfile
+const railsConsoleProbe = `if [ -x bin/rails ]; then echo "bin/rails console"; ` +
+	`elif [ -f Gemfile ] && command -v bundle >/dev/null 2>&1; then echo "bundle exec rails console"; ` +
+	`elif command -v rails >/dev/null 2>&1; then echo "rails console"; fi`
+
+// railsConsoleFile maps workload keys to their console commands
+type railsConsoleFile map[string]RailsConsole
+
+func railsConsolePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rails_console.json"), nil
+}
+
+func loadRailsConsoleFile() railsConsoleFile {
+	consoles := railsConsoleFile{}
+	path, err := railsConsolePath()
+	if err != nil {
+		return consoles
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return consoles
+	}
+	// A corrupt cache is simply probed again
+	json.Unmarshal(data, &consoles)
+	return consoles
+}
+
+func saveRailsConsoleFile(consoles railsConsoleFile) error {
+	path, err := railsConsolePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(consoles, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// RailsConsoleKey identifies the workload a pod belongs to, so the console command found
+// for one pod is reused for its replicas and replacements
+func RailsConsoleKey(projectID string, pod *Pod) string {
+	workload := podOwner(*pod)
+	if workload == "" {
+		workload = "pod/" + pod.Metadata.Name
+	}
+	return strings.Join([]string{projectID, pod.Metadata.Namespace, workload}, "/")
+}
+
+// CachedRailsConsole returns the console command saved for a workload key
+func CachedRailsConsole(key string) (RailsConsole, bool) {
+	console, ok := loadRailsConsoleFile()[key]
+	return console, ok
+}
+
+// SaveRailsConsole remembers the console command for a workload key
+func SaveRailsConsole(key string, console RailsConsole) error {
+	consoles := loadRailsConsoleFile()
+	consoles[key] = console
+	return saveRailsConsoleFile(consoles)
+}
+
+// ForgetRailsConsole removes a saved console command, e.g. after it stopped working
+func ForgetRailsConsole(key string) error {
+	consoles := loadRailsConsoleFile()
+	if _, ok := consoles[key]; !ok {
+		return nil
+	}
+	delete(consoles, key)
+	return saveRailsConsoleFile(consoles)
+}
+
+// ProbeRailsConsole looks for the Rails entrypoint in the pod's containers, application
+// containers first, with one non-interactive exec per container. It returns false if no
+// container has bin/rails, bundler with a Gemfile, or rails on the PATH.
+func ProbeRailsConsole(pod string, p *Pod) (RailsConsole, bool, error) {
+	var firstErr error
+	for _, c := range ShellContainers(p) {
+		output, err := probeExec(pod, c.Name, railsConsoleProbe)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("container %s: %w", c.Name, err)
+			}
+			continue
+		}
+		if command := strings.TrimSpace(output); command != "" {
+			return RailsConsole{Container: c.Name, Command: command}, true, nil
+		}
+	}
+	return RailsConsole{}, false, firstErr
+}
@@ -0,0 +1,278 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of objects workloads reference
+const (
+	KindSecret         = "secret"
+	KindConfigMap      = "configmap"
+	KindServiceAccount = "serviceaccount"
+	KindPVC            = "persistentvolumeclaim"
+)
+
+// ParseReferenceKind normalizes the kind of a referenced object as typed on the command line
+func ParseReferenceKind(kind string) (string, error) {
+	switch strings.ToLower(kind) {
+	case "secret", "secrets":
+		return KindSecret, nil
+	case "configmap", "configmaps", "cm":
+		return KindConfigMap, nil
+	case "serviceaccount", "serviceaccounts", "sa":
+		return KindServiceAccount, nil
+	case "persistentvolumeclaim", "persistentvolumeclaims", "pvc":
+		return KindPVC, nil
+	default:
+		return "", fmt.Errorf("unknown kind %q (use configmap, secret, sa or pvc)", kind)
+	}
+}
+
+// ParseConfigKind normalizes a secret or configmap kind as typed on the command line
+func ParseConfigKind(kind string) (string, error) {
+	parsed, err := ParseReferenceKind(kind)
+	if err == nil && parsed != KindSecret && parsed != KindConfigMap {
+		err = fmt.Errorf("unsupported kind %q (use secret or configmap)", kind)
+	}
+	return parsed, err
+}
+
+// References lists how a pod spec uses a Secret, ConfigMap, ServiceAccount or PVC:
+// mounted as a volume, read into environment variables, used as an image pull secret or
+// as the pod's service account
+func (s PodSpec) References(kind, name string) []string {
+	var uses []string
+	if kind == KindServiceAccount {
+		// Pods without a service account run as "default"
+		if s.ServiceAccountName == name || (s.ServiceAccountName == "" && name == "default") {
+			uses = append(uses, "serviceAccountName")
+		}
+		return uses
+	}
+
+	for _, v := range s.Volumes {
+		mounted := false
+		switch kind {
+		case KindSecret:
+			mounted = v.Secret != nil && v.Secret.SecretName == name
+		case KindConfigMap:
+			mounted = v.ConfigMap != nil && v.ConfigMap.Name == name
+		case KindPVC:
+			mounted = v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == name
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				switch kind {
+				case KindSecret:
+					mounted = mounted || (source.Secret != nil && source.Secret.Name == name)
+				case KindConfigMap:
+					mounted = mounted || (source.ConfigMap != nil && source.ConfigMap.Name == name)
+				}
+			}
+		}
+		if mounted {
+			uses = append(uses, "volume "+v.Name)
+		}
+	}
+
+	if kind == KindSecret {
+		for _, ref := range s.ImagePullSecrets {
+			if ref.Name == name {
+				uses = append(uses, "imagePullSecrets")
+			}
+		}
+	}
+
+	for _, c := range append(append([]Container{}, s.InitContainers...), s.Containers...) {
+		for _, from := range c.EnvFrom {
+			if (kind == KindSecret && from.SecretRef != nil && from.SecretRef.Name == name) ||
+				(kind == KindConfigMap && from.ConfigMapRef != nil && from.ConfigMapRef.Name == name) {
+				uses = append(uses, "envFrom in "+c.Name)
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			var ref *KeyReference
+			switch kind {
+			case KindSecret:
+				ref = env.ValueFrom.SecretKeyRef
+			case KindConfigMap:
+				ref = env.ValueFrom.ConfigMapKeyRef
+			}
+			if ref != nil && ref.Name == name {
+				uses = append(uses, fmt.Sprintf("env %s in %s", env.Name, c.Name))
+			}
+		}
+	}
+	return uses
+}
+
+// ConfigConsumer is a deployment that uses a Secret or ConfigMap
+type ConfigConsumer struct {
+	Deployment Deployment
+	Uses       []string
+}
+
+// FindConfigObject returns the namespaces, among application namespaces or the namespace
+// set with SetNamespaceScope, that have an object of this kind and name
+func FindConfigObject(kind, name string) ([]string, error) {
+	var list struct {
+		Items []struct {
+			Metadata ObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	args := append([]string{"get", kind + "s"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "--field-selector", "metadata.name="+name, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+	for _, item := range list.Items {
+		if !isSystemNamespace(item.Metadata.Namespace) {
+			namespaces = append(namespaces, item.Metadata.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// FindConfigConsumers finds the deployments in a namespace whose pods mount or read
+// environment variables from a Secret or ConfigMap
+func FindConfigConsumers(kind, namespace, name string) ([]ConfigConsumer, error) {
+	var list DeploymentList
+	if err := KubectlJSON(&list, "get", "deployments", "-n", namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var consumers []ConfigConsumer
+	for _, d := range list.Items {
+		var uses []string
+		for _, use := range d.Spec.Template.Spec.References(kind, name) {
+			// Pull secrets are read by the kubelet on the next image pull, not by the pods
+			if use != "imagePullSecrets" {
+				uses = append(uses, use)
+			}
+		}
+		if len(uses) > 0 {
+			consumers = append(consumers, ConfigConsumer{Deployment: d, Uses: uses})
+		}
+	}
+	return consumers, nil
+}
+
+// WorkloadReference is a workload that references an object
+type WorkloadReference struct {
+	// Kind is the workload's kind, e.g. "Deployment" or "CronJob"
+	Kind      string
+	Namespace string
+	Name      string
+	Uses      []string
+}
+
+// workloadKinds are the resources searched for references, controllers first
+const workloadKinds = "deployments,statefulsets,daemonsets,cronjobs,jobs,pods"
+
+// FindReferences finds the workloads (Deployments, StatefulSets, DaemonSets, CronJobs,
+// and Jobs and pods that no controller manages) that reference an object, in application
+// namespaces or the namespace set with SetNamespaceScope. When namespace is set, only
+// workloads in that namespace are considered.
+func FindReferences(kind, namespace, name string) ([]WorkloadReference, error) {
+	args := []string{"get", workloadKinds}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	} else {
+		args = append(args, namespaceArgs()...)
+	}
+
+	var list struct {
+		Items []struct {
+			Kind     string          `json:"kind"`
+			Metadata ObjectMeta      `json:"metadata"`
+			Spec     json.RawMessage `json:"spec"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var refs []WorkloadReference
+	for _, item := range list.Items {
+		if isSystemNamespace(item.Metadata.Namespace) {
+			continue
+		}
+		// Pods and Jobs of a controller are reported as the controller
+		if (item.Kind == "Pod" || item.Kind == "Job") && hasController(item.Metadata) {
+			continue
+		}
+
+		var spec PodSpec
+		var claimTemplates []string
+		switch item.Kind {
+		case "Pod":
+			if err := json.Unmarshal(item.Spec, &spec); err != nil {
+				return nil, err
+			}
+		case "CronJob":
+			var cronJob struct {
+				JobTemplate struct {
+					Spec struct {
+						Template PodTemplateSpec `json:"template"`
+					} `json:"spec"`
+				} `json:"jobTemplate"`
+			}
+			if err := json.Unmarshal(item.Spec, &cronJob); err != nil {
+				return nil, err
+			}
+			spec = cronJob.JobTemplate.Spec.Template.Spec
+		default:
+			var workload struct {
+				Template             PodTemplateSpec `json:"template"`
+				VolumeClaimTemplates []struct {
+					Metadata ObjectMeta `json:"metadata"`
+				} `json:"volumeClaimTemplates"`
+			}
+			if err := json.Unmarshal(item.Spec, &workload); err != nil {
+				return nil, err
+			}
+			spec = workload.Template.Spec
+			for _, t := range workload.VolumeClaimTemplates {
+				claimTemplates = append(claimTemplates, t.Metadata.Name)
+			}
+		}
+
+		uses := spec.References(kind, name)
+		// StatefulSet claims are named <template>-<statefulset>-<ordinal>
+		if kind == KindPVC {
+			for _, t := range claimTemplates {
+				if strings.HasPrefix(name, t+"-"+item.Metadata.Name+"-") {
+					uses = append(uses, "volumeClaimTemplate "+t)
+				}
+			}
+		}
+		if len(uses) > 0 {
+			refs = append(refs, WorkloadReference{Kind: item.Kind, Namespace: item.Metadata.Namespace, Name: item.Metadata.Name, Uses: uses})
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Kind+"/"+refs[i].Name < refs[j].Kind+"/"+refs[j].Name
+	})
+	return refs, nil
+}
+
+func hasController(meta ObjectMeta) bool {
+	for _, ref := range meta.OwnerReferences {
+		if ref.Controller {
+			return true
+		}
+	}
+	return false
+}
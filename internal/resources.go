@@ -0,0 +1,518 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Minimal Kubernetes object shapes decoded from `kubectl get -o json`.
+// Only the fields gcpeasy reads are declared.
+
+// ObjectMeta is the subset of Kubernetes object metadata gcpeasy uses
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	UID               string            `json:"uid"`
+	Generation        int64             `json:"generation"`
+	Labels            map[string]string `json:"labels"`
+	Annotations       map[string]string `json:"annotations"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	DeletionTimestamp string            `json:"deletionTimestamp"`
+	OwnerReferences   []OwnerReference  `json:"ownerReferences"`
+}
+
+// OwnerReference identifies the controller that manages an object
+type OwnerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller"`
+}
+
+// Condition is a status condition as used by most Kubernetes objects and CRDs
+type Condition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+// negativeConditions are condition types that signal a problem when True
+var negativeConditions = map[string]bool{
+	"Stalled": true, "Degraded": true, "Failed": true, "Failure": true, "Error": true,
+}
+
+// Healthy reports whether the condition is in its good state: True for positive
+// types like Ready, False for negative types like Stalled
+func (c Condition) Healthy() bool {
+	if negativeConditions[c.Type] {
+		return c.Status != "True"
+	}
+	return c.Status == "True"
+}
+
+// Container is a container definition within a pod spec
+type Container struct {
+	Name            string               `json:"name"`
+	Image           string               `json:"image"`
+	Ports           []ContainerPort      `json:"ports"`
+	Resources       ResourceRequirements `json:"resources"`
+	Env             []EnvVar             `json:"env"`
+	EnvFrom         []EnvFromSource      `json:"envFrom"`
+	LivenessProbe   *Probe               `json:"livenessProbe"`
+	ReadinessProbe  *Probe               `json:"readinessProbe"`
+	StartupProbe    *Probe               `json:"startupProbe"`
+	SecurityContext *SecurityContext     `json:"securityContext"`
+}
+
+// SecurityContext is the subset of a container's or pod's security context gcpeasy uses
+type SecurityContext struct {
+	RunAsUser    *int64 `json:"runAsUser"`
+	RunAsNonRoot *bool  `json:"runAsNonRoot"`
+	Privileged   *bool  `json:"privileged"`
+}
+
+// Probe is a container liveness, readiness or startup probe; one handler is set
+type Probe struct {
+	HTTPGet *struct {
+		Path   string `json:"path"`
+		Port   any    `json:"port"`
+		Scheme string `json:"scheme"`
+	} `json:"httpGet"`
+	TCPSocket *struct {
+		Port any `json:"port"`
+	} `json:"tcpSocket"`
+	GRPC *struct {
+		Port int `json:"port"`
+	} `json:"grpc"`
+	Exec *struct {
+		Command []string `json:"command"`
+	} `json:"exec"`
+	InitialDelaySeconds int `json:"initialDelaySeconds"`
+	TimeoutSeconds      int `json:"timeoutSeconds"`
+	PeriodSeconds       int `json:"periodSeconds"`
+	FailureThreshold    int `json:"failureThreshold"`
+}
+
+// ObjectReference names a Secret or ConfigMap in the pod's namespace
+type ObjectReference struct {
+	Name string `json:"name"`
+}
+
+// KeyReference selects a key of a Secret or ConfigMap
+type KeyReference struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// EnvVar is a container environment variable, set directly or from a Secret or ConfigMap
+type EnvVar struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	ValueFrom *struct {
+		SecretKeyRef    *KeyReference `json:"secretKeyRef"`
+		ConfigMapKeyRef *KeyReference `json:"configMapKeyRef"`
+	} `json:"valueFrom"`
+}
+
+// EnvFromSource imports all keys of a Secret or ConfigMap as environment variables
+type EnvFromSource struct {
+	SecretRef    *ObjectReference `json:"secretRef"`
+	ConfigMapRef *ObjectReference `json:"configMapRef"`
+}
+
+// Volume is a pod volume; only Secret, ConfigMap, PVC and projected sources are declared
+type Volume struct {
+	Name   string `json:"name"`
+	Secret *struct {
+		SecretName string `json:"secretName"`
+	} `json:"secret"`
+	ConfigMap *ObjectReference `json:"configMap"`
+	Projected *struct {
+		Sources []struct {
+			Secret    *ObjectReference `json:"secret"`
+			ConfigMap *ObjectReference `json:"configMap"`
+		} `json:"sources"`
+	} `json:"projected"`
+	PersistentVolumeClaim *struct {
+		ClaimName string `json:"claimName"`
+	} `json:"persistentVolumeClaim"`
+}
+
+// ContainerPort is a port exposed by a container
+type ContainerPort struct {
+	Name          string `json:"name"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// ResourceRequirements are a container's resource requests and limits
+type ResourceRequirements struct {
+	Requests map[string]string `json:"requests"`
+	Limits   map[string]string `json:"limits"`
+}
+
+// PodSpec is the subset of a pod spec gcpeasy uses
+type PodSpec struct {
+	Containers         []Container       `json:"containers"`
+	InitContainers     []Container       `json:"initContainers"`
+	Volumes            []Volume          `json:"volumes"`
+	ServiceAccountName string            `json:"serviceAccountName"`
+	ImagePullSecrets   []ObjectReference `json:"imagePullSecrets"`
+	NodeName           string            `json:"nodeName"`
+	SecurityContext    *SecurityContext  `json:"securityContext"`
+	// AutomountServiceAccountToken overrides the service account's setting when set
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken"`
+}
+
+// ContainerState is the state of a container; only one field is set
+type ContainerState struct {
+	Waiting *struct {
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"waiting"`
+	Running *struct {
+		StartedAt string `json:"startedAt"`
+	} `json:"running"`
+	Terminated *struct {
+		Reason     string `json:"reason"`
+		Message    string `json:"message"`
+		ExitCode   int    `json:"exitCode"`
+		StartedAt  string `json:"startedAt"`
+		FinishedAt string `json:"finishedAt"`
+	} `json:"terminated"`
+}
+
+// ContainerStatus is the observed status of a container
+type ContainerStatus struct {
+	Name         string         `json:"name"`
+	Ready        bool           `json:"ready"`
+	RestartCount int            `json:"restartCount"`
+	Image        string         `json:"image"`
+	State        ContainerState `json:"state"`
+	LastState    ContainerState `json:"lastState"`
+}
+
+// Pod is a Kubernetes v1 Pod
+type Pod struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     PodSpec    `json:"spec"`
+	Status   struct {
+		Phase                 string            `json:"phase"`
+		Reason                string            `json:"reason"`
+		Message               string            `json:"message"`
+		StartTime             string            `json:"startTime"`
+		PodIP                 string            `json:"podIP"`
+		QOSClass              string            `json:"qosClass"`
+		Conditions            []Condition       `json:"conditions"`
+		ContainerStatuses     []ContainerStatus `json:"containerStatuses"`
+		InitContainerStatuses []ContainerStatus `json:"initContainerStatuses"`
+	} `json:"status"`
+}
+
+// PodList is the result of `kubectl get pods -o json`
+type PodList struct {
+	Items []Pod `json:"items"`
+}
+
+// Container returns the spec of the named container
+func (p Pod) Container(name string) (Container, bool) {
+	for _, c := range p.Spec.Containers {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Container{}, false
+}
+
+// DefaultContainer returns the container kubectl exec uses when none is given: the one
+// named by the kubectl.kubernetes.io/default-container annotation, else the first
+func (p Pod) DefaultContainer() (Container, bool) {
+	if name := p.Metadata.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		if c, ok := p.Container(name); ok {
+			return c, true
+		}
+	}
+	if len(p.Spec.Containers) == 0 {
+		return Container{}, false
+	}
+	return p.Spec.Containers[0], true
+}
+
+// PodTemplateSpec is a pod template embedded in workload specs
+type PodTemplateSpec struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     PodSpec    `json:"spec"`
+}
+
+// Deployment is a Kubernetes apps/v1 Deployment
+type Deployment struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int            `json:"replicas"`
+		Selector LabelSelector   `json:"selector"`
+		Template PodTemplateSpec `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		ObservedGeneration int64       `json:"observedGeneration"`
+		Replicas           int         `json:"replicas"`
+		ReadyReplicas      int         `json:"readyReplicas"`
+		UpdatedReplicas    int         `json:"updatedReplicas"`
+		AvailableReplicas  int         `json:"availableReplicas"`
+		Conditions         []Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// DesiredReplicas returns spec.replicas, defaulting to 1 like the API server does
+func (d Deployment) DesiredReplicas() int {
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
+
+// DeploymentList is the result of `kubectl get deployments -o json`
+type DeploymentList struct {
+	Items []Deployment `json:"items"`
+}
+
+// ReplicaSet is a Kubernetes apps/v1 ReplicaSet
+type ReplicaSet struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int            `json:"replicas"`
+		Template PodTemplateSpec `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		Replicas int `json:"replicas"`
+	} `json:"status"`
+}
+
+// ReplicaSetList is the result of `kubectl get replicasets -o json`
+type ReplicaSetList struct {
+	Items []ReplicaSet `json:"items"`
+}
+
+// KubeEvent is a Kubernetes core/v1 Event
+type KubeEvent struct {
+	Metadata       ObjectMeta `json:"metadata"`
+	Type           string     `json:"type"`
+	Reason         string     `json:"reason"`
+	Message        string     `json:"message"`
+	Count          int        `json:"count"`
+	FirstTimestamp string     `json:"firstTimestamp"`
+	LastTimestamp  string     `json:"lastTimestamp"`
+	EventTime      string     `json:"eventTime"`
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		// FieldPath points at a container, e.g. spec.containers{app}
+		FieldPath string `json:"fieldPath"`
+	} `json:"involvedObject"`
+}
+
+// KubeEventList is the result of `kubectl get events -o json`
+type KubeEventList struct {
+	Items []KubeEvent `json:"items"`
+}
+
+// Job is a Kubernetes batch/v1 Job
+type Job struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Completions  *int `json:"completions"`
+		BackoffLimit *int `json:"backoffLimit"`
+	} `json:"spec"`
+	Status struct {
+		Active         int         `json:"active"`
+		Succeeded      int         `json:"succeeded"`
+		Failed         int         `json:"failed"`
+		StartTime      string      `json:"startTime"`
+		CompletionTime string      `json:"completionTime"`
+		Conditions     []Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// JobList is the result of `kubectl get jobs -o json`
+type JobList struct {
+	Items []Job `json:"items"`
+}
+
+// CronJob is a Kubernetes batch/v1 CronJob
+type CronJob struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Schedule string `json:"schedule"`
+		Suspend  *bool  `json:"suspend"`
+	} `json:"spec"`
+	Status struct {
+		Active             []ObjectReference `json:"active"`
+		LastScheduleTime   string            `json:"lastScheduleTime"`
+		LastSuccessfulTime string            `json:"lastSuccessfulTime"`
+	} `json:"status"`
+}
+
+// CronJobList is the result of `kubectl get cronjobs -o json`
+type CronJobList struct {
+	Items []CronJob `json:"items"`
+}
+
+// LabelSelector is a Kubernetes label selector with matchLabels and matchExpressions
+type LabelSelector struct {
+	MatchLabels      map[string]string `json:"matchLabels"`
+	MatchExpressions []struct {
+		Key      string   `json:"key"`
+		Operator string   `json:"operator"`
+		Values   []string `json:"values"`
+	} `json:"matchExpressions"`
+}
+
+// Matches reports whether the labels satisfy the selector. An empty selector matches everything.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for k, v := range s.MatchLabels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	for _, e := range s.MatchExpressions {
+		value, exists := labels[e.Key]
+		in := false
+		for _, v := range e.Values {
+			if v == value {
+				in = true
+				break
+			}
+		}
+		switch e.Operator {
+		case "In":
+			if !exists || !in {
+				return false
+			}
+		case "NotIn":
+			if exists && in {
+				return false
+			}
+		case "Exists":
+			if !exists {
+				return false
+			}
+		case "DoesNotExist":
+			if exists {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String formats the selector in kubectl's -l syntax, e.g. "app=web,tier in (a,b)"
+func (s LabelSelector) String() string {
+	var parts []string
+	for k, v := range s.MatchLabels {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	for _, e := range s.MatchExpressions {
+		switch e.Operator {
+		case "In", "NotIn":
+			parts = append(parts, fmt.Sprintf("%s %s (%s)", e.Key, strings.ToLower(e.Operator), strings.Join(e.Values, ",")))
+		case "Exists":
+			parts = append(parts, e.Key)
+		case "DoesNotExist":
+			parts = append(parts, "!"+e.Key)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// PodDisruptionBudget is a Kubernetes policy/v1 PodDisruptionBudget
+type PodDisruptionBudget struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		MinAvailable   interface{}   `json:"minAvailable"`
+		MaxUnavailable interface{}   `json:"maxUnavailable"`
+		Selector       LabelSelector `json:"selector"`
+	} `json:"spec"`
+	Status struct {
+		CurrentHealthy     int `json:"currentHealthy"`
+		DesiredHealthy     int `json:"desiredHealthy"`
+		ExpectedPods       int `json:"expectedPods"`
+		DisruptionsAllowed int `json:"disruptionsAllowed"`
+	} `json:"status"`
+}
+
+// PodDisruptionBudgetList is the result of `kubectl get pdb -o json`
+type PodDisruptionBudgetList struct {
+	Items []PodDisruptionBudget `json:"items"`
+}
+
+// ResourceQuota is a Kubernetes v1 ResourceQuota
+type ResourceQuota struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Status   struct {
+		Hard map[string]string `json:"hard"`
+		Used map[string]string `json:"used"`
+	} `json:"status"`
+}
+
+// LimitRange is a Kubernetes v1 LimitRange
+type LimitRange struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Limits []struct {
+			Type                 string            `json:"type"`
+			Default              map[string]string `json:"default"`
+			DefaultRequest       map[string]string `json:"defaultRequest"`
+			Min                  map[string]string `json:"min"`
+			Max                  map[string]string `json:"max"`
+			MaxLimitRequestRatio map[string]string `json:"maxLimitRequestRatio"`
+		} `json:"limits"`
+	} `json:"spec"`
+}
+
+// Service is a Kubernetes Service
+type Service struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Type     string            `json:"type"`
+		Selector map[string]string `json:"selector"`
+		Ports    []ServicePort     `json:"ports"`
+	} `json:"spec"`
+}
+
+// ServicePort is a port of a Service. TargetPort is a number, a container port name, or
+// nil when it is the same as Port.
+type ServicePort struct {
+	Name       string `json:"name"`
+	Port       int    `json:"port"`
+	TargetPort any    `json:"targetPort"`
+	Protocol   string `json:"protocol"`
+}
+
+// ServiceList is the result of `kubectl get services -o json`
+type ServiceList struct {
+	Items []Service `json:"items"`
+}
+
+// PersistentVolume is a Kubernetes PersistentVolume backed by a GCE persistent disk
+type PersistentVolume struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		CSI *struct {
+			Driver       string `json:"driver"`
+			VolumeHandle string `json:"volumeHandle"`
+		} `json:"csi"`
+		GCEPersistentDisk *struct {
+			PDName string `json:"pdName"`
+		} `json:"gcePersistentDisk"`
+		// PersistentVolumeReclaimPolicy is Delete, Retain or Recycle
+		PersistentVolumeReclaimPolicy string `json:"persistentVolumeReclaimPolicy"`
+	} `json:"spec"`
+}
+
+// PersistentVolumeList is the result of `kubectl get pv -o json`
+type PersistentVolumeList struct {
+	Items []PersistentVolume `json:"items"`
+}
@@ -0,0 +1,256 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// rightsizePeriod is the alignment period of the usage samples
+const rightsizePeriod = 5 * time.Minute
+
+// UsagePercentiles summarises a container's usage samples across all of a deployment's pods
+type UsagePercentiles struct {
+	P50     float64
+	P95     float64
+	P99     float64
+	Max     float64
+	Samples int
+}
+
+// percentiles computes nearest-rank percentiles of the samples
+func percentiles(samples []float64) UsagePercentiles {
+	if len(samples) == 0 {
+		return UsagePercentiles{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	return UsagePercentiles{
+		P50:     rank(0.50),
+		P95:     rank(0.95),
+		P99:     rank(0.99),
+		Max:     sorted[len(sorted)-1],
+		Samples: len(sorted),
+	}
+}
+
+// ContainerRightsizing compares a container's resources with its observed usage. CPU is in
+// cores and memory in bytes; zero means unset.
+type ContainerRightsizing struct {
+	Container string
+	CPU       UsagePercentiles
+	Memory    UsagePercentiles
+
+	CPURequest    float64
+	CPULimit      float64
+	MemoryRequest float64
+	MemoryLimit   float64
+
+	SuggestedCPURequest    float64
+	SuggestedCPULimit      float64
+	SuggestedMemoryRequest float64
+	SuggestedMemoryLimit   float64
+
+	// CPURequestCapped and MemoryRequestCapped are set when the suggested request was
+	// lowered to the limit, as the API server rejects a request above the limit; a capped
+	// request is always part of the patch
+	CPURequestCapped    bool
+	MemoryRequestCapped bool
+}
+
+// HasData reports whether any usage was recorded for the container
+func (r ContainerRightsizing) HasData() bool {
+	return r.CPU.Samples > 0 || r.Memory.Samples > 0
+}
+
+// deploymentPodPattern matches the names of pods created by a deployment's ReplicaSets:
+// <deployment>-<pod-template-hash>-<suffix>
+func deploymentPodPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile("^" + regexp.QuoteMeta(name) + `-[a-z0-9]+-[a-z0-9]+$`)
+}
+
+// GetDeploymentUsage collects CPU (cores) and memory (bytes) usage percentiles per container
+// of a deployment from Cloud Monitoring, pooling the samples of all its pods over the window
+func GetDeploymentUsage(projectID, clusterName string, d Deployment, since time.Duration) (map[string]UsagePercentiles, map[string]UsagePercentiles, error) {
+	filter := fmt.Sprintf(`resource.type="k8s_container" AND resource.labels.namespace_name="%s" AND resource.labels.pod_name=starts_with("%s-")`, d.Metadata.Namespace, d.Metadata.Name)
+	if clusterName != "" {
+		filter += fmt.Sprintf(` AND resource.labels.cluster_name="%s"`, clusterName)
+	}
+	pods := deploymentPodPattern(d.Metadata.Name)
+
+	collect := func(metric, aligner string) (map[string]UsagePercentiles, error) {
+		series, err := QueryTimeSeries(projectID, MonitoringQuery{
+			Filter:  metric + " AND " + filter,
+			Window:  since,
+			Period:  rightsizePeriod,
+			Aligner: aligner,
+			Reducer: "REDUCE_SUM",
+			GroupBy: []string{"resource.labels.pod_name", "resource.labels.container_name"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		samples := make(map[string][]float64)
+		for _, s := range series {
+			// Skip pods of other deployments sharing the name prefix (e.g. web-worker for web)
+			if !pods.MatchString(s.ResourceLabels["pod_name"]) {
+				continue
+			}
+			container := s.ResourceLabels["container_name"]
+			samples[container] = append(samples[container], s.Points...)
+		}
+		usage := make(map[string]UsagePercentiles)
+		for container, points := range samples {
+			usage[container] = percentiles(points)
+		}
+		return usage, nil
+	}
+
+	cpu, err := collect(`metric.type="kubernetes.io/container/cpu/core_usage_time"`, "ALIGN_RATE")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query CPU usage: %w", err)
+	}
+	memory, err := collect(`metric.type="kubernetes.io/container/memory/used_bytes" AND metric.labels.memory_type="non-evictable"`, "ALIGN_MAX")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query memory usage: %w", err)
+	}
+	return cpu, memory, nil
+}
+
+const (
+	cpuRequestStep    = 0.005
+	minCPURequest     = 0.01
+	memoryRequestStep = 16 << 20
+)
+
+// roundUp rounds v up to a multiple of step
+func roundUp(v, step float64) float64 {
+	return math.Ceil(v/step) * step
+}
+
+// RightsizeDeployment proposes resources for each of a deployment's containers from their
+// usage: requests cover p95 usage with 15% headroom, the memory limit leaves 25% headroom
+// over peak usage (like SuggestMemoryLimit), and a CPU limit, which throttles rather than
+// kills, is only proposed when one is already set, at 50% over p99 usage. Containers
+// without usage data keep their current values.
+func RightsizeDeployment(d Deployment, cpu, memory map[string]UsagePercentiles) []ContainerRightsizing {
+	var result []ContainerRightsizing
+	for _, c := range d.Spec.Template.Spec.Containers {
+		r := ContainerRightsizing{Container: c.Name, CPU: cpu[c.Name], Memory: memory[c.Name]}
+		r.CPURequest, _ = ParseQuantity(c.Resources.Requests["cpu"])
+		r.CPULimit, _ = ParseQuantity(c.Resources.Limits["cpu"])
+		r.MemoryRequest, _ = ParseQuantity(c.Resources.Requests["memory"])
+		r.MemoryLimit, _ = ParseQuantity(c.Resources.Limits["memory"])
+
+		r.SuggestedCPURequest, r.SuggestedCPULimit = r.CPURequest, r.CPULimit
+		if r.CPU.Samples > 0 {
+			r.SuggestedCPURequest = max(roundUp(r.CPU.P95*1.15, cpuRequestStep), minCPURequest)
+			if r.CPULimit > 0 {
+				r.SuggestedCPULimit = max(roundUp(r.CPU.P99*1.5, cpuRequestStep), r.SuggestedCPURequest)
+			}
+		}
+
+		r.SuggestedMemoryRequest, r.SuggestedMemoryLimit = r.MemoryRequest, r.MemoryLimit
+		if r.Memory.Samples > 0 {
+			r.SuggestedMemoryRequest = max(roundUp(r.Memory.P95*1.15, memoryRequestStep), memoryRequestStep)
+			r.SuggestedMemoryLimit = max(roundUp(r.Memory.Max*1.25, memoryLimitStep), r.SuggestedMemoryRequest)
+		}
+
+		// Only significant changes are applied, so a kept limit can be below a new request,
+		// or a new limit below a kept request
+		if limit := appliedValue(r.CPULimit, r.SuggestedCPULimit); limit > 0 && appliedValue(r.CPURequest, r.SuggestedCPURequest) > limit {
+			r.SuggestedCPURequest, r.CPURequestCapped = limit, true
+		}
+		if limit := appliedValue(r.MemoryLimit, r.SuggestedMemoryLimit); limit > 0 && appliedValue(r.MemoryRequest, r.SuggestedMemoryRequest) > limit {
+			r.SuggestedMemoryRequest, r.MemoryRequestCapped = limit, true
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// SignificantChange reports whether a suggested value differs enough from the current
+// one to be worth a rollout: it is newly set, or more than 10% away
+func SignificantChange(current, suggested float64) bool {
+	if suggested == 0 {
+		return false
+	}
+	if current == 0 {
+		return true
+	}
+	return math.Abs(suggested-current)/current > 0.1
+}
+
+// appliedValue is the value left in place by a suggestion: the suggested value when it is
+// a significant change, otherwise the current one
+func appliedValue(current, suggested float64) float64 {
+	if SignificantChange(current, suggested) {
+		return suggested
+	}
+	return current
+}
+
+// RightsizePatch builds a strategic merge patch setting the significantly changed and
+// capped resources, or nil when nothing changes
+func RightsizePatch(suggestions []ContainerRightsizing) ([]byte, error) {
+	type resources struct {
+		Requests map[string]string `json:"requests,omitempty"`
+		Limits   map[string]string `json:"limits,omitempty"`
+	}
+	type container struct {
+		Name      string    `json:"name"`
+		Resources resources `json:"resources"`
+	}
+
+	var containers []container
+	for _, r := range suggestions {
+		c := container{Name: r.Container, Resources: resources{Requests: map[string]string{}, Limits: map[string]string{}}}
+		if r.CPURequestCapped || SignificantChange(r.CPURequest, r.SuggestedCPURequest) {
+			c.Resources.Requests["cpu"] = FormatCPU(r.SuggestedCPURequest)
+		}
+		if SignificantChange(r.CPULimit, r.SuggestedCPULimit) {
+			c.Resources.Limits["cpu"] = FormatCPU(r.SuggestedCPULimit)
+		}
+		if r.MemoryRequestCapped || SignificantChange(r.MemoryRequest, r.SuggestedMemoryRequest) {
+			c.Resources.Requests["memory"] = FormatMemory(r.SuggestedMemoryRequest)
+		}
+		if SignificantChange(r.MemoryLimit, r.SuggestedMemoryLimit) {
+			c.Resources.Limits["memory"] = FormatMemory(r.SuggestedMemoryLimit)
+		}
+		if len(c.Resources.Requests) > 0 || len(c.Resources.Limits) > 0 {
+			containers = append(containers, c)
+		}
+	}
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{"containers": containers},
+			},
+		},
+	}
+	return json.Marshal(patch)
+}
+
+// PatchDeployment applies a strategic merge patch to a deployment
+func PatchDeployment(d Deployment, patch []byte) error {
+	cmd := exec.Command("kubectl", "patch", "deployment", d.Metadata.Name, "-n", d.Metadata.Namespace, "--type", "strategic", "-p", string(patch))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to patch deployment %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
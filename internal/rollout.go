@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RevisionAnnotation holds the rollout revision of a deployment and its ReplicaSets
+const RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// Revision is a rollout revision of a deployment, backed by one of its ReplicaSets
+type Revision struct {
+	Number      int
+	ReplicaSet  string
+	Created     time.Time
+	ChangeCause string
+	Images      []string
+	Replicas    int
+	// Current is the revision the deployment runs
+	Current bool
+}
+
+// GetRevisions returns the revisions of a deployment kept in its revision history, newest
+// first
+func GetRevisions(d Deployment) ([]Revision, error) {
+	var list ReplicaSetList
+	if err := KubectlJSON(&list, "get", "replicasets", "-n", d.Metadata.Namespace, "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	current := d.Metadata.Annotations[RevisionAnnotation]
+	var revisions []Revision
+	for _, rs := range list.Items {
+		owned := false
+		for _, ref := range rs.Metadata.OwnerReferences {
+			if ref.Kind == "Deployment" && ref.Name == d.Metadata.Name {
+				owned = true
+			}
+		}
+		number, err := strconv.Atoi(rs.Metadata.Annotations[RevisionAnnotation])
+		if !owned || err != nil {
+			continue
+		}
+
+		r := Revision{
+			Number:      number,
+			ReplicaSet:  rs.Metadata.Name,
+			ChangeCause: rs.Metadata.Annotations[ChangeCauseAnnotation],
+			Replicas:    rs.Status.Replicas,
+			Current:     rs.Metadata.Annotations[RevisionAnnotation] == current,
+		}
+		r.Created, _ = time.Parse(time.RFC3339, rs.Metadata.CreationTimestamp)
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			r.Images = append(r.Images, c.Image)
+		}
+		revisions = append(revisions, r)
+	}
+	sort.Slice(revisions, func(i, k int) bool { return revisions[i].Number > revisions[k].Number })
+	return revisions, nil
+}
+
+// SelectRevision prompts the user to select a revision to roll back to. The previous
+// revision is the default.
+func SelectRevision(revisions []Revision) (*Revision, error) {
+	var candidates []Revision
+	for _, r := range revisions {
+		if !r.Current {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no earlier revisions available")
+	}
+
+	fmt.Printf("📋 Found %d earlier revision(s):\n", len(candidates))
+	fmt.Println()
+
+	for i, r := range candidates {
+		fmt.Printf("%d. revision %d, %s ago: %s", i+1, r.Number, FormatDuration(time.Since(r.Created)), strings.Join(r.Images, ", "))
+		if r.ChangeCause != "" {
+			fmt.Printf(" (%s)", r.ChangeCause)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	fmt.Print("Select revision (number, Enter for 1, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+	if input == "" {
+		return &candidates[0], nil
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(candidates) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &candidates[num-1], nil
+}
+
+// RollbackDeployment rolls a deployment back to a revision, as with `kubectl rollout undo`
+func RollbackDeployment(d Deployment, revision int) error {
+	if _, err := runOutput("kubectl", "rollout", "undo", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace,
+		"--to-revision="+strconv.Itoa(revision)); err != nil {
+		return fmt.Errorf("failed to roll back %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// RolloutState reports the progress of a deployment's rollout the way `kubectl rollout
+// status` does: done once all replicas are updated and available and no old replicas
+// remain, failed when the progress deadline is exceeded. message describes what the
+// rollout is waiting for.
+func (d Deployment) RolloutState() (done, failed bool, message string) {
+	if d.Status.ObservedGeneration < d.Metadata.Generation {
+		return false, false, "waiting for the deployment spec update to be observed"
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == "Progressing" && c.Reason == "ProgressDeadlineExceeded" {
+			return false, true, "progress deadline exceeded: " + c.Message
+		}
+	}
+
+	desired := d.DesiredReplicas()
+	switch {
+	case d.Status.UpdatedReplicas < desired:
+		return false, false, fmt.Sprintf("%d of %d new replicas updated", d.Status.UpdatedReplicas, desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return false, false, fmt.Sprintf("%d old replica(s) pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return false, false, fmt.Sprintf("%d of %d updated replicas available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	}
+	return true, false, fmt.Sprintf("%d of %d replicas updated and available", d.Status.AvailableReplicas, desired)
+}
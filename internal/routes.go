@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Routing resources gcpeasy knows how to report on
+const (
+	IstioVirtualServiceResource = "virtualservices.networking.istio.io"
+	HTTPRouteResource           = "httproutes.gateway.networking.k8s.io"
+)
+
+// RouteInfo summarises how an Istio VirtualService or Gateway API HTTPRoute sends traffic
+type RouteInfo struct {
+	Type      string
+	Namespace string
+	Name      string
+	Hosts     []string
+	Parents   []string
+	Rules     []RouteRule
+}
+
+// RouteRule is one match → backends rule of a route
+type RouteRule struct {
+	Match    string
+	Backends []RouteBackend
+}
+
+// RouteBackend is a weighted destination service
+type RouteBackend struct {
+	Service string
+	Port    int
+	Subset  string
+	Percent float64
+}
+
+// String renders the backend as service[:port][ (subset)]
+func (b RouteBackend) String() string {
+	s := b.Service
+	if b.Port != 0 {
+		s = fmt.Sprintf("%s:%d", s, b.Port)
+	}
+	if b.Subset != "" {
+		s += " (" + b.Subset + ")"
+	}
+	return s
+}
+
+// Split reports whether the rule divides traffic between several backends
+func (r RouteRule) Split() bool {
+	return len(r.Backends) > 1
+}
+
+type stringMatch struct {
+	Exact  string `json:"exact"`
+	Prefix string `json:"prefix"`
+	Regex  string `json:"regex"`
+}
+
+func (m *stringMatch) String() string {
+	switch {
+	case m == nil:
+		return ""
+	case m.Exact != "":
+		return "=" + m.Exact
+	case m.Prefix != "":
+		return m.Prefix + "*"
+	case m.Regex != "":
+		return "~" + m.Regex
+	}
+	return ""
+}
+
+type virtualServiceList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Hosts    []string `json:"hosts"`
+			Gateways []string `json:"gateways"`
+			HTTP     []struct {
+				Name  string `json:"name"`
+				Match []struct {
+					URI     *stringMatch           `json:"uri"`
+					Headers map[string]stringMatch `json:"headers"`
+				} `json:"match"`
+				Route []struct {
+					Destination struct {
+						Host   string `json:"host"`
+						Subset string `json:"subset"`
+						Port   struct {
+							Number int `json:"number"`
+						} `json:"port"`
+					} `json:"destination"`
+					Weight int `json:"weight"`
+				} `json:"route"`
+				Redirect *struct {
+					URI       string `json:"uri"`
+					Authority string `json:"authority"`
+				} `json:"redirect"`
+			} `json:"http"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+type httpRouteList struct {
+	Items []struct {
+		Metadata ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Hostnames  []string `json:"hostnames"`
+			ParentRefs []struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"parentRefs"`
+			Rules []struct {
+				Matches []struct {
+					Path *struct {
+						Type  string `json:"type"`
+						Value string `json:"value"`
+					} `json:"path"`
+					Headers []struct {
+						Name string `json:"name"`
+					} `json:"headers"`
+				} `json:"matches"`
+				BackendRefs []struct {
+					Name   string `json:"name"`
+					Port   int    `json:"port"`
+					Weight *int   `json:"weight"`
+				} `json:"backendRefs"`
+			} `json:"rules"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// GetVirtualServices returns the HTTP routing of every Istio VirtualService in application namespaces
+func GetVirtualServices() ([]RouteInfo, error) {
+	var list virtualServiceList
+	if err := KubectlJSON(&list, "get", IstioVirtualServiceResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var routes []RouteInfo
+	for _, vs := range list.Items {
+		if isSystemNamespace(vs.Metadata.Namespace) {
+			continue
+		}
+		r := RouteInfo{
+			Type:      "istio",
+			Namespace: vs.Metadata.Namespace,
+			Name:      vs.Metadata.Name,
+			Hosts:     vs.Spec.Hosts,
+			Parents:   vs.Spec.Gateways,
+		}
+
+		for _, h := range vs.Spec.HTTP {
+			var matches []string
+			for _, m := range h.Match {
+				match := m.URI.String()
+				if len(m.Headers) > 0 {
+					match = strings.TrimSpace(match + " +headers")
+				}
+				if match != "" {
+					matches = append(matches, match)
+				}
+			}
+			rule := RouteRule{Match: matchOrDefault(matches)}
+
+			if h.Redirect != nil {
+				rule.Backends = append(rule.Backends, RouteBackend{
+					Service: "redirect → " + h.Redirect.Authority + h.Redirect.URI,
+					Percent: 100,
+				})
+			}
+
+			// Istio weights are percentages; a lone destination without one gets all traffic
+			for _, dest := range h.Route {
+				weight := float64(dest.Weight)
+				if len(h.Route) == 1 && dest.Weight == 0 {
+					weight = 100
+				}
+				rule.Backends = append(rule.Backends, RouteBackend{
+					Service: dest.Destination.Host,
+					Port:    dest.Destination.Port.Number,
+					Subset:  dest.Destination.Subset,
+					Percent: weight,
+				})
+			}
+			r.Rules = append(r.Rules, rule)
+		}
+
+		routes = append(routes, r)
+	}
+
+	sortRoutes(routes)
+	return routes, nil
+}
+
+// GetHTTPRoutes returns the routing of every Gateway API HTTPRoute in application namespaces
+func GetHTTPRoutes() ([]RouteInfo, error) {
+	var list httpRouteList
+	if err := KubectlJSON(&list, "get", HTTPRouteResource, "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var routes []RouteInfo
+	for _, hr := range list.Items {
+		if isSystemNamespace(hr.Metadata.Namespace) {
+			continue
+		}
+		r := RouteInfo{
+			Type:      "httproute",
+			Namespace: hr.Metadata.Namespace,
+			Name:      hr.Metadata.Name,
+			Hosts:     hr.Spec.Hostnames,
+		}
+		for _, p := range hr.Spec.ParentRefs {
+			if p.Namespace != "" {
+				r.Parents = append(r.Parents, p.Namespace+"/"+p.Name)
+			} else {
+				r.Parents = append(r.Parents, p.Name)
+			}
+		}
+
+		for _, rule := range hr.Spec.Rules {
+			var matches []string
+			for _, m := range rule.Matches {
+				match := ""
+				if m.Path != nil {
+					switch m.Path.Type {
+					case "Exact":
+						match = "=" + m.Path.Value
+					case "RegularExpression":
+						match = "~" + m.Path.Value
+					default:
+						match = m.Path.Value + "*"
+					}
+				}
+				if len(m.Headers) > 0 {
+					match = strings.TrimSpace(match + " +headers")
+				}
+				if match != "" {
+					matches = append(matches, match)
+				}
+			}
+			rr := RouteRule{Match: matchOrDefault(matches)}
+
+			// Gateway API weights are relative and default to 1
+			total := 0
+			for _, b := range rule.BackendRefs {
+				total += backendWeight(b.Weight)
+			}
+			for _, b := range rule.BackendRefs {
+				percent := 0.0
+				if total > 0 {
+					percent = float64(backendWeight(b.Weight)) * 100 / float64(total)
+				}
+				rr.Backends = append(rr.Backends, RouteBackend{Service: b.Name, Port: b.Port, Percent: percent})
+			}
+			r.Rules = append(r.Rules, rr)
+		}
+
+		routes = append(routes, r)
+	}
+
+	sortRoutes(routes)
+	return routes, nil
+}
+
+func backendWeight(w *int) int {
+	if w == nil {
+		return 1
+	}
+	return *w
+}
+
+func matchOrDefault(matches []string) string {
+	if len(matches) == 0 {
+		return "/*"
+	}
+	return strings.Join(matches, " | ")
+}
+
+func sortRoutes(routes []RouteInfo) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+}
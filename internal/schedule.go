@@ -0,0 +1,321 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ScheduleNamespace holds the CronJobs and RBAC objects of scheduled scaling
+const ScheduleNamespace = "gcpeasy-system"
+
+// Labels and annotations used by scheduled scaling
+const (
+	ScheduleLabel = "gcpeasy.io/schedule"
+	// ScaledDownAnnotation records a deployment's replicas before it was scaled down
+	ScaledDownAnnotation = "gcpeasy.io/scaled-down-replicas"
+	// KeepRunningLabel opts a deployment out of scheduled scale-downs
+	KeepRunningLabel = "gcpeasy.io/keep-running"
+)
+
+// ScheduleImage is the default image the scaling CronJobs run; it has sh and kubectl
+const ScheduleImage = "alpine/k8s:1.31.4"
+
+const scheduleName = "gcpeasy-scale"
+
+// ScaleUpCronJob is the CronJob that restores scaled-down deployments
+const ScaleUpCronJob = scheduleName + "-up"
+
+// ScaleSchedule describes an overnight scale-down and morning scale-up
+type ScaleSchedule struct {
+	// Down and Up are cron schedules, e.g. "0 19 * * *"
+	Down     string
+	Up       string
+	TimeZone string
+	// Namespace limits scaling to one namespace; empty means all application namespaces
+	Namespace string
+	Image     string
+}
+
+// CronSchedule converts a time of day ("19:00") to a cron schedule, every day or on
+// weekdays only
+func CronSchedule(clock string, weekdays bool) (string, error) {
+	h, m, ok := strings.Cut(clock, ":")
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if !ok || err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", fmt.Errorf("invalid time %q, use HH:MM (e.g. 19:00)", clock)
+	}
+	days := "*"
+	if weekdays {
+		days = "1-5"
+	}
+	return fmt.Sprintf("%d %d * * %s", minute, hour, days), nil
+}
+
+// LocalTimeZone returns the IANA name of the local time zone (from TZ or /etc/localtime),
+// or Etc/UTC when it can't be determined
+func LocalTimeZone() string {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" && !strings.HasPrefix(tz, "/") {
+		return tz
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return name
+		}
+	}
+	return "Etc/UTC"
+}
+
+// scaleScript returns the shell script a scaling CronJob runs
+func scaleScript(direction, namespace string) string {
+	scope := "-A"
+	if namespace != "" {
+		scope = "-n " + namespace
+	}
+	skip := strings.Join(append(append([]string{}, systemNamespaces...), ScheduleNamespace), "|")
+
+	if direction == "down" {
+		return fmt.Sprintf(`set -eu
+kubectl get deployments %s -l '!%s' \
+  -o jsonpath='{range .items[*]}{.metadata.namespace} {.metadata.name} {.spec.replicas}{"\n"}{end}' |
+while read -r ns name replicas; do
+  case "$ns" in %s) continue ;; esac
+  [ "$replicas" = "0" ] && continue
+  kubectl annotate deployment "$name" -n "$ns" --overwrite %s="$replicas"
+  kubectl scale deployment "$name" -n "$ns" --replicas=0
+done
+`, scope, KeepRunningLabel, skip, ScaledDownAnnotation)
+	}
+
+	// Deployments paused with 'env pause' stay paused; the fields are prefixed as read
+	// would shift empty ones
+	jsonPathKey := func(annotation string) string { return strings.ReplaceAll(annotation, ".", `\.`) }
+	return fmt.Sprintf(`set -eu
+kubectl get deployments %s \
+  -o jsonpath='{range .items[*]}{.metadata.namespace} {.metadata.name} r={.metadata.annotations.%s} p={.metadata.annotations.%s}{"\n"}{end}' |
+while read -r ns name replicas paused; do
+  replicas=${replicas#r=}
+  [ -n "$replicas" ] || continue
+  [ "$paused" = "p=" ] || continue
+  kubectl scale deployment "$name" -n "$ns" --replicas="$replicas"
+  kubectl annotate deployment "$name" -n "$ns" %s-
+done
+`, scope, jsonPathKey(ScaledDownAnnotation), jsonPathKey(PausedAnnotation), ScaledDownAnnotation)
+}
+
+// scheduleManifests builds the namespace, RBAC objects and CronJobs of a scale schedule
+func scheduleManifests(s ScaleSchedule) []map[string]any {
+	labels := map[string]any{"app.kubernetes.io/managed-by": "gcpeasy", ScheduleLabel: "scale"}
+	meta := func(name string, namespaced bool) map[string]any {
+		m := map[string]any{"name": name, "labels": labels}
+		if namespaced {
+			m["namespace"] = ScheduleNamespace
+		}
+		return m
+	}
+
+	objects := []map[string]any{
+		{"apiVersion": "v1", "kind": "Namespace", "metadata": meta(ScheduleNamespace, false)},
+		{"apiVersion": "v1", "kind": "ServiceAccount", "metadata": meta(scheduleName, true)},
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "metadata": meta(scheduleName, false),
+			"rules": []any{
+				map[string]any{"apiGroups": []string{"apps"}, "resources": []string{"deployments"}, "verbs": []string{"get", "list", "patch"}},
+				map[string]any{"apiGroups": []string{"apps"}, "resources": []string{"deployments/scale"}, "verbs": []string{"get", "patch", "update"}},
+			},
+		},
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRoleBinding", "metadata": meta(scheduleName, false),
+			"roleRef":  map[string]any{"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": scheduleName},
+			"subjects": []any{map[string]any{"kind": "ServiceAccount", "name": scheduleName, "namespace": ScheduleNamespace}},
+		},
+	}
+
+	for _, direction := range []string{"down", "up"} {
+		schedule := s.Down
+		if direction == "up" {
+			schedule = s.Up
+		}
+		objects = append(objects, map[string]any{
+			"apiVersion": "batch/v1", "kind": "CronJob", "metadata": meta(scheduleName+"-"+direction, true),
+			"spec": map[string]any{
+				"schedule":                   schedule,
+				"timeZone":                   s.TimeZone,
+				"concurrencyPolicy":          "Forbid",
+				"successfulJobsHistoryLimit": 1,
+				"failedJobsHistoryLimit":     3,
+				"jobTemplate": map[string]any{"spec": map[string]any{
+					"backoffLimit": 2,
+					"template": map[string]any{"spec": map[string]any{
+						"serviceAccountName": scheduleName,
+						"restartPolicy":      "Never",
+						"containers": []any{map[string]any{
+							"name":    "scale",
+							"image":   s.Image,
+							"command": []string{"/bin/sh", "-c", scaleScript(direction, s.Namespace)},
+						}},
+					}},
+				}},
+			},
+		})
+	}
+	return objects
+}
+
+// ScaleScheduleManifests returns the objects of a scale schedule as YAML documents
+func ScaleScheduleManifests(s ScaleSchedule) ([]string, error) {
+	var docs []string
+	for _, object := range scheduleManifests(s) {
+		data, err := marshalYAML(object)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(data))
+	}
+	return docs, nil
+}
+
+// ApplyScaleSchedule creates or updates the CronJobs that scale deployments down and up
+func ApplyScaleSchedule(s ScaleSchedule) error {
+	docs, err := ScaleScheduleManifests(s)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("kubectl", "apply", "-f", "-")
+	cmd.Stdin = strings.NewReader(strings.Join(docs, "---\n"))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to apply the schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteScaleSchedule removes the scaling CronJobs and their RBAC objects. Deployments
+// that are scaled down stay that way.
+func DeleteScaleSchedule() error {
+	selector := ScheduleLabel + "=scale"
+	if _, err := runOutput("kubectl", "delete", "cronjobs,serviceaccounts", "-n", ScheduleNamespace, "-l", selector, "--ignore-not-found"); err != nil {
+		return err
+	}
+	_, err := runOutput("kubectl", "delete", "clusterroles,clusterrolebindings", "-l", selector, "--ignore-not-found")
+	return err
+}
+
+// ScheduledJob is the status of a scaling CronJob
+type ScheduledJob struct {
+	Name           string
+	Schedule       string
+	TimeZone       string
+	Suspended      bool
+	LastSchedule   string
+	LastSuccessful string
+	ActiveJobs     int
+}
+
+// GetScaleSchedule returns the scaling CronJobs, if any
+func GetScaleSchedule() ([]ScheduledJob, error) {
+	var list struct {
+		Items []struct {
+			Metadata ObjectMeta `json:"metadata"`
+			Spec     struct {
+				Schedule string `json:"schedule"`
+				TimeZone string `json:"timeZone"`
+				Suspend  bool   `json:"suspend"`
+			} `json:"spec"`
+			Status struct {
+				Active             []any  `json:"active"`
+				LastScheduleTime   string `json:"lastScheduleTime"`
+				LastSuccessfulTime string `json:"lastSuccessfulTime"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := KubectlJSON(&list, "get", "cronjobs", "-n", ScheduleNamespace, "-l", ScheduleLabel+"=scale", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var jobs []ScheduledJob
+	for _, item := range list.Items {
+		jobs = append(jobs, ScheduledJob{
+			Name:           item.Metadata.Name,
+			Schedule:       item.Spec.Schedule,
+			TimeZone:       item.Spec.TimeZone,
+			Suspended:      item.Spec.Suspend,
+			LastSchedule:   item.Status.LastScheduleTime,
+			LastSuccessful: item.Status.LastSuccessfulTime,
+			ActiveJobs:     len(item.Status.Active),
+		})
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
+
+// ScaledDownDeployments returns the deployments a scheduled scale-down has scaled to zero,
+// with the replicas they will be restored to
+func ScaledDownDeployments() ([]Deployment, error) {
+	var list DeploymentList
+	if err := KubectlJSON(&list, "get", "deployments", "--all-namespaces", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	var scaled []Deployment
+	for _, d := range list.Items {
+		if _, ok := d.Metadata.Annotations[ScaledDownAnnotation]; ok {
+			scaled = append(scaled, d)
+		}
+	}
+	return scaled, nil
+}
+
+// PausedAnnotation records a deployment's replicas while its environment is paused
+const PausedAnnotation = "gcpeasy.io/paused-replicas"
+
+// PausedReplicas returns the replicas a pause records for a deployment: its current
+// replicas, or the ones a scheduled scale-down will restore while it is scaled down
+func PausedReplicas(d Deployment) int {
+	if d.DesiredReplicas() == 0 {
+		if replicas, err := strconv.Atoi(d.Metadata.Annotations[ScaledDownAnnotation]); err == nil {
+			return replicas
+		}
+	}
+	return d.DesiredReplicas()
+}
+
+// PauseDeployment records a deployment's replicas (see PausedReplicas) in PausedAnnotation
+// and scales it to zero. The scheduled scale-down annotation is removed, so the morning
+// scale-up doesn't undo the pause.
+func PauseDeployment(d Deployment) error {
+	replicas := strconv.Itoa(PausedReplicas(d))
+	args := []string{"annotate", "deployment/" + d.Metadata.Name, "-n", d.Metadata.Namespace, "--overwrite", PausedAnnotation + "=" + replicas}
+	if _, ok := d.Metadata.Annotations[ScaledDownAnnotation]; ok {
+		args = append(args, ScaledDownAnnotation+"-")
+	}
+	if _, err := runOutput("kubectl", args...); err != nil {
+		return fmt.Errorf("failed to record replicas of %s: %w", d.Metadata.Name, err)
+	}
+	if _, err := runOutput("kubectl", "scale", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace, "--replicas=0"); err != nil {
+		return fmt.Errorf("failed to scale down %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
+
+// ResumeDeployment scales a paused deployment back to the replicas recorded in
+// PausedAnnotation and removes the annotation
+func ResumeDeployment(d Deployment) error {
+	replicas, ok := d.Metadata.Annotations[PausedAnnotation]
+	if !ok {
+		return fmt.Errorf("%s is not paused", d.Metadata.Name)
+	}
+	if _, err := runOutput("kubectl", "scale", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace, "--replicas="+replicas); err != nil {
+		return fmt.Errorf("failed to scale up %s: %w", d.Metadata.Name, err)
+	}
+	if _, err := runOutput("kubectl", "annotate", "deployment/"+d.Metadata.Name, "-n", d.Metadata.Namespace, PausedAnnotation+"-"); err != nil {
+		return fmt.Errorf("failed to clear paused replicas of %s: %w", d.Metadata.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity levels of security findings, from most to least severe
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// findingSeverityRank orders severities, higher is more severe
+var findingSeverityRank = map[string]int{SeverityHigh: 3, SeverityMedium: 2, SeverityLow: 1}
+
+// SeverityAtLeast reports whether severity is at least as severe as min
+func SeverityAtLeast(severity, min string) bool {
+	return findingSeverityRank[severity] >= findingSeverityRank[min]
+}
+
+// ValidSeverity reports whether s is one of the severity levels
+func ValidSeverity(s string) bool {
+	return findingSeverityRank[s] > 0
+}
+
+// SecurityFinding is a security posture problem of a workload
+type SecurityFinding struct {
+	Namespace string `json:"namespace"`
+	// Workload is "<Kind>/<name>", e.g. Deployment/web
+	Workload  string `json:"workload"`
+	Container string `json:"container,omitempty"`
+	Severity  string `json:"severity"`
+	Check     string `json:"check"`
+	Message   string `json:"message"`
+}
+
+// workload is a pod-creating object with its pod template
+type workload struct {
+	Kind     string     `json:"kind"`
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Template    PodTemplateSpec `json:"template"`
+		JobTemplate struct {
+			Spec struct {
+				Template PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		} `json:"jobTemplate"`
+	} `json:"spec"`
+}
+
+// podSpec returns the workload's pod template spec; CronJobs nest it in their job template
+func (w workload) podSpec() PodSpec {
+	if w.Kind == "CronJob" {
+		return w.Spec.JobTemplate.Spec.Template.Spec
+	}
+	return w.Spec.Template.Spec
+}
+
+// serviceAccount is the subset of a ServiceAccount the token check uses
+type serviceAccount struct {
+	Metadata                     ObjectMeta `json:"metadata"`
+	AutomountServiceAccountToken *bool      `json:"automountServiceAccountToken"`
+}
+
+// ScanWorkloadSecurity checks the Deployments, StatefulSets, DaemonSets and CronJobs in
+// application namespaces, or in the namespace set with SetNamespaceScope, for containers
+// that are privileged, run as root, have no resource limits or use :latest images, and for
+// pods that mount the default service account's token. Findings are sorted by namespace,
+// workload and severity.
+func ScanWorkloadSecurity() ([]SecurityFinding, error) {
+	var list struct {
+		Items []workload `json:"items"`
+	}
+	args := append([]string{"get", "deployments,statefulsets,daemonsets,cronjobs"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var accounts struct {
+		Items []serviceAccount `json:"items"`
+	}
+	args = append([]string{"get", "serviceaccounts"}, namespaceArgs()...)
+	if err := KubectlJSON(&accounts, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+	// Default service accounts that disable token mounting themselves
+	noDefaultToken := make(map[string]bool)
+	for _, sa := range accounts.Items {
+		if sa.Metadata.Name == "default" && sa.AutomountServiceAccountToken != nil && !*sa.AutomountServiceAccountToken {
+			noDefaultToken[sa.Metadata.Namespace] = true
+		}
+	}
+
+	var findings []SecurityFinding
+	for _, w := range list.Items {
+		if isSystemNamespace(w.Metadata.Namespace) {
+			continue
+		}
+		name := w.Kind + "/" + w.Metadata.Name
+		spec := w.podSpec()
+		add := func(container, severity, check, message string) {
+			findings = append(findings, SecurityFinding{
+				Namespace: w.Metadata.Namespace, Workload: name, Container: container,
+				Severity: severity, Check: check, Message: message,
+			})
+		}
+
+		for _, c := range append(append([]Container{}, spec.InitContainers...), spec.Containers...) {
+			if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+				add(c.Name, SeverityHigh, "privileged", "runs privileged, with full access to the node")
+			}
+			if severity, message, ok := runsAsRoot(spec.SecurityContext, c.SecurityContext); ok {
+				add(c.Name, severity, "root", message)
+			}
+			if c.Resources.Limits["memory"] == "" && c.Resources.Limits["cpu"] == "" {
+				add(c.Name, SeverityMedium, "limits", "has no resource limits")
+			} else if c.Resources.Limits["memory"] == "" {
+				add(c.Name, SeverityMedium, "limits", "has no memory limit")
+			}
+			if message, ok := floatingImageTag(c.Image); ok {
+				add(c.Name, SeverityMedium, "latest", message)
+			}
+		}
+
+		account := spec.ServiceAccountName
+		if account == "" {
+			account = "default"
+		}
+		mounted := !noDefaultToken[w.Metadata.Namespace]
+		if spec.AutomountServiceAccountToken != nil {
+			mounted = *spec.AutomountServiceAccountToken
+		}
+		if account == "default" && mounted {
+			add("", SeverityLow, "token", "mounts the default service account token; set automountServiceAccountToken: false unless it calls the Kubernetes API")
+		}
+	}
+
+	sort.SliceStable(findings, func(i, k int) bool {
+		a, b := findings[i], findings[k]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Workload != b.Workload {
+			return a.Workload < b.Workload
+		}
+		return findingSeverityRank[a.Severity] > findingSeverityRank[b.Severity]
+	})
+	return findings, nil
+}
+
+// runsAsRoot checks the effective security context of a container, whose settings
+// override the pod's. Running as UID 0 explicitly is high severity; not requiring a
+// non-root user is medium, as the image's default user may be root.
+func runsAsRoot(pod, container *SecurityContext) (severity, message string, ok bool) {
+	var runAsUser *int64
+	var runAsNonRoot *bool
+	for _, sc := range []*SecurityContext{pod, container} {
+		if sc == nil {
+			continue
+		}
+		if sc.RunAsUser != nil {
+			runAsUser = sc.RunAsUser
+		}
+		if sc.RunAsNonRoot != nil {
+			runAsNonRoot = sc.RunAsNonRoot
+		}
+	}
+
+	switch {
+	case runAsUser != nil && *runAsUser == 0:
+		return SeverityHigh, "runs as root (runAsUser: 0)", true
+	case runAsUser != nil || (runAsNonRoot != nil && *runAsNonRoot):
+		return "", "", false
+	default:
+		return SeverityMedium, "may run as root: sets neither runAsNonRoot nor a non-zero runAsUser", true
+	}
+}
+
+// floatingImageTag reports images that use :latest or no tag (which means latest), unless
+// pinned by digest
+func floatingImageTag(image string) (message string, ok bool) {
+	if strings.Contains(image, "@") {
+		return "", false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, tagged := strings.Cut(name, ":")
+	switch {
+	case !tagged:
+		return fmt.Sprintf("uses the untagged image %s, which means :latest", image), true
+	case tag == "latest":
+		return fmt.Sprintf("uses the :latest image %s", image), true
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetApplicationServices returns the Services with a selector in application namespaces,
+// or in the namespace set with SetNamespaceScope
+func GetApplicationServices() ([]Service, error) {
+	var list ServiceList
+	args := append([]string{"get", "services"}, namespaceArgs()...)
+	if err := KubectlJSON(&list, append(args, "-o", "json")...); err != nil {
+		return nil, err
+	}
+
+	var services []Service
+	for _, svc := range list.Items {
+		if !isSystemNamespace(svc.Metadata.Namespace) && len(svc.Spec.Selector) > 0 {
+			services = append(services, svc)
+		}
+	}
+	return services, nil
+}
+
+// FindService looks up a Service given as "namespace/name" or a bare name, which must be
+// unique across application namespaces
+func FindService(target string) (*Service, error) {
+	namespace, name, qualified := strings.Cut(target, "/")
+	args := []string{"get", "services"}
+	if qualified {
+		args = append(args, "-n", namespace)
+	} else {
+		name = target
+		args = append(args, namespaceArgs()...)
+	}
+
+	var list ServiceList
+	if err := KubectlJSON(&list, append(args, "--field-selector", "metadata.name="+name, "-o", "json")...); err != nil {
+		return nil, err
+	}
+	var matches []Service
+	for _, svc := range list.Items {
+		if !isSystemNamespace(svc.Metadata.Namespace) {
+			matches = append(matches, svc)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("service %s not found", target)
+	case 1:
+		return &matches[0], nil
+	default:
+		var names []string
+		for _, svc := range matches {
+			names = append(names, svc.Metadata.Namespace+"/"+svc.Metadata.Name)
+		}
+		return nil, fmt.Errorf("%s matches several services (%s), use namespace/name", target, strings.Join(names, ", "))
+	}
+}
+
+// SelectService prompts the user to pick one of the services
+func SelectService(services []Service) (*Service, error) {
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no services available")
+	}
+
+	fmt.Printf("📋 Found %d service(s):\n", len(services))
+	fmt.Println()
+
+	for i, svc := range services {
+		var ports []string
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, strconv.Itoa(p.Port))
+		}
+		fmt.Printf("%d. %s/%s (port %s)\n", i+1, svc.Metadata.Namespace, svc.Metadata.Name, strings.Join(ports, ", "))
+	}
+
+	fmt.Println()
+	fmt.Print("Select service (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(services) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &services[num-1], nil
+}
+
+// PodSelector is the Service's selector as a kubectl label selector ("app=web,tier=api")
+func (s Service) PodSelector() string {
+	var selector []string
+	for k, v := range s.Spec.Selector {
+		selector = append(selector, k+"="+v)
+	}
+	sort.Strings(selector)
+	return strings.Join(selector, ",")
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// KnownShells are the shells looked for, in order of preference, when none is configured
+var KnownShells = []string{"/bin/bash", "/bin/zsh", "/bin/ash", "/bin/sh", "/busybox/sh"}
+
+// sidecarContainers are name fragments of well-known proxy and agent containers, which are
+// only used for a shell when nothing else has one
+var sidecarContainers = []string{
+	"istio-proxy", "linkerd-proxy", "cloud-sql-proxy", "cloudsql-proxy", "envoy",
+	"datadog", "fluent-bit", "fluentd", "otel-collector", "vault-agent", "oauth2-proxy",
+}
+
+// probeInterpreters run the probe script; distroless debug images only have busybox
+var probeInterpreters = []string{"sh", "/busybox/sh"}
+
+// probeScript prints each of its arguments that is an executable file, looking up bare
+// names such as "bash" on the container's PATH
+const probeScript = `for s in "$@"; do
+  case "$s" in
+    */*) [ -x "$s" ] && echo "$s" ;;
+    *) p=$(command -v "$s" 2>/dev/null) && [ -x "$p" ] && echo "$s" ;;
+  esac
+done
+exit 0`
+
+// IsSidecarContainer reports whether a container name looks like a proxy or agent sidecar
+func IsSidecarContainer(name string) bool {
+	for _, sidecar := range sidecarContainers {
+		if strings.Contains(name, sidecar) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShellContainers returns the pod's containers in the order to look for a shell: the
+// default container, the other application containers, then sidecars
+func ShellContainers(p *Pod) []Container {
+	var containers, sidecars []Container
+	defaultContainer, hasDefault := p.DefaultContainer()
+	if hasDefault {
+		containers = append(containers, defaultContainer)
+	}
+	for _, c := range p.Spec.Containers {
+		switch {
+		case hasDefault && c.Name == defaultContainer.Name:
+		case IsSidecarContainer(c.Name):
+			sidecars = append(sidecars, c)
+		default:
+			containers = append(containers, c)
+		}
+	}
+	return append(containers, sidecars...)
+}
+
+// ProbeShells checks which of shells exist in a container of a pod ("namespace/pod") with a
+// single non-interactive exec, returning the available ones in the given order. Containers
+// without a shell to run the probe, e.g. distroless images, return none.
+func ProbeShells(pod, container string, shells []string) ([]string, error) {
+	output, err := probeExec(pod, container, probeScript, shells...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// probeExec runs script with args in a container, non-interactively, and returns its
+// output. It returns "" without an error when the container has no shell to run it.
+func probeExec(pod, container, script string, args ...string) (string, error) {
+	namespace, podName, ok := strings.Cut(pod, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid pod format: %s", pod)
+	}
+
+	for _, interpreter := range probeInterpreters {
+		execArgs := []string{"exec", podName, "-n", namespace}
+		if container != "" {
+			execArgs = append(execArgs, "-c", container)
+		}
+		execArgs = append(execArgs, "--", interpreter, "-c", script, "sh")
+		execArgs = append(execArgs, args...)
+
+		cmd := exec.Command("kubectl", execArgs...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			if isMissingExecutable(stderr.String()) {
+				continue
+			}
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("kubectl exec: %s", msg)
+			}
+			return "", fmt.Errorf("kubectl exec: %w", err)
+		}
+		return string(output), nil
+	}
+	return "", nil
+}
+
+// isMissingExecutable reports whether kubectl exec failed because the command doesn't
+// exist in the container
+func isMissingExecutable(stderr string) bool {
+	for _, msg := range []string{"executable file not found", "no such file or directory", "exit code 127"} {
+		if strings.Contains(stderr, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// DebugContainerImage is the default image of ephemeral debug containers; busybox has a
+// shell and the usual tools
+const DebugContainerImage = "busybox"
+
+// DebugContainerShell adds an ephemeral debug container to a pod ("namespace/pod") with
+// kubectl debug and attaches an interactive shell to it. The container shares the process
+// namespace of target, so its processes are visible and its filesystem is reachable under
+// /proc/<pid>/root. Ephemeral containers can't be removed; it stops when the shell exits.
+func DebugContainerShell(pod, target, image string, env []string) error {
+	namespace, name, _ := strings.Cut(pod, "/")
+	args := []string{"debug", name, "-n", namespace, "-it", "--image", image, "--target", target,
+		"--profile", "general", "--container", fmt.Sprintf("gcpeasy-debug-%d", time.Now().Unix())}
+	for _, kv := range env {
+		args = append(args, "--env", kv)
+	}
+	args = append(args, "--", "sh")
+
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// SmokeConfig is an environment's synthetic HTTP checks, run against each of its URLs
+type SmokeConfig struct {
+	URLs []string `yaml:"urls"`
+	// Timeout bounds each request, in the ParseDuration format (default 10s)
+	Timeout string       `yaml:"timeout"`
+	Checks  []SmokeCheck `yaml:"checks"`
+}
+
+// SmokeCheck is an HTTP request and what its response must look like. Status defaults to
+// any 2xx; Body is a substring the response body must contain. $VAR and ${VAR} in header
+// values are expanded from the environment, so tokens stay out of the config.
+type SmokeCheck struct {
+	Name    string            `yaml:"name"`
+	Method  string            `yaml:"method"`
+	Path    string            `yaml:"path"`
+	Headers map[string]string `yaml:"headers"`
+	Data    string            `yaml:"data"`
+	Status  int               `yaml:"status"`
+	Body    string            `yaml:"body"`
+}
+
+// Label is the check's name, or its method and path
+func (c SmokeCheck) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	method := c.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return strings.ToUpper(method) + " " + c.Path
+}
+
+// SmokeResult is the outcome of a check against one URL
+type SmokeResult struct {
+	Check    SmokeCheck
+	URL      string
+	Status   int
+	Duration time.Duration
+	Err      error
+}
+
+// SmokeClient returns the HTTP client for the environment's checks
+func SmokeClient(cfg SmokeConfig) (*http.Client, error) {
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		d, err := ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid smoke timeout: %w", err)
+		}
+		timeout = d
+	}
+	return &http.Client{Timeout: timeout}, nil
+}
+
+// RunSmokeCheck sends a check's request to baseURL and compares the response with it
+func RunSmokeCheck(client *http.Client, baseURL string, c SmokeCheck) SmokeResult {
+	url := strings.TrimSuffix(baseURL, "/")
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		url += "/"
+	}
+	url += c.Path
+	result := SmokeResult{Check: c, URL: url}
+
+	method := c.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if c.Data != "" {
+		body = strings.NewReader(c.Data)
+	}
+	req, err := http.NewRequest(strings.ToUpper(method), url, body)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Duration = time.Since(start)
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	result.Duration = time.Since(start)
+	result.Status = resp.StatusCode
+	if err != nil {
+		result.Err = fmt.Errorf("failed to read the response: %w", err)
+		return result
+	}
+
+	switch {
+	case c.Status != 0 && resp.StatusCode != c.Status:
+		result.Err = fmt.Errorf("expected status %d", c.Status)
+	case c.Status == 0 && (resp.StatusCode < 200 || resp.StatusCode >= 300):
+		result.Err = fmt.Errorf("expected a 2xx status")
+	case c.Body != "" && !strings.Contains(string(data), c.Body):
+		result.Err = fmt.Errorf("response doesn't contain %q", c.Body)
+	}
+	return result
+}
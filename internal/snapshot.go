@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotKinds are the resource types exported by CreateSnapshot
+var SnapshotKinds = []string{"deployments", "services", "configmaps", "ingresses", "secrets"}
+
+var snapshotKindDirs = map[string]string{
+	"Deployment": "deployments",
+	"Service":    "services",
+	"ConfigMap":  "configmaps",
+	"Ingress":    "ingresses",
+	"Secret":     "secrets",
+}
+
+// skipped objects that exist in every cluster and only add noise
+var snapshotIgnored = map[string]bool{
+	"configmaps/kube-root-ca.crt": true,
+	"services/kubernetes":         true,
+}
+
+type genericList struct {
+	Items []map[string]interface{} `json:"items"`
+}
+
+// CreateSnapshot exports the live manifests of application namespaces into dir,
+// one file per object at <namespace>/<kind>/<name>.yaml. Secret values are redacted.
+func CreateSnapshot(dir string) (int, error) {
+	var list genericList
+	if err := KubectlJSON(&list, "get", strings.Join(SnapshotKinds, ","), "--all-namespaces", "-o", "json"); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, obj := range list.Items {
+		meta, _ := obj["metadata"].(map[string]interface{})
+		namespace, _ := meta["namespace"].(string)
+		name, _ := meta["name"].(string)
+		kind, ok := snapshotKindDirs[fmt.Sprint(obj["kind"])]
+		if !ok {
+			continue
+		}
+
+		if isSystemNamespace(namespace) || snapshotIgnored[kind+"/"+name] {
+			continue
+		}
+
+		SanitizeManifest(obj)
+		if kind == "secrets" {
+			redactSecret(obj)
+		}
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return count, fmt.Errorf("failed to encode %s/%s: %w", kind, name, err)
+		}
+
+		path := filepath.Join(dir, namespace, kind, name+".yaml")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return count, err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// SanitizeManifest strips server-populated fields so manifests are comparable over time
+func SanitizeManifest(obj map[string]interface{}) {
+	delete(obj, "status")
+	meta, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"} {
+		delete(meta, field)
+	}
+	if annotations, ok := meta["annotations"].(map[string]interface{}); ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		delete(annotations, "deployment.kubernetes.io/revision")
+		if len(annotations) == 0 {
+			delete(meta, "annotations")
+		}
+	}
+}
+
+// redactedValue replaces every secret value in a snapshot. A hash of the value would let
+// anyone with the snapshot confirm guesses of short secrets offline, so only the keys are kept.
+const redactedValue = "<redacted>"
+
+// redactSecret replaces secret values with a fixed marker, so diffs report added and
+// removed keys but never anything derived from the values
+func redactSecret(obj map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := obj[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k := range data {
+			data[k] = redactedValue
+		}
+	}
+}
+
+// LoadSnapshot reads every manifest in a snapshot directory keyed by its relative path
+func LoadSnapshot(dir string) (map[string]map[string]interface{}, error) {
+	objects := make(map[string]map[string]interface{})
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		objects[strings.TrimSuffix(filepath.ToSlash(rel), ".yaml")] = obj
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// SnapshotDiff is the result of comparing two snapshots
+type SnapshotDiff struct {
+	Added    []string
+	Removed  []string
+	Modified map[string][]FieldChange
+}
+
+// DiffSnapshots compares two loaded snapshots
+func DiffSnapshots(a, b map[string]map[string]interface{}) SnapshotDiff {
+	diff := SnapshotDiff{Modified: make(map[string][]FieldChange)}
+
+	for key, objA := range a {
+		objB, ok := b[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, key)
+			continue
+		}
+		if changes := DiffFields(objA, objB); len(changes) > 0 {
+			diff.Modified[key] = changes
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			diff.Added = append(diff.Added, key)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
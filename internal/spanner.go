@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// SpannerInstance is a Cloud Spanner instance
+type SpannerInstance struct {
+	// Name is the full resource name: projects/<project>/instances/<instance>
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Config      string `json:"config"`
+	State       string `json:"state"`
+}
+
+// ID is the instance's short name
+func (i SpannerInstance) ID() string {
+	return i.Name[strings.LastIndex(i.Name, "/")+1:]
+}
+
+// SpannerDatabase is a database in a Cloud Spanner instance
+type SpannerDatabase struct {
+	// Name is the full resource name: projects/<project>/instances/<instance>/databases/<database>
+	Name            string `json:"name"`
+	State           string `json:"state"`
+	DatabaseDialect string `json:"databaseDialect"`
+}
+
+// ID is the database's short name
+func (d SpannerDatabase) ID() string {
+	return d.Name[strings.LastIndex(d.Name, "/")+1:]
+}
+
+// GetSpannerInstances returns the Cloud Spanner instances in the project
+func GetSpannerInstances(projectID string) ([]SpannerInstance, error) {
+	var instances []SpannerInstance
+	if err := GcloudJSON(&instances, "spanner", "instances", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// GetSpannerDatabases returns the databases of a Cloud Spanner instance
+func GetSpannerDatabases(projectID, instance string) ([]SpannerDatabase, error) {
+	var databases []SpannerDatabase
+	if err := GcloudJSON(&databases, "spanner", "databases", "list", "--instance", instance, "--project", projectID); err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// SelectSpannerInstance prompts the user to pick one of the instances
+func SelectSpannerInstance(instances []SpannerInstance) (*SpannerInstance, error) {
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no Spanner instances available")
+	}
+
+	fmt.Printf("📋 Found %d Spanner instance(s):\n", len(instances))
+	fmt.Println()
+
+	for i, instance := range instances {
+		fmt.Printf("%d. %s (%s, %s)\n", i+1, instance.ID(), instance.DisplayName, instance.State)
+	}
+
+	fmt.Println()
+	fmt.Print("Select instance (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(instances) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &instances[num-1], nil
+}
+
+// SelectSpannerDatabase prompts the user to pick one of the databases
+func SelectSpannerDatabase(databases []SpannerDatabase) (*SpannerDatabase, error) {
+	if len(databases) == 0 {
+		return nil, fmt.Errorf("no databases available")
+	}
+
+	fmt.Printf("📋 Found %d database(s):\n", len(databases))
+	fmt.Println()
+
+	for i, database := range databases {
+		fmt.Printf("%d. %s (%s)\n", i+1, database.ID(), database.State)
+	}
+
+	fmt.Println()
+	fmt.Print("Select database (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(databases) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &databases[num-1], nil
+}
+
+// IsReadOnlySQL reports whether a statement only reads data, judged by its first keyword
+func IsReadOnlySQL(sql string) bool {
+	fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(sql), "("))
+	if len(fields) == 0 {
+		return true
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH", "SHOW", "EXPLAIN", "DESCRIBE":
+		return true
+	}
+	return false
+}
+
+// SpannerQuery runs a SQL statement against a Spanner database, writing the result to out
+// as a table, or as JSON when asJSON is set
+func SpannerQuery(projectID, instance, database, sql string, asJSON bool, out io.Writer) error {
+	args := []string{"spanner", "databases", "execute-sql", database,
+		"--instance", instance, "--project", projectID, "--sql", sql}
+	if asJSON {
+		args = append(args, "--format=json")
+	}
+	cmd := exec.Command("gcloud", args...)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timeline sources
+const (
+	TimelineDeploy  = "deploy"
+	TimelineRestart = "restart"
+	TimelineNode    = "node"
+	TimelineAudit   = "audit"
+	TimelineAlert   = "alert"
+)
+
+// TimelineSources lists the sources in the order they are gathered
+var TimelineSources = []string{TimelineDeploy, TimelineRestart, TimelineNode, TimelineAudit, TimelineAlert}
+
+// TimelineEvent is something that happened in an environment
+type TimelineEvent struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`
+	Subject string    `json:"subject"`
+	Detail  string    `json:"detail"`
+}
+
+// SortTimeline orders events oldest first
+func SortTimeline(events []TimelineEvent) {
+	sort.SliceStable(events, func(i, k int) bool { return events[i].Time.Before(events[k].Time) })
+}
+
+// RolloutTimeline returns the deployment rollouts of the last since
+func RolloutTimeline(since time.Duration) ([]TimelineEvent, error) {
+	rollouts, err := GetRollouts(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []TimelineEvent
+	for _, r := range rollouts {
+		detail := "rolled out revision " + r.Revision
+		if r.Revision == "" {
+			detail = "rolled out"
+		}
+		if r.ChangeCause != "" {
+			detail += " (" + r.ChangeCause + ")"
+		}
+		events = append(events, TimelineEvent{Time: r.Time, Source: TimelineDeploy, Subject: r.Namespace + "/" + r.Deployment, Detail: detail})
+	}
+	return events, nil
+}
+
+// RestartTimeline returns the last container restart of each application pod within
+// since; Kubernetes only keeps the last termination of a container
+func RestartTimeline(since time.Duration) ([]TimelineEvent, error) {
+	pods, err := GetApplicationPods()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	var events []TimelineEvent
+	for _, p := range pods {
+		for _, c := range p.Status.ContainerStatuses {
+			t := c.LastState.Terminated
+			if t == nil || c.RestartCount == 0 {
+				continue
+			}
+			finished, err := time.Parse(time.RFC3339, t.FinishedAt)
+			if err != nil || finished.Before(cutoff) {
+				continue
+			}
+			events = append(events, TimelineEvent{
+				Time:    finished,
+				Source:  TimelineRestart,
+				Subject: fmt.Sprintf("%s/%s (%s)", p.Metadata.Namespace, p.Metadata.Name, c.Name),
+				Detail:  fmt.Sprintf("%s, exit code %d (%d restart(s) in total)", orUnknown(t.Reason), t.ExitCode, c.RestartCount),
+			})
+		}
+	}
+	return events, nil
+}
+
+// NodeTimeline returns the events of nodes within since, such as NodeNotReady or Rebooted
+func NodeTimeline(since time.Duration) ([]TimelineEvent, error) {
+	var list KubeEventList
+	if err := KubectlJSON(&list, "get", "events", "--all-namespaces", "--field-selector", "involvedObject.kind=Node", "-o", "json"); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-since)
+	var events []TimelineEvent
+	for _, e := range list.Items {
+		if e.Time().Before(cutoff) {
+			continue
+		}
+		detail := e.Reason
+		if e.Message != "" {
+			detail += ": " + e.Message
+		}
+		if e.Count > 1 {
+			detail += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		events = append(events, TimelineEvent{Time: e.Time(), Source: TimelineNode, Subject: e.InvolvedObject.Name, Detail: detail})
+	}
+	return events, nil
+}
+
+// AuditTimeline returns the Admin Activity audit log entries of the last since made by
+// people and service accounts, leaving out Kubernetes system components and Google
+// service agents
+func AuditTimeline(projectID string, since time.Duration, limit int) ([]TimelineEvent, error) {
+	filter := strings.Join([]string{
+		fmt.Sprintf(`logName="projects/%s/logs/cloudaudit.googleapis.com%%2Factivity"`, projectID),
+		fmt.Sprintf(`timestamp>=%q`, time.Now().Add(-since).UTC().Format(time.RFC3339)),
+		`NOT protoPayload.authenticationInfo.principalEmail:"system:"`,
+	}, " AND ")
+
+	var raw []struct {
+		Timestamp    string `json:"timestamp"`
+		ProtoPayload struct {
+			MethodName         string `json:"methodName"`
+			ResourceName       string `json:"resourceName"`
+			AuthenticationInfo struct {
+				PrincipalEmail string `json:"principalEmail"`
+			} `json:"authenticationInfo"`
+			Status struct {
+				Code int `json:"code"`
+			} `json:"status"`
+		} `json:"protoPayload"`
+	}
+	if err := GcloudJSON(&raw, "logging", "read", filter, "--project", projectID, "--limit", strconv.Itoa(limit)); err != nil {
+		return nil, err
+	}
+
+	var events []TimelineEvent
+	for _, r := range raw {
+		principal := r.ProtoPayload.AuthenticationInfo.PrincipalEmail
+		if isServiceAgent(principal) {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, r.Timestamp)
+		if err != nil {
+			continue
+		}
+		detail := r.ProtoPayload.MethodName + " " + r.ProtoPayload.ResourceName
+		if r.ProtoPayload.Status.Code != 0 {
+			detail += fmt.Sprintf(" (failed, code %d)", r.ProtoPayload.Status.Code)
+		}
+		events = append(events, TimelineEvent{Time: t, Source: TimelineAudit, Subject: orUnknown(principal), Detail: detail})
+	}
+	return events, nil
+}
+
+// isServiceAgent reports whether a principal is a Google-managed service agent, such as
+// service-123@container-engine-robot.iam.gserviceaccount.com
+func isServiceAgent(principal string) bool {
+	return strings.HasPrefix(principal, "service-") && strings.HasSuffix(principal, ".gserviceaccount.com")
+}
+
+// AlertTimeline returns the Cloud Monitoring alerts opened in the last since, and when
+// they closed
+func AlertTimeline(projectID string, since time.Duration) ([]TimelineEvent, error) {
+	alerts, err := GetAlertsSince(projectID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []TimelineEvent
+	for _, a := range alerts {
+		subject := a.Policy.DisplayName
+		if a.Policy.Severity != "" && a.Policy.Severity != "SEVERITY_UNSPECIFIED" {
+			subject += " [" + a.Policy.Severity + "]"
+		}
+		events = append(events, TimelineEvent{Time: a.OpenTime, Source: TimelineAlert, Subject: subject, Detail: "fired"})
+		if !a.CloseTime.IsZero() {
+			events = append(events, TimelineEvent{
+				Time:    a.CloseTime,
+				Source:  TimelineAlert,
+				Subject: subject,
+				Detail:  "resolved after " + FormatDuration(a.CloseTime.Sub(a.OpenTime)),
+			})
+		}
+	}
+	return events, nil
+}
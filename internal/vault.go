@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// VaultService is the service name vault entries are stored under in the OS keychain
+const VaultService = "gcpeasy"
+
+// VaultPrefix marks a config value that is read from the vault, e.g. "vault:slack-webhook"
+const VaultPrefix = "vault:"
+
+// ErrVaultNotFound is returned when a vault entry doesn't exist
+var ErrVaultNotFound = errors.New("not found in the vault")
+
+var vaultNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateVaultName checks a vault entry name is usable as a keychain account
+func ValidateVaultName(name string) error {
+	if !vaultNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// VaultSet stores a value in the OS keychain: the macOS login keychain, or the Secret
+// Service (GNOME Keyring, KWallet) through secret-tool on Linux
+func VaultSet(name, value string) error {
+	if err := ValidateVaultName(name); err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// The command is written to security's interactive mode so the value never appears
+		// in argv, where other local processes could read it; -X takes it hex-encoded, so
+		// it needs no quoting
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -l \"%s: %s\" -X %s\n",
+			VaultService, name, VaultService, name, hex.EncodeToString([]byte(value))))
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label", VaultService+": "+name, "service", VaultService, "account", name)
+		cmd.Stdin = strings.NewReader(value)
+	default:
+		return errVaultUnsupported()
+	}
+	if err := runVault(cmd); err != nil {
+		return err
+	}
+	if runtime.GOOS == "darwin" {
+		// security -i keeps going after a failed command, so its exit status can't be trusted
+		if stored, err := VaultGet(name); err != nil || stored != value {
+			return fmt.Errorf("failed to store %s in the keychain", name)
+		}
+	}
+	return updateVaultIndex(name, true)
+}
+
+// VaultGet reads a value from the OS keychain
+func VaultGet(name string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", VaultService, "-a", name, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", VaultService, "account", name)
+	default:
+		return "", errVaultUnsupported()
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := runVault(cmd); err != nil {
+		if _, ok := err.(*exec.ExitError); ok || strings.Contains(err.Error(), "could not be found") {
+			return "", fmt.Errorf("%s: %w", name, ErrVaultNotFound)
+		}
+		return "", err
+	}
+	// security adds a newline; secret-tool returns the value as stored
+	value := stdout.String()
+	if runtime.GOOS == "darwin" {
+		value = strings.TrimSuffix(value, "\n")
+	}
+	return value, nil
+}
+
+// VaultDelete removes a value from the OS keychain
+func VaultDelete(name string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", VaultService, "-a", name)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", VaultService, "account", name)
+	default:
+		return errVaultUnsupported()
+	}
+	if err := runVault(cmd); err != nil {
+		if _, ok := err.(*exec.ExitError); ok || strings.Contains(err.Error(), "could not be found") {
+			return fmt.Errorf("%s: %w", name, ErrVaultNotFound)
+		}
+		return err
+	}
+	return updateVaultIndex(name, false)
+}
+
+// ResolveSecret returns a config value, reading it from the vault when it's a
+// "vault:<name>" reference
+func ResolveSecret(value string) (string, error) {
+	name, ok := strings.CutPrefix(value, VaultPrefix)
+	if !ok {
+		return value, nil
+	}
+	return VaultGet(name)
+}
+
+func errVaultUnsupported() error {
+	return fmt.Errorf("the vault is not supported on %s (needs the macOS keychain or secret-tool on Linux)", runtime.GOOS)
+}
+
+// runVault runs a keychain command. A missing binary is explained, and a failure carries
+// stderr unless the command simply exited non-zero without a message (e.g. secret-tool
+// lookup of a missing entry), in which case the *exec.ExitError is returned.
+func runVault(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		if cmd.Args[0] == "secret-tool" {
+			return fmt.Errorf("secret-tool not found; install libsecret-tools (Debian/Ubuntu) or libsecret (Fedora/Arch)")
+		}
+		return err
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// The keychains can't list entries portably, so the names (never the values) are kept
+// in vault.json in the config directory
+
+func vaultIndexPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vault.json"), nil
+}
+
+// VaultNames returns the names of the entries stored with VaultSet, sorted
+func VaultNames() ([]string, error) {
+	path, err := vaultIndexPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func updateVaultIndex(name string, present bool) error {
+	names, err := VaultNames()
+	if err != nil {
+		return err
+	}
+	var updated []string
+	for _, n := range names {
+		if n != name {
+			updated = append(updated, n)
+		}
+	}
+	if present {
+		updated = append(updated, name)
+	}
+	sort.Strings(updated)
+
+	path, err := vaultIndexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// VMInfo describes a Compute Engine VM instance
+type VMInfo struct {
+	Name            string
+	Zone            string
+	MachineType     string
+	Status          string
+	InternalIP      string
+	ExternalIP      string
+	Network         string
+	Tags            []string
+	ServiceAccounts []string
+	Labels          map[string]string
+}
+
+type computeInstance struct {
+	Name        string            `json:"name"`
+	Zone        string            `json:"zone"`
+	MachineType string            `json:"machineType"`
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Tags        struct {
+		Items []string `json:"items"`
+	} `json:"tags"`
+	ServiceAccounts []struct {
+		Email string `json:"email"`
+	} `json:"serviceAccounts"`
+	NetworkInterfaces []struct {
+		Network       string `json:"network"`
+		NetworkIP     string `json:"networkIP"`
+		AccessConfigs []struct {
+			NatIP string `json:"natIP"`
+		} `json:"accessConfigs"`
+	} `json:"networkInterfaces"`
+}
+
+// GetVMs returns all Compute Engine instances in the project
+func GetVMs(projectID string) ([]VMInfo, error) {
+	var instances []computeInstance
+	if err := GcloudJSON(&instances, "compute", "instances", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	vms := make([]VMInfo, 0, len(instances))
+	for _, i := range instances {
+		vm := VMInfo{
+			Name:        i.Name,
+			Zone:        path.Base(i.Zone),
+			MachineType: path.Base(i.MachineType),
+			Status:      i.Status,
+			Tags:        i.Tags.Items,
+			Labels:      i.Labels,
+		}
+		for _, sa := range i.ServiceAccounts {
+			vm.ServiceAccounts = append(vm.ServiceAccounts, sa.Email)
+		}
+		if len(i.NetworkInterfaces) > 0 {
+			nic := i.NetworkInterfaces[0]
+			vm.Network = path.Base(nic.Network)
+			vm.InternalIP = nic.NetworkIP
+			if len(nic.AccessConfigs) > 0 {
+				vm.ExternalIP = nic.AccessConfigs[0].NatIP
+			}
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
+
+// FindVM returns the VM with the given name
+func FindVM(vms []VMInfo, name string) (*VMInfo, bool) {
+	for i := range vms {
+		if vms[i].Name == name {
+			return &vms[i], true
+		}
+	}
+	return nil, false
+}
+
+// SelectVM prompts user to select a VM from the list
+func SelectVM(vms []VMInfo) (*VMInfo, error) {
+	if len(vms) == 0 {
+		return nil, fmt.Errorf("no VMs available")
+	}
+
+	fmt.Printf("📋 Found %d VM(s):\n", len(vms))
+	fmt.Println()
+
+	for i, vm := range vms {
+		fmt.Printf("%d. %s (%s, %s)\n", i+1, vm.Name, vm.Zone, vm.Status)
+	}
+
+	fmt.Println()
+	fmt.Print("Select VM (number, or 'q' to quit): ")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read input")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+
+	// Check for quit command
+	if input == "q" {
+		return nil, fmt.Errorf("cancelled by user")
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(vms) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+
+	return &vms[num-1], nil
+}
+
+// SetVMState starts or stops a VM ("start" or "stop")
+func SetVMState(projectID string, vm VMInfo, action string) error {
+	cmd := exec.Command("gcloud", "compute", "instances", action, vm.Name, "--zone", vm.Zone, "--project", projectID)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to %s VM %s: %w", action, vm.Name, err)
+	}
+	return nil
+}
+
+// SSHToVM opens an interactive SSH session (or runs a remote command) through an IAP tunnel
+func SSHToVM(projectID string, vm VMInfo, remote []string) error {
+	args := []string{"compute", "ssh", vm.Name, "--zone", vm.Zone, "--project", projectID, "--tunnel-through-iap"}
+	if len(remote) > 0 {
+		args = append(args, "--command", strings.Join(remote, " "))
+	}
+
+	cmd := exec.Command("gcloud", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// TunnelThroughVM forwards localPort to host:port as seen from a VM, over SSH through an
+// IAP tunnel, until interrupted
+func TunnelThroughVM(projectID string, vm VMInfo, localPort int, host string, port int) error {
+	forward := fmt.Sprintf("127.0.0.1:%d:%s:%d", localPort, host, port)
+	args := []string{"compute", "ssh", vm.Name, "--zone", vm.Zone, "--project", projectID, "--tunnel-through-iap",
+		"--", "-N", "-L", forward, "-o", "ExitOnForwardFailure=yes", "-o", "ServerAliveInterval=30"}
+
+	cmd := exec.Command("gcloud", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,281 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Approximate us-central1 list prices per month
+var (
+	// diskPricePerGB is the price per provisioned GB of persistent disk, by disk type
+	diskPricePerGB = map[string]float64{
+		"pd-standard":        0.040,
+		"pd-balanced":        0.100,
+		"pd-ssd":             0.170,
+		"pd-extreme":         0.125,
+		"hyperdisk-balanced": 0.080,
+	}
+	// staticIPPrice is the price of a reserved external IP address that isn't in use
+	staticIPPrice = 0.010 * HoursPerMonth
+	// storagePricePerGB is the price of Cloud Storage Standard class storage
+	storagePricePerGB = 0.020
+)
+
+// UnusedResource is a GCP resource that costs money without being used
+type UnusedResource struct {
+	// Kind is "disk", "address", "sql-backup" or "sql-export"
+	Kind     string
+	Name     string
+	Location string
+	// Regional is set for regional disks and addresses, Global for global addresses
+	Regional bool
+	Global   bool
+	Detail   string
+	Age      time.Duration
+	// MonthlyCost is the estimated monthly waste, 0 when unknown
+	MonthlyCost float64
+	// Instance is the Cloud SQL instance of a backup or export
+	Instance string
+	// PVC is the PersistentVolumeClaim ("namespace/name") a GKE-provisioned disk was created
+	// for. The claim may still exist, e.g. for a StatefulSet scaled to zero.
+	PVC string
+}
+
+// diskPVC returns the PersistentVolumeClaim ("namespace/name") a disk was provisioned for,
+// from the JSON the GKE persistent disk provisioner writes to the disk's description
+func diskPVC(description string) string {
+	var created map[string]string
+	if json.Unmarshal([]byte(description), &created) != nil {
+		return ""
+	}
+	name := created["kubernetes.io/created-for/pvc/name"]
+	if name == "" {
+		return ""
+	}
+	return created["kubernetes.io/created-for/pvc/namespace"] + "/" + name
+}
+
+// FindUnusedResources finds unattached persistent disks, reserved external IP addresses
+// that aren't in use, and Cloud SQL on-demand backups and exports to Cloud Storage that
+// are older than olderThan. Cloud SQL problems are reported as warnings, since many
+// projects don't use it.
+func FindUnusedResources(projectID string, olderThan time.Duration) ([]UnusedResource, []string, error) {
+	now := time.Now()
+	age := func(timestamp string) time.Duration {
+		// gcloud storage writes offsets without a colon, e.g. +0000
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.999999999-0700"} {
+			if t, err := time.Parse(layout, timestamp); err == nil {
+				return now.Sub(t)
+			}
+		}
+		return 0
+	}
+
+	var unused []UnusedResource
+	var warnings []string
+
+	var disks []struct {
+		Name                string   `json:"name"`
+		Zone                string   `json:"zone"`
+		Region              string   `json:"region"`
+		SizeGB              string   `json:"sizeGb"`
+		Type                string   `json:"type"`
+		Description         string   `json:"description"`
+		Users               []string `json:"users"`
+		CreationTimestamp   string   `json:"creationTimestamp"`
+		LastDetachTimestamp string   `json:"lastDetachTimestamp"`
+	}
+	if err := GcloudJSON(&disks, "compute", "disks", "list", "--project", projectID); err != nil {
+		return nil, nil, fmt.Errorf("failed to list disks: %w", err)
+	}
+	for _, d := range disks {
+		if len(d.Users) > 0 {
+			continue
+		}
+		size, _ := strconv.ParseFloat(d.SizeGB, 64)
+		diskType := path.Base(d.Type)
+		r := UnusedResource{
+			Kind:        "disk",
+			Name:        d.Name,
+			Location:    path.Base(d.Zone),
+			Detail:      fmt.Sprintf("%s GB %s", d.SizeGB, diskType),
+			Age:         age(d.CreationTimestamp),
+			MonthlyCost: size * diskPricePerGB[diskType],
+			PVC:         diskPVC(d.Description),
+		}
+		if d.LastDetachTimestamp != "" {
+			r.Age = age(d.LastDetachTimestamp)
+		}
+		if d.Zone == "" {
+			r.Location, r.Regional = path.Base(d.Region), true
+			// Regional disks are replicated in two zones
+			r.MonthlyCost *= 2
+		}
+		unused = append(unused, r)
+	}
+
+	var addresses []struct {
+		Name              string `json:"name"`
+		Address           string `json:"address"`
+		AddressType       string `json:"addressType"`
+		Status            string `json:"status"`
+		Region            string `json:"region"`
+		CreationTimestamp string `json:"creationTimestamp"`
+	}
+	if err := GcloudJSON(&addresses, "compute", "addresses", "list", "--project", projectID); err != nil {
+		return nil, nil, fmt.Errorf("failed to list addresses: %w", err)
+	}
+	for _, a := range addresses {
+		// Internal addresses are free; reserved external ones are billed while unused
+		if a.Status != "RESERVED" || a.AddressType == "INTERNAL" {
+			continue
+		}
+		r := UnusedResource{
+			Kind:        "address",
+			Name:        a.Name,
+			Location:    path.Base(a.Region),
+			Regional:    true,
+			Detail:      a.Address,
+			Age:         age(a.CreationTimestamp),
+			MonthlyCost: staticIPPrice,
+		}
+		if a.Region == "" {
+			r.Location, r.Regional, r.Global = "global", false, true
+		}
+		unused = append(unused, r)
+	}
+
+	sqlUnused, err := findUnusedSQLData(projectID, olderThan, age)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Cloud SQL: %v", err))
+	}
+	unused = append(unused, sqlUnused...)
+
+	sort.SliceStable(unused, func(i, j int) bool {
+		if unused[i].Kind != unused[j].Kind {
+			return unused[i].Kind < unused[j].Kind
+		}
+		return unused[i].MonthlyCost > unused[j].MonthlyCost
+	})
+	return unused, warnings, nil
+}
+
+// findUnusedSQLData finds on-demand backups and exports older than olderThan. Automated
+// backups are left to the instance's retention settings.
+func findUnusedSQLData(projectID string, olderThan time.Duration, age func(string) time.Duration) ([]UnusedResource, error) {
+	var instances []struct {
+		Name string `json:"name"`
+	}
+	if err := GcloudJSON(&instances, "sql", "instances", "list", "--project", projectID); err != nil {
+		return nil, err
+	}
+
+	var unused []UnusedResource
+	for _, instance := range instances {
+		var backups []struct {
+			ID              string `json:"id"`
+			Type            string `json:"type"`
+			Status          string `json:"status"`
+			Description     string `json:"description"`
+			WindowStartTime string `json:"windowStartTime"`
+		}
+		if err := GcloudJSON(&backups, "sql", "backups", "list", "--instance", instance.Name, "--project", projectID); err != nil {
+			return unused, err
+		}
+		for _, b := range backups {
+			if b.Type != "ON_DEMAND" || b.Status != "SUCCESSFUL" || age(b.WindowStartTime) < olderThan {
+				continue
+			}
+			unused = append(unused, UnusedResource{
+				Kind:     "sql-backup",
+				Name:     b.ID,
+				Location: instance.Name,
+				Detail:   orDefault(b.Description, "on-demand backup"),
+				Age:      age(b.WindowStartTime),
+				Instance: instance.Name,
+			})
+		}
+
+		var operations []struct {
+			OperationType string `json:"operationType"`
+			Status        string `json:"status"`
+			EndTime       string `json:"endTime"`
+			ExportContext struct {
+				URI string `json:"uri"`
+			} `json:"exportContext"`
+		}
+		if err := GcloudJSON(&operations, "sql", "operations", "list", "--instance", instance.Name, "--project", projectID); err != nil {
+			return unused, err
+		}
+		seen := make(map[string]bool)
+		for _, op := range operations {
+			uri := op.ExportContext.URI
+			if op.OperationType != "EXPORT" || op.Status != "DONE" || uri == "" || seen[uri] {
+				continue
+			}
+			seen[uri] = true
+
+			// The export may have been deleted or moved since
+			var object struct {
+				Size         json.Number `json:"size"`
+				CreationTime string      `json:"creation_time"`
+			}
+			if err := GcloudJSON(&object, "storage", "objects", "describe", uri, "--project", projectID); err != nil {
+				continue
+			}
+			created := orDefault(object.CreationTime, op.EndTime)
+			if age(created) < olderThan {
+				continue
+			}
+			size, _ := object.Size.Float64()
+			unused = append(unused, UnusedResource{
+				Kind:        "sql-export",
+				Name:        uri,
+				Location:    instance.Name,
+				Detail:      FormatMemory(size),
+				Age:         age(created),
+				MonthlyCost: size / (1 << 30) * storagePricePerGB,
+				Instance:    instance.Name,
+			})
+		}
+	}
+	return unused, nil
+}
+
+func orDefault(s, fallback string) string {
+	if strings.TrimSpace(s) == "" {
+		return fallback
+	}
+	return s
+}
+
+// DeleteUnusedResource deletes a resource found by FindUnusedResources
+func DeleteUnusedResource(projectID string, r UnusedResource) error {
+	var args []string
+	switch r.Kind {
+	case "disk":
+		args = []string{"compute", "disks", "delete", r.Name, "--zone", r.Location}
+		if r.Regional {
+			args = []string{"compute", "disks", "delete", r.Name, "--region", r.Location}
+		}
+	case "address":
+		args = []string{"compute", "addresses", "delete", r.Name, "--region", r.Location}
+		if r.Global {
+			args = []string{"compute", "addresses", "delete", r.Name, "--global"}
+		}
+	case "sql-backup":
+		args = []string{"sql", "backups", "delete", r.Name, "--instance", r.Instance}
+	case "sql-export":
+		args = []string{"storage", "rm", r.Name}
+	default:
+		return fmt.Errorf("unknown resource kind: %s", r.Kind)
+	}
+
+	_, err := runOutput("gcloud", append(args, "--project", projectID, "--quiet")...)
+	return err
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"gcpeasy/cmd"
+	"github.com/scttymn/gcpeasy/cmd"
 )
 
 func main() {
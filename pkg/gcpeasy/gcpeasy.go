@@ -0,0 +1,221 @@
+// Package gcpeasy exposes the workflows behind the gcpeasy CLI — project discovery,
+// GKE cluster setup, application pod discovery and selection, and log streaming — so
+// other Go tools can embed them instead of shelling out to the gcpeasy binary.
+//
+// Like the CLI, the package drives the gcloud and kubectl binaries, which must be
+// installed and authenticated. Unlike the CLI, nothing here prompts or prints: callers
+// choose clusters and pods themselves and receive plain errors.
+//
+// A typical embedding resolves the environment, makes sure kubectl points at one of its
+// clusters, picks a pod of a workload and streams its logs:
+//
+//	project, err := gcpeasy.CurrentProject()
+//	if err != nil {
+//		return err
+//	}
+//	if _, err := gcpeasy.EnsureCluster(project); err != nil {
+//		return err
+//	}
+//	pods, err := gcpeasy.ApplicationPods()
+//	if err != nil {
+//		return err
+//	}
+//	pod, ok := gcpeasy.FindPod(pods, "deployment/web")
+//	if !ok {
+//		return errors.New("no web pod running")
+//	}
+//	return gcpeasy.StreamLogs(ctx, pod, gcpeasy.LogOptions{Follow: true}, os.Stdout)
+package gcpeasy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/scttymn/gcpeasy/internal"
+)
+
+// ErrNoProject is returned when no GCP project is configured
+var ErrNoProject = errors.New("no GCP project selected")
+
+// ErrClusterSelectionRequired is returned by EnsureCluster when the project has several
+// clusters and kubectl does not already point at one of them
+var ErrClusterSelectionRequired = errors.New("project has multiple clusters, choose one with UseCluster")
+
+// Project is a GCP project, which gcpeasy calls an environment
+type Project struct {
+	ID   string
+	Name string
+}
+
+// Projects returns the GCP projects the active gcloud account can access
+func Projects() ([]Project, error) {
+	projects, err := internal.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Project, len(projects))
+	for i, p := range projects {
+		result[i] = Project{ID: p.ProjectID, Name: p.Name}
+	}
+	return result, nil
+}
+
+// CurrentProject returns the active environment: GCPEASY_PROJECT when set, otherwise
+// the project gcloud is configured to use
+func CurrentProject() (string, error) {
+	if project := os.Getenv("GCPEASY_PROJECT"); project != "" {
+		return project, nil
+	}
+	if project := internal.GetCurrentProject(); project != "" {
+		return project, nil
+	}
+	return "", ErrNoProject
+}
+
+// SetProject switches gcloud's configured project
+func SetProject(projectID string) error {
+	return internal.SetCurrentProject(projectID)
+}
+
+// Cluster is a GKE cluster
+type Cluster struct {
+	Name     string
+	Location string
+}
+
+// Clusters returns the GKE clusters in a project
+func Clusters(projectID string) ([]Cluster, error) {
+	clusters, err := internal.GetGKEClusters(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GKE clusters: %w", err)
+	}
+	result := make([]Cluster, len(clusters))
+	for i, c := range clusters {
+		result[i] = Cluster{Name: c.Name, Location: c.Location}
+	}
+	return result, nil
+}
+
+// UseCluster fetches credentials for a cluster and makes it kubectl's current context,
+// returning the context name
+func UseCluster(projectID string, cluster Cluster) (string, error) {
+	info := internal.ClusterInfo{Name: cluster.Name, Location: cluster.Location}
+	if err := internal.ConfigureKubectl(projectID, info); err != nil {
+		return "", err
+	}
+	return internal.ClusterContextName(projectID, info), nil
+}
+
+// CurrentContext returns kubectl's current context
+func CurrentContext() (string, error) {
+	return internal.GetCurrentCluster()
+}
+
+// EnsureCluster makes sure kubectl points at a cluster in the project and returns the
+// context name. The current context is kept when it belongs to the project; otherwise the
+// project's only cluster is configured, or ErrClusterSelectionRequired is returned.
+func EnsureCluster(projectID string) (string, error) {
+	if context, err := internal.GetCurrentCluster(); err == nil && internal.ContextBelongsToProject(context, projectID) {
+		return context, nil
+	}
+
+	clusters, err := Clusters(projectID)
+	if err != nil {
+		return "", err
+	}
+	switch len(clusters) {
+	case 0:
+		return "", fmt.Errorf("no GKE clusters found in project %s", projectID)
+	case 1:
+		return UseCluster(projectID, clusters[0])
+	default:
+		return "", ErrClusterSelectionRequired
+	}
+}
+
+// Pod is a running application pod
+type Pod struct {
+	Namespace string
+	Name      string
+	// Workload is the owning controller as "kind/name" (e.g. "deployment/web"), or empty
+	Workload string
+}
+
+// String returns the pod as "namespace/name", the form gcpeasy uses everywhere
+func (p Pod) String() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// ApplicationPods returns the running pods outside system namespaces in the current
+// kubectl context, with their owning workloads
+func ApplicationPods() ([]Pod, error) {
+	names, err := internal.FindApplicationPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find application pods: %w", err)
+	}
+	owners, err := internal.GetPodOwners()
+	if err != nil {
+		owners = nil
+	}
+
+	pods := make([]Pod, 0, len(names))
+	for _, name := range names {
+		namespace, podName, _ := strings.Cut(name, "/")
+		pods = append(pods, Pod{Namespace: namespace, Name: podName, Workload: owners[name]})
+	}
+	return pods, nil
+}
+
+// FindPod picks a pod by target, which may be "namespace/pod", a workload as "kind/name"
+// or "namespace/kind/name" (any of its pods is returned), or a pod that no longer exists,
+// in which case a pod of the same workload is returned, surviving rollouts
+func FindPod(pods []Pod, target string) (Pod, bool) {
+	for _, p := range pods {
+		if p.Workload == "" {
+			continue
+		}
+		if target == p.Workload || target == p.Namespace+"/"+p.Workload {
+			return p, true
+		}
+	}
+
+	names := make([]string, len(pods))
+	for i, p := range pods {
+		names[i] = p.String()
+	}
+	resolved, ok := internal.ResolvePod(names, target)
+	if !ok {
+		return Pod{}, false
+	}
+	for _, p := range pods {
+		if p.String() == resolved {
+			return p, true
+		}
+	}
+	return Pod{}, false
+}
+
+// LogOptions controls StreamLogs
+type LogOptions struct {
+	// Follow keeps streaming new log lines until ctx is cancelled
+	Follow bool
+}
+
+// StreamLogs copies a pod's logs to w until they end, or until ctx is cancelled when following
+func StreamLogs(ctx context.Context, pod Pod, opts LogOptions, w io.Writer) error {
+	var stderr strings.Builder
+	if err := internal.StreamPodLogs(ctx, pod.String(), internal.LogStreamOptions{Follow: opts.Follow}, w, &stderr); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to stream logs for %s: %s", pod, msg)
+		}
+		return fmt.Errorf("failed to stream logs for %s: %w", pod, err)
+	}
+	return nil
+}